@@ -19,10 +19,16 @@ contract zkLogin is MerkleTreeWithHistory {
      */
     IFoodBankVerifier private immutable foodBankVerifier; //ZKVoting Verifier
 
-    // TreeIDs for Foodbanks and Users (RESERVED)
+    // RoleIDs for Foodbanks and Users (RESERVED), placed in the trees by the role registry
     uint32 private immutable FOODBANKS = 0;
     uint32 private immutable USERS = 1;
 
+    // Food Bank Governance: actions of the proposals, approvals and lifetime
+    uint8 private constant ADD_FOODBANK = 0;
+    uint8 private constant TERMINATE_FOODBANK = 1;
+    uint32 private immutable foodBankQuorum; // Approvals required to execute a proposal
+    uint64 private immutable proposalLifetime; // Seconds before a proposal expires
+
     /**
      ** Structs
      */
@@ -32,6 +38,36 @@ contract zkLogin is MerkleTreeWithHistory {
         uint256[2] pi_c; // Array representing the 'c' part of the proof
     }
 
+    // Role of the registry: the tree and subtree holding its members
+    struct RoleDefinition {
+        uint32 id;
+        uint32 tree;
+        uint32 subtree;
+    }
+
+    // Members of the registrar role may register members of role
+    struct RolePermission {
+        uint32 registrar;
+        uint32 role;
+    }
+
+    struct RoleTree {
+        uint32 tree;
+        uint32 subtree;
+        bool exists;
+    }
+
+    // Proposal to add a food bank by address or terminate one by hashed address, executed once
+    // approved by foodBankQuorum food banks
+    struct Proposal {
+        uint8 action;
+        address account;
+        uint256 hashedAddress;
+        uint64 expiresAt;
+        uint32 approvals;
+        bool executed;
+    }
+
     /**
      ** Mappings
      */
@@ -39,6 +75,17 @@ contract zkLogin is MerkleTreeWithHistory {
         internal isExists; // Duplicate protection of leafs
     mapping(uint256 => uint256[]) private foodBankUsers;
     mapping(address => bool) private blacklist; // Revocation List
+    mapping(uint256 => bool) private revoked; // Revocation List by hashed address
+    mapping(uint256 => uint256) private registrars; // Hashed Member => Hashed Registrar, 0 for governance
+    mapping(uint256 => uint256) private rotatedFrom; // Key Rotations, new => old hashed address
+    mapping(uint256 => uint256) private rotatedTo; // Key Rotations, old => new hashed address
+    mapping(uint32 => RoleTree) private roles; // Role Registry
+    mapping(uint32 => mapping(uint32 => bool)) private permissions; // Registrar => Role
+    mapping(bytes32 => Proposal) private proposals; // Food Bank Governance
+    mapping(bytes32 => mapping(uint256 => bool)) private approvals; // Proposal => Hashed Food Bank
+    mapping(bytes32 => uint256[]) private approvers; // Proposal => Hashed Food Banks, in order of approval
+    mapping(uint256 => bool) private terminatedFoodBanks; // Hashed Food Banks terminated by themselves
+    bytes32[] private proposalIds;
 
     /**
      ** Modifiers
@@ -55,38 +102,18 @@ contract zkLogin is MerkleTreeWithHistory {
         Groth16Proof calldata _proof,
         uint256[2] calldata _publicSignals
     ) {
-        // Verify that 1 public signal is zero
-        require(
-            _publicSignals[1] == 0,
-            "zkEthereumAddress: Invalid Public Signals"
-        );
-        // Verify Proof (zkEthereumAddress)
-        require(
-            foodBankVerifier.verifyProof(
-                _proof.pi_a,
-                _proof.pi_b,
-                _proof.pi_c,
-                _publicSignals
-            ),
-            "zkEthereumAddress: Invalid Proofs"
-        );
-        // Verify that the hashedAddress of the transaction originator is the same as in
-        // circuit's output
-        require(
-            hashAddress(_user) == _publicSignals[0],
-            "zkEthereumAddress: Unauthorized Access"
-        );
+        requireEthereumAddressZKP(_user, _proof, _publicSignals);
         _;
     }
 
-    // Verify Merkle Tree ZKP
+    // Verify Merkle Tree ZKP of a member of the role
     modifier validMerkleTreeZKP(
-        uint32 _treeId,
-        uint32 _subtreeId,
+        uint32 _role,
         address _user,
         Groth16Proof calldata _proof,
         uint256[2] calldata _publicSignals
     ) {
+        RoleTree storage placement = roleTree(_role);
         // Verify that 0 and 1 public signals are zeroes
         require(
             _publicSignals[0] != 0 && _publicSignals[1] != 0,
@@ -108,9 +135,18 @@ contract zkLogin is MerkleTreeWithHistory {
             hashAddress(_user) == _publicSignals[0],
             "zkMerkleTree: Unauthorized Access"
         );
+        // Verify that the account has not been revoked by another member
+        require(
+            !revoked[_publicSignals[0]],
+            "zkMerkleTree: Revoked Account Detected"
+        );
         // Verify that the root calculated from the circuit is known
         require(
-            isKnownRoot(_treeId, _subtreeId, _publicSignals[1]),
+            isKnownRoot(
+                placement.tree,
+                placement.subtree,
+                _publicSignals[1]
+            ),
             "zkMerkleTree: Unknown Root Detected"
         );
         _;
@@ -122,6 +158,20 @@ contract zkLogin is MerkleTreeWithHistory {
         _;
     }
 
+    // Check if the members of the registrar role may register members of the role
+    modifier permitted(uint32 _registrar, uint32 _role) {
+        require(
+            permissions[_registrar][_role],
+            "Registration not Permitted"
+        );
+        // Food banks are added by proposal once more than one approval is required
+        require(
+            _role != FOODBANKS || foodBankQuorum == 1,
+            "Food Bank Proposal Required"
+        );
+        _;
+    }
+
     /**
      ** Events
      */
@@ -142,6 +192,10 @@ contract zkLogin is MerkleTreeWithHistory {
      **     4) _hasher: Hasher contract address
      **     5) _foodBankVerifier: Voting verifier contract address
      **     6) _foodBanks: Array of initial food bank addresses
+     **     7) _roles: Role registry, the tree and subtree of each role (FOODBANKS and USERS required)
+     **     8) _permissions: Roles whose members may register members of another role
+     **     9) _foodBankQuorum: Food bank approvals required to add or terminate a food bank
+     **     10) _proposalLifetime: Seconds before a food bank proposal expires
      */
     constructor(
         uint32 _trees,
@@ -149,7 +203,11 @@ contract zkLogin is MerkleTreeWithHistory {
         uint32[] memory _levels,
         IHasher _hasher,
         IFoodBankVerifier _foodBankVerifier,
-        address[] memory _foodBanks
+        address[] memory _foodBanks,
+        RoleDefinition[] memory _roles,
+        RolePermission[] memory _permissions,
+        uint32 _foodBankQuorum,
+        uint64 _proposalLifetime
     )
         validAddress(_msgSender())
         validAddress(address(_hasher))
@@ -159,20 +217,41 @@ contract zkLogin is MerkleTreeWithHistory {
         // Contract Address of the Voting Verifier
         foodBankVerifier = _foodBankVerifier;
 
+        // Food Bank Governance
+        require(
+            _foodBankQuorum != 0 && _proposalLifetime != 0,
+            "Invalid Food Bank Governance"
+        );
+        foodBankQuorum = _foodBankQuorum;
+        proposalLifetime = _proposalLifetime;
+
+        // Place the roles in the trees
+        defineRoles(_subtrees, _roles, _permissions);
+
         // Check if the array is empty
         uint256 foodBanksLength = _foodBanks.length;
         require(foodBanksLength != 0, "No FoodBanks' addresses presented");
 
         // Add initial addresses to the Merkle tree
+        uint256 registered;
         for (uint256 i; i < foodBanksLength; i++) {
             // Check if the address is empty
             if (_foodBanks[i] == address(0)) {
                 continue;
             }
             // Register FoodBank
-            _registerUser(FOODBANKS, 0, _foodBanks[i]);
+            _registerUser(
+                roles[FOODBANKS].tree,
+                roles[FOODBANKS].subtree,
+                _foodBanks[i]
+            );
+            registered++;
             //emit ContractInteraction(_msgSender(), "constructor", abi.encodePacked(addressToBigint(_foodBanks[i])));
         }
+        require(
+            registered >= _foodBankQuorum,
+            "Food Bank Quorum is Unreachable"
+        );
     }
 
     /***
@@ -193,7 +272,6 @@ contract zkLogin is MerkleTreeWithHistory {
         validAccount(_msgSender())
         validMerkleTreeZKP(
             FOODBANKS,
-            0,
             _msgSender(),
             _foodBankMerkleProof,
             _foodBankPublicSignals
@@ -202,8 +280,10 @@ contract zkLogin is MerkleTreeWithHistory {
     {
         // Terminate the Account
         terminateAccount(_msgSender());
+        // Its pending proposal approvals no longer count
+        terminatedFoodBanks[_foodBankPublicSignals[0]] = true;
         // Delete FoodBank's Merkle Proofs
-        deleteMerkleProof(FOODBANKS, 0);
+        deleteMerkleProof(FOODBANKS);
         return true;
     }
 
@@ -225,7 +305,6 @@ contract zkLogin is MerkleTreeWithHistory {
         validAccount(_msgSender())
         validMerkleTreeZKP(
             USERS,
-            0,
             _msgSender(),
             _userMerkleProof,
             _userMerklePublicSignals
@@ -235,7 +314,7 @@ contract zkLogin is MerkleTreeWithHistory {
         // Terminate the Account
         terminateAccount(_msgSender());
         // Delete User's Merkle Proofs
-        deleteMerkleProof(USERS, 0);
+        deleteMerkleProof(USERS);
         return true;
     }
 
@@ -266,14 +345,12 @@ contract zkLogin is MerkleTreeWithHistory {
         validAccount(_user)
         validMerkleTreeZKP(
             FOODBANKS,
-            0,
             _msgSender(),
             _foodBankMerkleProof,
             _foodBankPublicSignals
         )
         validMerkleTreeZKP(
             USERS,
-            0,
             _user,
             _userMerkleProof,
             _userMerklePublicSignals
@@ -283,6 +360,62 @@ contract zkLogin is MerkleTreeWithHistory {
         return true;
     }
 
+    /***
+     ** @dev Verifies the Proofs of many Users in one call
+     ** @notice Only Food Banks
+     ** @param
+     **   1) _foodBankMerkleProof: Zero Knowledge Merkle Proofs of the FoodBank (transactor)
+     **   2) _foodBankPublicSignals: Array representing the public signals (Lenght = 2)
+     **   3) _users: The addresses of the Users
+     **   4) _userMerkleProofs: Zero Knowledge Merkle Proofs of each User
+     **   5) _userMerklePublicSignals: Public signals of each User (Lenght = 2)
+     ** @return
+     **   1) Bitmap of the results, bit i (word i / 256, bit i % 256) set when the proof of _users[i] is valid
+     */
+    function verifyProofs(
+        Groth16Proof calldata _foodBankMerkleProof,
+        uint256[2] calldata _foodBankPublicSignals,
+        address[] calldata _users,
+        Groth16Proof[] calldata _userMerkleProofs,
+        uint256[2][] calldata _userMerklePublicSignals
+    )
+        external
+        view
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        validMerkleTreeZKP(
+            FOODBANKS,
+            _msgSender(),
+            _foodBankMerkleProof,
+            _foodBankPublicSignals
+        )
+        returns (uint256[] memory)
+    {
+        uint256 usersLength = _users.length;
+        require(
+            usersLength == _userMerkleProofs.length &&
+                usersLength == _userMerklePublicSignals.length,
+            "Length of Users, Proofs and Public Signals mismatch"
+        );
+
+        RoleTree storage users = roles[USERS];
+        uint256[] memory results = new uint256[]((usersLength + 255) / 256);
+        for (uint256 i; i < usersLength; i++) {
+            if (
+                isValidMerkleTreeZKP(
+                    users.tree,
+                    users.subtree,
+                    _users[i],
+                    _userMerkleProofs[i],
+                    _userMerklePublicSignals[i]
+                )
+            ) {
+                results[i >> 8] |= 1 << (i & 255);
+            }
+        }
+        return results;
+    }
+
     /**
      ** @dev Return Merkle Proofs for Food Banks
      ** @notice Only Food Banks
@@ -307,7 +440,7 @@ contract zkLogin is MerkleTreeWithHistory {
         )
         returns (MerkleProof memory)
     {
-        return fetchMerkleProof(FOODBANKS, 0);
+        return fetchMerkleProof(FOODBANKS);
     }
 
     /**
@@ -328,14 +461,13 @@ contract zkLogin is MerkleTreeWithHistory {
         validAccount(_msgSender())
         validMerkleTreeZKP(
             FOODBANKS,
-            0,
             _msgSender(),
             _foodBankMerkleProof,
             _foodBankPublicSignals
         )
         returns (bool)
     {
-        return deleteMerkleProof(FOODBANKS, 0);
+        return deleteMerkleProof(FOODBANKS);
     }
 
     /**
@@ -362,7 +494,7 @@ contract zkLogin is MerkleTreeWithHistory {
         )
         returns (MerkleProof memory)
     {
-        return fetchMerkleProof(USERS, 0);
+        return fetchMerkleProof(USERS);
     }
 
     /**
@@ -383,14 +515,13 @@ contract zkLogin is MerkleTreeWithHistory {
         validAccount(_msgSender())
         validMerkleTreeZKP(
             USERS,
-            0,
             _msgSender(),
             _userMerkleProof,
             _userPublicSignals
         )
         returns (bool)
     {
-        return deleteMerkleProof(USERS, 0);
+        return deleteMerkleProof(USERS);
     }
 
     /**
@@ -417,9 +548,9 @@ contract zkLogin is MerkleTreeWithHistory {
         validAddress(_newFoodBank)
         validAccount(_msgSender())
         validAccount(_newFoodBank)
+        permitted(FOODBANKS, FOODBANKS)
         validMerkleTreeZKP(
             FOODBANKS,
-            0,
             _msgSender(),
             _foodBankMerkleProof,
             _foodBankPublicSignals
@@ -431,7 +562,13 @@ contract zkLogin is MerkleTreeWithHistory {
         )
         returns (bool)
     {
-        return _registerUser(FOODBANKS, 0, _newFoodBank);
+        bool success = _registerUser(
+            roles[FOODBANKS].tree,
+            roles[FOODBANKS].subtree,
+            _newFoodBank
+        );
+        registrars[_newFoodBankPublicSignals[0]] = _foodBankPublicSignals[0];
+        return success;
     }
 
     /**
@@ -458,9 +595,9 @@ contract zkLogin is MerkleTreeWithHistory {
         validAddress(_newUser)
         validAccount(_msgSender())
         validAccount(_newUser)
+        permitted(FOODBANKS, USERS)
         validMerkleTreeZKP(
             FOODBANKS,
-            0,
             _msgSender(),
             _foodBankMerkleProof,
             _foodBankPublicSignals
@@ -472,12 +609,78 @@ contract zkLogin is MerkleTreeWithHistory {
         )
         returns (bool)
     {
-        bool success = _registerUser(USERS, 0, _newUser);
+        bool success = _registerUser(
+            roles[USERS].tree,
+            roles[USERS].subtree,
+            _newUser
+        );
         // Add the user to the food bank's list
-        foodBankUsers[_foodBankPublicSignals[0]].push(hashAddress(_newUser));
+        addFoodBankUser(_foodBankPublicSignals[0], hashAddress(_newUser));
         return success;
     }
 
+    /**
+     ** @dev Register many new Users in one transaction, all or none
+     ** @notice Only Food Banks
+     ** @param
+     **   1) _foodBankMerkleProof: Zero Knowledge Merkle Proofs of the Food Bank (transactor)
+     **   2) _foodBankPublicSignals: Array representing the public signals (Lenght = 2)
+     **   3) _newUsers: The addresses of the new Users
+     **   4) _newUserEthereumAddressProofs: Zero Knowledge Ethereum Address Proofs of each new User
+     **   5) _newUserPublicSignals: Public signals of each new User (Lenght = 2)
+     ** @return
+     **   1) SUCCESS (true), any invalid User reverts the whole batch
+     */
+    function registerUsers(
+        Groth16Proof calldata _foodBankMerkleProof,
+        uint256[2] calldata _foodBankPublicSignals,
+        address[] calldata _newUsers,
+        Groth16Proof[] calldata _newUserEthereumAddressProofs,
+        uint256[2][] calldata _newUserPublicSignals
+    )
+        external
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        permitted(FOODBANKS, USERS)
+        validMerkleTreeZKP(
+            FOODBANKS,
+            _msgSender(),
+            _foodBankMerkleProof,
+            _foodBankPublicSignals
+        )
+        returns (bool)
+    {
+        uint256 newUsersLength = _newUsers.length;
+        require(newUsersLength != 0, "No Users' addresses presented");
+        require(
+            newUsersLength == _newUserEthereumAddressProofs.length &&
+                newUsersLength == _newUserPublicSignals.length,
+            "Length of Users, Proofs and Public Signals mismatch"
+        );
+
+        RoleTree storage role = roles[USERS];
+        for (uint256 i; i < newUsersLength; i++) {
+            // The checks of validAddress and validAccount on each new User
+            require(_newUsers[i] != address(0), "Zero Address Detected");
+            require(!blacklist[_newUsers[i]], "Blacklisted User Detected");
+            requireEthereumAddressZKP(
+                _newUsers[i],
+                _newUserEthereumAddressProofs[i],
+                _newUserPublicSignals[i]
+            );
+            require(
+                _registerUser(role.tree, role.subtree, _newUsers[i]),
+                "User Registration Failed"
+            );
+            // Add the user to the food bank's list
+            addFoodBankUser(
+                _foodBankPublicSignals[0],
+                hashAddress(_newUsers[i])
+            );
+        }
+        return true;
+    }
+
     /**
      ** @dev Retrieves the array of hashed users
      ** @notice Only Food Banks
@@ -497,7 +700,6 @@ contract zkLogin is MerkleTreeWithHistory {
         validAccount(_msgSender())
         validMerkleTreeZKP(
             FOODBANKS,
-            0,
             _msgSender(),
             _foodBankMerkleProof,
             _foodBankPublicSignals
@@ -513,6 +715,573 @@ contract zkLogin is MerkleTreeWithHistory {
         return foodBankUsers[_foodBankPublicSignals[0]];
     }
 
+    /**
+     ** @dev Register a new Member of a Role
+     ** @notice Only Members of a Role permitted to register the Role
+     ** @param
+     **   1) _registrarRole: The Role of the transactor
+     **   2) _registrarMerkleProof: Zero Knowledge Merkle Proofs of the transactor
+     **   3) _registrarPublicSignals: Array representing the public signals (Lenght = 2)
+     **   4) _role: The Role of the new Member
+     **   5) _newMember: The address of the new Member
+     **   6) _newMemberEthereumAddressProof: Zero Knowledge Ethereum Address Proofs of the new Member
+     **   7) _newMemberPublicSignals: Array representing the public signals (Lenght = 2)
+     ** @return
+     **   1) SUCCESS (true) or FAILED (false)
+     */
+    function registerMember(
+        uint32 _registrarRole,
+        Groth16Proof calldata _registrarMerkleProof,
+        uint256[2] calldata _registrarPublicSignals,
+        uint32 _role,
+        address _newMember,
+        Groth16Proof calldata _newMemberEthereumAddressProof,
+        uint256[2] calldata _newMemberPublicSignals
+    )
+        external
+        validAddress(_msgSender())
+        validAddress(_newMember)
+        validAccount(_msgSender())
+        validAccount(_newMember)
+        permitted(_registrarRole, _role)
+        validMerkleTreeZKP(
+            _registrarRole,
+            _msgSender(),
+            _registrarMerkleProof,
+            _registrarPublicSignals
+        )
+        validEthereumAddressZKP(
+            _newMember,
+            _newMemberEthereumAddressProof,
+            _newMemberPublicSignals
+        )
+        returns (bool)
+    {
+        RoleTree storage role = roleTree(_role);
+        bool success = _registerUser(role.tree, role.subtree, _newMember);
+        if (_role == USERS) {
+            // Add the user to the registrar's list
+            addFoodBankUser(
+                _registrarPublicSignals[0],
+                hashAddress(_newMember)
+            );
+        } else {
+            registrars[_newMemberPublicSignals[0]] = _registrarPublicSignals[0];
+        }
+        return success;
+    }
+
+    /**
+     ** @dev Return Merkle Proofs for Members of a Role
+     ** @notice Only Members of the Role
+     ** @param
+     **   1) _role: The Role of the transactor
+     **   2) _memberEthereumAddressProof: Zero Knowledge Ethereum Address Proofs of the transactor
+     **   3) _memberEthereumAddressPublicSignals: Array representing the public signals (Lenght = 2)
+     ** @return
+     **   1) MerkleProof Struct (PathElements and PathIndices)
+     */
+    function fetchMemberMerkleProofs(
+        uint32 _role,
+        Groth16Proof calldata _memberEthereumAddressProof,
+        uint256[2] calldata _memberEthereumAddressPublicSignals
+    )
+        external
+        view
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        validEthereumAddressZKP(
+            _msgSender(),
+            _memberEthereumAddressProof,
+            _memberEthereumAddressPublicSignals
+        )
+        returns (MerkleProof memory)
+    {
+        return fetchMerkleProof(_role);
+    }
+
+    /**
+     ** @dev Delete Merkle Proofs for Members of a Role
+     ** @notice Only Members of the Role
+     ** @param
+     **   1) _role: The Role of the transactor
+     **   2) _memberMerkleProof: Zero Knowledge Merkle Proofs of the transactor
+     **   3) _memberPublicSignals: Array representing the public signals (Lenght = 2)
+     ** @return
+     **   1) SUCCESS (true) or FAILED (false)
+     */
+    function deleteMemberMerkleProofs(
+        uint32 _role,
+        Groth16Proof calldata _memberMerkleProof,
+        uint256[2] calldata _memberPublicSignals
+    )
+        external
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        validMerkleTreeZKP(
+            _role,
+            _msgSender(),
+            _memberMerkleProof,
+            _memberPublicSignals
+        )
+        returns (bool)
+    {
+        return deleteMerkleProof(_role);
+    }
+
+    /***
+     ** @dev Terminates the Account of a Member of a Role
+     ** @notice Only Members of the Role
+     ** @param
+     **   1) _role: The Role of the transactor
+     **   2) _memberMerkleProof: Zero Knowledge Merkle Proofs of the transactor
+     **   3) _memberPublicSignals: Array representing the public signals (Lenght = 2)
+     ** @return
+     **   1) SUCCESS (true) or FAILED (false)
+     */
+    function terminateMember(
+        uint32 _role,
+        Groth16Proof calldata _memberMerkleProof,
+        uint256[2] calldata _memberPublicSignals
+    )
+        external
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        validMerkleTreeZKP(
+            _role,
+            _msgSender(),
+            _memberMerkleProof,
+            _memberPublicSignals
+        )
+        returns (bool)
+    {
+        // Terminate the Account
+        terminateAccount(_msgSender());
+        // Delete Member's Merkle Proofs
+        deleteMerkleProof(_role);
+        return true;
+    }
+
+    /***
+     ** @dev Revokes a Member of a Role by hashed address
+     ** @notice Only the Member that registered it (under its current or a previous key), as long
+     ** as its Role is still permitted to register the Role. Members added by Governance are
+     ** revoked by Proposal
+     ** @param
+     **   1) _revokerRole: The Role of the transactor
+     **   2) _revokerMerkleProof: Zero Knowledge Merkle Proofs of the transactor
+     **   3) _revokerPublicSignals: Array representing the public signals (Lenght = 2)
+     **   4) _role: The Role of the revoked Member
+     **   5) _hashedAddress: The hashed address of the revoked Member
+     ** @return
+     **   1) SUCCESS (true) or FAILED (false)
+     */
+    function revokeMember(
+        uint32 _revokerRole,
+        Groth16Proof calldata _revokerMerkleProof,
+        uint256[2] calldata _revokerPublicSignals,
+        uint32 _role,
+        uint256 _hashedAddress
+    )
+        external
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        permitted(_revokerRole, _role)
+        validMerkleTreeZKP(
+            _revokerRole,
+            _msgSender(),
+            _revokerMerkleProof,
+            _revokerPublicSignals
+        )
+        returns (bool)
+    {
+        RoleTree storage role = roleTree(_role);
+        require(
+            isExists[role.tree][role.subtree][_hashedAddress],
+            "Not a registered Member"
+        );
+        require(
+            isRegistrar(_hashedAddress, _revokerPublicSignals[0]),
+            "Not the Registrar of the Member"
+        );
+        require(!revoked[_hashedAddress], "Account already Revoked");
+        revoked[_hashedAddress] = true;
+        return true;
+    }
+
+    /***
+     ** @dev Moves the membership of a Member of a Role to a new key (e.g. a new device)
+     ** @notice Only new keys of Members other than Food Banks, authorised by the old key
+     ** @param
+     **   1) _role: The Role of the Member
+     **   2) _oldMember: The address of the old key
+     **   3) _oldMemberMerkleProof: Zero Knowledge Merkle Proofs of the old key
+     **   4) _oldMemberPublicSignals: Array representing the public signals (Lenght = 2)
+     **   5) _newMemberEthereumAddressProof: Zero Knowledge Ethereum Address Proofs of the new key
+     **      (transactor)
+     **   6) _newMemberPublicSignals: Array representing the public signals (Lenght = 2)
+     **   7) _signature: Signature of the old key over keyRotationHash(_role, transactor)
+     ** @return
+     **   1) SUCCESS (true) or FAILED (false)
+     */
+    function rotateKey(
+        uint32 _role,
+        address _oldMember,
+        Groth16Proof calldata _oldMemberMerkleProof,
+        uint256[2] calldata _oldMemberPublicSignals,
+        Groth16Proof calldata _newMemberEthereumAddressProof,
+        uint256[2] calldata _newMemberPublicSignals,
+        bytes calldata _signature
+    )
+        external
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        validMerkleTreeZKP(
+            _role,
+            _oldMember,
+            _oldMemberMerkleProof,
+            _oldMemberPublicSignals
+        )
+        validEthereumAddressZKP(
+            _msgSender(),
+            _newMemberEthereumAddressProof,
+            _newMemberPublicSignals
+        )
+        returns (bool)
+    {
+        // Food Banks are keyed by hashed address in their lists and approvals
+        require(_role != FOODBANKS, "Food Bank Key Rotation not Supported");
+        require(!blacklist[_oldMember], "Blacklisted User Detected");
+        // Verify that the old key signed over the new key
+        require(
+            recoverSigner(keyRotationHash(_role, _msgSender()), _signature) ==
+                _oldMember,
+            "Invalid Key Rotation Signature"
+        );
+
+        RoleTree storage role = roles[_role];
+        uint256 oldHashedAddress = _oldMemberPublicSignals[0];
+        uint256 newHashedAddress = _newMemberPublicSignals[0];
+        // Revoke the old key and insert the new one
+        revoked[oldHashedAddress] = true;
+        delete merkleProofs[role.tree][role.subtree][_oldMember];
+        bool success = _registerUser(role.tree, role.subtree, _msgSender());
+        // Keep the history of the Member
+        rotatedFrom[newHashedAddress] = oldHashedAddress;
+        rotatedTo[oldHashedAddress] = newHashedAddress;
+        uint256 registrar = registrars[oldHashedAddress];
+        if (registrar != 0) {
+            registrars[newHashedAddress] = registrar;
+            if (_role == USERS) {
+                // Add the new key to the food bank's list, next to the old one
+                foodBankUsers[registrar].push(newHashedAddress);
+            }
+        }
+        return success;
+    }
+
+    /**
+     ** @dev Return the hash the old key signs to rotate to a new key
+     ** @param
+     **   1) _role: The Role of the Member
+     **   2) _newMember: The address of the new key
+     ** @return
+     **   1) EIP-191 hash of abi.encode(zkLogin, chainid, _role, _newMember)
+     */
+    function keyRotationHash(
+        uint32 _role,
+        address _newMember
+    ) public view returns (bytes32) {
+        return
+            keccak256(
+                abi.encodePacked(
+                    "\x19Ethereum Signed Message:\n32",
+                    keccak256(
+                        abi.encode(
+                            address(this),
+                            block.chainid,
+                            _role,
+                            _newMember
+                        )
+                    )
+                )
+            );
+    }
+
+    /**
+     ** @dev Return the key rotations of an Account by hashed address
+     ** @param
+     **   1) _hashedAddress: The hashed address of the Account
+     ** @return
+     **   1) Hashed address of the previous key (0 if none)
+     **   2) Hashed address of the next key (0 if none)
+     */
+    function fetchKeyRotation(
+        uint256 _hashedAddress
+    ) external view returns (uint256, uint256) {
+        return (rotatedFrom[_hashedAddress], rotatedTo[_hashedAddress]);
+    }
+
+    /**
+     ** @dev Whether an Account has been revoked by hashed address
+     ** @param
+     **   1) _hashedAddress: The hashed address of the Account
+     ** @return
+     **   1) REVOKED (true) or NOT (false)
+     */
+    function isRevoked(uint256 _hashedAddress) external view returns (bool) {
+        return revoked[_hashedAddress];
+    }
+
+    /**
+     ** @dev Return the Tree and Subtree of a Role
+     ** @param
+     **   1) _role: The Role
+     ** @return
+     **   1) Tree and Subtree of the Role's Members
+     */
+    function fetchRole(
+        uint32 _role
+    ) external view returns (uint32, uint32) {
+        RoleTree storage role = roleTree(_role);
+        return (role.tree, role.subtree);
+    }
+
+    /**
+     ** @dev Whether the Members of a Role may register Members of another Role
+     ** @param
+     **   1) _registrarRole: The Role of the registrar
+     **   2) _role: The Role of the new Members
+     ** @return
+     **   1) PERMITTED (true) or NOT (false)
+     */
+    function isPermitted(
+        uint32 _registrarRole,
+        uint32 _role
+    ) external view returns (bool) {
+        return permissions[_registrarRole][_role];
+    }
+
+    /**
+     ** @dev Propose to add a new Food Bank, approved by the proposer
+     ** @notice Only Food Banks
+     ** @param
+     **   1) _foodBankMerkleProof: Zero Knowledge Merkle Proofs of the Food Bank (transactor)
+     **   2) _foodBankPublicSignals: Array representing the public signals (Lenght = 2)
+     **   3) _newFoodBank: The address of the new Food Bank
+     **   4) _newFoodBankEthereumAddressProof: Zero Knowledge Ethereum Address Proofs of the new Food Bank
+     **   5) _newFoodBankPublicSignals: Array representing the public signals (Lenght = 2)
+     ** @return
+     **   1) Proposal ID, keccak256(abi.encode(action, account, hashedAddress, expiresAt))
+     */
+    function proposeFoodBank(
+        Groth16Proof calldata _foodBankMerkleProof,
+        uint256[2] calldata _foodBankPublicSignals,
+        address _newFoodBank,
+        Groth16Proof calldata _newFoodBankEthereumAddressProof,
+        uint256[2] calldata _newFoodBankPublicSignals
+    )
+        external
+        validAddress(_msgSender())
+        validAddress(_newFoodBank)
+        validAccount(_msgSender())
+        validAccount(_newFoodBank)
+        validMerkleTreeZKP(
+            FOODBANKS,
+            _msgSender(),
+            _foodBankMerkleProof,
+            _foodBankPublicSignals
+        )
+        validEthereumAddressZKP(
+            _newFoodBank,
+            _newFoodBankEthereumAddressProof,
+            _newFoodBankPublicSignals
+        )
+        returns (bytes32)
+    {
+        return
+            propose(
+                ADD_FOODBANK,
+                _newFoodBank,
+                0,
+                _foodBankPublicSignals[0]
+            );
+    }
+
+    /**
+     ** @dev Propose to terminate another Food Bank, approved by the proposer
+     ** @notice Only Food Banks
+     ** @param
+     **   1) _foodBankMerkleProof: Zero Knowledge Merkle Proofs of the Food Bank (transactor)
+     **   2) _foodBankPublicSignals: Array representing the public signals (Lenght = 2)
+     **   3) _hashedFoodBank: The hashed address of the Food Bank to terminate
+     ** @return
+     **   1) Proposal ID, keccak256(abi.encode(action, account, hashedAddress, expiresAt))
+     */
+    function proposeFoodBankTermination(
+        Groth16Proof calldata _foodBankMerkleProof,
+        uint256[2] calldata _foodBankPublicSignals,
+        uint256 _hashedFoodBank
+    )
+        external
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        validMerkleTreeZKP(
+            FOODBANKS,
+            _msgSender(),
+            _foodBankMerkleProof,
+            _foodBankPublicSignals
+        )
+        returns (bytes32)
+    {
+        RoleTree storage role = roles[FOODBANKS];
+        require(
+            isExists[role.tree][role.subtree][_hashedFoodBank] &&
+                !revoked[_hashedFoodBank],
+            "Not a registered Food Bank"
+        );
+        return
+            propose(
+                TERMINATE_FOODBANK,
+                address(0),
+                _hashedFoodBank,
+                _foodBankPublicSignals[0]
+            );
+    }
+
+    /**
+     ** @dev Approve a pending Proposal, once per Food Bank
+     ** @notice Only Food Banks
+     ** @param
+     **   1) _foodBankMerkleProof: Zero Knowledge Merkle Proofs of the Food Bank (transactor)
+     **   2) _foodBankPublicSignals: Array representing the public signals (Lenght = 2)
+     **   3) _proposal: The Proposal ID
+     ** @return
+     **   1) SUCCESS (true) or FAILED (false)
+     */
+    function approveProposal(
+        Groth16Proof calldata _foodBankMerkleProof,
+        uint256[2] calldata _foodBankPublicSignals,
+        bytes32 _proposal
+    )
+        external
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        validMerkleTreeZKP(
+            FOODBANKS,
+            _msgSender(),
+            _foodBankMerkleProof,
+            _foodBankPublicSignals
+        )
+        returns (bool)
+    {
+        Proposal storage proposal = pendingProposal(_proposal);
+        require(
+            !approvals[_proposal][_foodBankPublicSignals[0]],
+            "Proposal already Approved"
+        );
+        approvals[_proposal][_foodBankPublicSignals[0]] = true;
+        approvers[_proposal].push(_foodBankPublicSignals[0]);
+        proposal.approvals++;
+        return true;
+    }
+
+    /**
+     ** @dev Execute a pending Proposal approved by foodBankQuorum Food Banks still active,
+     ** approvals of Food Banks terminated or revoked since they approved are not counted
+     ** @notice Only Food Banks
+     ** @param
+     **   1) _foodBankMerkleProof: Zero Knowledge Merkle Proofs of the Food Bank (transactor)
+     **   2) _foodBankPublicSignals: Array representing the public signals (Lenght = 2)
+     **   3) _proposal: The Proposal ID
+     ** @return
+     **   1) SUCCESS (true) or FAILED (false)
+     */
+    function executeProposal(
+        Groth16Proof calldata _foodBankMerkleProof,
+        uint256[2] calldata _foodBankPublicSignals,
+        bytes32 _proposal
+    )
+        external
+        validAddress(_msgSender())
+        validAccount(_msgSender())
+        validMerkleTreeZKP(
+            FOODBANKS,
+            _msgSender(),
+            _foodBankMerkleProof,
+            _foodBankPublicSignals
+        )
+        returns (bool)
+    {
+        Proposal storage proposal = pendingProposal(_proposal);
+        require(
+            activeApprovals(_proposal) >= foodBankQuorum,
+            "Proposal Quorum not Reached"
+        );
+        proposal.executed = true;
+
+        RoleTree storage role = roles[FOODBANKS];
+        if (proposal.action == ADD_FOODBANK) {
+            return _registerUser(role.tree, role.subtree, proposal.account);
+        }
+        // Revoke the Food Bank
+        revoked[proposal.hashedAddress] = true;
+        return true;
+    }
+
+    /**
+     ** @dev Return a Proposal
+     ** @param
+     **   1) _proposal: The Proposal ID
+     ** @return
+     **   1) Proposal Struct (Action, Account, HashedAddress, ExpiresAt, Approvals of the Food Banks
+     **      still active and Executed)
+     */
+    function fetchProposal(
+        bytes32 _proposal
+    ) external view returns (Proposal memory) {
+        require(
+            proposals[_proposal].expiresAt != 0,
+            "Unknown Proposal Detected"
+        );
+        Proposal memory proposal = proposals[_proposal];
+        proposal.approvals = activeApprovals(_proposal);
+        return proposal;
+    }
+
+    /**
+     ** @dev Return the IDs of every Proposal, in order
+     ** @return
+     **   1) Array (bytes32[]) of Proposal IDs
+     */
+    function fetchProposals() external view returns (bytes32[] memory) {
+        return proposalIds;
+    }
+
+    /**
+     ** @dev Whether a Food Bank approved a Proposal
+     ** @param
+     **   1) _proposal: The Proposal ID
+     **   2) _hashedFoodBank: The hashed address of the Food Bank
+     ** @return
+     **   1) APPROVED (true) or NOT (false)
+     */
+    function hasApproved(
+        bytes32 _proposal,
+        uint256 _hashedFoodBank
+    ) external view returns (bool) {
+        return approvals[_proposal][_hashedFoodBank];
+    }
+
+    /**
+     ** @dev Return the Food Bank Governance
+     ** @return
+     **   1) Approvals required to execute a Proposal and Proposal lifetime in seconds
+     */
+    function fetchGovernance() external view returns (uint32, uint64) {
+        return (foodBankQuorum, proposalLifetime);
+    }
+
     /**
      ** Helper Functions
      */
@@ -533,11 +1302,12 @@ contract zkLogin is MerkleTreeWithHistory {
     {
         // Convert address to BigInt and Hash leaf using mimc converting to uint256
         uint256 hashedAddress = hashAddress(_newUser);
-        // Check if user is already registered
+        // Check if user is already registered or has been revoked
         require(
             !isExists[_treeId][_subtreeId][hashedAddress],
             "User is already Registered"
         );
+        require(!revoked[hashedAddress], "Revoked Account Detected");
         isExists[_treeId][_subtreeId][hashedAddress] = true;
         // Insert leaf to Merkle Tree and store indexes
         merkleProofs[_treeId][_subtreeId][_newUser] = _insert(
@@ -548,6 +1318,103 @@ contract zkLogin is MerkleTreeWithHistory {
         return true;
     }
 
+    // Adds a User to the list of its Food Bank
+    function addFoodBankUser(uint256 _foodBank, uint256 _user) private {
+        foodBankUsers[_foodBank].push(_user);
+        registrars[_user] = _foodBank;
+    }
+
+    // Whether a Member was registered by a key of the registrar, its current or a previous one
+    function isRegistrar(
+        uint256 _member,
+        uint256 _registrar
+    ) private view returns (bool) {
+        uint256 registrar = registrars[_member];
+        if (registrar == 0) {
+            return false;
+        }
+        for (uint256 key = _registrar; key != 0; key = rotatedFrom[key]) {
+            if (key == registrar) {
+                return true;
+            }
+        }
+        return false;
+    }
+
+    // Recovers the signer of an EIP-191 hash from a 65 bytes signature
+    function recoverSigner(
+        bytes32 _hash,
+        bytes calldata _signature
+    ) private pure returns (address) {
+        require(_signature.length == 65, "Invalid Signature Length");
+        bytes32 r = bytes32(_signature[0:32]);
+        bytes32 s = bytes32(_signature[32:64]);
+        uint8 v = uint8(_signature[64]);
+        if (v < 27) {
+            v += 27;
+        }
+        address signer = ecrecover(_hash, v, r, s);
+        require(signer != address(0), "Invalid Signature");
+        return signer;
+    }
+
+    // Verify Ethereum Address ZKP, the check of validEthereumAddressZKP
+    function requireEthereumAddressZKP(
+        address _user,
+        Groth16Proof calldata _proof,
+        uint256[2] calldata _publicSignals
+    ) private view {
+        // Verify that 1 public signal is zero
+        require(
+            _publicSignals[1] == 0,
+            "zkEthereumAddress: Invalid Public Signals"
+        );
+        // Verify Proof (zkEthereumAddress)
+        require(
+            foodBankVerifier.verifyProof(
+                _proof.pi_a,
+                _proof.pi_b,
+                _proof.pi_c,
+                _publicSignals
+            ),
+            "zkEthereumAddress: Invalid Proofs"
+        );
+        // Verify that the hashedAddress of the transaction originator is the same as in
+        // circuit's output
+        require(
+            hashAddress(_user) == _publicSignals[0],
+            "zkEthereumAddress: Unauthorized Access"
+        );
+    }
+
+    // Checks a Merkle Tree ZKP like validMerkleTreeZKP, without reverting
+    function isValidMerkleTreeZKP(
+        uint32 _treeId,
+        uint32 _subtreeId,
+        address _user,
+        Groth16Proof calldata _proof,
+        uint256[2] calldata _publicSignals
+    ) private view returns (bool) {
+        if (
+            _user == address(0) ||
+            blacklist[_user] ||
+            revoked[_publicSignals[0]] ||
+            _publicSignals[0] == 0 ||
+            _publicSignals[1] == 0
+        ) {
+            return false;
+        }
+        return
+            hashAddress(_user) == _publicSignals[0] &&
+            isKnownRoot(_treeId, _subtreeId, _publicSignals[1]) &&
+            foodBankVerifier.verifyProof(
+                _proof.pi_a,
+                _proof.pi_b,
+                _proof.pi_c,
+                _publicSignals
+            );
+    }
+
     // Terminate a User Account
     function terminateAccount(
         address _user
@@ -559,36 +1426,122 @@ contract zkLogin is MerkleTreeWithHistory {
 
     // MerkleProof returns the PathElements and PathIndices
     function fetchMerkleProof(
-        uint32 _treeId,
-        uint32 _subtreeId
-    )
-        private
-        view
-        validAddress(_msgSender())
-        validTree(_treeId, 0)
-        validSubtree(_subtreeId)
-        validLevel(merkleTrees[_treeId][_subtreeId].levels)
-        returns (MerkleProof memory)
-    {
-        return merkleProofs[_treeId][_subtreeId][_msgSender()];
+        uint32 _role
+    ) private view validAddress(_msgSender()) returns (MerkleProof memory) {
+        RoleTree storage role = roleTree(_role);
+        return merkleProofs[role.tree][role.subtree][_msgSender()];
     }
 
     // Delete Merkle Proofs
     function deleteMerkleProof(
-        uint32 _treeId,
-        uint32 _subtreeId
-    )
-        private
-        validAddress(_msgSender())
-        validTree(_treeId, 0)
-        validSubtree(_subtreeId)
-        validLevel(merkleTrees[_treeId][_subtreeId].levels)
-        returns (bool)
-    {
-        delete merkleProofs[_treeId][_subtreeId][_msgSender()];
+        uint32 _role
+    ) private validAddress(_msgSender()) returns (bool) {
+        RoleTree storage role = roleTree(_role);
+        delete merkleProofs[role.tree][role.subtree][_msgSender()];
         return true;
     }
 
+    // Opens a proposal approved by its proposer, identified by its action, subject and expiry
+    function propose(
+        uint8 _action,
+        address _account,
+        uint256 _hashedAddress,
+        uint256 _proposer
+    ) private returns (bytes32) {
+        uint64 expiresAt = uint64(block.timestamp) + proposalLifetime;
+        bytes32 id = keccak256(
+            abi.encode(_action, _account, _hashedAddress, expiresAt)
+        );
+        require(proposals[id].expiresAt == 0, "Proposal already Exists");
+        proposals[id] = Proposal(
+            _action,
+            _account,
+            _hashedAddress,
+            expiresAt,
+            1,
+            false
+        );
+        approvals[id][_proposer] = true;
+        approvers[id].push(_proposer);
+        proposalIds.push(id);
+        return id;
+    }
+
+    // Counts the approvals of a proposal by Food Banks neither terminated nor revoked
+    function activeApprovals(
+        bytes32 _proposal
+    ) private view returns (uint32 count) {
+        uint256[] storage foodBanks = approvers[_proposal];
+        uint256 length = foodBanks.length;
+        for (uint256 i; i < length; i++) {
+            if (
+                !revoked[foodBanks[i]] && !terminatedFoodBanks[foodBanks[i]]
+            ) {
+                count++;
+            }
+        }
+    }
+
+    // Returns a proposal that can still be approved or executed
+    function pendingProposal(
+        bytes32 _proposal
+    ) private view returns (Proposal storage) {
+        Proposal storage proposal = proposals[_proposal];
+        require(proposal.expiresAt != 0, "Unknown Proposal Detected");
+        require(!proposal.executed, "Proposal already Executed");
+        require(block.timestamp < proposal.expiresAt, "Proposal Expired");
+        return proposal;
+    }
+
+    // Defines the role registry, each role on its own existing subtree
+    function defineRoles(
+        uint32[] memory _subtrees,
+        RoleDefinition[] memory _roles,
+        RolePermission[] memory _permissions
+    ) private {
+        for (uint256 i; i < _roles.length; i++) {
+            RoleDefinition memory definition = _roles[i];
+            require(!roles[definition.id].exists, "Role is already Defined");
+            require(
+                definition.tree < _subtrees.length &&
+                    definition.subtree < _subtrees[definition.tree],
+                "Invalid Role Tree Detected"
+            );
+            for (uint256 j; j < i; j++) {
+                require(
+                    _roles[j].tree != definition.tree ||
+                        _roles[j].subtree != definition.subtree,
+                    "Role Tree is already Assigned"
+                );
+            }
+            roles[definition.id] = RoleTree(
+                definition.tree,
+                definition.subtree,
+                true
+            );
+        }
+        require(
+            roles[FOODBANKS].exists && roles[USERS].exists,
+            "FoodBanks and Users Roles are required"
+        );
+
+        for (uint256 i; i < _permissions.length; i++) {
+            require(
+                roles[_permissions[i].registrar].exists &&
+                    roles[_permissions[i].role].exists,
+                "Unknown Role Detected"
+            );
+            permissions[_permissions[i].registrar][_permissions[i].role] = true;
+        }
+    }
+
+    // Returns the tree and subtree of a defined role
+    function roleTree(uint32 _role) private view returns (RoleTree storage) {
+        RoleTree storage role = roles[_role];
+        require(role.exists, "Unknown Role Detected");
+        return role;
+    }
+
     // Retrieves the address of the transactor
     function _msgSender() internal view virtual returns (address) {
         return msg.sender;
@@ -13,18 +13,23 @@ import (
 
 	verifier "deployer/internal/abigen/Verifier"
 	"deployer/internal/abigen/mimc"
-	zklogin "deployer/internal/abigen/zkLogin"
 	"deployer/internal/accounts"
 	"deployer/internal/addresses"
 	"deployer/internal/banner"
 	"deployer/internal/config"
 	"deployer/internal/directory"
 	"deployer/internal/ethutil"
+	"deployer/internal/hasher"
 	"deployer/internal/logger"
-	mimcsponge "deployer/internal/mimc"
+	"deployer/internal/membership"
+	"deployer/internal/poseidon"
+	"deployer/internal/session"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 var (
@@ -67,6 +72,11 @@ func main() {
 		banner.PrintBanner(cfg.Version)
 	}
 
+	// Refuse a zkLogin binding not compiled from the current zkLogin.sol before deploying anything
+	if err := membership.CheckBinding(); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Run go run cmd/main.go compile && go run cmd/main.go abigen in go-contracts")
+	}
+
 	// Delete Old Accounts
 	if err := directory.DeleteDir(cfg.AccountsDir); err != nil && !errors.Is(err, os.ErrNotExist) {
 		logger.Logger.Fatal().Err(err).Msgf("Failed to delete directory")
@@ -75,11 +85,10 @@ func main() {
 		logger.Logger.Fatal().Err(err).Msgf("Failed to create directory")
 	}
 
-	// Initialize Mimc
-	// Mimc is a global variable, so we can use it directly
-	mimcsponge, err := mimcsponge.NewMiMCSponge(mimcsponge.Seed, mimcsponge.MimcNbRounds)
+	// Initialize the Hasher (MiMC or Poseidon)
+	hasher, err := hasher.New(cfg.Hasher)
 	if err != nil {
-		logger.Logger.Fatal().Err(err).Msg("Failed to initialize MiMC Sponge")
+		logger.Logger.Fatal().Err(err).Str("hasher", string(cfg.Hasher)).Msg("Failed to initialize Hasher")
 	}
 
 	base := cfg.AccountsDir
@@ -89,7 +98,7 @@ func main() {
 	foodbanksPath := filepath.Join(base, fmt.Sprintf("%s.json", foodbanksFilename))
 	// Create new Accounts object
 	foodbanks := accounts.NewAccounts(foodbanksFilename)
-	foodbanks.SetMiMC(mimcsponge) // Set MiMC for hashing addresses
+	foodbanks.SetHasher(hasher) // Set Hasher for hashing addresses
 	// Create Accounts
 	foodbanks.CreateAccounts(cfg.AccountsNumber)
 	// Save to file
@@ -132,20 +141,50 @@ func main() {
 	trOpts.GasLimit = 20_000_000
 	trOpts.GasPrice = big.NewInt(0)
 
-	// Deploy Mimc
-	mimcAddress, txMimc, _, err := mimc.DeployMimc(trOpts, ethclient)
+	// Deploy the Hasher: the Mimc contract, or a Poseidon contract generated like circomlibjs
+	var (
+		hasherAddress common.Address
+		txHasher      *ethtypes.Transaction
+	)
+	switch cfg.Hasher {
+	case types.HasherPoseidon:
+		hasherAddress, txHasher, _, err = poseidon.DeployContract(trOpts, ethclient, 2)
+	default:
+		hasherAddress, txHasher, _, err = mimc.DeployMimc(trOpts, ethclient)
+	}
 	if err != nil {
-		logger.Logger.Fatal().Err(err).Str("contract", "Mimc").Msgf("Failed to deploy contract")
+		logger.Logger.Fatal().Err(err).Str("contract", string(cfg.Hasher)).Msgf("Failed to deploy contract")
 	}
-	_, err = bind.WaitMined(ctx, ethclient, txMimc)
+	_, err = bind.WaitMined(ctx, ethclient, txHasher)
 	if err != nil {
-		logger.Logger.Fatal().Err(err).Str("contract", "Mimc").Msgf("Failed to mine tx")
+		logger.Logger.Fatal().Err(err).Str("contract", string(cfg.Hasher)).Msgf("Failed to mine tx")
 	}
 
-	logger.Logger.Info().Str("address", mimcAddress.Hex()).Msg("Mimc")
-	contractAddresses.AddContract("mimc", mimcAddress) // Add the address to the contractAddresses object
+	logger.Logger.Info().Str("address", hasherAddress.Hex()).Msg(string(cfg.Hasher))
+	contractAddresses.AddContract(string(cfg.Hasher), hasherAddress) // Add the address to the contractAddresses object
+
+	// Deploy Verifier, which must embed the configured verification key (pinned by the manifest)
+	vkey, err := os.ReadFile(cfg.VerificationKeyFilename)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to read verification key")
+	}
+	if err := zkp.CheckVerifierBytecode(common.FromHex(verifier.VerifierMetaData.Bin), vkey); err != nil {
+		logger.Logger.Fatal().Err(err).Str("contract", "Verifier").Msg("Verifier binding does not match the verification key, regenerate it with gen-verifier")
+	}
+	if cfg.ManifestFilename != "" {
+		manifestKey, err := session.LoadVerificationKey(cfg.ManifestKeyFilename)
+		if err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to load manifest verification key")
+		}
+		manifest, err := zkp.LoadManifest(zkp.Path(cfg.ManifestFilename), manifestKey)
+		if err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to load ZK artifact manifest")
+		}
+		if err := zkp.CheckArtifact("vkey", manifest.Vkey, vkey); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Verification key does not match the manifest")
+		}
+	}
 
-	// Deploy Verifier
 	verifierAddress, txVerifier, _, err := verifier.DeployVerifier(trOpts, ethclient)
 	if err != nil {
 		logger.Logger.Fatal().Err(err).Str("contract", "Verifier").Msgf("Failed to deploy contract")
@@ -156,13 +195,13 @@ func main() {
 	}
 
 	logger.Logger.Info().Str("address", verifierAddress.Hex()).Msg("Verifier")
-	contractAddresses.AddContract("verifier", verifierAddress) // Add the address to the contractAddresses object
+	contractAddresses.AddVerifier("verifier", verifierAddress, zkp.SHA256(vkey)) // Add the address and the key hash to the contractAddresses object
 
 	// Get Foodbank addresses
 	fb := derefAddresses(foodbanks.ExtractAddresses())
 
-	// Deploy ZkLogin
-	zkLoginAddress, txZkLogin, _, err := zklogin.DeployZklogin(trOpts, ethclient, 2, []uint32{1, 1}, []uint32{32, 32}, mimcAddress, verifierAddress, fb)
+	// Deploy ZkLogin with the trees of the role registry
+	zkLoginAddress, txZkLogin, _, err := membership.DeployZkLogin(trOpts, ethclient, 32, hasherAddress, verifierAddress, fb, uint32(cfg.FoodBankQuorum), cfg.ProposalLifetime)
 	if err != nil {
 		logger.Logger.Fatal().Err(err).Str("contract", "ZkLogin").Msgf("Failed to deploy contract")
 	}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+
+	"deployer/internal/accounts"
+	"deployer/internal/addresses"
+	"deployer/internal/audit"
+	"deployer/internal/banner"
+	"deployer/internal/config"
+	"deployer/internal/directory"
+	"deployer/internal/ethutil"
+	"deployer/internal/gateway"
+	"deployer/internal/hasher"
+	"deployer/internal/indexer"
+	"deployer/internal/logger"
+	"deployer/internal/membership"
+	"deployer/internal/metrics"
+	"deployer/internal/session"
+	"deployer/internal/sign"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// Find the number of cpus the system has.
+	maxProcs = runtime.NumCPU()
+)
+
+func main() {
+	runtime.GOMAXPROCS(maxProcs)
+
+	// Initialize config first
+	cfg := config.NewConfig()
+
+	// Set GOMAXPROCS to the number of CPUs available
+	logger.Logger.Info().Msgf("Setting GOMAXPROCS to %d", maxProcs)
+	logger.Logger.Info().Msgf("Starting Gateway on %d CPU(s)", maxProcs)
+	logger.Logger.Info().Msgf("Go Version: %s", runtime.Version())
+	logger.Logger.Info().Msgf("OS: %s", runtime.GOOS)
+	logger.Logger.Info().Msgf("Architecture: %s", runtime.GOARCH)
+
+	// Load configuration
+	err := cfg.LoadConfig()
+	if err != nil {
+		// Log the error and exit
+		logger.Logger.Fatal().Err(err).Msg("Failed to load Config")
+	}
+
+	// Reload Logger if production mode is set
+	if cfg.LoggerMode == "production" {
+		logger.SetupLogger("production")
+		logger.Logger.Info().Msg("Production logger Initialized")
+	}
+
+	logger.Logger.Info().Msg("Configuration loaded Successfully")
+
+	// Print Banner
+	if !cfg.DisableBanner {
+		banner.PrintBanner(cfg.Version)
+	}
+
+	// Start collecting metrics before any proof or transaction
+	metrics.Enable()
+
+	// Stop on SIGINT/SIGTERM (SIGHUP is reserved for certificate reloads)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	server, err := gateway.NewServer(cfg)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to create gateway")
+	}
+
+	server.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	server.Handle("GET /metrics", metrics.Handler())
+
+	// Session signing key
+	var signingKey ed25519.PrivateKey
+	if cfg.SessionSigningKeyFilename != "" {
+		signingKey, err = session.LoadSigningKey(cfg.SessionSigningKeyFilename)
+	} else {
+		logger.Logger.Warn().Msg("No session signing key configured, using an ephemeral key (sessions will not survive a restart)")
+		signingKey, err = session.GenerateSigningKey()
+	}
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to load session signing key")
+	}
+
+	revocations := session.NewRevocations()
+	issuer, err := session.NewIssuer(signingKey, cfg.SessionIssuer, cfg.SessionAccessTTL, cfg.SessionRefreshTTL, revocations)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to create session issuer")
+	}
+
+	// Drop expired revocations once per access token lifetime
+	go func() {
+		ticker := time.NewTicker(cfg.SessionAccessTTL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				revocations.Prune()
+			}
+		}
+	}()
+
+	hasher, err := hasher.New(cfg.Hasher)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Str("hasher", string(cfg.Hasher)).Msg("Failed to initialize Hasher")
+	}
+
+	// Load Addresses
+	contractAddresses := addresses.NewAddresses()
+	if err := contractAddresses.LoadFromFile(filepath.Join(cfg.AddressesDir, fmt.Sprintf("%s.json", "addresses"))); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to load contract addresses")
+	}
+
+	zkLoginAddress, err := contractAddresses.GetContractAddressByName("zklogin")
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Str("contract", "ZkLogin").Msg("Failed to get contract address")
+	}
+
+	// Signed manifest of the ZK artifacts, the prover and the verifier refuse any other
+	var manifest *types.ArtifactManifest
+	if cfg.ManifestFilename != "" {
+		manifestKey, err := session.LoadVerificationKey(cfg.ManifestKeyFilename)
+		if err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to load manifest verification key")
+		}
+		if manifest, err = zkp.LoadManifest(zkp.Path(cfg.ManifestFilename), manifestKey); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to load ZK artifact manifest")
+		}
+		logger.Logger.Info().Str("circuit", manifest.Circuit).Int("nPublic", manifest.NPublic).Msg("ZK artifact manifest verified")
+	} else {
+		logger.Logger.Warn().Msg("No ZK artifact manifest, the wasm, zkey and verification key are not checked")
+	}
+
+	// The deployed Verifier must check proofs of the same verification key
+	vkey, err := os.ReadFile(cfg.VerificationKeyFilename)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to read verification key")
+	}
+	if manifest != nil {
+		if err := zkp.CheckArtifact("vkey", manifest.Vkey, vkey); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Verification key does not match the manifest")
+		}
+	}
+	vkeyHash, err := contractAddresses.GetVerificationKeyHash("verifier")
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Str("contract", "Verifier").Msg("Failed to get contract verification key hash")
+	}
+	if vkeyHash == "" {
+		logger.Logger.Warn().Str("contract", "Verifier").Msg("Deployment did not record the verification key hash, redeploy to check it")
+	} else if err := zkp.CheckArtifact("vkey", vkeyHash, vkey); err != nil {
+		logger.Logger.Fatal().Err(err).Str("contract", "Verifier").Msg("Verification key differs from the deployed Verifier")
+	}
+
+	// Connect to EthClient
+	client, chainId, err := ethutil.NewEthClient(ctx, cfg.GethNodeUrl)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to connect to Ethereum node")
+	}
+	defer client.Close()
+
+	// Record the latency of every RPC method
+	backend := ethutil.NewInstrumentedClient(client.EthClient)
+
+	// Operator: the food bank account that proves its membership for registrations
+	foodbanksFilename := "foodBanks"
+	foodbanks := accounts.NewAccounts(foodbanksFilename)
+	foodbanks.SetHasher(hasher)
+	if err := foodbanks.LoadFromFile(filepath.Join(cfg.AccountsDir, fmt.Sprintf("%s.json", foodbanksFilename))); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to load food bank accounts")
+	}
+
+	operatorPrivateKeyHex, err := foodbanks.GetPrivateKey(cfg.OperatorAccountIndex)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Int("index", cfg.OperatorAccountIndex).Msg("Failed to fetch operator private key from accounts")
+	}
+
+	operatorPrivateKey := sign.NewECDSA()
+	if err := operatorPrivateKey.LoadPrivateKeyFromHex(operatorPrivateKeyHex); err != nil {
+		logger.Logger.Fatal().Err(err).Int("index", cfg.OperatorAccountIndex).Msg("Failed to load operator private key")
+	}
+
+	// Load the witness calculator and the Zkey into a prover object
+	prover, err := zkp.NewProver(zkp.WitnessSource{
+		Backend: cfg.WitnessBackend,
+		Wasm:    zkp.Path(cfg.WasmFilename),
+		Binary:  zkp.Path(cfg.WitnessBinaryFilename),
+	}, zkp.Path(cfg.ZkeyFilename), manifest)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to initialize ZKP prover")
+	}
+
+	service, err := membership.NewService(backend, chainId, zkLoginAddress, operatorPrivateKey.GetPrivateKey(), prover, hasher)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Str("contract", "zkLogin").Msg("Failed to create membership service")
+	}
+	logger.Logger.Info().Str("operator", service.Operator().Address().Hex()).Msg("Membership operator loaded")
+
+	// Indexer: off-chain replica of the zkLogin trees
+	idx, err := indexer.New(ctx, backend, zkLoginAddress, hasher, cfg.IndexerStartBlock, cfg.IndexerPollInterval)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to create indexer")
+	}
+	idx.SetConfirmations(cfg.IndexerConfirmations)
+
+	// Revoke the sessions of terminated accounts, whoever relayed the transaction
+	events, unsubscribe := idx.Subscribe(1024)
+	defer unsubscribe()
+	go func() {
+		for event := range events {
+			if event.Type == indexer.EventRevoked {
+				issuer.Revoke(event.HashedAddress)
+			}
+		}
+		if ctx.Err() == nil {
+			logger.Logger.Fatal().Msg("Revocation subscriber fell behind the indexer")
+		}
+	}()
+
+	// Audit log of every zkLogin interaction, written before a block is marked as indexed
+	if cfg.AuditLogFilename != "" {
+		if err := directory.CreateDirIfNotExists(filepath.Dir(cfg.AuditLogFilename)); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to create audit log directory")
+		}
+
+		auditLog, err := audit.Open(cfg.AuditLogFilename)
+		if err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to open audit log")
+		}
+		defer auditLog.Close()
+		idx.SetRecorder(auditLog)
+
+		if cfg.AuditAnchorInterval > 0 {
+			go auditLog.RunAnchors(ctx, service, cfg.AuditAnchorInterval)
+		}
+	}
+
+	// Cache of the operator proof, reused while the food bank root does not change
+	if cfg.ProofCacheTTL > 0 {
+		cache := zkp.NewProofCache(cfg.ProofCacheTTL)
+		if cfg.ProofCacheFilename != "" {
+			if err := directory.CreateDirIfNotExists(filepath.Dir(cfg.ProofCacheFilename)); err != nil {
+				logger.Logger.Fatal().Err(err).Msg("Failed to create proof cache directory")
+			}
+
+			secret := crypto.FromECDSA(operatorPrivateKey.GetPrivateKey())
+			cache, err = zkp.OpenProofCache(cfg.ProofCacheFilename, secret, cfg.ProofCacheTTL)
+			if errors.Is(err, zkp.ErrInvalidProofCache) {
+				// Written under another operator key, or corrupted: start over
+				logger.Logger.Warn().Err(err).Str("file", cfg.ProofCacheFilename).Msg("Discarding proof cache")
+				if err := os.Remove(cfg.ProofCacheFilename); err != nil {
+					logger.Logger.Fatal().Err(err).Msg("Failed to remove proof cache")
+				}
+				cache, err = zkp.OpenProofCache(cfg.ProofCacheFilename, secret, cfg.ProofCacheTTL)
+			}
+			if err != nil {
+				logger.Logger.Fatal().Err(err).Msg("Failed to open proof cache")
+			}
+		}
+		service.UseProofCache(cache, idx)
+	}
+
+	go func() {
+		if err := idx.Run(ctx); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Indexer failed")
+		}
+	}()
+
+	var proofVerifier session.ProofVerifier
+	switch cfg.SessionVerification {
+	case types.LoginVerificationLocal:
+		verifier, err := zkp.NewVerifier(zkp.Path(cfg.VerificationKeyFilename), manifest)
+		if err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to initialize ZKP verifier")
+		}
+
+		proofVerifier = session.NewLocalProofVerifier(verifier, hasher, idx)
+	case types.LoginVerificationOnChain:
+		proofVerifier, err = session.NewChainProofVerifier(zkLoginAddress, backend)
+		if err != nil {
+			logger.Logger.Fatal().Err(err).Str("contract", "zkLogin").Msg("Failed to conncect to contract")
+		}
+	}
+	logger.Logger.Info().Str("verification", string(cfg.SessionVerification)).Msg("Session login verification")
+
+	challenges, err := session.NewChallenges(cfg.SessionIssuer, cfg.SessionChallengeTTL)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to create login challenges")
+	}
+	go func() {
+		ticker := time.NewTicker(cfg.SessionChallengeTTL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				challenges.Prune()
+			}
+		}
+	}()
+	proofVerifier = session.NewChallengeVerifier(challenges, hasher, proofVerifier)
+
+	gateway.NewSessionHandler(issuer, challenges, proofVerifier).Register(server)
+	gateway.NewMembershipHandler(service, issuer).Register(server)
+
+	if cfg.GRPCAddress != "" {
+		grpcServer, err := gateway.NewGRPCServer(cfg.GRPCAddress, server, issuer, service, idx)
+		if err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to create gRPC server")
+		}
+
+		go func() {
+			if err := grpcServer.Run(ctx); err != nil {
+				logger.Logger.Fatal().Err(err).Msg("gRPC server failed")
+			}
+		}()
+	}
+
+	if err := server.Run(ctx); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Gateway failed")
+	}
+}
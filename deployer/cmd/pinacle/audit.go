@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"deployer/internal/audit"
+	"deployer/internal/ethutil"
+	"deployer/internal/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Node used to check the on-chain anchors, empty skips them
+	auditRPC string
+	// Operator address expected to send the anchors
+	auditOperator string
+
+	auditCMD = &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the gateway audit log",
+	}
+
+	auditVerifyCMD = &cobra.Command{
+		Use:   "verify <audit.jsonl>",
+		Short: "Verify the hash chain of an audit log and, with --rpc, its on-chain anchors",
+		Example: `
+  pinacle audit verify ./audit/audit.jsonl
+  pinacle audit verify ./audit/audit.jsonl --rpc http://localhost:8545 --operator 0x5FbDB2315678afecb367f032d93F642f64180aa3
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := audit.VerifyFile(args[0])
+			if err != nil {
+				return err
+			}
+			logger.Logger.Info().Uint64("entries", report.Entries).Str("head", report.Head.Hex()).Msg("Audit log hash chain verified")
+
+			if auditRPC == "" {
+				if len(report.Anchors) > 0 {
+					logger.Logger.Warn().Int("anchors", len(report.Anchors)).Msg("On-chain anchors not checked, use --rpc")
+				}
+				return nil
+			}
+
+			if !common.IsHexAddress(auditOperator) {
+				return fmt.Errorf("--operator must be the address that sends the anchors, got %q", auditOperator)
+			}
+
+			ctx := context.Background()
+			client, _, err := ethutil.NewEthClient(ctx, auditRPC)
+			if err != nil {
+				return fmt.Errorf("failed to connect to Ethereum node: %w", err)
+			}
+			defer client.Close()
+
+			if err := audit.VerifyAnchors(ctx, client.EthClient, report.Anchors, common.HexToAddress(auditOperator)); err != nil {
+				return err
+			}
+			logger.Logger.Info().Int("anchors", len(report.Anchors)).Msg("Audit log anchors verified")
+			return nil
+		},
+	}
+)
+
+func init() {
+	auditVerifyCMD.Flags().StringVar(&auditRPC, "rpc", "", "RPC URL of a node to check the on-chain anchors")
+	auditVerifyCMD.Flags().StringVar(&auditOperator, "operator", "", "Address of the gateway operator that sends the anchors, required with --rpc")
+	auditCMD.AddCommand(auditVerifyCMD)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"path/filepath"
+
+	"deployer/internal/accounts"
+	"deployer/internal/addresses"
+	"deployer/internal/config"
+	"deployer/internal/ethutil"
+	"deployer/internal/hasher"
+	"deployer/internal/membership"
+	"deployer/internal/session"
+	"deployer/internal/sign"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// loadManifest loads the signed ZK artifact manifest, nil when none is configured.
+func loadManifest(cfg *config.Config) (*types.ArtifactManifest, error) {
+	if cfg.ManifestFilename == "" {
+		return nil, nil
+	}
+	manifestKey, err := session.LoadVerificationKey(cfg.ManifestKeyFilename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest verification key: %w", err)
+	}
+	manifest, err := zkp.LoadManifest(zkp.Path(cfg.ManifestFilename), manifestKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ZK artifact manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// newOperatorService connects to the node and binds a membership service acting as the food bank
+// at index in accounts/foodBanks.json. The returned function closes the connection.
+func newOperatorService(ctx context.Context, cfg *config.Config, hasher hasher.Hasher, manifest *types.ArtifactManifest, index int) (*membership.Service, func(), error) {
+	foodbanks := accounts.NewAccounts("foodBanks")
+	foodbanks.SetHasher(hasher)
+	if err := foodbanks.LoadFromFile(filepath.Join(cfg.AccountsDir, "foodBanks.json")); err != nil {
+		return nil, nil, fmt.Errorf("failed to load food bank accounts: %w", err)
+	}
+	operatorPrivateKeyHex, err := foodbanks.GetPrivateKey(index)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch operator private key: %w", err)
+	}
+	operatorPrivateKey := sign.NewECDSA()
+	if err := operatorPrivateKey.LoadPrivateKeyFromHex(operatorPrivateKeyHex); err != nil {
+		return nil, nil, fmt.Errorf("failed to load operator private key: %w", err)
+	}
+	return newService(ctx, cfg, hasher, manifest, operatorPrivateKey.GetPrivateKey())
+}
+
+// newService connects to the node and binds a membership service acting as the account of key.
+// The returned function closes the connection.
+func newService(ctx context.Context, cfg *config.Config, hasher hasher.Hasher, manifest *types.ArtifactManifest, key *ecdsa.PrivateKey) (*membership.Service, func(), error) {
+	contractAddresses := addresses.NewAddresses()
+	if err := contractAddresses.LoadFromFile(filepath.Join(cfg.AddressesDir, "addresses.json")); err != nil {
+		return nil, nil, fmt.Errorf("failed to load contract addresses: %w", err)
+	}
+	zkLoginAddress, err := contractAddresses.GetContractAddressByName("zklogin")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get zkLogin address: %w", err)
+	}
+
+	prover, err := zkp.NewProver(zkp.WitnessSource{
+		Backend: cfg.WitnessBackend,
+		Wasm:    zkp.Path(cfg.WasmFilename),
+		Binary:  zkp.Path(cfg.WitnessBinaryFilename),
+	}, zkp.Path(cfg.ZkeyFilename), manifest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize ZKP prover: %w", err)
+	}
+
+	client, chainId, err := ethutil.NewEthClient(ctx, cfg.GethNodeUrl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+
+	service, err := membership.NewService(client.EthClient, chainId, zkLoginAddress, key, prover, hasher)
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	return service, client.Close, nil
+}
+
+// newFoodBankService loads the config and binds a membership service acting as the food bank at
+// index in accounts/foodBanks.json, OPERATOR_ACCOUNT_INDEX when negative.
+func newFoodBankService(ctx context.Context, index int) (*membership.Service, hasher.Hasher, func(), error) {
+	cfg, hasher, manifest, err := loadServiceConfig()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if index < 0 {
+		index = cfg.OperatorAccountIndex
+	}
+	service, closeClient, err := newOperatorService(ctx, cfg, hasher, manifest, index)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return service, hasher, closeClient, nil
+}
+
+// loadServiceConfig loads the config, the hasher and the ZK artifact manifest of a membership service.
+func loadServiceConfig() (*config.Config, hasher.Hasher, *types.ArtifactManifest, error) {
+	cfg := config.NewConfig()
+	if err := cfg.LoadConfig(); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	hasher, err := hasher.New(cfg.Hasher)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to initialize hasher: %w", err)
+	}
+
+	manifest, err := loadManifest(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cfg, hasher, manifest, nil
+}
+
+// parseHashedAddress parses a hashed address, in decimal or 0x-hex, or hashes an address.
+func parseHashedAddress(s string, hasher hasher.Hasher) (*big.Int, error) {
+	if common.IsHexAddress(s) {
+		account := common.HexToAddress(s)
+		return hasher.HashAddress(&account), nil
+	}
+	hashedAddress, ok := new(big.Int).SetString(s, 0)
+	if !ok || hashedAddress.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid address or hashed address %q", s)
+	}
+	return hashedAddress, nil
+}
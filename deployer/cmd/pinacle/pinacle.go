@@ -12,8 +12,9 @@ import (
 	"deployer/internal/banner"
 	"deployer/internal/config"
 	"deployer/internal/ethutil"
+	"deployer/internal/hasher"
 	"deployer/internal/logger"
-	"deployer/internal/mimc"
+	"deployer/internal/session"
 	"deployer/internal/sign"
 	"deployer/internal/types"
 	"deployer/internal/zkp"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/spf13/cobra"
 )
 
 var (
@@ -33,9 +35,34 @@ var (
 
 	// Keep track of the last processed block from events
 	LatestProcessedBlockNumber atomic.Uint64
+
+	// Commands
+	rootCMD = &cobra.Command{
+		Use:   "pinacle",
+		Short: "Generate the zkMerkleTree proofs of a food bank",
+		Run: func(cmd *cobra.Command, args []string) {
+			run()
+		},
+		SilenceUsage:  true, // Avoid showing usage on errors like "flag not found"
+		SilenceErrors: true, // Avoid showing errors on command execution
+	}
 )
 
 func main() {
+	rootCMD.AddCommand(auditCMD)
+	rootCMD.AddCommand(zkCMD)
+	rootCMD.AddCommand(registerUsersCMD)
+	rootCMD.AddCommand(proposalCMD)
+	rootCMD.AddCommand(revokeCMD)
+	rootCMD.AddCommand(rotateKeyCMD)
+
+	if err := rootCMD.Execute(); err != nil {
+		logger.Logger.Fatal().Msgf("Command failed: %v", err)
+	}
+}
+
+// run generates the zkEthereumAddress and zkMerkleTree proofs of the first food bank.
+func run() {
 	runtime.GOMAXPROCS(maxProcs)
 
 	// Initialize config first
@@ -68,11 +95,10 @@ func main() {
 		banner.PrintBanner(cfg.Version)
 	}
 
-	// Initialize Mimc
-	// Mimc is a global variable, so we can use it directly
-	mimc, err := mimc.NewMiMCSponge(mimc.Seed, mimc.MimcNbRounds)
+	// Initialize the Hasher (MiMC or Poseidon)
+	hasher, err := hasher.New(cfg.Hasher)
 	if err != nil {
-		logger.Logger.Fatal().Err(err).Msg("Failed to initialize MiMC Sponge")
+		logger.Logger.Fatal().Err(err).Str("hasher", string(cfg.Hasher)).Msg("Failed to initialize Hasher")
 	}
 
 	// Load Accounts
@@ -83,7 +109,7 @@ func main() {
 	foodbanksPath := filepath.Join(accountsBase, fmt.Sprintf("%s.json", foodbanksFilename))
 	// Create new Accounts object
 	foodbanks := accounts.NewAccounts(foodbanksFilename)
-	foodbanks.SetMiMC(mimc) // Set MiMC for hashing addresses
+	foodbanks.SetHasher(hasher) // Set Hasher for hashing addresses
 	foodbanks.LoadFromFile(foodbanksPath)
 
 	// Load Addresses
@@ -96,13 +122,29 @@ func main() {
 	contractAddresses := addresses.NewAddresses()
 	contractAddresses.LoadFromFile(contractAddressesPath)
 
-	// Load Wasm and Zkey into a prover object
-	prover, err := zkp.NewProver(zkp.Path(cfg.WasmFilename), zkp.Path(cfg.ZkeyFilename))
+	// Signed manifest of the ZK artifacts, the prover refuses any other
+	var manifest *types.ArtifactManifest
+	if cfg.ManifestFilename != "" {
+		manifestKey, err := session.LoadVerificationKey(cfg.ManifestKeyFilename)
+		if err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to load manifest verification key")
+		}
+		if manifest, err = zkp.LoadManifest(zkp.Path(cfg.ManifestFilename), manifestKey); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to load ZK artifact manifest")
+		}
+	}
+
+	// Load the witness calculator and the Zkey into a prover object
+	prover, err := zkp.NewProver(zkp.WitnessSource{
+		Backend: cfg.WitnessBackend,
+		Wasm:    zkp.Path(cfg.WasmFilename),
+		Binary:  zkp.Path(cfg.WitnessBinaryFilename),
+	}, zkp.Path(cfg.ZkeyFilename), manifest)
 	if err != nil {
 		logger.Logger.Fatal().Err(err).Msg("Failed to initialize ZKP prover")
 	}
 
-	// verifier, err := zkp.NewVerifier(zkp.Path(cfg.VerificationKeyFilename))
+	// verifier, err := zkp.NewVerifier(zkp.Path(cfg.VerificationKeyFilename), manifest)
 	// if err != nil {
 	// 	logger.Logger.Fatal().Err(err).Msg("Failed to initialize ZKP verifier")
 	// }
@@ -176,8 +218,8 @@ func main() {
 		logger.Logger.Fatal().Err(err).Uint32("treeId", uint32(types.RoleFoodBank)).Uint8("zkp", uint8(types.ZKEthereumAddress)).Msg("Failed to convert zk proofs")
 	}
 
-	publicSignalsConverted, err := proofs.ConvertPublicSignals()
-	if err != nil {
+	var publicSignalsConverted [types.PINACLE_PUBLIC_SIGNALS]*big.Int
+	if err := proofs.ConvertPublicSignalsTo(publicSignalsConverted[:]); err != nil {
 		logger.Logger.Fatal().Err(err).Uint32("treeId", uint32(types.RoleFoodBank)).Uint8("zkp", uint8(types.ZKEthereumAddress)).Msg("Failed to convert zk public signals")
 	}
 
@@ -208,8 +250,7 @@ func main() {
 		logger.Logger.Fatal().Err(err).Uint32("treeId", uint32(types.RoleFoodBank)).Uint8("zkp", uint8(types.ZKMerkleTree)).Msg("Failed to convert zk proofs")
 	}
 
-	publicSignalsConverted, err = proofs.ConvertPublicSignals()
-	if err != nil {
+	if err := proofs.ConvertPublicSignalsTo(publicSignalsConverted[:]); err != nil {
 		logger.Logger.Fatal().Err(err).Uint32("treeId", uint32(types.RoleFoodBank)).Uint8("zkp", uint8(types.ZKMerkleTree)).Msg("Failed to convert zk public signals")
 	}
 
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"deployer/internal/logger"
+	"deployer/internal/membership"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Food bank of accounts/foodBanks.json acting, -1 for OPERATOR_ACCOUNT_INDEX
+	proposalAccountIndex int
+	// List executed and expired proposals too
+	proposalListAll bool
+
+	proposalCMD = &cobra.Command{
+		Use:   "proposal",
+		Short: "Add or terminate food banks with the approval of the food bank quorum",
+		Long: `Food banks are added and terminated by proposals once zkLogin requires more than one approval
+(FOODBANK_QUORUM at deployment). A proposal counts the approval of its proposer, each food bank
+approves it once with its zkMerkleTree proof, and any food bank executes it once the quorum is
+reached, before it expires (FOODBANK_PROPOSAL_LIFETIME).`,
+	}
+
+	proposalProposeCMD = &cobra.Command{
+		Use:   "propose <add|terminate> <address> [proof]",
+		Short: "Propose to add a food bank, given its zkEthereumAddress proof, or to terminate one",
+		Long: `Propose to add the food bank of the address, given its zkEthereumAddress proof, or to terminate
+the food bank of the address or hashed address (decimal or 0x-hex).`,
+		Example: `
+  pinacle proposal propose add 0x5FbDB2315678afecb367f032d93F642f64180aa3 ./proof.json
+  pinacle proposal propose terminate 0x5FbDB2315678afecb367f032d93F642f64180aa3 --account 1
+  pinacle proposal propose terminate 1257894000000000000000000000000000000000000000000000000000000000000000000000
+`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			service, hasher, closeClient, err := newFoodBankService(ctx, proposalAccountIndex)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			var proposed *membership.Proposed
+			switch args[0] {
+			case "add":
+				if len(args) != 3 {
+					return fmt.Errorf("adding a food bank requires its zkEthereumAddress proof")
+				}
+				if !common.IsHexAddress(args[1]) {
+					return fmt.Errorf("invalid address %q", args[1])
+				}
+				account := common.HexToAddress(args[1])
+				proof, err := membership.ReadAddressProof(args[2], hasher, account)
+				if err != nil {
+					return err
+				}
+				proposed, err = service.ProposeFoodBank(ctx, account, proof)
+				if err != nil {
+					return err
+				}
+			case "terminate":
+				if len(args) != 2 {
+					return fmt.Errorf("terminating a food bank takes no proof")
+				}
+				hashedAddress, err := parseHashedAddress(args[1], hasher)
+				if err != nil {
+					return err
+				}
+				proposed, err = service.ProposeFoodBankTermination(ctx, hashedAddress)
+				if err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unknown proposal action %q, expected add or terminate", args[0])
+			}
+
+			logger.Logger.Info().
+				Str("proposal", proposed.ID.Hex()).
+				Str("action", args[0]).
+				Str("account", args[1]).
+				Str("tx", proposed.Hash.Hex()).
+				Msg("Food bank proposed")
+			return nil
+		},
+	}
+
+	proposalApproveCMD = &cobra.Command{
+		Use:   "approve <proposal>",
+		Short: "Approve a pending proposal",
+		Example: `
+  pinacle proposal approve 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925 --account 2
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transactProposal(args[0], "Proposal approved", (*membership.Service).ApproveProposal)
+		},
+	}
+
+	proposalExecuteCMD = &cobra.Command{
+		Use:   "execute <proposal>",
+		Short: "Execute a pending proposal approved by the quorum",
+		Example: `
+  pinacle proposal execute 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transactProposal(args[0], "Proposal executed", (*membership.Service).ExecuteProposal)
+		},
+	}
+
+	proposalListCMD = &cobra.Command{
+		Use:   "list",
+		Short: "List the pending proposals, with --all the executed and expired ones too",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			service, _, closeClient, err := newFoodBankService(ctx, proposalAccountIndex)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			governance, err := service.Governance(ctx)
+			if err != nil {
+				return err
+			}
+			proposals, err := service.Proposals(ctx)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			for _, proposal := range proposals {
+				expired := proposal.Expired(now)
+				if !proposalListAll && (proposal.Executed || expired) {
+					continue
+				}
+				logger.Logger.Info().
+					Str("proposal", proposal.ID.Hex()).
+					Stringer("action", proposal.Action).
+					Str("account", proposal.Account.Hex()).
+					Uint32("approvals", proposal.Approvals).
+					Uint32("quorum", governance.Quorum).
+					Bool("approvedByMe", proposal.Approved).
+					Bool("executed", proposal.Executed).
+					Bool("expired", expired).
+					Time("expiresAt", proposal.ExpiresAt).
+					Msg("Proposal")
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	proposalCMD.PersistentFlags().IntVar(&proposalAccountIndex, "account", -1, "Food bank account index in accounts/foodBanks.json (default OPERATOR_ACCOUNT_INDEX)")
+	proposalListCMD.Flags().BoolVar(&proposalListAll, "all", false, "Also list the executed and expired proposals")
+	proposalCMD.AddCommand(proposalProposeCMD, proposalApproveCMD, proposalExecuteCMD, proposalListCMD)
+}
+
+// transactProposal approves or executes the proposal of the hex ID.
+func transactProposal(hexID, msg string, transact func(*membership.Service, context.Context, common.Hash) (*membership.Receipt, error)) error {
+	id, err := parseProposalID(hexID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	service, _, closeClient, err := newFoodBankService(ctx, proposalAccountIndex)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	receipt, err := transact(service, ctx, id)
+	if err != nil {
+		return err
+	}
+	logger.Logger.Info().
+		Str("proposal", id.Hex()).
+		Str("tx", receipt.Hash.Hex()).
+		Uint64("block", receipt.BlockNumber).
+		Msg(msg)
+	return nil
+}
+
+// parseProposalID parses a 0x-prefixed 32-byte hex proposal ID.
+func parseProposalID(s string) (common.Hash, error) {
+	data, err := hexutil.Decode(s)
+	if err != nil || len(data) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid proposal ID %q, expected 32 bytes of 0x-hex", s)
+	}
+	return common.BytesToHash(data), nil
+}
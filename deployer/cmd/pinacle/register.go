@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"deployer/internal/config"
+	"deployer/internal/hasher"
+	"deployer/internal/logger"
+	"deployer/internal/membership"
+	"deployer/internal/zkp"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Users per registerUsers transaction, bounded by the block gas limit
+	registerBatchSize int
+	// Check the batch without sending it
+	registerDryRun bool
+
+	registerUsersCMD = &cobra.Command{
+		Use:   "register-users <users.csv>",
+		Short: "Register the users of a CSV of addresses and zkEthereumAddress proofs in batches",
+		Long: `Register the users of a CSV of "address,proof" rows with registerUsers, as the operator food bank.
+The proof is the snarkjs JSON, the soliditycalldata, the 0x-hex compact encoding or the path
+of a snarkjs JSON file. The proofs are verified off-chain first; a batch is registered whole or not at all.`,
+		Example: `
+  pinacle register-users ./users.csv
+  pinacle register-users ./users.csv --batch-size 10 --dry-run
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if registerBatchSize < 1 {
+				return fmt.Errorf("batch size must be positive, got %d", registerBatchSize)
+			}
+
+			cfg := config.NewConfig()
+			if err := cfg.LoadConfig(); err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			hasher, err := hasher.New(cfg.Hasher)
+			if err != nil {
+				return fmt.Errorf("failed to initialize hasher: %w", err)
+			}
+
+			registrations, err := membership.ReadRegistrations(args[0], hasher)
+			if err != nil {
+				return err
+			}
+			if len(registrations) == 0 {
+				return fmt.Errorf("no users in %s", args[0])
+			}
+
+			manifest, err := loadManifest(cfg)
+			if err != nil {
+				return err
+			}
+
+			// Pre-screen the proofs, the contract reverting a batch holding an invalid one
+			verifier, err := zkp.NewVerifier(zkp.Path(cfg.VerificationKeyFilename), manifest)
+			if err != nil {
+				return fmt.Errorf("failed to initialize ZKP verifier: %w", err)
+			}
+			proofs := make([]*zkp.ZKProof, len(registrations))
+			for i := range registrations {
+				proofs[i] = registrations[i].Proof
+			}
+			results, err := verifier.VerifyBatch(proofs)
+			if err != nil {
+				return fmt.Errorf("failed to verify proofs: %w", err)
+			}
+			invalid := 0
+			for i, ok := range results {
+				if !ok {
+					invalid++
+					logger.Logger.Error().Int("line", registrations[i].Line).Str("address", registrations[i].Account.Hex()).Msg("Invalid zkEthereumAddress proof")
+				}
+			}
+			if invalid > 0 {
+				return fmt.Errorf("%w: %d invalid proofs", membership.ErrInvalidRegistration, invalid)
+			}
+			logger.Logger.Info().Int("users", len(registrations)).Msg("Registrations verified")
+
+			if registerDryRun {
+				return nil
+			}
+
+			ctx := context.Background()
+			service, closeClient, err := newOperatorService(ctx, cfg, hasher, manifest, cfg.OperatorAccountIndex)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			for start := 0; start < len(registrations); start += registerBatchSize {
+				batch := registrations[start:min(start+registerBatchSize, len(registrations))]
+				receipt, err := service.RegisterUsers(ctx, batch)
+				if err != nil {
+					return fmt.Errorf("failed to register lines %d-%d (%d users registered before): %w", batch[0].Line, batch[len(batch)-1].Line, start, err)
+				}
+				logger.Logger.Info().
+					Int("users", len(batch)).
+					Str("tx", receipt.Hash.Hex()).
+					Uint64("block", receipt.BlockNumber).
+					Uint64("gasUsed", receipt.GasUsed).
+					Msg("Users registered")
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	registerUsersCMD.Flags().IntVar(&registerBatchSize, "batch-size", 25, "Users per registerUsers transaction")
+	registerUsersCMD.Flags().BoolVar(&registerDryRun, "dry-run", false, "Check the CSV and verify the proofs without registering")
+}
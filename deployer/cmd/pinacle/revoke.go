@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"deployer/internal/logger"
+	"deployer/internal/types"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Food bank of accounts/foodBanks.json acting, -1 for OPERATOR_ACCOUNT_INDEX
+	revokeAccountIndex int
+
+	revokeCMD = &cobra.Command{
+		Use:   "revoke <role> <address>",
+		Short: "Revoke a member the food bank registered, by address or hashed address",
+		Long: `Revoke the member of the role with the address or hashed address (decimal or 0x-hex) with
+revokeMember, as the food bank that registered it. The member can no longer prove its membership
+nor log in to the gateway. Food banks added by proposal or at deployment are terminated with
+"pinacle proposal propose terminate" instead.`,
+		Example: `
+  pinacle revoke User 0x5FbDB2315678afecb367f032d93F642f64180aa3
+  pinacle revoke Volunteer 1257894000000000000000000000000000000000000000000000000000000000000000000000 --account 1
+`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			role, err := types.ParseRole(args[0])
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			service, hasher, closeClient, err := newFoodBankService(ctx, revokeAccountIndex)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			hashedAddress, err := parseHashedAddress(args[1], hasher)
+			if err != nil {
+				return err
+			}
+			receipt, err := service.Revoke(ctx, role, hashedAddress)
+			if err != nil {
+				return fmt.Errorf("failed to revoke %s %s: %w", role, args[1], err)
+			}
+			logger.Logger.Info().
+				Stringer("role", role).
+				Str("hashedAddress", hashedAddress.String()).
+				Str("tx", receipt.Hash.Hex()).
+				Uint64("block", receipt.BlockNumber).
+				Msg("Member revoked")
+			return nil
+		},
+	}
+)
+
+func init() {
+	revokeCMD.Flags().IntVar(&revokeAccountIndex, "account", -1, "Food bank account index in accounts/foodBanks.json (default OPERATOR_ACCOUNT_INDEX)")
+}
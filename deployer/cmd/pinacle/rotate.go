@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"deployer/internal/logger"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var rotateKeyCMD = &cobra.Command{
+	Use:   "rotate-key <role> <old-key-file> <new-key-file>",
+	Short: "Move the membership of a role to a new key, e.g. after a change of device",
+	Long: `Move the membership of the role from the old key to the new key with rotateKey, each file
+holding a hex private key. The new key is generated and saved when its file does not exist.
+The old key proves its membership and signs over the new address, the new key proves its
+address and sends the transaction. The old key is revoked. The member that registered it may
+still revoke the new key, and a food bank finds it next to the old one. Food banks cannot rotate
+their keys.`,
+	Example: `
+  pinacle rotate-key User ./old.key ./new.key
+`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		role, err := types.ParseRole(args[0])
+		if err != nil {
+			return err
+		}
+		oldKey, err := crypto.LoadECDSA(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to load old key: %w", err)
+		}
+		newKey, err := loadOrGenerateKey(args[2])
+		if err != nil {
+			return err
+		}
+
+		cfg, hasher, manifest, err := loadServiceConfig()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		service, closeClient, err := newService(ctx, cfg, hasher, manifest, newKey)
+		if err != nil {
+			return err
+		}
+		defer closeClient()
+
+		rotation, err := service.RotateKey(ctx, role, oldKey)
+		if err != nil {
+			return fmt.Errorf("failed to rotate %s key: %w", role, err)
+		}
+		logger.Logger.Info().
+			Stringer("role", role).
+			Str("oldAddress", crypto.PubkeyToAddress(oldKey.PublicKey).Hex()).
+			Str("newAddress", crypto.PubkeyToAddress(newKey.PublicKey).Hex()).
+			Str("oldHashedAddress", rotation.OldHashedAddress.String()).
+			Str("newHashedAddress", rotation.NewHashedAddress.String()).
+			Str("tx", rotation.Hash.Hex()).
+			Uint64("block", rotation.BlockNumber).
+			Msg("Key rotated")
+		return nil
+	},
+}
+
+// loadOrGenerateKey loads the hex private key of the file, generating and saving one when the
+// file does not exist.
+func loadOrGenerateKey(file string) (*ecdsa.PrivateKey, error) {
+	key, err := crypto.LoadECDSA(file)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load new key: %w", err)
+	}
+
+	if key, err = crypto.GenerateKey(); err != nil {
+		return nil, fmt.Errorf("failed to generate new key: %w", err)
+	}
+	if err := crypto.SaveECDSA(file, key); err != nil {
+		return nil, fmt.Errorf("failed to save new key: %w", err)
+	}
+	logger.Logger.Info().Str("file", file).Str("address", crypto.PubkeyToAddress(key.PublicKey).Hex()).Msg("New key generated")
+	return key, nil
+}
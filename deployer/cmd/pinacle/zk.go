@@ -0,0 +1,251 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"deployer/internal/directory"
+	"deployer/internal/logger"
+	"deployer/internal/session"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// manifestFilename is the default name of the signed manifest, next to the zkey
+const manifestFilename = "artifacts.json"
+
+var (
+	// Artifacts, empty flags default to the ZK_* paths of the .env
+	zkWasm        string
+	zkZkey        string
+	zkVkey        string
+	zkBinary      string
+	zkManifest    string
+	zkManifestKey string
+	zkSigningKey  string
+	zkCircuit     string
+	zkExport      string
+	zkInputs      string
+
+	zkCMD = &cobra.Command{
+		Use:   "zk",
+		Short: "Inspect and pin the circuit artifacts (wasm, zkey, verification key)",
+	}
+
+	zkSignManifestCMD = &cobra.Command{
+		Use:   "sign-manifest",
+		Short: "Hash the artifacts and sign the manifest the prover and verifier check at startup",
+		Example: `
+  openssl genpkey -algorithm ed25519 -out manifest.pem
+  openssl pkey -in manifest.pem -pubout -out manifest.pub.pem
+  pinacle zk sign-manifest --key manifest.pem
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := resolveArtifacts(); err != nil {
+				return err
+			}
+			key, err := session.LoadSigningKey(zkSigningKey)
+			if err != nil {
+				return err
+			}
+
+			manifest, err := zkp.NewManifest(zkCircuit, zkp.Path(zkWasm), zkp.Path(zkZkey), zkp.Path(zkVkey), zkp.Path(zkBinary))
+			if err != nil {
+				return err
+			}
+			signed, err := zkp.SignManifest(manifest, key)
+			if err != nil {
+				return err
+			}
+
+			if zkManifest == "" {
+				zkManifest = filepath.Join(filepath.Dir(zkZkey), manifestFilename)
+			}
+			if err := directory.SaveToFile(zkManifest, signed); err != nil {
+				return err
+			}
+			logger.Logger.Info().Str("manifest", zkManifest).Str("circuit", manifest.Circuit).Int("nPublic", manifest.NPublic).
+				Str("wasm", manifest.Wasm).Str("zkey", manifest.Zkey).Str("vkey", manifest.Vkey).Str("witnessBinary", manifest.WitnessBinary).Msg("Manifest signed")
+			return nil
+		},
+	}
+
+	zkCheckManifestCMD = &cobra.Command{
+		Use:   "check-manifest",
+		Short: "Check the manifest signature and the artifacts against it, like the gateway at startup",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := resolveArtifacts(); err != nil {
+				return err
+			}
+			if zkManifest == "" || zkManifestKey == "" {
+				return fmt.Errorf("manifest and its verification key are required (--manifest, --pubkey or ZK_MANIFEST_*)")
+			}
+			key, err := session.LoadVerificationKey(zkManifestKey)
+			if err != nil {
+				return err
+			}
+			manifest, err := zkp.LoadManifest(zkp.Path(zkManifest), key)
+			if err != nil {
+				return err
+			}
+
+			artifacts := []struct{ kind, path, sum string }{
+				{"wasm", zkWasm, manifest.Wasm},
+				{"zkey", zkZkey, manifest.Zkey},
+				{"vkey", zkVkey, manifest.Vkey},
+			}
+			if manifest.WitnessBinary != "" {
+				artifacts = append(artifacts, struct{ kind, path, sum string }{"witness binary", zkBinary, manifest.WitnessBinary},
+					struct{ kind, path, sum string }{"witness dat", zkBinary + ".dat", manifest.WitnessDat})
+			}
+			for _, artifact := range artifacts {
+				data, err := os.ReadFile(artifact.path)
+				if err != nil {
+					return err
+				}
+				if err := zkp.CheckArtifact(artifact.kind, artifact.sum, data); err != nil {
+					return err
+				}
+			}
+			logger.Logger.Info().Str("manifest", zkManifest).Str("circuit", manifest.Circuit).Int("nPublic", manifest.NPublic).Msg("Artifacts match the manifest")
+			return nil
+		},
+	}
+
+	zkInfoCMD = &cobra.Command{
+		Use:   "info",
+		Short: "Print the circuit of the zkey and the wasm, and check they come from the same build",
+		Example: `
+  pinacle zk info
+  pinacle zk info --export verification_key.json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := resolveArtifacts(); err != nil {
+				return err
+			}
+
+			header, err := zkp.ReadZkeyHeader(zkp.Path(zkZkey))
+			if err != nil {
+				return err
+			}
+			logger.Logger.Info().Str("zkey", zkZkey).Str("protocol", header.Protocol).Str("curve", header.Curve).
+				Int("nPublic", header.NPublic).Int("nVars", header.NVars).Int("domainSize", header.DomainSize).Msg("🔑 Zkey")
+
+			circuit := zkp.PinacleCircuit
+			if zkInputs != "" {
+				if circuit, err = zkp.LoadCircuitDescriptor(zkp.Path(zkInputs)); err != nil {
+					return err
+				}
+			}
+			names := make([]string, len(circuit.Inputs))
+			for i, signal := range circuit.Inputs {
+				names[i] = signal.Name
+			}
+			wasm, err := zkp.InspectWasm(zkp.Path(zkWasm), names)
+			if err != nil {
+				return err
+			}
+			inputs := zerolog.Dict()
+			for _, name := range names {
+				inputs.Int(name, wasm.Inputs[name])
+			}
+			logger.Logger.Info().Str("wasm", zkWasm).Int("circomVersion", wasm.Version).Int("witnessSize", wasm.WitnessSize).
+				Int("inputSize", wasm.InputSize).Dict("inputs", inputs).Msg("⚙️ Wasm")
+
+			var mismatches []string
+			check := func(ok bool, format string, a ...any) {
+				if !ok {
+					mismatches = append(mismatches, fmt.Sprintf(format, a...))
+				}
+			}
+			if circuit.Circuit == zkp.PINACLE_CIRCUIT {
+				check(header.NPublic == types.PINACLE_PUBLIC_SIGNALS, "zkey has %d public signals, the Pinacle circuit %d", header.NPublic, types.PINACLE_PUBLIC_SIGNALS)
+			}
+			check(header.R.Cmp(wasm.Prime) == 0, "zkey prime %s differs from the wasm prime %s", header.R, wasm.Prime)
+			check(header.NVars == wasm.WitnessSize, "zkey has %d variables, the wasm computes %d", header.NVars, wasm.WitnessSize)
+			total := 0
+			for _, signal := range circuit.Inputs {
+				size, ok := wasm.Inputs[signal.Name]
+				check(ok, "wasm has no %s input", signal.Name)
+				check(!ok || size == signal.Size(), "wasm %s input has %d elements, the %s inputs %d", signal.Name, size, circuit.Circuit, signal.Size())
+				total += signal.Size()
+			}
+			check(wasm.InputSize == total, "wasm has %d input elements, the %s inputs %d", wasm.InputSize, circuit.Circuit, total)
+
+			if data, err := os.ReadFile(zkVkey); err == nil {
+				var vkey types.VerificationKey
+				err := json.Unmarshal(data, &vkey)
+				check(err == nil && reflect.DeepEqual(&vkey, header.VerificationKey), "verification key %s is not the key of the zkey", zkVkey)
+			} else if zkVkey != "" {
+				logger.Logger.Warn().Err(err).Msg("Verification key not compared")
+			}
+
+			if zkExport != "" {
+				if err := directory.SaveToFile(zkExport, header.VerificationKey); err != nil {
+					return err
+				}
+				logger.Logger.Info().Str("vkey", zkExport).Msg("Verification key exported")
+			}
+
+			for _, mismatch := range mismatches {
+				logger.Logger.Error().Msg(mismatch)
+			}
+			if len(mismatches) > 0 {
+				return fmt.Errorf("artifacts do not come from the same Pinacle build: %d mismatches", len(mismatches))
+			}
+			logger.Logger.Info().Str("circuit", circuit.Circuit).Msg("✅ Wasm, zkey and verification key match the circuit")
+			return nil
+		},
+	}
+)
+
+func init() {
+	zkCMD.PersistentFlags().StringVar(&zkWasm, "wasm", "", "Circuit wasm (default ZK_WASM_FILENAME)")
+	zkCMD.PersistentFlags().StringVar(&zkZkey, "zkey", "", "Final zkey (default ZK_ZKEY_FILENAME)")
+	zkCMD.PersistentFlags().StringVar(&zkVkey, "vkey", "", "Verification key (default ZK_VERIFICATION_KEY_FILENAME)")
+	zkCMD.PersistentFlags().StringVar(&zkBinary, "witness-binary", "", "Witness binary of the native backend, with its .dat next to it (default ZK_WITNESS_BINARY_FILENAME)")
+	zkCMD.PersistentFlags().StringVar(&zkManifest, "manifest", "", "Signed manifest (default ZK_MANIFEST_FILENAME, or "+manifestFilename+" next to the zkey when signing)")
+
+	zkSignManifestCMD.Flags().StringVar(&zkSigningKey, "key", "", "Ed25519 PKCS#8 PEM private key of the signer")
+	zkSignManifestCMD.Flags().StringVar(&zkCircuit, "circuit", zkp.PINACLE_CIRCUIT, "Circuit name")
+	zkSignManifestCMD.MarkFlagRequired("key")
+	zkCheckManifestCMD.Flags().StringVar(&zkManifestKey, "pubkey", "", "Ed25519 PKIX PEM public key of the signer (default ZK_MANIFEST_VERIFICATION_KEY_FILENAME)")
+
+	zkInfoCMD.Flags().StringVar(&zkInputs, "inputs", "", "JSON circuit descriptor of the wasm inputs (default the Pinacle inputs)")
+	zkInfoCMD.Flags().StringVar(&zkExport, "export", "", "Write the verification key embedded in the zkey to this file")
+
+	zkCMD.AddCommand(zkSignManifestCMD, zkCheckManifestCMD, zkInfoCMD)
+}
+
+// resolveArtifacts fills the empty flags from the .env, without requiring the rest of the config.
+func resolveArtifacts() error {
+	env := viper.New()
+	env.SetConfigFile(".env")
+	if err := env.ReadInConfig(); err != nil && (zkWasm == "" || zkZkey == "" || zkVkey == "") {
+		return fmt.Errorf("Failed to read config file: %w", err)
+	}
+
+	for _, flag := range []struct {
+		value *string
+		key   string
+	}{
+		{&zkWasm, "ZK_WASM_FILENAME"},
+		{&zkZkey, "ZK_ZKEY_FILENAME"},
+		{&zkVkey, "ZK_VERIFICATION_KEY_FILENAME"},
+		{&zkBinary, "ZK_WITNESS_BINARY_FILENAME"},
+		{&zkManifest, "ZK_MANIFEST_FILENAME"},
+		{&zkManifestKey, "ZK_MANIFEST_VERIFICATION_KEY_FILENAME"},
+	} {
+		if *flag.value == "" {
+			*flag.value = env.GetString(flag.key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"testing"
+
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:generate sh -c "cd ../../go-contracts && go run ./cmd zk-build --circuit ../deployer/e2e/testdata/Test.circom --build-dir ../deployer/e2e/testdata/build --power 8 --contributions 1 --deployer-env '' --clean"
+//go:generate sh -c "circom testdata/Test.circom --c -o testdata/build && make -C testdata/build/Test_cpp"
+//go:generate sh -c "cd ../../go-contracts && SOLC_OUTPUT_DIR=../deployer/e2e/testdata go run ./cmd gen-verifier --vkey ../deployer/e2e/testdata/build/keys/verification_key.json --name TestVerifier --output-dir ../deployer/e2e/testdata --no-bind"
+
+// The test circuit (testdata/Test.circom) is built with zk-build and its verifier rendered and
+// compiled with gen-verifier, like the Pinacle ones. Regenerate them with go generate ./e2e, which
+// needs circom, snarkjs and network access for the powers of tau and solc. The witness binary
+// (Test_cpp/Test) is only used by the witness backend benchmark of the zkp package.
+const (
+	testCircuitWasm = "testdata/build/Test_js/Test.wasm"
+	testCircuitZkey = "testdata/build/keys/Test_final.zkey"
+	testCircuitVkey = "testdata/build/keys/verification_key.json"
+	testVerifier    = "testdata/TestVerifier.json"
+)
+
+// testCircuit proves the public signals of the test circuit with the prover of the gateway.
+type testCircuit struct {
+	prover      *zkp.Prover
+	vkey        []byte
+	verifierABI abi.ABI
+	verifierBin []byte
+}
+
+var loadTestCircuit = sync.OnceValues(func() (*testCircuit, error) {
+	prover, err := zkp.NewProver(zkp.WitnessSource{Backend: types.WitnessBackendWasm, Wasm: testCircuitWasm}, testCircuitZkey, nil)
+	if err != nil {
+		return nil, err
+	}
+	vkey, err := os.ReadFile(testCircuitVkey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Contract written by gen-verifier
+	data, err := os.ReadFile(testVerifier)
+	if err != nil {
+		return nil, err
+	}
+	var compiled struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode string          `json:"bytecode"`
+	}
+	if err := json.Unmarshal(data, &compiled); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", testVerifier, err)
+	}
+	verifierABI, err := abi.JSON(bytes.NewReader(compiled.ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s ABI: %w", testVerifier, err)
+	}
+
+	return &testCircuit{prover: prover, vkey: vkey, verifierABI: verifierABI, verifierBin: common.FromHex(compiled.Bytecode)}, nil
+})
+
+// requireTestCircuit skips the test unless the artifacts of the test circuit were generated.
+func requireTestCircuit(t *testing.T) *testCircuit {
+	t.Helper()
+
+	circuit, err := loadTestCircuit()
+	if err != nil {
+		t.Skipf("test circuit unavailable, generate it with go generate ./e2e: %v", err)
+	}
+	return circuit
+}
+
+// prove generates a proof of the public signals with the wasm witness calculator and rapidsnark.
+func (tc *testCircuit) prove(t *testing.T, hashedAddress, root *big.Int) *zkp.ZKProof {
+	t.Helper()
+
+	inputs, err := json.Marshal(map[string]string{"hashedAddr": hashedAddress.String(), "root": root.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := tc.prover.GenerateProofs(inputs)
+	if err != nil {
+		t.Fatalf("failed to prove: %v", err)
+	}
+	if signals := proof.GetPublicSignals(); len(signals) != 2 || signals[0] != hashedAddress.String() || signals[1] != root.String() {
+		t.Fatalf("proved public signals %v, expected [%s %s]", signals, hashedAddress, root)
+	}
+	return proof
+}
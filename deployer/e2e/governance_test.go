@@ -0,0 +1,209 @@
+package e2e
+
+import (
+	"math/big"
+	"testing"
+
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// proposeFoodBank proposes candidate as a food bank from foodBank and returns the proposal ID.
+func (c *chain) proposeFoodBank(foodBank, candidate *account) common.Hash {
+	c.t.Helper()
+
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+	newProof, newSignals := c.addressProof(candidate)
+	tx, err := c.zkLogin.ProposeFoodBank(c.transactor(foodBank), proof, signals, candidate.address, newProof, newSignals)
+	if err != nil {
+		c.t.Fatalf("failed to propose food bank: %v", err)
+	}
+	c.mine(tx)
+	return c.lastProposal()
+}
+
+// proposeTermination proposes to terminate the food bank hashed from foodBank and returns the proposal ID.
+func (c *chain) proposeTermination(foodBank *account, hashed *big.Int) common.Hash {
+	c.t.Helper()
+
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+	tx, err := c.zkLogin.ProposeFoodBankTermination(c.transactor(foodBank), proof, signals, hashed)
+	if err != nil {
+		c.t.Fatalf("failed to propose food bank termination: %v", err)
+	}
+	c.mine(tx)
+	return c.lastProposal()
+}
+
+func (c *chain) lastProposal() common.Hash {
+	c.t.Helper()
+
+	ids, err := c.zkLogin.FetchProposals(c.call(c.deployer))
+	if err != nil {
+		c.t.Fatalf("failed to fetch proposals: %v", err)
+	}
+	if len(ids) == 0 {
+		c.t.Fatal("no proposal")
+	}
+	return ids[len(ids)-1]
+}
+
+func (c *chain) approveProposal(foodBank *account, id common.Hash) (*ethtypes.Transaction, error) {
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+	return c.zkLogin.ApproveProposal(c.transactor(foodBank), proof, signals, id)
+}
+
+func (c *chain) executeProposal(foodBank *account, id common.Hash) (*ethtypes.Transaction, error) {
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+	return c.zkLogin.ExecuteProposal(c.transactor(foodBank), proof, signals, id)
+}
+
+// approvals returns the approvals of the proposal by food banks still active.
+func (c *chain) approvals(id common.Hash) uint32 {
+	c.t.Helper()
+
+	proposal, err := c.zkLogin.FetchProposal(c.call(c.deployer), id)
+	if err != nil {
+		c.t.Fatalf("failed to fetch proposal %s: %v", id.Hex(), err)
+	}
+	return proposal.Approvals
+}
+
+func requireApprovals(t *testing.T, c *chain, id common.Hash, expected uint32) {
+	t.Helper()
+
+	if approvals := c.approvals(id); approvals != expected {
+		t.Fatalf("proposal %s has %d approvals, expected %d", id.Hex(), approvals, expected)
+	}
+}
+
+func TestGovernance(t *testing.T) {
+	requireMethods(t, "proposeFoodBank", "proposeFoodBankTermination", "approveProposal", "executeProposal", "fetchProposal", "fetchProposals", "hasApproved", "isRevoked")
+
+	// Three initial food banks, two approvals per proposal
+	c := newGovernedChain(t, 6, 3, 2)
+	fb0, fb1, fb2 := c.foodBanks[0], c.foodBanks[1], c.foodBanks[2]
+	candidate, other, late := c.accounts[2], c.accounts[3], c.accounts[4]
+
+	t.Run("registration requires a proposal", func(t *testing.T) {
+		proof, signals := c.membershipProof(fb0, types.RoleFoodBank)
+		newProof, newSignals := c.addressProof(candidate)
+		_, err := c.zkLogin.RegisterFoodBank(c.transactor(fb0), proof, signals, candidate.address, newProof, newSignals)
+		requireRevert(t, err, "Food Bank Proposal Required")
+	})
+
+	t.Run("M of N", func(t *testing.T) {
+		id := c.proposeFoodBank(fb0, candidate)
+		requireApprovals(t, c, id, 1)
+
+		_, err := c.executeProposal(fb0, id)
+		requireRevert(t, err, "Proposal Quorum not Reached")
+
+		_, err = c.approveProposal(fb0, id)
+		requireRevert(t, err, "Proposal already Approved")
+
+		tx, err := c.approveProposal(fb1, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+		requireApprovals(t, c, id, 2)
+		if approved, err := c.zkLogin.HasApproved(c.call(fb0), id, fb1.hashed); err != nil || !approved {
+			t.Fatalf("approval of the second food bank not recorded (%v)", err)
+		}
+
+		// Any food bank executes a proposal that reached the quorum
+		tx, err = c.executeProposal(fb2, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+		c.checkRoot(candidate, types.RoleFoodBank, c.insert(types.RoleFoodBank, candidate))
+
+		_, err = c.executeProposal(fb0, id)
+		requireRevert(t, err, "Proposal already Executed")
+	})
+
+	// Approved by fb0 and the new food bank, revoked below
+	pending := c.proposeFoodBank(fb0, other)
+	tx, err := c.approveProposal(candidate, pending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.mine(tx)
+	requireApprovals(t, c, pending, 2)
+
+	t.Run("approver terminated", func(t *testing.T) {
+		id := c.proposeTermination(fb1, candidate.hashed)
+		tx, err := c.approveProposal(fb2, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+		requireApprovals(t, c, id, 2)
+
+		// fb2 leaves before the proposal is executed
+		proof, signals := c.membershipProof(fb2, types.RoleFoodBank)
+		tx, err = c.zkLogin.TerminateFoodBank(c.transactor(fb2), proof, signals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+		requireApprovals(t, c, id, 1)
+
+		_, err = c.executeProposal(fb1, id)
+		requireRevert(t, err, "Proposal Quorum not Reached")
+
+		tx, err = c.approveProposal(fb0, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+		tx, err = c.executeProposal(fb1, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+		if revoked, err := c.zkLogin.IsRevoked(c.call(fb0), candidate.hashed); err != nil || !revoked {
+			t.Fatalf("terminated food bank not revoked (%v)", err)
+		}
+	})
+
+	t.Run("approver revoked", func(t *testing.T) {
+		// The approval of the food bank revoked above no longer counts
+		requireApprovals(t, c, pending, 1)
+		_, err := c.executeProposal(fb0, pending)
+		requireRevert(t, err, "Proposal Quorum not Reached")
+
+		tx, err := c.approveProposal(fb1, pending)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+		tx, err = c.executeProposal(fb0, pending)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+		c.checkRoot(other, types.RoleFoodBank, c.insert(types.RoleFoodBank, other))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		id := c.proposeFoodBank(fb0, late)
+		if err := c.backend.AdjustTime(proposalTTL); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := c.approveProposal(fb1, id)
+		requireRevert(t, err, "Proposal Expired")
+		_, err = c.executeProposal(fb0, id)
+		requireRevert(t, err, "Proposal Expired")
+	})
+
+	t.Run("unknown proposal", func(t *testing.T) {
+		_, err := c.approveProposal(fb0, common.Hash{1})
+		requireRevert(t, err, "Unknown Proposal Detected")
+	})
+}
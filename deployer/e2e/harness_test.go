@@ -0,0 +1,351 @@
+package e2e
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	mimccontract "deployer/internal/abigen/mimc"
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/hasher"
+	"deployer/internal/membership"
+	"deployer/internal/merkletree"
+	"deployer/internal/poseidon"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	blockGasLimit  = 100_000_000
+	treeLevels     = 32
+	foodBankQuorum = 1
+	proposalTTL    = 24 * time.Hour
+)
+
+var simulatedChainID = big.NewInt(1337)
+
+// account is a funded externally owned account of the simulated chain.
+type account struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+	hashed  *big.Int // hashed address, the leaf of the account
+}
+
+// chain is a simulated chain with the hasher, the verifier of the test circuit and zkLogin deployed as cmd/deploy does,
+// the deployer being the only initial food bank unless started by newGovernedChain.
+type chain struct {
+	t        *testing.T
+	backend  *simulated.Backend
+	client   simulated.Client
+	kind     types.HasherKind
+	hasher   hasher.Hasher
+	circuit  *testCircuit
+	deployer *account
+	accounts []*account
+
+	// Initial food banks, the deployer first, and the approvals a food bank proposal needs
+	foodBanks []*account
+	quorum    uint32
+
+	hasherAddress   common.Address
+	verifierAddress common.Address
+	zkLoginAddress  common.Address
+	zkLogin         *zklogin.Zklogin
+
+	// Off-chain replicas of the membership trees, to cross-check the on-chain roots
+	trees map[types.Role]*merkletree.Tree
+}
+
+// newChain starts a simulated chain with the deployer and nbAccounts more funded accounts.
+func newChain(t *testing.T, nbAccounts int) *chain {
+	return newChainWithHasher(t, nbAccounts, types.HasherMiMC)
+}
+
+// newChainWithHasher starts a simulated chain where zkLogin hashes with the hasher contract of kind.
+func newChainWithHasher(t *testing.T, nbAccounts int, kind types.HasherKind) *chain {
+	t.Helper()
+	return startChain(t, nbAccounts, kind, 1, foodBankQuorum)
+}
+
+// newGovernedChain starts a simulated chain with nbFoodBanks initial food banks, the deployer and
+// the first accounts, quorum of them approving the food bank proposals. The other accounts follow.
+func newGovernedChain(t *testing.T, nbAccounts, nbFoodBanks int, quorum uint32) *chain {
+	t.Helper()
+	return startChain(t, nbAccounts, types.HasherMiMC, nbFoodBanks, quorum)
+}
+
+func startChain(t *testing.T, nbAccounts int, kind types.HasherKind, nbFoodBanks int, quorum uint32) *chain {
+	t.Helper()
+
+	h, err := hasher.New(kind)
+	if err != nil {
+		t.Fatalf("failed to initialize %s: %v", kind, err)
+	}
+
+	c := &chain{t: t, kind: kind, hasher: h, circuit: requireTestCircuit(t), quorum: quorum}
+
+	alloc := ethtypes.GenesisAlloc{}
+	for i := 0; i <= nbAccounts; i++ {
+		acc := c.newAccount()
+		alloc[acc.address] = ethtypes.Account{Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))}
+		if i == 0 {
+			c.deployer = acc
+		} else {
+			c.accounts = append(c.accounts, acc)
+		}
+		if i < nbFoodBanks {
+			c.foodBanks = append(c.foodBanks, acc)
+		}
+	}
+
+	c.backend = simulated.NewBackend(alloc, simulated.WithBlockGasLimit(blockGasLimit))
+	t.Cleanup(func() { c.backend.Close() })
+	c.client = c.backend.Client()
+
+	c.trees = map[types.Role]*merkletree.Tree{}
+	for _, role := range []types.Role{types.RoleFoodBank, types.RoleUser} {
+		tree, err := merkletree.NewTree(treeLevels, h)
+		if err != nil {
+			t.Fatalf("failed to create tree %d: %v", role, err)
+		}
+		c.trees[role] = tree
+	}
+
+	c.deploy()
+	return c
+}
+
+// newAccount generates an account, which is only funded when created before the chain starts.
+func (c *chain) newAccount() *account {
+	c.t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		c.t.Fatalf("failed to generate key: %v", err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	return &account{key: key, address: address, hashed: c.hasher.HashAddress(&address)}
+}
+
+func (c *chain) deploy() {
+	c.t.Helper()
+
+	var (
+		address common.Address
+		tx      *ethtypes.Transaction
+		err     error
+	)
+	switch c.kind {
+	case types.HasherPoseidon:
+		address, tx, _, err = poseidon.DeployContract(c.transactor(c.deployer), c.client, 2)
+	default:
+		address, tx, _, err = mimccontract.DeployMimc(c.transactor(c.deployer), c.client)
+	}
+	if err != nil {
+		c.t.Fatalf("failed to deploy %s: %v", c.kind, err)
+	}
+	c.mine(tx)
+	c.hasherAddress = address
+
+	// The verifier rendered for the key of the test circuit
+	address, tx, _, err = bind.DeployContract(c.transactor(c.deployer), c.circuit.verifierABI, c.circuit.verifierBin, c.client)
+	if err != nil {
+		c.t.Fatalf("failed to deploy Verifier: %v", err)
+	}
+	c.mine(tx)
+	c.verifierAddress = address
+
+	foodBanks := make([]common.Address, len(c.foodBanks))
+	for i, foodBank := range c.foodBanks {
+		foodBanks[i] = foodBank.address
+	}
+	address, tx, contract, err := c.deployZkLogin(foodBanks)
+	if err != nil {
+		c.t.Fatalf("failed to deploy zkLogin: %v", err)
+	}
+	c.mine(tx)
+	c.zkLoginAddress, c.zkLogin = address, contract
+	for _, foodBank := range c.foodBanks {
+		c.insert(types.RoleFoodBank, foodBank)
+	}
+}
+
+// deployZkLogin deploys zkLogin as membership.DeployZkLogin does, even from a stale binding: the
+// tests of the methods its bytecode lacks skip with requireMethods.
+func (c *chain) deployZkLogin(foodBanks []common.Address) (common.Address, *ethtypes.Transaction, *zklogin.Zklogin, error) {
+	subtrees := types.RoleTrees()
+	levels := make([]uint32, len(subtrees))
+	for i := range levels {
+		levels[i] = treeLevels
+	}
+	definitions, permissions := membership.RoleRegistry()
+	return zklogin.DeployZklogin(c.transactor(c.deployer), c.client, uint32(len(subtrees)), subtrees, levels, c.hasherAddress, c.verifierAddress, foodBanks, definitions, permissions, c.quorum, uint64(proposalTTL/time.Second))
+}
+
+// requireMethods skips the test unless the zkLogin binding bytecode implements methods.
+func requireMethods(t *testing.T, methods ...string) {
+	t.Helper()
+
+	missing, err := membership.MissingMethods()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range methods {
+		if slices.Contains(missing, method) {
+			t.Skipf("zkLogin binding bytecode lacks %s, regenerate it with go run cmd/main.go compile && go run cmd/main.go abigen in go-contracts", method)
+		}
+	}
+}
+
+// transactor signs transactions from acc, gas is estimated so reverts surface before sending.
+func (c *chain) transactor(acc *account) *bind.TransactOpts {
+	c.t.Helper()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(acc.key, simulatedChainID)
+	if err != nil {
+		c.t.Fatalf("failed to create transactor: %v", err)
+	}
+	opts.Context = context.Background()
+	return opts
+}
+
+// call returns the options of a call from acc, msg.sender being checked by every zkLogin method.
+func (c *chain) call(acc *account) *bind.CallOpts {
+	return &bind.CallOpts{From: acc.address, Context: context.Background()}
+}
+
+// mine seals a block with tx and checks that it succeeded.
+func (c *chain) mine(tx *ethtypes.Transaction) *ethtypes.Receipt {
+	c.t.Helper()
+
+	c.backend.Commit()
+	receipt, err := c.client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		c.t.Fatalf("failed to fetch receipt of %s: %v", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		c.t.Fatalf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return receipt
+}
+
+// insert mirrors a registration in the off-chain replica and returns the new root.
+func (c *chain) insert(role types.Role, acc *account) *big.Int {
+	c.t.Helper()
+
+	if _, ok := c.trees[role]; !ok {
+		tree, err := merkletree.NewTree(treeLevels, c.hasher)
+		if err != nil {
+			c.t.Fatalf("failed to create tree %d: %v", role, err)
+		}
+		c.trees[role] = tree
+	}
+	root, _, err := c.trees[role].Insert(acc.hashed)
+	if err != nil {
+		c.t.Fatalf("failed to insert into replica %d: %v", role, err)
+	}
+	return root
+}
+
+// zkProof generates a proof for the public signals, as the prover returns it.
+func (c *chain) zkProof(hashedAddress, root *big.Int) *zkp.ZKProof {
+	c.t.Helper()
+	return c.circuit.prove(c.t, hashedAddress, root)
+}
+
+// proof generates a proof for the public signals and converts it like the gateway does.
+func (c *chain) proof(hashedAddress, root *big.Int) (zklogin.ZkLoginGroth16Proof, [2]*big.Int) {
+	c.t.Helper()
+	return c.convert(c.zkProof(hashedAddress, root))
+}
+
+// convert converts proof to the arguments of zkLogin.
+func (c *chain) convert(proof *zkp.ZKProof) (zklogin.ZkLoginGroth16Proof, [2]*big.Int) {
+	c.t.Helper()
+
+	converted, err := proof.ConvertProof()
+	if err != nil {
+		c.t.Fatalf("failed to convert proof: %v", err)
+	}
+	var publicSignals [types.PINACLE_PUBLIC_SIGNALS]*big.Int
+	if err := proof.ConvertPublicSignalsTo(publicSignals[:]); err != nil {
+		c.t.Fatalf("failed to convert public signals: %v", err)
+	}
+	return *converted, publicSignals
+}
+
+// addressProof proves the hashed address of acc (the root signal is zero).
+func (c *chain) addressProof(acc *account) (zklogin.ZkLoginGroth16Proof, [2]*big.Int) {
+	return c.proof(acc.hashed, big.NewInt(0))
+}
+
+// merkleProofs fetches the Merkle proofs stored for acc in the tree of role.
+func (c *chain) merkleProofs(acc *account, role types.Role) (zklogin.MerkleTreeWithHistoryMerkleProof, error) {
+	proof, signals := c.addressProof(acc)
+	switch role {
+	case types.RoleFoodBank:
+		return c.zkLogin.FetchFoodBankMerkleProofs(c.call(acc), proof, signals)
+	case types.RoleUser:
+		return c.zkLogin.FetchUserMerkleProofs(c.call(acc), proof, signals)
+	default:
+		return c.zkLogin.FetchMemberMerkleProofs(c.call(acc), uint32(role), proof, signals)
+	}
+}
+
+// membershipProof fetches the Merkle proofs of acc and proves its membership against the
+// root they lead to.
+func (c *chain) membershipProof(acc *account, role types.Role) (zklogin.ZkLoginGroth16Proof, [2]*big.Int) {
+	c.t.Helper()
+
+	merkleProof, err := c.merkleProofs(acc, role)
+	if err != nil {
+		c.t.Fatalf("failed to fetch Merkle proofs of tree %d: %v", role, err)
+	}
+	return c.proof(acc.hashed, c.root(acc.hashed, merkleProof))
+}
+
+// root hashes leaf up the path of merkleProof.
+func (c *chain) root(leaf *big.Int, merkleProof zklogin.MerkleTreeWithHistoryMerkleProof) *big.Int {
+	c.t.Helper()
+
+	if len(merkleProof.PathElements) != treeLevels || len(merkleProof.PathIndices) != treeLevels {
+		c.t.Fatalf("expected %d path elements, got %d elements and %d indices", treeLevels, len(merkleProof.PathElements), len(merkleProof.PathIndices))
+	}
+
+	current := new(big.Int).Set(leaf)
+	for i, element := range merkleProof.PathElements {
+		left, right := current, element
+		if merkleProof.PathIndices[i].Sign() != 0 {
+			left, right = element, current
+		}
+		hash, err := c.hasher.HashLeftRight(left, right)
+		if err != nil {
+			c.t.Fatalf("failed to hash level %d: %v", i, err)
+		}
+		current = hash.BigInt(new(big.Int))
+	}
+	return current
+}
+
+// requireRevert fails unless err is a revert with reason.
+func requireRevert(t *testing.T, err error, reason string) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("expected revert %q, got success", reason)
+	}
+	if !strings.Contains(err.Error(), reason) {
+		t.Fatalf("expected revert %q, got %v", reason, err)
+	}
+}
@@ -0,0 +1,143 @@
+package e2e
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/membership"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signRotation signs the rotation of role to newMember with the key of signer, as the old key does.
+func (c *chain) signRotation(signer *account, role types.Role, newMember common.Address) []byte {
+	c.t.Helper()
+
+	signature, err := crypto.Sign(membership.KeyRotationHash(c.zkLoginAddress, simulatedChainID, role, newMember), signer.key)
+	if err != nil {
+		c.t.Fatalf("failed to sign the key rotation: %v", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature
+}
+
+// rotateKey sends the rotation of role from the old key, proven by oldProof and oldSignals, to sender.
+func (c *chain) rotateKey(role types.Role, old, sender *account, oldProof zklogin.ZkLoginGroth16Proof, oldSignals [2]*big.Int, signature []byte) error {
+	c.t.Helper()
+
+	newProof, newSignals := c.addressProof(sender)
+	tx, err := c.zkLogin.RotateKey(c.transactor(sender), uint32(role), old.address, oldProof, oldSignals, newProof, newSignals, signature)
+	if err != nil {
+		return err
+	}
+	c.mine(tx)
+	return nil
+}
+
+func requireRotation(t *testing.T, c *chain, hashed, expectedPrevious, expectedNext *big.Int) {
+	t.Helper()
+
+	previous, next, err := c.zkLogin.FetchKeyRotation(c.call(c.deployer), hashed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous.Cmp(expectedPrevious) != 0 || next.Cmp(expectedNext) != 0 {
+		t.Fatalf("rotation of %s: got %s => %s, expected %s => %s", hashed, previous, next, expectedPrevious, expectedNext)
+	}
+}
+
+func TestRotateKey(t *testing.T) {
+	requireMethods(t, "rotateKey", "keyRotationHash", "fetchKeyRotation", "isRevoked", "revokeMember", "registerMember")
+
+	c := newChain(t, 6)
+	foodBank, user, newKey, thief := c.deployer, c.accounts[0], c.accounts[1], c.accounts[2]
+	volunteer, newVolunteerKey := c.accounts[3], c.accounts[4]
+	c.registerUser(foodBank, user)
+	c.registerMember(foodBank, types.RoleFoodBank, types.RoleVolunteer, volunteer)
+
+	userProof, userSignals := c.membershipProof(user, types.RoleUser)
+	signature := c.signRotation(user, types.RoleUser, newKey.address)
+
+	t.Run("hash", func(t *testing.T) {
+		hash, err := c.zkLogin.KeyRotationHash(c.call(user), uint32(types.RoleUser), newKey.address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := membership.KeyRotationHash(c.zkLoginAddress, simulatedChainID, types.RoleUser, newKey.address); !bytes.Equal(hash[:], expected) {
+			t.Fatalf("keyRotationHash is %x, expected %x", hash, expected)
+		}
+	})
+
+	t.Run("wrong signer", func(t *testing.T) {
+		err := c.rotateKey(types.RoleUser, user, newKey, userProof, userSignals, c.signRotation(thief, types.RoleUser, newKey.address))
+		requireRevert(t, err, "Invalid Key Rotation Signature")
+	})
+
+	t.Run("signature for another key", func(t *testing.T) {
+		err := c.rotateKey(types.RoleUser, user, thief, userProof, userSignals, signature)
+		requireRevert(t, err, "Invalid Key Rotation Signature")
+	})
+
+	t.Run("signature for another role", func(t *testing.T) {
+		err := c.rotateKey(types.RoleUser, user, newKey, userProof, userSignals, c.signRotation(user, types.RoleVolunteer, newKey.address))
+		requireRevert(t, err, "Invalid Key Rotation Signature")
+	})
+
+	t.Run("invalid signature length", func(t *testing.T) {
+		err := c.rotateKey(types.RoleUser, user, newKey, userProof, userSignals, signature[:64])
+		requireRevert(t, err, "Invalid Signature Length")
+	})
+
+	t.Run("food bank", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		err := c.rotateKey(types.RoleFoodBank, foodBank, newKey, proof, signals, c.signRotation(foodBank, types.RoleFoodBank, newKey.address))
+		requireRevert(t, err, "Food Bank Key Rotation not Supported")
+	})
+
+	t.Run("rotated", func(t *testing.T) {
+		if err := c.rotateKey(types.RoleUser, user, newKey, userProof, userSignals, signature); err != nil {
+			t.Fatal(err)
+		}
+		c.checkRoot(newKey, types.RoleUser, c.insert(types.RoleUser, newKey))
+		requireRevoked(t, c, user, true)
+		requireRevoked(t, c, newKey, false)
+		requireRotation(t, c, user.hashed, big.NewInt(0), newKey.hashed)
+		requireRotation(t, c, newKey.hashed, user.hashed, big.NewInt(0))
+
+		// The food bank finds the new key next to the old one
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		users, err := c.zkLogin.FetchUsersAsFoodBank(c.call(foodBank), proof, signals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != 2 || users[0].Cmp(user.hashed) != 0 || users[1].Cmp(newKey.hashed) != 0 {
+			t.Fatalf("unexpected users of the food bank: %v", users)
+		}
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		err := c.rotateKey(types.RoleUser, user, newKey, userProof, userSignals, signature)
+		requireRevert(t, err, "zkMerkleTree: Revoked Account Detected")
+
+		err = c.rotateKey(types.RoleUser, user, thief, userProof, userSignals, c.signRotation(user, types.RoleUser, thief.address))
+		requireRevert(t, err, "zkMerkleTree: Revoked Account Detected")
+	})
+
+	t.Run("registrar kept", func(t *testing.T) {
+		proof, signals := c.membershipProof(volunteer, types.RoleVolunteer)
+		if err := c.rotateKey(types.RoleVolunteer, volunteer, newVolunteerKey, proof, signals, c.signRotation(volunteer, types.RoleVolunteer, newVolunteerKey.address)); err != nil {
+			t.Fatal(err)
+		}
+		c.checkRoot(newVolunteerKey, types.RoleVolunteer, c.insert(types.RoleVolunteer, newVolunteerKey))
+
+		// The food bank that registered the old key revokes the new one
+		if err := c.revokeMember(foodBank, types.RoleFoodBank, types.RoleVolunteer, newVolunteerKey.hashed); err != nil {
+			t.Fatal(err)
+		}
+		requireRevoked(t, c, newVolunteerKey, true)
+	})
+}
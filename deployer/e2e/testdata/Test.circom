@@ -0,0 +1,15 @@
+pragma circom 2.2.1;
+
+// Test has the public signals of Pinacle (hashed address, root) without its membership
+// statement: the prover picks both. zkLogin checks the statement on top of the proof (hashed
+// address of the sender, known root), which is what the e2e tests exercise.
+template Test() {
+    signal input hashedAddr;
+    signal input root;
+
+    // Binds the public signals to the proof
+    signal square;
+    square <== (hashedAddr + root) * (hashedAddr + root);
+}
+
+component main {public [hashedAddr, root]} = Test();
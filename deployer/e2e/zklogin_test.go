@@ -0,0 +1,935 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	verifier "deployer/internal/abigen/Verifier"
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/membership"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// registerUser registers acc as a user of foodBank and checks the root acc's Merkle proofs lead to.
+func (c *chain) registerUser(foodBank, acc *account) {
+	c.t.Helper()
+
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+	newProof, newSignals := c.addressProof(acc)
+	tx, err := c.zkLogin.RegisterUser(c.transactor(foodBank), proof, signals, acc.address, newProof, newSignals)
+	if err != nil {
+		c.t.Fatalf("failed to register user: %v", err)
+	}
+	c.mine(tx)
+	c.checkRoot(acc, types.RoleUser, c.insert(types.RoleUser, acc))
+}
+
+// registerFoodBank registers acc as a food bank and checks the root acc's Merkle proofs lead to.
+func (c *chain) registerFoodBank(foodBank, acc *account) {
+	c.t.Helper()
+
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+	newProof, newSignals := c.addressProof(acc)
+	tx, err := c.zkLogin.RegisterFoodBank(c.transactor(foodBank), proof, signals, acc.address, newProof, newSignals)
+	if err != nil {
+		c.t.Fatalf("failed to register food bank: %v", err)
+	}
+	c.mine(tx)
+	c.checkRoot(acc, types.RoleFoodBank, c.insert(types.RoleFoodBank, acc))
+}
+
+func (c *chain) checkRoot(acc *account, role types.Role, expected *big.Int) {
+	c.t.Helper()
+
+	merkleProof, err := c.merkleProofs(acc, role)
+	if err != nil {
+		c.t.Fatalf("failed to fetch Merkle proofs of tree %d: %v", role, err)
+	}
+	if root := c.root(acc.hashed, merkleProof); root.Cmp(expected) != 0 {
+		c.t.Fatalf("Merkle proofs lead to %s, replica root is %s", root, expected)
+	}
+}
+
+// forge breaks the pairing equation of a valid proof by negating A.
+func forge(proof zklogin.ZkLoginGroth16Proof) zklogin.ZkLoginGroth16Proof {
+	proof.PiA = [2]*big.Int{proof.PiA[0], new(big.Int).Sub(fp.Modulus(), proof.PiA[1])}
+	return proof
+}
+
+func TestDeployment(t *testing.T) {
+	c := newChain(t, 0)
+
+	t.Run("no food banks", func(t *testing.T) {
+		_, _, _, err := c.deployZkLogin(nil)
+		requireRevert(t, err, "No FoodBanks' addresses presented")
+	})
+
+	t.Run("initial food bank", func(t *testing.T) {
+		c.checkRoot(c.deployer, types.RoleFoodBank, c.trees[types.RoleFoodBank].Root())
+	})
+
+	t.Run("local verifier", func(t *testing.T) {
+		// The off-chain verifier of the gateway accepts the proofs zkLogin accepts
+		v, err := zkp.NewVerifier(testCircuitVkey, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proof := c.zkProof(c.deployer.hashed, c.trees[types.RoleFoodBank].Root())
+		if err := v.VerifyProofs(proof); err != nil {
+			t.Fatalf("valid proof rejected: %v", err)
+		}
+
+		proof.SetPublicSignals([]string{proof.GetPublicSignals()[0], "1"})
+		if err := v.VerifyProofs(proof); err == nil {
+			t.Fatal("proof accepted for other public signals")
+		}
+	})
+
+	t.Run("verifier key", func(t *testing.T) {
+		// cmd/deploy refuses a Verifier binding that does not embed the configured key
+		if err := zkp.CheckVerifierBytecode(c.circuit.verifierBin, c.circuit.vkey); err != nil {
+			t.Fatalf("verifier of the test circuit rejected: %v", err)
+		}
+		if err := zkp.CheckVerifierBytecode(common.FromHex(verifier.VerifierMetaData.Bin), c.circuit.vkey); !errors.Is(err, zkp.ErrVerifierKeyMismatch) {
+			t.Fatalf("expected %v for the Pinacle verifier, got %v", zkp.ErrVerifierKeyMismatch, err)
+		}
+	})
+}
+
+func TestFetchMerkleProofs(t *testing.T) {
+	c := newChain(t, 2)
+	foodBank, user, other := c.deployer, c.accounts[0], c.accounts[1]
+	c.registerUser(foodBank, user)
+
+	t.Run("unregistered account", func(t *testing.T) {
+		merkleProof, err := c.merkleProofs(other, types.RoleUser)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(merkleProof.PathElements) != 0 || len(merkleProof.PathIndices) != 0 {
+			t.Fatalf("expected no Merkle proofs, got %d path elements", len(merkleProof.PathElements))
+		}
+	})
+
+	t.Run("other tree", func(t *testing.T) {
+		merkleProof, err := c.merkleProofs(user, types.RoleFoodBank)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(merkleProof.PathElements) != 0 {
+			t.Fatalf("expected no food bank Merkle proofs for a user, got %d path elements", len(merkleProof.PathElements))
+		}
+	})
+
+	t.Run("invalid public signals", func(t *testing.T) {
+		proof, signals := c.proof(user.hashed, big.NewInt(1))
+		_, err := c.zkLogin.FetchUserMerkleProofs(c.call(user), proof, signals)
+		requireRevert(t, err, "zkEthereumAddress: Invalid Public Signals")
+	})
+
+	t.Run("invalid proofs", func(t *testing.T) {
+		proof, signals := c.addressProof(user)
+		_, err := c.zkLogin.FetchUserMerkleProofs(c.call(user), forge(proof), signals)
+		requireRevert(t, err, "zkEthereumAddress: Invalid Proofs")
+
+		_, err = c.zkLogin.FetchFoodBankMerkleProofs(c.call(foodBank), proof, signals)
+		requireRevert(t, err, "zkEthereumAddress: Unauthorized Access")
+	})
+
+	t.Run("unauthorized access", func(t *testing.T) {
+		// A valid proof of someone else's address does not reveal their Merkle proofs
+		proof, signals := c.addressProof(user)
+		_, err := c.zkLogin.FetchUserMerkleProofs(c.call(other), proof, signals)
+		requireRevert(t, err, "zkEthereumAddress: Unauthorized Access")
+	})
+}
+
+func TestRegisterUser(t *testing.T) {
+	c := newChain(t, 4)
+	foodBank, user, other, stranger := c.deployer, c.accounts[0], c.accounts[1], c.accounts[2]
+
+	t.Run("no users yet", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		_, err := c.zkLogin.FetchUsersAsFoodBank(c.call(foodBank), proof, signals)
+		requireRevert(t, err, "Not a registered food bank or no users found")
+	})
+
+	t.Run("register", func(t *testing.T) {
+		c.registerUser(foodBank, user)
+		c.registerUser(foodBank, other)
+
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		users, err := c.zkLogin.FetchUsersAsFoodBank(c.call(foodBank), proof, signals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != 2 || users[0].Cmp(user.hashed) != 0 || users[1].Cmp(other.hashed) != 0 {
+			t.Fatalf("unexpected users of the food bank: %v", users)
+		}
+	})
+
+	t.Run("earlier root", func(t *testing.T) {
+		// The Merkle proofs of user were issued before other joined, their root stays known
+		proof, signals := c.membershipProof(user, types.RoleUser)
+		if signals[1].Cmp(c.trees[types.RoleUser].Root()) == 0 {
+			t.Fatal("expected a root from the history")
+		}
+		tx, err := c.zkLogin.DeleteUserMerkleProofs(c.transactor(user), proof, signals)
+		if err != nil {
+			t.Fatalf("historical root rejected: %v", err)
+		}
+		c.mine(tx)
+	})
+
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+
+	t.Run("already registered", func(t *testing.T) {
+		newProof, newSignals := c.addressProof(user)
+		_, err := c.zkLogin.RegisterUser(c.transactor(foodBank), proof, signals, user.address, newProof, newSignals)
+		requireRevert(t, err, "User is already Registered")
+	})
+
+	t.Run("zero address", func(t *testing.T) {
+		newProof, newSignals := c.addressProof(stranger)
+		_, err := c.zkLogin.RegisterUser(c.transactor(foodBank), proof, signals, common.Address{}, newProof, newSignals)
+		requireRevert(t, err, "Zero Address Detected")
+	})
+
+	newProof, newSignals := c.addressProof(stranger)
+
+	t.Run("invalid public signals", func(t *testing.T) {
+		addressProof, addressSignals := c.addressProof(foodBank)
+		_, err := c.zkLogin.RegisterUser(c.transactor(foodBank), addressProof, addressSignals, stranger.address, newProof, newSignals)
+		requireRevert(t, err, "zkMerkleTree: Invalid Public Signals")
+	})
+
+	t.Run("invalid proofs", func(t *testing.T) {
+		_, err := c.zkLogin.RegisterUser(c.transactor(foodBank), forge(proof), signals, stranger.address, newProof, newSignals)
+		requireRevert(t, err, "zkMerkleTree: Invalid Proofs")
+	})
+
+	t.Run("unauthorized access", func(t *testing.T) {
+		// Replaying the food bank's proof from another account
+		_, err := c.zkLogin.RegisterUser(c.transactor(user), proof, signals, stranger.address, newProof, newSignals)
+		requireRevert(t, err, "zkMerkleTree: Unauthorized Access")
+	})
+
+	t.Run("unknown root", func(t *testing.T) {
+		unknownProof, unknownSignals := c.proof(foodBank.hashed, big.NewInt(1))
+		_, err := c.zkLogin.RegisterUser(c.transactor(foodBank), unknownProof, unknownSignals, stranger.address, newProof, newSignals)
+		requireRevert(t, err, "zkMerkleTree: Unknown Root Detected")
+	})
+
+	t.Run("user as food bank", func(t *testing.T) {
+		// A user's membership proof is rooted in the users tree
+		userProof, userSignals := c.membershipProof(other, types.RoleUser)
+		_, err := c.zkLogin.RegisterUser(c.transactor(other), userProof, userSignals, stranger.address, newProof, newSignals)
+		requireRevert(t, err, "zkMerkleTree: Unknown Root Detected")
+	})
+
+	t.Run("new user invalid public signals", func(t *testing.T) {
+		badProof, badSignals := c.proof(stranger.hashed, big.NewInt(1))
+		_, err := c.zkLogin.RegisterUser(c.transactor(foodBank), proof, signals, stranger.address, badProof, badSignals)
+		requireRevert(t, err, "zkEthereumAddress: Invalid Public Signals")
+	})
+
+	t.Run("new user invalid proofs", func(t *testing.T) {
+		_, err := c.zkLogin.RegisterUser(c.transactor(foodBank), proof, signals, stranger.address, forge(newProof), newSignals)
+		requireRevert(t, err, "zkEthereumAddress: Invalid Proofs")
+	})
+
+	t.Run("new user unauthorized access", func(t *testing.T) {
+		// The address proof must be the new user's
+		_, err := c.zkLogin.RegisterUser(c.transactor(foodBank), proof, signals, c.accounts[3].address, newProof, newSignals)
+		requireRevert(t, err, "zkEthereumAddress: Unauthorized Access")
+	})
+}
+
+// writeRegistrations writes a CSV of registrations, one proof per encoding of ReadRegistrations.
+func (c *chain) writeRegistrations(dir string, accounts ...*account) string {
+	c.t.Helper()
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"address", "proof"}); err != nil {
+		c.t.Fatal(err)
+	}
+	for i, acc := range accounts {
+		proof := c.zkProof(acc.hashed, big.NewInt(0))
+		var cell string
+		switch i % 3 {
+		case 0:
+			data, err := proof.MarshalSnarkJS()
+			if err != nil {
+				c.t.Fatal(err)
+			}
+			cell = string(data)
+		case 1:
+			data, err := proof.MarshalBinary()
+			if err != nil {
+				c.t.Fatal(err)
+			}
+			cell = "0x" + hex.EncodeToString(data)
+		default:
+			data, err := proof.MarshalSnarkJS()
+			if err != nil {
+				c.t.Fatal(err)
+			}
+			cell = fmt.Sprintf("proof-%d.json", i)
+			if err := os.WriteFile(filepath.Join(dir, cell), data, 0o600); err != nil {
+				c.t.Fatal(err)
+			}
+		}
+		if err := w.Write([]string{acc.address.Hex(), cell}); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+	w.Flush()
+
+	path := filepath.Join(dir, "registrations.csv")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		c.t.Fatal(err)
+	}
+	return path
+}
+
+func TestRegisterUsers(t *testing.T) {
+	requireMethods(t, "registerUsers")
+
+	c := newChain(t, 5)
+	foodBank, newUsers, stranger := c.deployer, c.accounts[:4], c.accounts[4]
+
+	registrations, err := membership.ReadRegistrations(c.writeRegistrations(t.TempDir(), newUsers...), c.hasher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(registrations) != len(newUsers) {
+		t.Fatalf("expected %d registrations, got %d", len(newUsers), len(registrations))
+	}
+
+	accounts := make([]common.Address, len(registrations))
+	proofs := make([]zklogin.ZkLoginGroth16Proof, len(registrations))
+	publicSignals := make([][2]*big.Int, len(registrations))
+	for i, registration := range registrations {
+		accounts[i] = registration.Account
+		proofs[i], publicSignals[i] = c.convert(registration.Proof)
+	}
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+
+	t.Run("length mismatch", func(t *testing.T) {
+		_, err := c.zkLogin.RegisterUsers(c.transactor(foodBank), proof, signals, accounts, proofs[:1], publicSignals)
+		requireRevert(t, err, "Length of Users, Proofs and Public Signals mismatch")
+	})
+
+	t.Run("proof of another user", func(t *testing.T) {
+		// One bad proof reverts the whole batch
+		swapped := append([]zklogin.ZkLoginGroth16Proof{}, proofs...)
+		swappedSignals := append([][2]*big.Int{}, publicSignals...)
+		swapped[1], swappedSignals[1] = c.addressProof(stranger)
+		_, err := c.zkLogin.RegisterUsers(c.transactor(foodBank), proof, signals, accounts, swapped, swappedSignals)
+		requireRevert(t, err, "zkEthereumAddress: Unauthorized Access")
+	})
+
+	t.Run("zero address", func(t *testing.T) {
+		// One invalid address reverts the whole batch
+		invalid := append([]common.Address{}, accounts...)
+		invalid[2] = common.Address{}
+		_, err := c.zkLogin.RegisterUsers(c.transactor(foodBank), proof, signals, invalid, proofs, publicSignals)
+		requireRevert(t, err, "Zero Address Detected")
+	})
+
+	t.Run("register", func(t *testing.T) {
+		tx, err := c.zkLogin.RegisterUsers(c.transactor(foodBank), proof, signals, accounts, proofs, publicSignals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+		// Every user got the Merkle proofs of the root right after its insertion
+		for _, acc := range newUsers {
+			c.checkRoot(acc, types.RoleUser, c.insert(types.RoleUser, acc))
+		}
+
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		users, err := c.zkLogin.FetchUsersAsFoodBank(c.call(foodBank), proof, signals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != len(newUsers) {
+			t.Fatalf("expected %d users of the food bank, got %d", len(newUsers), len(users))
+		}
+		for i, acc := range newUsers {
+			if users[i].Cmp(acc.hashed) != 0 {
+				t.Fatalf("user %d of the food bank is %s, expected %s", i, users[i], acc.hashed)
+			}
+		}
+	})
+
+	t.Run("membership", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		for _, acc := range newUsers {
+			userProof, userSignals := c.membershipProof(acc, types.RoleUser)
+			ok, err := c.zkLogin.VerifyProof(c.call(foodBank), proof, signals, acc.address, userProof, userSignals)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatalf("membership of %s rejected", acc.address.Hex())
+			}
+		}
+	})
+
+	t.Run("already registered", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		strangerProof, strangerSignals := c.addressProof(stranger)
+		_, err := c.zkLogin.RegisterUsers(c.transactor(foodBank), proof, signals,
+			[]common.Address{stranger.address, accounts[0]},
+			[]zklogin.ZkLoginGroth16Proof{strangerProof, proofs[0]},
+			[][2]*big.Int{strangerSignals, publicSignals[0]})
+		requireRevert(t, err, "User is already Registered")
+	})
+
+	t.Run("terminated user", func(t *testing.T) {
+		userProof, userSignals := c.membershipProof(newUsers[0], types.RoleUser)
+		tx, err := c.zkLogin.TerminateUser(c.transactor(newUsers[0]), userProof, userSignals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		strangerProof, strangerSignals := c.addressProof(stranger)
+		_, err = c.zkLogin.RegisterUsers(c.transactor(foodBank), proof, signals,
+			[]common.Address{stranger.address, accounts[0]},
+			[]zklogin.ZkLoginGroth16Proof{strangerProof, proofs[0]},
+			[][2]*big.Int{strangerSignals, publicSignals[0]})
+		requireRevert(t, err, "Blacklisted User Detected")
+	})
+}
+
+func TestReadRegistrations(t *testing.T) {
+	c := newChain(t, 2)
+	user, other := c.accounts[0], c.accounts[1]
+
+	dir := t.TempDir()
+	path := c.writeRegistrations(dir, user, other)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("proof of another address", func(t *testing.T) {
+		swapped := bytes.Replace(data, []byte(user.address.Hex()), []byte(c.deployer.address.Hex()), 1)
+		if err := os.WriteFile(path, swapped, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := membership.ReadRegistrations(path, c.hasher); !errors.Is(err, membership.ErrInvalidRegistration) || !strings.Contains(err.Error(), "proof of another address") {
+			t.Fatalf("got %v, want %v", err, membership.ErrInvalidRegistration)
+		}
+	})
+
+	t.Run("repeated address", func(t *testing.T) {
+		repeated := append(append([]byte{}, data...), bytes.SplitAfter(data, []byte("\n"))[1]...)
+		if err := os.WriteFile(path, repeated, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := membership.ReadRegistrations(path, c.hasher); !errors.Is(err, membership.ErrInvalidRegistration) || !strings.Contains(err.Error(), "already on line 2") {
+			t.Fatalf("got %v, want %v", err, membership.ErrInvalidRegistration)
+		}
+	})
+}
+
+// registerMember registers acc with role by registrar, member of registrarRole, and checks the
+// root acc's Merkle proofs lead to.
+func (c *chain) registerMember(registrar *account, registrarRole, role types.Role, acc *account) {
+	c.t.Helper()
+
+	proof, signals := c.membershipProof(registrar, registrarRole)
+	newProof, newSignals := c.addressProof(acc)
+	tx, err := c.zkLogin.RegisterMember(c.transactor(registrar), uint32(registrarRole), proof, signals, uint32(role), acc.address, newProof, newSignals)
+	if err != nil {
+		c.t.Fatalf("failed to register %s: %v", role, err)
+	}
+	c.mine(tx)
+	c.checkRoot(acc, role, c.insert(role, acc))
+}
+
+func TestRegisterMember(t *testing.T) {
+	requireMethods(t, "registerMember", "fetchMemberMerkleProofs", "isPermitted")
+
+	c := newChain(t, 5)
+	foodBank, intermediary, user, stranger := c.deployer, c.accounts[0], c.accounts[1], c.accounts[2]
+
+	t.Run("permissions", func(t *testing.T) {
+		for _, registrar := range types.Roles() {
+			for _, role := range types.Roles() {
+				ok, err := c.zkLogin.IsPermitted(c.call(foodBank), uint32(registrar.Role), uint32(role.Role))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := registrar.Role.CanRegister(role.Role); ok != want {
+					t.Fatalf("%s registers %s: got %v, expected %v", registrar.Role, role.Role, ok, want)
+				}
+			}
+		}
+	})
+
+	t.Run("permitted", func(t *testing.T) {
+		c.registerMember(foodBank, types.RoleFoodBank, types.RoleIntermediary, intermediary)
+		c.registerMember(intermediary, types.RoleIntermediary, types.RoleUser, user)
+
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		userProof, userSignals := c.membershipProof(user, types.RoleUser)
+		ok, err := c.zkLogin.VerifyProof(c.call(foodBank), proof, signals, user.address, userProof, userSignals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("user registered by the intermediary rejected")
+		}
+	})
+
+	newProof, newSignals := c.addressProof(stranger)
+
+	t.Run("unpermitted role", func(t *testing.T) {
+		proof, signals := c.membershipProof(intermediary, types.RoleIntermediary)
+		for _, role := range []types.Role{types.RoleFoodBank, types.RoleVolunteer, types.RoleIntermediary, types.RoleAuditor} {
+			_, err := c.zkLogin.RegisterMember(c.transactor(intermediary), uint32(types.RoleIntermediary), proof, signals, uint32(role), stranger.address, newProof, newSignals)
+			requireRevert(t, err, "Registration not Permitted")
+		}
+	})
+
+	t.Run("registrar without permissions", func(t *testing.T) {
+		proof, signals := c.membershipProof(user, types.RoleUser)
+		_, err := c.zkLogin.RegisterMember(c.transactor(user), uint32(types.RoleUser), proof, signals, uint32(types.RoleUser), stranger.address, newProof, newSignals)
+		requireRevert(t, err, "Registration not Permitted")
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		_, err := c.zkLogin.RegisterMember(c.transactor(foodBank), uint32(types.RoleFoodBank), proof, signals, 200, stranger.address, newProof, newSignals)
+		requireRevert(t, err, "Registration not Permitted")
+	})
+
+	t.Run("role not held", func(t *testing.T) {
+		// The intermediary claims the food bank role with its own membership proof
+		proof, signals := c.membershipProof(intermediary, types.RoleIntermediary)
+		_, err := c.zkLogin.RegisterMember(c.transactor(intermediary), uint32(types.RoleFoodBank), proof, signals, uint32(types.RoleVolunteer), stranger.address, newProof, newSignals)
+		requireRevert(t, err, "zkMerkleTree: Unknown Root Detected")
+	})
+}
+
+func TestRegisterFoodBank(t *testing.T) {
+	c := newChain(t, 3)
+	foodBank, newFoodBank, user := c.deployer, c.accounts[0], c.accounts[1]
+
+	c.registerFoodBank(foodBank, newFoodBank)
+
+	t.Run("already registered", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		newProof, newSignals := c.addressProof(newFoodBank)
+		_, err := c.zkLogin.RegisterFoodBank(c.transactor(foodBank), proof, signals, newFoodBank.address, newProof, newSignals)
+		requireRevert(t, err, "User is already Registered")
+	})
+
+	t.Run("zero address", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		newProof, newSignals := c.addressProof(user)
+		_, err := c.zkLogin.RegisterFoodBank(c.transactor(foodBank), proof, signals, common.Address{}, newProof, newSignals)
+		requireRevert(t, err, "Zero Address Detected")
+	})
+
+	t.Run("new food bank registers users", func(t *testing.T) {
+		c.registerUser(newFoodBank, user)
+
+		proof, signals := c.membershipProof(newFoodBank, types.RoleFoodBank)
+		users, err := c.zkLogin.FetchUsersAsFoodBank(c.call(newFoodBank), proof, signals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != 1 || users[0].Cmp(user.hashed) != 0 {
+			t.Fatalf("unexpected users of the new food bank: %v", users)
+		}
+
+		// Users are listed per food bank
+		proof, signals = c.membershipProof(foodBank, types.RoleFoodBank)
+		_, err = c.zkLogin.FetchUsersAsFoodBank(c.call(foodBank), proof, signals)
+		requireRevert(t, err, "Not a registered food bank or no users found")
+	})
+
+	t.Run("user registers food bank", func(t *testing.T) {
+		proof, signals := c.membershipProof(user, types.RoleUser)
+		newProof, newSignals := c.addressProof(c.accounts[2])
+		_, err := c.zkLogin.RegisterFoodBank(c.transactor(user), proof, signals, c.accounts[2].address, newProof, newSignals)
+		requireRevert(t, err, "zkMerkleTree: Unknown Root Detected")
+	})
+}
+
+func TestVerifyProof(t *testing.T) {
+	c := newChain(t, 3)
+	foodBank, user, other, stranger := c.deployer, c.accounts[0], c.accounts[1], c.accounts[2]
+	c.registerUser(foodBank, user)
+	c.registerUser(foodBank, other)
+
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+	userProof, userSignals := c.membershipProof(user, types.RoleUser)
+
+	t.Run("valid", func(t *testing.T) {
+		ok, err := c.zkLogin.VerifyProof(c.call(foodBank), proof, signals, user.address, userProof, userSignals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("valid proofs rejected")
+		}
+	})
+
+	t.Run("zero address", func(t *testing.T) {
+		_, err := c.zkLogin.VerifyProof(c.call(foodBank), proof, signals, common.Address{}, userProof, userSignals)
+		requireRevert(t, err, "Zero Address Detected")
+	})
+
+	t.Run("not a food bank", func(t *testing.T) {
+		otherProof, otherSignals := c.membershipProof(other, types.RoleUser)
+		_, err := c.zkLogin.VerifyProof(c.call(other), otherProof, otherSignals, user.address, userProof, userSignals)
+		requireRevert(t, err, "zkMerkleTree: Unknown Root Detected")
+	})
+
+	t.Run("user invalid public signals", func(t *testing.T) {
+		addressProof, addressSignals := c.addressProof(user)
+		_, err := c.zkLogin.VerifyProof(c.call(foodBank), proof, signals, user.address, addressProof, addressSignals)
+		requireRevert(t, err, "zkMerkleTree: Invalid Public Signals")
+	})
+
+	t.Run("user invalid proofs", func(t *testing.T) {
+		_, err := c.zkLogin.VerifyProof(c.call(foodBank), proof, signals, user.address, forge(userProof), userSignals)
+		requireRevert(t, err, "zkMerkleTree: Invalid Proofs")
+	})
+
+	t.Run("user unauthorized access", func(t *testing.T) {
+		// The user's proof does not vouch for another account
+		_, err := c.zkLogin.VerifyProof(c.call(foodBank), proof, signals, other.address, userProof, userSignals)
+		requireRevert(t, err, "zkMerkleTree: Unauthorized Access")
+	})
+
+	t.Run("user unknown root", func(t *testing.T) {
+		strangerProof, strangerSignals := c.proof(stranger.hashed, big.NewInt(1))
+		_, err := c.zkLogin.VerifyProof(c.call(foodBank), proof, signals, stranger.address, strangerProof, strangerSignals)
+		requireRevert(t, err, "zkMerkleTree: Unknown Root Detected")
+	})
+}
+
+func TestVerifyProofs(t *testing.T) {
+	requireMethods(t, "verifyProofs")
+
+	c := newChain(t, 3)
+	foodBank, user, other, stranger := c.deployer, c.accounts[0], c.accounts[1], c.accounts[2]
+	c.registerUser(foodBank, user)
+	c.registerUser(foodBank, other)
+
+	proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+	userProof, userSignals := c.membershipProof(user, types.RoleUser)
+	otherProof, otherSignals := c.membershipProof(other, types.RoleUser)
+	strangerProof, strangerSignals := c.proof(stranger.hashed, big.NewInt(1))
+
+	// Valid, forged, unknown root, another account's proof and valid again
+	users := []common.Address{user.address, other.address, stranger.address, other.address, other.address}
+	proofs := []zklogin.ZkLoginGroth16Proof{userProof, forge(otherProof), strangerProof, userProof, otherProof}
+	publicSignals := [][2]*big.Int{userSignals, otherSignals, strangerSignals, userSignals, otherSignals}
+
+	t.Run("valid and invalid", func(t *testing.T) {
+		results, err := c.zkLogin.VerifyProofs(c.call(foodBank), proof, signals, users, proofs, publicSignals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result word, got %d", len(results))
+		}
+		for i := range users {
+			if got, want := results[0].Bit(i) == 1, i == 0 || i == 4; got != want {
+				t.Fatalf("proof %d: got %v, expected %v", i, got, want)
+			}
+		}
+	})
+
+	t.Run("length mismatch", func(t *testing.T) {
+		_, err := c.zkLogin.VerifyProofs(c.call(foodBank), proof, signals, users, proofs[:2], publicSignals)
+		requireRevert(t, err, "Length of Users, Proofs and Public Signals mismatch")
+	})
+
+	t.Run("not a food bank", func(t *testing.T) {
+		_, err := c.zkLogin.VerifyProofs(c.call(user), userProof, userSignals, users, proofs, publicSignals)
+		requireRevert(t, err, "zkMerkleTree: Unknown Root Detected")
+	})
+}
+
+func TestDeleteMerkleProofs(t *testing.T) {
+	c := newChain(t, 1)
+	foodBank, user := c.deployer, c.accounts[0]
+	c.registerUser(foodBank, user)
+
+	t.Run("user", func(t *testing.T) {
+		proof, signals := c.membershipProof(user, types.RoleUser)
+		tx, err := c.zkLogin.DeleteUserMerkleProofs(c.transactor(user), proof, signals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+
+		merkleProof, err := c.merkleProofs(user, types.RoleUser)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(merkleProof.PathElements) != 0 {
+			t.Fatalf("Merkle proofs still stored: %d path elements", len(merkleProof.PathElements))
+		}
+
+		// Deleting the stored copy does not revoke the membership
+		fbProof, fbSignals := c.membershipProof(foodBank, types.RoleFoodBank)
+		ok, err := c.zkLogin.VerifyProof(c.call(foodBank), fbProof, fbSignals, user.address, proof, signals)
+		if err != nil || !ok {
+			t.Fatalf("membership lost with the Merkle proofs: %v", err)
+		}
+	})
+
+	t.Run("user invalid proofs", func(t *testing.T) {
+		proof, signals := c.proof(user.hashed, c.trees[types.RoleUser].Root())
+		_, err := c.zkLogin.DeleteUserMerkleProofs(c.transactor(user), forge(proof), signals)
+		requireRevert(t, err, "zkMerkleTree: Invalid Proofs")
+	})
+
+	t.Run("food bank", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		tx, err := c.zkLogin.DeleteFoodBankMerkleProofs(c.transactor(foodBank), proof, signals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+
+		merkleProof, err := c.merkleProofs(foodBank, types.RoleFoodBank)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(merkleProof.PathElements) != 0 {
+			t.Fatalf("Merkle proofs still stored: %d path elements", len(merkleProof.PathElements))
+		}
+	})
+
+	t.Run("food bank unauthorized access", func(t *testing.T) {
+		proof, signals := c.proof(foodBank.hashed, c.trees[types.RoleFoodBank].Root())
+		_, err := c.zkLogin.DeleteFoodBankMerkleProofs(c.transactor(user), proof, signals)
+		requireRevert(t, err, "zkMerkleTree: Unauthorized Access")
+	})
+}
+
+func TestTerminate(t *testing.T) {
+	c := newChain(t, 3)
+	foodBank, newFoodBank, user, other := c.deployer, c.accounts[0], c.accounts[1], c.accounts[2]
+	c.registerFoodBank(foodBank, newFoodBank)
+	c.registerUser(foodBank, user)
+
+	userProof, userSignals := c.membershipProof(user, types.RoleUser)
+	newFoodBankProof, newFoodBankSignals := c.membershipProof(newFoodBank, types.RoleFoodBank)
+
+	t.Run("user unknown root", func(t *testing.T) {
+		proof, signals := c.proof(user.hashed, big.NewInt(1))
+		_, err := c.zkLogin.TerminateUser(c.transactor(user), proof, signals)
+		requireRevert(t, err, "zkMerkleTree: Unknown Root Detected")
+	})
+
+	t.Run("user", func(t *testing.T) {
+		tx, err := c.zkLogin.TerminateUser(c.transactor(user), userProof, userSignals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+
+		_, err = c.merkleProofs(user, types.RoleUser)
+		requireRevert(t, err, "Blacklisted User Detected")
+
+		_, err = c.zkLogin.TerminateUser(c.transactor(user), userProof, userSignals)
+		requireRevert(t, err, "Blacklisted User Detected")
+
+		// validAccount checks the blacklist for msg.sender whatever its argument, so a terminated
+		// account is only rejected as a sender. Keeping it out of verifyProof and registrations
+		// is left to the gateway, which revokes it from the indexed events.
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		ok, err := c.zkLogin.VerifyProof(c.call(foodBank), proof, signals, user.address, userProof, userSignals)
+		if err != nil || !ok {
+			t.Fatalf("verifyProof now rejects terminated users (%v), update the gateway revocation path", err)
+		}
+	})
+
+	t.Run("food bank", func(t *testing.T) {
+		tx, err := c.zkLogin.TerminateFoodBank(c.transactor(newFoodBank), newFoodBankProof, newFoodBankSignals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.mine(tx)
+
+		newProof, newSignals := c.addressProof(other)
+		_, err = c.zkLogin.RegisterUser(c.transactor(newFoodBank), newFoodBankProof, newFoodBankSignals, other.address, newProof, newSignals)
+		requireRevert(t, err, "Blacklisted User Detected")
+
+		_, err = c.zkLogin.FetchUsersAsFoodBank(c.call(newFoodBank), newFoodBankProof, newFoodBankSignals)
+		requireRevert(t, err, "Blacklisted User Detected")
+
+		// Other food banks are unaffected
+		c.registerUser(foodBank, other)
+	})
+
+	t.Run("food bank unauthorized access", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		_, err := c.zkLogin.TerminateFoodBank(c.transactor(other), proof, signals)
+		requireRevert(t, err, "zkMerkleTree: Unauthorized Access")
+	})
+}
+
+func (c *chain) revokeMember(revoker *account, revokerRole, role types.Role, hashed *big.Int) error {
+	c.t.Helper()
+
+	proof, signals := c.membershipProof(revoker, revokerRole)
+	tx, err := c.zkLogin.RevokeMember(c.transactor(revoker), uint32(revokerRole), proof, signals, uint32(role), hashed)
+	if err != nil {
+		return err
+	}
+	c.mine(tx)
+	return nil
+}
+
+func requireRevoked(t *testing.T, c *chain, acc *account, expected bool) {
+	t.Helper()
+
+	revoked, err := c.zkLogin.IsRevoked(c.call(c.deployer), acc.hashed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked != expected {
+		t.Fatalf("%s revoked: got %v, expected %v", acc.address.Hex(), revoked, expected)
+	}
+}
+
+func TestRevokeMember(t *testing.T) {
+	requireMethods(t, "revokeMember", "isRevoked", "registerMember")
+
+	c := newChain(t, 7)
+	foodBank, newFoodBank, intermediary := c.deployer, c.accounts[0], c.accounts[1]
+	user, other, stranger, intermediaryUser := c.accounts[2], c.accounts[3], c.accounts[4], c.accounts[5]
+	c.registerFoodBank(foodBank, newFoodBank)
+	c.registerMember(foodBank, types.RoleFoodBank, types.RoleIntermediary, intermediary)
+	c.registerUser(foodBank, user)
+	c.registerUser(newFoodBank, other)
+	c.registerMember(intermediary, types.RoleIntermediary, types.RoleUser, intermediaryUser)
+
+	t.Run("by hashed address", func(t *testing.T) {
+		if err := c.revokeMember(foodBank, types.RoleFoodBank, types.RoleUser, user.hashed); err != nil {
+			t.Fatal(err)
+		}
+		requireRevoked(t, c, user, true)
+		requireRevoked(t, c, other, false)
+
+		proof, signals := c.membershipProof(user, types.RoleUser)
+		_, err := c.zkLogin.DeleteUserMerkleProofs(c.transactor(user), proof, signals)
+		requireRevert(t, err, "zkMerkleTree: Revoked Account Detected")
+
+		err = c.revokeMember(foodBank, types.RoleFoodBank, types.RoleUser, user.hashed)
+		requireRevert(t, err, "Account already Revoked")
+	})
+
+	t.Run("registered again", func(t *testing.T) {
+		proof, signals := c.membershipProof(foodBank, types.RoleFoodBank)
+		newProof, newSignals := c.addressProof(user)
+		_, err := c.zkLogin.RegisterMember(c.transactor(foodBank), uint32(types.RoleFoodBank), proof, signals, uint32(types.RoleVolunteer), user.address, newProof, newSignals)
+		requireRevert(t, err, "Revoked Account Detected")
+	})
+
+	t.Run("not the registrar", func(t *testing.T) {
+		err := c.revokeMember(foodBank, types.RoleFoodBank, types.RoleUser, other.hashed)
+		requireRevert(t, err, "Not the Registrar of the Member")
+
+		// The food bank registered the intermediary, not its users
+		err = c.revokeMember(foodBank, types.RoleFoodBank, types.RoleUser, intermediaryUser.hashed)
+		requireRevert(t, err, "Not the Registrar of the Member")
+
+		// Food banks added at deployment have no registrar
+		err = c.revokeMember(newFoodBank, types.RoleFoodBank, types.RoleFoodBank, foodBank.hashed)
+		requireRevert(t, err, "Not the Registrar of the Member")
+		requireRevoked(t, c, other, false)
+		requireRevoked(t, c, foodBank, false)
+	})
+
+	t.Run("intermediary", func(t *testing.T) {
+		if err := c.revokeMember(intermediary, types.RoleIntermediary, types.RoleUser, intermediaryUser.hashed); err != nil {
+			t.Fatal(err)
+		}
+		requireRevoked(t, c, intermediaryUser, true)
+
+		if err := c.revokeMember(foodBank, types.RoleFoodBank, types.RoleIntermediary, intermediary.hashed); err != nil {
+			t.Fatal(err)
+		}
+		requireRevoked(t, c, intermediary, true)
+	})
+
+	t.Run("food bank", func(t *testing.T) {
+		if err := c.revokeMember(foodBank, types.RoleFoodBank, types.RoleFoodBank, newFoodBank.hashed); err != nil {
+			t.Fatal(err)
+		}
+		requireRevoked(t, c, newFoodBank, true)
+
+		// A revoked food bank can no longer revoke the users it registered
+		err := c.revokeMember(newFoodBank, types.RoleFoodBank, types.RoleUser, other.hashed)
+		requireRevert(t, err, "zkMerkleTree: Revoked Account Detected")
+		requireRevoked(t, c, other, false)
+	})
+
+	t.Run("unregistered", func(t *testing.T) {
+		err := c.revokeMember(foodBank, types.RoleFoodBank, types.RoleUser, stranger.hashed)
+		requireRevert(t, err, "Not a registered Member")
+	})
+
+	t.Run("unpermitted", func(t *testing.T) {
+		c.registerUser(foodBank, stranger)
+		err := c.revokeMember(stranger, types.RoleUser, types.RoleUser, other.hashed)
+		requireRevert(t, err, "Registration not Permitted")
+	})
+}
+
+func TestPoseidonHasher(t *testing.T) {
+	// zkLogin deployed with the generated Poseidon contract: the on-chain hashAddress and
+	// hashLeftRight, and so the roots, match the Go Poseidon
+	c := newChainWithHasher(t, 3, types.HasherPoseidon)
+	foodBank, newFoodBank, user := c.deployer, c.accounts[0], c.accounts[1]
+
+	t.Run("initial food bank", func(t *testing.T) {
+		c.checkRoot(foodBank, types.RoleFoodBank, c.trees[types.RoleFoodBank].Root())
+	})
+
+	t.Run("register", func(t *testing.T) {
+		c.registerFoodBank(foodBank, newFoodBank)
+		c.registerUser(newFoodBank, user)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		proof, signals := c.membershipProof(newFoodBank, types.RoleFoodBank)
+		userProof, userSignals := c.membershipProof(user, types.RoleUser)
+		ok, err := c.zkLogin.VerifyProof(c.call(newFoodBank), proof, signals, user.address, userProof, userSignals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("valid proofs rejected")
+		}
+	})
+}
@@ -42,9 +42,32 @@ type ZkLoginGroth16Proof struct {
 	PiC [2]*big.Int
 }
 
+// ZkLoginProposal is an auto generated low-level Go binding around an user-defined struct.
+type ZkLoginProposal struct {
+	Action        uint8
+	Account       common.Address
+	HashedAddress *big.Int
+	ExpiresAt     uint64
+	Approvals     uint32
+	Executed      bool
+}
+
+// ZkLoginRoleDefinition is an auto generated low-level Go binding around an user-defined struct.
+type ZkLoginRoleDefinition struct {
+	Id      uint32
+	Tree    uint32
+	Subtree uint32
+}
+
+// ZkLoginRolePermission is an auto generated low-level Go binding around an user-defined struct.
+type ZkLoginRolePermission struct {
+	Registrar uint32
+	Role      uint32
+}
+
 // ZkloginMetaData contains all meta data concerning the Zklogin contract.
 var ZkloginMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_trees\",\"type\":\"uint32\"},{\"internalType\":\"uint32[]\",\"name\":\"_subtrees\",\"type\":\"uint32[]\"},{\"internalType\":\"uint32[]\",\"name\":\"_levels\",\"type\":\"uint32[]\"},{\"internalType\":\"contractIHasher\",\"name\":\"_hasher\",\"type\":\"address\"},{\"internalType\":\"contractIFoodBankVerifier\",\"name\":\"_foodBankVerifier\",\"type\":\"address\"},{\"internalType\":\"address[]\",\"name\":\"_foodBanks\",\"type\":\"address[]\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"deleteFoodBankMerkleProofs\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_userMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_userPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"deleteUserMerkleProofs\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankEthereumAddressProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankEthereumAddressPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"fetchFoodBankMerkleProofs\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256[]\",\"name\":\"pathElements\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"pathIndices\",\"type\":\"uint256[]\"}],\"internalType\":\"structMerkleTreeWithHistory.MerkleProof\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_userEthereumAddressProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_userEthereumAddressPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"fetchUserMerkleProofs\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256[]\",\"name\":\"pathElements\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"pathIndices\",\"type\":\"uint256[]\"}],\"internalType\":\"structMerkleTreeWithHistory.MerkleProof\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"fetchUsersAsFoodBank\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"address\",\"name\":\"_newFoodBank\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_newFoodBankEthereumAddressProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_newFoodBankPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"registerFoodBank\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"address\",\"name\":\"_newUser\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_newUserEthereumAddressProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_newUserPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"registerUser\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"terminateFoodBank\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_userMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_userMerklePublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"terminateUser\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"address\",\"name\":\"_user\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_userMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_userMerklePublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"verifyProof\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_trees\",\"type\":\"uint32\"},{\"internalType\":\"uint32[]\",\"name\":\"_subtrees\",\"type\":\"uint32[]\"},{\"internalType\":\"uint32[]\",\"name\":\"_levels\",\"type\":\"uint32[]\"},{\"internalType\":\"contractIHasher\",\"name\":\"_hasher\",\"type\":\"address\"},{\"internalType\":\"contractIFoodBankVerifier\",\"name\":\"_foodBankVerifier\",\"type\":\"address\"},{\"internalType\":\"address[]\",\"name\":\"_foodBanks\",\"type\":\"address[]\"},{\"components\":[{\"internalType\":\"uint32\",\"name\":\"id\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"tree\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"subtree\",\"type\":\"uint32\"}],\"internalType\":\"structzkLogin.RoleDefinition[]\",\"name\":\"_roles\",\"type\":\"tuple[]\"},{\"components\":[{\"internalType\":\"uint32\",\"name\":\"registrar\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"role\",\"type\":\"uint32\"}],\"internalType\":\"structzkLogin.RolePermission[]\",\"name\":\"_permissions\",\"type\":\"tuple[]\"},{\"internalType\":\"uint32\",\"name\":\"_foodBankQuorum\",\"type\":\"uint32\"},{\"internalType\":\"uint64\",\"name\":\"_proposalLifetime\",\"type\":\"uint64\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_foodBankMerkleProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"bytes32\",\"name\":\"_proposal\",\"type\":\"bytes32\"}],\"name\":\"approveProposal\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"deleteFoodBankMerkleProofs\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_role\",\"type\":\"uint32\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_memberMerkleProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_memberPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"deleteMemberMerkleProofs\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_userMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_userPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"deleteUserMerkleProofs\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_foodBankMerkleProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"bytes32\",\"name\":\"_proposal\",\"type\":\"bytes32\"}],\"name\":\"executeProposal\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankEthereumAddressProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankEthereumAddressPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"fetchFoodBankMerkleProofs\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256[]\",\"name\":\"pathElements\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"pathIndices\",\"type\":\"uint256[]\"}],\"internalType\":\"structMerkleTreeWithHistory.MerkleProof\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"fetchGovernance\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_hashedAddress\",\"type\":\"uint256\"}],\"name\":\"fetchKeyRotation\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_role\",\"type\":\"uint32\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_memberEthereumAddressProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_memberEthereumAddressPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"fetchMemberMerkleProofs\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256[]\",\"name\":\"pathElements\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"pathIndices\",\"type\":\"uint256[]\"}],\"internalType\":\"structMerkleTreeWithHistory.MerkleProof\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_proposal\",\"type\":\"bytes32\"}],\"name\":\"fetchProposal\",\"outputs\":[{\"components\":[{\"internalType\":\"uint8\",\"name\":\"action\",\"type\":\"uint8\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"hashedAddress\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"expiresAt\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"approvals\",\"type\":\"uint32\"},{\"internalType\":\"bool\",\"name\":\"executed\",\"type\":\"bool\"}],\"internalType\":\"structzkLogin.Proposal\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"fetchProposals\",\"outputs\":[{\"internalType\":\"bytes32[]\",\"name\":\"\",\"type\":\"bytes32[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_role\",\"type\":\"uint32\"}],\"name\":\"fetchRole\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_userEthereumAddressProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_userEthereumAddressPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"fetchUserMerkleProofs\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256[]\",\"name\":\"pathElements\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"pathIndices\",\"type\":\"uint256[]\"}],\"internalType\":\"structMerkleTreeWithHistory.MerkleProof\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"fetchUsersAsFoodBank\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_proposal\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_hashedFoodBank\",\"type\":\"uint256\"}],\"name\":\"hasApproved\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_registrarRole\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"_role\",\"type\":\"uint32\"}],\"name\":\"isPermitted\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_hashedAddress\",\"type\":\"uint256\"}],\"name\":\"isRevoked\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_role\",\"type\":\"uint32\"},{\"internalType\":\"address\",\"name\":\"_newMember\",\"type\":\"address\"}],\"name\":\"keyRotationHash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_foodBankMerkleProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"address\",\"name\":\"_newFoodBank\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_newFoodBankEthereumAddressProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_newFoodBankPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"proposeFoodBank\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_foodBankMerkleProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256\",\"name\":\"_hashedFoodBank\",\"type\":\"uint256\"}],\"name\":\"proposeFoodBankTermination\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"address\",\"name\":\"_newFoodBank\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_newFoodBankEthereumAddressProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_newFoodBankPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"registerFoodBank\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_registrarRole\",\"type\":\"uint32\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_registrarMerkleProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_registrarPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint32\",\"name\":\"_role\",\"type\":\"uint32\"},{\"internalType\":\"address\",\"name\":\"_newMember\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_newMemberEthereumAddressProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_newMemberPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"registerMember\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"address\",\"name\":\"_newUser\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_newUserEthereumAddressProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_newUserPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"registerUser\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"address[]\",\"name\":\"_newUsers\",\"type\":\"address[]\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof[]\",\"name\":\"_newUserEthereumAddressProofs\",\"type\":\"tuple[]\"},{\"internalType\":\"uint256[2][]\",\"name\":\"_newUserPublicSignals\",\"type\":\"uint256[2][]\"}],\"name\":\"registerUsers\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_revokerRole\",\"type\":\"uint32\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_revokerMerkleProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_revokerPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint32\",\"name\":\"_role\",\"type\":\"uint32\"},{\"internalType\":\"uint256\",\"name\":\"_hashedAddress\",\"type\":\"uint256\"}],\"name\":\"revokeMember\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_role\",\"type\":\"uint32\"},{\"internalType\":\"address\",\"name\":\"_oldMember\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_oldMemberMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_oldMemberPublicSignals\",\"type\":\"uint256[2]\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_newMemberEthereumAddressProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_newMemberPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"bytes\",\"name\":\"_signature\",\"type\":\"bytes\"}],\"name\":\"rotateKey\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"terminateFoodBank\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_role\",\"type\":\"uint32\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"type\":\"tuple\",\"name\":\"_memberMerkleProof\"},{\"internalType\":\"uint256[2]\",\"name\":\"_memberPublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"terminateMember\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_userMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_userMerklePublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"terminateUser\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"address\",\"name\":\"_user\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_userMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_userMerklePublicSignals\",\"type\":\"uint256[2]\"}],\"name\":\"verifyProof\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof\",\"name\":\"_foodBankMerkleProof\",\"type\":\"tuple\"},{\"internalType\":\"uint256[2]\",\"name\":\"_foodBankPublicSignals\",\"type\":\"uint256[2]\"},{\"internalType\":\"address[]\",\"name\":\"_users\",\"type\":\"address[]\"},{\"components\":[{\"internalType\":\"uint256[2]\",\"name\":\"pi_a\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"pi_b\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"pi_c\",\"type\":\"uint256[2]\"}],\"internalType\":\"structzkLogin.Groth16Proof[]\",\"name\":\"_userMerkleProofs\",\"type\":\"tuple[]\"},{\"internalType\":\"uint256[2][]\",\"name\":\"_userMerklePublicSignals\",\"type\":\"uint256[2][]\"}],\"name\":\"verifyProofs\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
 	Bin: "0x61014080604052346200207a57620051f680380380916200002182856200209b565b833981019060c0818303126200207a576200003c81620020bf565b60208201516001600160401b0381116200207a57836200005e918401620020e9565b60408301516001600160401b0381116200207a578462000080918501620020e9565b6060840151949093906001600160a01b03861686036200207a576080820151906001600160a01b03821682036200207a5760a08301516001600160401b0381116200207a5781601f8286010112156200207a578084015190620000e382620020d1565b94620000f360405196876200209b565b828652602086019360208460051b8484010101116200207a5780820160200193915b60208460051b828401010185106200205257505050505063ffffffff1960005416600055600360a052602060c05282518063ffffffff861614908162002046575b501562001fc25760808681527f2fe54c60d3acabf3343a35b6eba15db4821b340f76e741e2249685ed4899af6c7f17ef568e3e12ab5b9c7254a8d58478811de00f9e6eb34345acd53bf8fd09d3ec557f256a6135777eee2fd26f54b8b7037a25439d5235caee224154186d2b8a52e31d7fabd6e7cb50984ff9c2f3e18a2660c3353dadf4e3291deeb275dae2cd1e44fe05557f1151949895e82ab19924de92c40a3d6f7bcb60d92b00504b8199613683f0c2007f91da3fd0782e51c6b3986e9e672fd566868e71f3dbc2d6c2cd6fbb3e361af2a7557f20121ee811489ff8d61f09fb89e313f14959a0f28bb428a20dba6b0b068b3bdb7f2e174c10e159ea99b867ce3205125c24a42d128804e4070ed6fcc8cc98166aa0557f0a89ca6ffa14cc462cfedb842c30ed221a50a3d6bf022a6a57dc82ab24c157c97f1a1e6821cde7d0159c0d293177871e09677b4e42307c7db3ba94f8648a5a050f557f24ca05c2b5cd42e890d6be94c68d0689f4f21c9cec9c0f13fe41d566dfb549597f04cde762ef08b6b6c5ded8e8c4c0b3f4e5c9ad7342c88fcc93681b4588b73f05557f1ccb97c932565a92c60156bdba2d08f3bf1377464e025cee765679e604a7315c7fc59312466997bb42aaaf719ece141047820e6b34531e1670dc1852a453648f0f557f19156fbd7d1a8bf5cba8909367de1b624534ebab4f0f79e003bccdd1b182bdb47fbeb3bad75134cb432e5707980e3245c52c5998a1125ee30f2f0dbf3925b1e551557f261af8c1f0912e465744641409f622d466c3920ac6e5ff37e36604cb11dfff807f2645749a946633740611cfc8178319f0958659d6922e4bf7e3a08b44789f53a4557e58459724ff6ca5a1652fcbc3e82b93895cf08e975b19beab3f54c217d1c0077f4ad5a04d53b5856f318545bb721f67d3f6d0a5a999f25eec7e20eaeb4c47b933557f1f04ef20dee48d39984d8eabe768a70eafa6310ad20849d4573c3c40c2ad1e307f5c6b02db8b672415ffad906d7ccee10bd53dbad7d0b29e2bc0e50c93d5f31093557f1bea3dec5dab51567ce7e200a30f7ba6d4276aeaa53e2686f962a46c66d511e57f0c1469ad586d86b6976c45826d7ae56d76ee516e37a2bccffbe904b74dbae7ea557f0ee0f941e2da4b9e31c3ca97a40d8fa9ce68d97c084177071b3cb46cd3372f0f7f140aabff1a85df08546c9a350c79ae18341bde4a2cef5d2fd460885c0128ce26557f1ca9503e8935884501bbaf20be14eb4c46b89772c97b96e3b2ebf3a36a948bbd7fa5022b2bfd144bf9103d80168549b5df7c72ab60bd51bf71a02a08d844853b4a557f133a80e30697cd55d8f7d4b0965b7be24057ba5dc3da898ee2187232446cb1087feb3e677499e881fe1bdbc344a49c412138038a9f40883b6dc68f713aab483523557f13e6d8fc88839ed76e182c2a779af5b2c0da9dd18c90427a644f7e148a6253b67f66b61daf77b854ca6ba000a8d4b340eafcdb71b6583753b4af89fceb54988fff557f1eb16b057a477f4bc8f572ea6bee39561098f78f15bfb3699dcbb7bd8db618547f4a597304b2df0a7a7b428b3c24c35ba6373aabebf9972387f5610f74a01b21bd557f0da2cb16a1ceaabf1c16b838f7a9e3f2a3a3088d9e0a6debaa748114620696ea7fac375bcb880242328180c23d4a918023a12a7caf7cf12b8c4074e4a3f39900a0557f24a3b3d822420b14b5d8cb6c28a574f01e98ea9e940551d2ebd75cee12649f9d7f7f6fa3f34639ea1891363ca773619dbd5f652d7ab50411111dde2f57e3ae13ad557f198622acbd783d1b0d9064105b1fc8e4d8889de95c4c519b3f635809fe6afc057f9bbf2ad10217b6212df1939350a047a69b6887b770020d3fa8c328c0653ee987557f29d7ed391256ccc3ea596c86e933b89ff339d25ea8ddced975ae2fe30b5296d47ff7deed9399d719bf61dcb1322c056a03a885c275ab093673b0cc182b84bea061557f19be59f2f0413ce78c0c3703a3a5451b1d7f39629fa33abd11548a76065b29677f1bb30a1647f6f6723cb3a88838ce0319afabe51263fc466f2f669a7a24ad88c6557f1ff3f61797e538b70e619310d33f2a063e7eb59104e112e95738da1254dc34537f87e655ef16e4075af30c6a90c2b439f7dcd2d83a606dafadaee10cffaf918132557f10c16ae9959cf8358980d9dd9616e48228737310a10e2b6b731c1a548f036c487fff624574ceefb6578b3887a7448cf2ca4d120002f646987b0a9b9ad3f6dc2c10557f0ba433a63174a90ac20992e75e3095496812b652685b5e1a2eae0b1bf4e8fcd17f1ac66383b86984a837d32661c9fdda480194de6e2dbd3891e29fadcb763a62da557f019ddb9df2bc98d987d0dfeca9d2b643deafab8f7036562e627c3667266a044c7feb5726be0cc40daa58a5f8f81528465ddb0c35e1e56e157eca916d69d6c34324557f2d3c88b23175c5a5565db928414c66d1912b11acf974b2e644caaac04739ce997ff6eb4279aa452568dd287204244d7e29d7ca1bc7a01440f08342bf2599f4b9b6557f2eab55f6ae4e66e32c5189eed5c470840863445760f5ed7e7b69b2a62600f3547fd8906b3e50614809ec86d7bb29bf3c4e8647f5376e87f81687a4a770137f7d59557e2df37a2642621802383cf952bf4dd1f32e05433beeb1fd41031fb7eace979d7f69bc8c08a6b955aec2072ca430bac7123bc3539264a736d1a23621b0f0c62f31557f104aeb41435db66c3e62feccc1d6f5d98d0a0ed75d1374db457cf462e3a1f4277f547911337f50119fe7598b1be3fa84d3d0506ffe5c730db17c43bc74040bbfce557f1f3c6fd858e9a7d4b0d1f38e256a09d81d5a5e3c963987e2d4b814cfab7c6ebb7f9041ee6632bd2142b9cc58f348e0761559f8d964fe48ac6d87dc2b689213e3bb557f2c7a07d20dff79d01fecedc1134284a8d08436606c93693b67e333f671bf69cc7f4c55bec45be59a99d441ccb7880f9b68f316b687ab5ac77efc4386a80700776855600560209081527f1471eb6eb2c5e789fc3de43f8ce62938c7d1836ec861730447e2ada8fd81017b805463ffffffff199081166002179091557f89832631fb3c3307a103ba2c84ab569c64d6182a18893dcd163f0f1c2090733a8054821660041790557fa9bc9a3a348c357ba16b37005d7e6b3236198c0e939f4af8c5f19b8deeb8ebc08054821660081790557f3eec716f11ba9e820c81ca75eb978ffb45831ef8b7a53e5e422c26008e1ca6d58054821660101790557f458b30c2d72bfd2c6317304a4594ecbafe5f729d3111b65fdc3a33bd48e5432d80548216831790557f069400f22b28c6c362558d92f66163cec5671cba50b61abd2eecfcd0eaeac5188054821660401790557feddb6698d7c569ff62ff64f1f1492bf14a54594835ba0faac91f84b4f5d81460805482169093179092557ffb33122aa9f93cc639ebe80a7bc4784c11e6053dde89c6f4f7e268c6a623da1e805483166101001790557fc0a4a8be475dfebc377ebef2d7c4ff47656f572a08dd92b81017efcdba0febe1805483166102001790557fa18b128af1c8fc61ff46f02d146e54546f34d340574cf2cef6a753cba6b6701d805483166104001790557f40f28f99a40bc9f6beea1013afdbc3cdcc689eb76b82c4de06c0acf1e1932ed5805483166108001790557ff907e7e6656fa73566b18c1215272fe9fca2c55c552e62c923e21e000ac4b4e6805483166110001790557f03145c75015e7a856ecd94c41432ef3cb669d6360af23433588937fefdfac825805483166120001790557f783638979e3582b3ffd6d53fc06c949ac31d1ac75a5e2c3531fbe1f91045eb53805483166140001790557f58f00e8ecc6f5419941dd0bafec65a4cc188d31713fb1fe224257460930df8af805483166180001790557f8b32256db898364c465749decac34aee435952ffe1739257aa5b0235e266d9c580548316620100001790557fb4e18992ad424cdedc46668609f2bafcf665a8d99577618d5923c69264d9cf5f80548316620200001790557fd1ccbf1f9f869f51cd81e6f099f905636b057f682c706fe990614b112051692880548316620400001790557f872ac8b0ab547ba6ba6686d487265a409b97d09cf043f98287b4b34e7bc04a7180548316620800001790557f3dfec54401578e5ad10d5cfe74972cfc24c82740aaca9c2d34cbb4be4a761cc580548316621000001790557fdcae836ed36bf3d20474cfcca00229d5b3b00239a2a956d8ca4bf29e25a7143c80548316622000001790557fb8657d180a4d2444fb942e94a4266075e5a1b59d96d88e88cf308d6927f00ff280548316624000001790557f1759eeb783be12e6871ee15567296c25cea65699ad38e9965540ba6254a9037f80548316628000001790557f5cc25df4297f13907c2e8c8bb7612ac7d899f1e24c7e8664c22a89192ac286a78054831663010000001790557fae2f6b16f0e0ac80673d6caef460ba44e001264158bf422be5bc239018ccc6778054831663020000001790557fce1f324a8a5d5daa4a6b2281780ab321637fd4089413dd89c573bbf705027cb98054831663040000001790557f2c8eed490e2e8e94ab99e89b6202d0db22c83d972d2b78b681fe35c98d2baa338054831663080000001790557f66eeecffab615cf4c69d47d3aa51576e95b697767264fa754ea36f4e363ea1938054831663100000001790557f348e8fe0716b12afdd2e814ae0b8b1bb9b5c7a197ef418c73b8bdd93bee14de58054831663200000001790557f3fb1f8b5b572f385df2ff517fa4200d6781fd017f742a2f073e874e0dca7758b8054831663400000001790557ff0566fba57f394cfd00b7b328d5cff9d096b0b4609f559321788bcbb79ff612c80548316638000000017905560009081527f071e9cfece6dd892566e0eb3e2a591eadf7d95b3a63c4bb6c30897234d67d5cc805490921663ffffffff179091559293909286865b63ffffffff83169363ffffffff8116851015620011f95762000f8663ffffffff62000f7c878b620021bf565b51169583620021bf565b519763ffffffff8916151580620011e2575b62000fa39062002219565b85151580620011cf575b1562001171579263ffffffff60009892989793975416966000985b8763ffffffff8b1610156200112e5760005b63ffffffff811663ffffffff8d168110156200103f57906200103991620010018262002321565b908c60005260036020528d63ffffffff6040600020911660005260205260016040600020019060005260205260406000205562002203565b62000fda565b50509298949194939093886000526003602052604060002063ffffffff8216600052602052604060002067ffffffff000000008c60201b1667ffffffff0000000019825416179055886000526002602052604060002063ffffffff821660005260205260406000209063ffffffff8c8160001991160111620010ff57620010f191620010d563ffffffff8e166000190162002321565b6000526020526040600020600160ff1982541617905562002203565b989294919493909362000fc8565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b97509750975091935091620011659060005463ffffffff62001152818316620022a5565b169063ffffffff19161760005562002203565b91939295909462000f50565b60646040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601e60248201527f4d6178696d756d20416c6c6f77656420537562747265657320617265203300006044820152fd5b5063ffffffff60a0511686111562000fad565b5060c05163ffffffff908116908a16111562000f98565b85876200122a856000610100526001610120526200121933151562002159565b6001600160a01b0316151562002159565b620012406001600160a01b038216151562002159565b60e052805190811562001f3e576000905b8282106200146157604051612ce89081620024ee8239608051818181612832015261298c015260a05181818161031d015281816104d80152818161060e01528181610e340152818161119a015281816114f20152818161166a015281816117a601528181611e6901528181612b440152612c34015260c051818181610c1101528181610ca3015281816110470152818161123901528181611fbc0152612bd6015260e051818181610114015281816102820152818161040701528181610da601528181610f3a0152818161112d015281816112f1015281816114550152818161158301528181611dd501526120280152610100518181816102ed0152818161034b015281816103b401528181610e0501528181610e6701528181610ecd015281816110f3015281816114c2015281816115200152818161163a015281816116aa015281816116fe01528181611776015281816117d40152818161183b01528181611929015281816119a701528181611a2101528181611a7e01528181611af401528181611b5d01528181611cd901528181611e330152612089015261012051818181610187015281816104a80152818161051201528181610566015281816105de0152818161063c015281816106a30152818161079201528181610826015281816108a00152818161090001528181610976015281816109df01528181610f94015281816112b70152611d4b0152f35b6001600160a01b03620014758383620021bf565b51161562001f37576101005163ffffffff16906001600160a01b036200149c8483620021bf565b5116620014ab33151562002159565b620014b881151562002159565b336000526008602052620014d560ff604060002054161562002346565b336000526008602052620014f260ff604060002054161562002346565b6200150763ffffffff600054168410620023ac565b6200151c63ffffffff60a05116151562002412565b600080516020620051d6833981519152811015620019ee57606490604060018060a01b03608051168151938480927f3f1a118700000000000000000000000000000000000000000000000000000000825285600483015260006024830152600060448301525afa80156200193a5760006064600080516020620051d6833981519152946040938391849162001f13575b5060018060a01b036080511690855197889586947f3f1a11870000000000000000000000000000000000000000000000000000000086520860048401526024830152600060448301525afa9182156200193a5760009262001eec575b5083600052600660205260406000206000805260205260406000208260005260205260ff6040600020541662001e8e578360005260066020526040600020600080526020526040600020826000526020526040600020600160ff19825416179055606060206040516200167b816200207f565b82815201526200169563ffffffff600054168510620023ac565b620016aa63ffffffff60a05116151562002412565b836000526003602052604060002060008052602052620016e463ffffffff60406000205460201c16801515908162001e7a575b5062002219565b811562001e1c57600084815260036020908152604080832083805282529091205463ffffffff808216979190921c90911693908415158062001e10575b6200172c90620022bb565b84600052600560205263ffffffff60406000205416871162001d8c57869262001755866200249e565b9262001761876200249e565b946000965b63ffffffff8816908982101562001ac3576001831662001a4c576200178b8962002321565b620017978389620021bf565b526000620017a6838a620021bf565b5280620017b38a62002321565b928c60005260036020526040600020600080526020526001604060002001906000526020526040600020555b600080516020620051d6833981519152811015620019ee57600080516020620051d68339815191528210156200196a57604060018060a01b03608051169160648251809481937f3f1a1187000000000000000000000000000000000000000000000000000000008352600483015260006024830152600060448301525afa9182156200193a576064604092600080516020620051d68339815191529460009160009162001946575b5060018060a01b036080511690855196879586947f3f1a11870000000000000000000000000000000000000000000000000000000086520860048401526024830152600060448301525afa9081156200193a57620018fb91637fffffff9160009162001903575b509260011c169762002203565b969062001766565b6200192a915060403d60401162001932575b6200192181836200209b565b810190620024d6565b508e620018ee565b503d62001915565b6040513d6000823e3d90fd5b9050620019639150843d861162001932576200192181836200209b565b3862001887565b60846040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152602160248201527f5f72696768742073686f756c6420626520696e7369646520746865206669656c60448201527f64000000000000000000000000000000000000000000000000000000000000006064820152fd5b60646040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152602060248201527f5f6c6566742073686f756c6420626520696e7369646520746865206669656c646044820152fd5b908a600052600360205260406000206000805260205260016040600020018160005260205260406000205462001a838289620021bf565b52600162001a92828a620021bf565b528a6000526003602052604060002060008052602052600160406000200190600052602052604060002054620017df565b929a949996959893975050508460005260026020526040600020600080526020526040600020906000526020526040600020600160ff1982541617905580865114908162001d80575b501562001cfc5762001b1e90620022a5565b82600052600360205260406000206000805260205263ffffffff6040600020911663ffffffff198254161790556040519362001b5a856200207f565b845260208401526000526001602052604060002060008052602052604060002090600052602052604060002090805180519060018060401b03821162001c8b5768010000000000000000821162001c8b57835482855580831062001ccf575b5060200183600052602060002060005b83811062001cba575050505060200151805191906001600160401b03831162001c8b5768010000000000000000831162001c8b57600182015483600184015580841062001c5b575b506020600191019101600052602060002060005b83811062001c4657505050505b6000198114620010ff576001019062001251565b60019060208451940193818401550162001c25565b600183016000526020600020908482015b818301811062001c7e57505062001c11565b6000815560010162001c6c565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b60019060208451940193818401550162001bc9565b846000526020600020908382015b818301811062001cef57505062001bb9565b6000815560010162001cdd565b60846040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152603460248201527f496e76616c69642070617468456c656d656e7473206f722070617468496e646960448201527f636573206c656e6774682044657465637465642e0000000000000000000000006064820152fd5b90508251148962001b0c565b60846040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152603060248201527f4d65726b6c6520747265652069732066756c6c2e204e6f206d6f7265206c656160448201527f7665732063616e206265206164646564000000000000000000000000000000006064820152fd5b50602085111562001721565b60646040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601a60248201527f496e76616c6964204c6561662f526f6f742044657465637465640000000000006044820152fd5b905063ffffffff60c05116101588620016dd565b60646040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601a60248201527f5573657220697320616c726561647920526567697374657265640000000000006044820152fd5b62001f0a91925060403d60401162001932576200192181836200209b565b50908662001608565b905062001f309150843d861162001932576200192181836200209b565b8b620015ac565b9062001c32565b60846040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152602160248201527f4e6f20466f6f6442616e6b7327206164647265737365732070726573656e746560448201527f64000000000000000000000000000000000000000000000000000000000000006064820152fd5b60846040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152602d60248201527f4c656e677468206f662054726565732c20537562747265657320616e64204c6560448201527f76656c73206d69736d61746368000000000000000000000000000000000000006064820152fd5b90508551143862000156565b8451926001600160a01b03841684036200207a57602081819582935201950194925062000115565b600080fd5b604081019081106001600160401b0382111762001c8b57604052565b601f909101601f19168101906001600160401b0382119082101762001c8b57604052565b519063ffffffff821682036200207a57565b6001600160401b03811162001c8b5760051b60200190565b81601f820112156200207a578051916200210383620020d1565b926200211360405194856200209b565b808452602092838086019260051b8201019283116200207a578301905b8282106200213f575050505090565b8380916200214d84620020bf565b81520191019062002130565b156200216157565b60646040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601560248201527f5a65726f204164647265737320446574656374656400000000000000000000006044820152fd5b8051821015620021d45760209160051b010190565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b63ffffffff809116908114620010ff5760010190565b156200222157565b60846040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152603060248201527f496e76616c6964204c6576656c2044657465637465642e204c6576656c73207360448201527f686f756c642062652028302c2033325d000000000000000000000000000000006064820152fd5b90600163ffffffff80931601918211620010ff57565b15620022c357565b60646040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601360248201527f496e646578206f7574206f6620626f756e6473000000000000000000000000006044820152fd5b63ffffffff166200233560208210620022bb565b600052600460205260406000205490565b156200234e57565b60646040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601960248201527f426c61636b6c69737465642055736572204465746563746564000000000000006044820152fd5b15620023b457565b60646040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601560248201527f496e76616c6964205472656520446574656374656400000000000000000000006044820152fd5b156200241a57565b60846040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152603360248201527f496e76616c696420537562747265652044657465637465642e2053756274726560448201527f65732073686f756c64206265205b302c203329000000000000000000000000006064820152fd5b90620024aa82620020d1565b620024b960405191826200209b565b8281528092620024cc601f1991620020d1565b0190602036910137565b91908260409103126200207a57602082519201519056fe608080604052600436101561001357600080fd5b60003560e01c90816253a7b314612004575080630bc7ce3d14611d81578063115445a314611d0f5780631e52457514611c9d57806323ffd3d8146113aa5780633186cad61461127b5780633767c934146110b75780639f29f35214610cf6578063aad559e9146101f95763c74a63441461008c57600080fd5b346101f457610110606061009f366120ca565b9081604051916100ae8361229c565b84835260209485809401526100c43315156121b8565b33600052600883526100de60ff60406000205416156121fc565b6100eb8383013515612592565b6040519586928392637ae4eb4f60e11b845260c08101906040810190600486016122f0565b03817f00000000000000000000000000000000000000000000000000000000000000006001600160a01b03165afa9081156101e85761015d61016e926060956000916101bb575b506125f0565b610166336127dd565b903514612646565b60405161017a8161229c565b82815201526101b76101ab7f0000000000000000000000000000000000000000000000000000000000000000612b11565b60405191829182612126565b0390f35b6101db9150853d87116101e1575b6101d381836122b7565b8101906122d8565b38610157565b503d6101c9565b6040513d6000823e3d90fd5b600080fd5b346101f45761027d61020a3661215e565b61021b9592959493943315156121b8565b6001600160a01b0393602090869061023689881615156121b8565b336000526008835261025060ff60406000205416156121fc565b336000526008835261026a60ff60406000205416156121fc565b8135151580610cea575b6100eb90612244565b0381877f0000000000000000000000000000000000000000000000000000000000000000165afa9283156101e857610402936102c191600091610ccb575b5061233e565b6102d585356102cf336127dd565b1461238a565b60208163ffffffff60005416936103138563ffffffff7f00000000000000000000000000000000000000000000000000000000000000001610612438565b61034463ffffffff7f000000000000000000000000000000000000000000000000000000000000000016151561247c565b63ffffffff7f00000000000000000000000000000000000000000000000000000000000000001660005260038352604060002060008052835261039e63ffffffff604060002054851c168015159081610c9a575b506124e4565b828801356103ad811515612549565b63ffffffff7f00000000000000000000000000000000000000000000000000000000000000001660005260028452604060002060008052845260406000209060005283526100de60ff604060002054166123e0565b0381877f0000000000000000000000000000000000000000000000000000000000000000165afa9081156101e857610448610453926104ce95600091610c7b57506125f0565b6101668588166127dd565b61045e3315156121b8565b61046b83861615156121b8565b33600052600860205261048660ff60406000205416156121fc565b3360005260086020526104a160ff60406000205416156121fc565b63ffffffff7f00000000000000000000000000000000000000000000000000000000000000001610612438565b6104ff63ffffffff7f000000000000000000000000000000000000000000000000000000000000000016151561247c565b61050a8184166127dd565b9063ffffffff7f000000000000000000000000000000000000000000000000000000000000000016600052600660205260406000206000805260205260406000208260005260205260ff60406000205416610c395763ffffffff7f00000000000000000000000000000000000000000000000000000000000000001660005260066020526040600020600080526020526040600020826000526020526040600020600160ff19825416179055606060206040516105c68161229c565b828152015261060463ffffffff6000541663ffffffff7f00000000000000000000000000000000000000000000000000000000000000001610612438565b61063563ffffffff7f000000000000000000000000000000000000000000000000000000000000000016151561247c565b63ffffffff7f000000000000000000000000000000000000000000000000000000000000000016600052600360205260406000206000805260205261069163ffffffff60406000205460201c168015159081610c0857506124e4565b61069c821515612549565b63ffffffff7f00000000000000000000000000000000000000000000000000000000000000008116600090815260036020908152604080832083805282529091205480831696911c909116939084151580610bfd575b6106fb90612a79565b84600052600560205263ffffffff604060002054168611610b9f57859261072186612781565b9261072b87612781565b976000965b8863ffffffff891610156108f4576107e6637fffffff91600189161560001461081f5761075c8a612abb565b61076c63ffffffff8c168a6127b3565b52600061077f63ffffffff8c168e6127b3565b528061078a8b612abb565b9163ffffffff7f0000000000000000000000000000000000000000000000000000000000000000166000526003602052604060002060008052602052600160406000200163ffffffff8d16600052602052604060002055612950565b9660011c169663ffffffff808216146108095763ffffffff166001019695610730565b634e487b7160e01b600052601160045260246000fd5b63ffffffff7f0000000000000000000000000000000000000000000000000000000000000000166000526003602052604060002060008052602052600160406000200163ffffffff8b1660005260205260406000205461088563ffffffff8c168a6127b3565b52600161089863ffffffff8c168e6127b3565b5263ffffffff7f0000000000000000000000000000000000000000000000000000000000000000166000526003602052604060002060008052602052600160406000200163ffffffff8b16600052602052604060002054612950565b90888a969263ffffffff7f00000000000000000000000000000000000000000000000000000000000000001660005260026020526040600020600080526020526040600020906000526020526040600020600160ff19825416179055808351149081610b94575b5015610b325760010163ffffffff81116108095763ffffffff7f000000000000000000000000000000000000000000000000000000000000000016600052600360205260406000206000805260205263ffffffff6040600020911663ffffffff19825416179055604051906109cf8261229c565b81526020810194855263ffffffff7f00000000000000000000000000000000000000000000000000000000000000001660005260016020526040600020600080526020526040600020838516600052602052604060002090519081516001600160401b0392838211610af457602090610a488385612727565b0182600052602060002060005b838110610b1e575050505060010194518051918211610af457602090610a7b8388612727565b019460005260206000209460005b828110610b0a5785610aab8686356000526007602052604060002092166127dd565b81549091600160401b821015610af45760018201808255821015610ade5760005260206000200155602060405160018152f35b634e487b7160e01b600052603260045260246000fd5b634e487b7160e01b600052604160045260246000fd5b600190602083519301928189015501610a89565b600190602084519401938184015501610a55565b60405162461bcd60e51b815260206004820152603460248201527f496e76616c69642070617468456c656d656e7473206f722070617468496e646960448201527331b2b9903632b733ba34102232ba32b1ba32b21760611b6064820152608490fd5b90508651148761095b565b60405162461bcd60e51b815260206004820152603060248201527f4d65726b6c6520747265652069732066756c6c2e204e6f206d6f7265206c656160448201526f1d995cc818d85b88189948185919195960821b6064820152608490fd5b5060208511156106f2565b905063ffffffff7f000000000000000000000000000000000000000000000000000000000000000016101586610398565b60405162461bcd60e51b815260206004820152601a602482015279155cd95c881a5cc8185b1c9958591e48149959da5cdd195c995960321b6044820152606490fd5b610c94915060203d6020116101e1576101d381836122b7565b89610157565b905063ffffffff7f00000000000000000000000000000000000000000000000000000000000000001610158b610398565b610ce4915060203d6020116101e1576101d381836122b7565b886102bb565b50818301351515610274565b346101f457610d043661215e565b610d1494929193943315156121b8565b6001600160a01b0392610da190610d2e84861615156121b8565b336000526008602052610d4960ff60406000205416156121fc565b336000526008602052610d6460ff60406000205416156121fc565b6020878035928315158061108c575b610d7c90612244565b6040519485928392637ae4eb4f60e11b845260c08101906040810190600486016122f0565b0381887f0000000000000000000000000000000000000000000000000000000000000000165afa9182156101e857610ded92610de491600091611098575061233e565b6102cf336127dd565b610f3563ffffffff6000541694610e2b8663ffffffff7f00000000000000000000000000000000000000000000000000000000000000001610612438565b602063ffffffff7f000000000000000000000000000000000000000000000000000000000000000016151597610e608961247c565b63ffffffff7f000000000000000000000000000000000000000000000000000000000000000016600052600382526040600020600080528252610eb963ffffffff604060002054841c168015159081610c9a57506124e4565b0135610ec6811515612549565b63ffffffff7f0000000000000000000000000000000000000000000000000000000000000000166000526002602052604060002060008052602052604060002090600052602052610f1e60ff604060002054166123e0565b6020838035928315158061108c57610d7c90612244565b0381887f0000000000000000000000000000000000000000000000000000000000000000165afa80156101e857610fc195602095610f816102cf93610f879660009161106f575061233e565b166127dd565b013592610fbc63ffffffff7f000000000000000000000000000000000000000000000000000000000000000016938410612438565b61247c565b806000526003602052604060002060008052602052610ff763ffffffff60406000205460201c16801515908161103e57506124e4565b611002821515612549565b600052600260205260406000206000805260205260406000209060005260205261103360ff604060002054166123e0565b602060405160018152f35b905063ffffffff7f000000000000000000000000000000000000000000000000000000000000000016101584610398565b6110869150893d8b116101e1576101d381836122b7565b8c6102bb565b50818301351515610d73565b6110b1915060203d6020116101e1576101d381836122b7565b896102bb565b346101f4576110c5366120ca565b906110d13315156121b8565b33600052602091600883526110ee60ff60406000205416156121fc565b6111297f00000000000000000000000000000000000000000000000000000000000000009184818035958615158061108c57610d7c90612244565b03817f00000000000000000000000000000000000000000000000000000000000000006001600160a01b03165afa80156101e85761122d94610de4611177928895600091611264575061233e565b013563ffffffff6111ef8184169161119481600054168410612438565b6111c1817f000000000000000000000000000000000000000000000000000000000000000016151561247c565b8260005260038752604060002060008052875280604060002054881c168015159182611237575b50506124e4565b6111fa821515612549565b600052600284526040600020600080528452604060002090600052835261122860ff604060002054166123e0565b612c01565b6040519015158152f35b7f0000000000000000000000000000000000000000000000000000000000000000161015905087806111e8565b6110b19150863d88116101e1576101d381836122b7565b346101f457611289366120ca565b906112953315156121b8565b33600052602091600883526112b260ff60406000205416156121fc565b6112ed7f00000000000000000000000000000000000000000000000000000000000000009184818035958615158061108c57610d7c90612244565b03817f00000000000000000000000000000000000000000000000000000000000000006001600160a01b03165afa80156101e8576113a094610de461133b928895600091611264575061233e565b013563ffffffff6113588184169161119481600054168410612438565b611363821515612549565b600052600284526040600020600080528452604060002090600052835261139160ff604060002054166123e0565b61139a33612ade565b50612c01565b5060405160018152f35b346101f45761145160206113bd3661215e565b9280946113d19793979692963315156121b8565b6113e56001600160a01b03891615156121b8565b33600052600883526113ff60ff60406000205416156121fc565b336000526008835261141960ff60406000205416156121fc565b8135151580611c91575b61142c90612244565b6040519384928392637ae4eb4f60e11b845260c08101906040810190600486016122f0565b03817f00000000000000000000000000000000000000000000000000000000000000006001600160a01b03165afa80156101e85761157f9461149e8492602094600091611c7a575061233e565b6114ac85356102cf336127dd565b8263ffffffff60005416956114e88763ffffffff7f00000000000000000000000000000000000000000000000000000000000000001610612438565b61151963ffffffff7f000000000000000000000000000000000000000000000000000000000000000016151561247c565b63ffffffff7f00000000000000000000000000000000000000000000000000000000000000001660005260038252604060002060008052825261157263ffffffff604060002054841c168015159081610c9a57506124e4565b01356103ad811515612549565b03817f00000000000000000000000000000000000000000000000000000000000000006001600160a01b03165afa9081156101e8576115cc6115de9261166095600091611c5b57506125f0565b6101666001600160a01b0386166127dd565b6115e93315156121b8565b6115fd6001600160a01b03841615156121b8565b33600052600860205261161860ff60406000205416156121fc565b33600052600860205261163360ff60406000205416156121fc565b63ffffffff7f00000000000000000000000000000000000000000000000000000000000000001610612438565b61169163ffffffff7f000000000000000000000000000000000000000000000000000000000000000016151561247c565b6116a36001600160a01b0382166127dd565b63ffffffff7f000000000000000000000000000000000000000000000000000000000000000016600052600660205260406000206000805260205260406000208160005260205260ff60406000205416610c395763ffffffff7f00000000000000000000000000000000000000000000000000000000000000001660005260066020526040600020600080526020526040600020816000526020526040600020600160ff198254161790556060602060405161175e8161229c565b828152015261179c63ffffffff6000541663ffffffff7f00000000000000000000000000000000000000000000000000000000000000001610612438565b6117cd63ffffffff7f000000000000000000000000000000000000000000000000000000000000000016151561247c565b63ffffffff7f000000000000000000000000000000000000000000000000000000000000000016600052600360205260406000206000805260205261182963ffffffff60406000205460201c16801515908161103e57506124e4565b611834811515612549565b63ffffffff7f0000000000000000000000000000000000000000000000000000000000000000811660009081526003602090815260408083208380528252909120549081901c821693911683151580611c50575b61189190612a79565b83600052600560205263ffffffff604060002054168111610b9f579081906118b885612781565b906118c286612781565b926000955b8763ffffffff88161015611a755761197d637fffffff9160018816156000146119a0576118f389612abb565b61190363ffffffff8b16886127b3565b52600061191663ffffffff8b16896127b3565b52806119218a612abb565b9163ffffffff7f0000000000000000000000000000000000000000000000000000000000000000166000526003602052604060002060008052602052600160406000200163ffffffff8c16600052602052604060002055612950565b9560011c169563ffffffff808216146108095763ffffffff1660010195946118c7565b63ffffffff7f0000000000000000000000000000000000000000000000000000000000000000166000526003602052604060002060008052602052600160406000200163ffffffff8a16600052602052604060002054611a0663ffffffff8b16886127b3565b526001611a1963ffffffff8b16896127b3565b5263ffffffff7f0000000000000000000000000000000000000000000000000000000000000000166000526003602052604060002060008052602052600160406000200163ffffffff8a16600052602052604060002054612950565b879063ffffffff7f00000000000000000000000000000000000000000000000000000000000000001660005260026020526040600020600080526020526040600020906000526020526040600020600160ff19825416179055808451149081611c45575b5015610b325760010163ffffffff81116108095763ffffffff7f000000000000000000000000000000000000000000000000000000000000000016600052600360205260406000206000805260205263ffffffff6040600020911663ffffffff1982541617905560405191611b4d8361229c565b82526020820192835263ffffffff7f000000000000000000000000000000000000000000000000000000000000000016600052600160205260406000206000805260205260406000209060018060a01b0316600052602052604060002090519081516001600160401b0392838211610af457602090611bcc8385612727565b0182600052602060002060005b838110611c3157865180516001870191888211610af457602090611bfd8385612727565b019160005260206000209160005b828110611c1d57602060405160018152f35b600190602083519301928186015501611c0b565b600190602084519401938184015501611bd9565b905084511485611ad9565b506020841115611888565b611c74915060203d6020116101e1576101d381836122b7565b87610157565b6110b19150853d87116101e1576101d381836122b7565b50818301351515611423565b346101f457611cab366120ca565b90611cb73315156121b8565b3360005260209160088352611cd460ff60406000205416156121fc565b6112ed7f00000000000000000000000000000000000000000000000000000000000000009184818035958615158061108c57610d7c90612244565b346101f457611d1d366120ca565b90611d293315156121b8565b3360005260209160088352611d4660ff60406000205416156121fc565b6111297f00000000000000000000000000000000000000000000000000000000000000009184818035958615158061108c57610d7c90612244565b346101f457611d8f366120ca565b90611d9b3315156121b8565b33600052611dd160209260088452611dbb60ff60406000205416156121fc565b83818035948515158061108c57610d7c90612244565b03817f00000000000000000000000000000000000000000000000000000000000000006001600160a01b03165afa9182156101e8578492611e1991600091611fe7575061233e565b611e26836102cf336127dd565b013563ffffffff611ebd817f00000000000000000000000000000000000000000000000000000000000000001691611e6381600054168410612438565b611e90817f000000000000000000000000000000000000000000000000000000000000000016151561247c565b8260005260038652604060002060008052865280604060002054871c168015159182611fba5750506124e4565b611ec8821515612549565b6000526002835260406000206000805283526040600020906000528252611ef660ff604060002054166123e0565b806000526007825260406000205415611f605760005260078152611f1d60406000206126a1565b906040519181839283018184528251809152816040850193019160005b828110611f4957505050500390f35b835185528695509381019392810192600101611f3a565b60405162461bcd60e51b815260048101839052602c60248201527f4e6f742061207265676973746572656420666f6f642062616e6b206f72206e6f60448201526b081d5cd95c9cc8199bdd5b9960a21b6064820152608490fd5b7f0000000000000000000000000000000000000000000000000000000000000000161015905086806111e8565b611ffe9150843d86116101e1576101d381836122b7565b866102bb565b346101f457606061202491612018366120ca565b82916100ae829461229c565b03817f00000000000000000000000000000000000000000000000000000000000000006001600160a01b03165afa9081156101e85761015d612070926060956000916120ad57506125f0565b60405161207c8161229c565b82815201526101b76101ab7f0000000000000000000000000000000000000000000000000000000000000000612b11565b6120c49150853d87116101e1576101d381836122b7565b86610157565b90600319820161014081126101f457610100136101f457600491610144116101f45761010490565b90815180825260208080930193019160005b828110612112575050505090565b835185529381019392810192600101612104565b9061215b91602081526020612146835160408385015260608401906120f2565b920151906040601f19828503019101526120f2565b90565b9060031982016102a081126101f4576101008091126101f457600492610144928184116101f45761010493356001600160a01b03811681036101f45792610163198301126101f457610164916102a4116101f45761026490565b156121bf57565b60405162461bcd60e51b815260206004820152601560248201527416995c9bc81059191c995cdcc811195d1958dd1959605a1b6044820152606490fd5b1561220357565b60405162461bcd60e51b8152602060048201526019602482015278109b1858dadb1a5cdd195908155cd95c8811195d1958dd1959603a1b6044820152606490fd5b1561224b57565b60405162461bcd60e51b8152602060048201526024808201527f7a6b4d65726b6c65547265653a20496e76616c6964205075626c6963205369676044820152636e616c7360e01b6064820152608490fd5b604081019081106001600160401b03821117610af457604052565b90601f801991011681019081106001600160401b03821117610af457604052565b908160209103126101f4575180151581036101f45790565b9493919094610140810195604094858092843760008383015b60028210612321575050610100935060c08301370137565b928084818860019596989997370193019101869294939194612309565b1561234557565b60405162461bcd60e51b815260206004820152601c60248201527f7a6b4d65726b6c65547265653a20496e76616c69642050726f6f6673000000006044820152606490fd5b1561239157565b60405162461bcd60e51b815260206004820152602160248201527f7a6b4d65726b6c65547265653a20556e617574686f72697a65642041636365736044820152607360f81b6064820152608490fd5b156123e757565b60405162461bcd60e51b815260206004820152602360248201527f7a6b4d65726b6c65547265653a20556e6b6e6f776e20526f6f742044657465636044820152621d195960ea1b6064820152608490fd5b1561243f57565b60405162461bcd60e51b8152602060048201526015602482015274125b9d985b1a5908151c99594811195d1958dd1959605a1b6044820152606490fd5b1561248357565b60405162461bcd60e51b815260206004820152603360248201527f496e76616c696420537562747265652044657465637465642e2053756274726560448201527265732073686f756c64206265205b302c20332960681b6064820152608490fd5b156124eb57565b60405162461bcd60e51b815260206004820152603060248201527f496e76616c6964204c6576656c2044657465637465642e204c6576656c73207360448201526f686f756c642062652028302c2033325d60801b6064820152608490fd5b1561255057565b60405162461bcd60e51b815260206004820152601a602482015279125b9d985b1a5908131958598bd49bdbdd0811195d1958dd195960321b6044820152606490fd5b1561259957565b60405162461bcd60e51b815260206004820152602960248201527f7a6b457468657265756d416464726573733a20496e76616c6964205075626c6960448201526863205369676e616c7360b81b6064820152608490fd5b156125f757565b60405162461bcd60e51b815260206004820152602160248201527f7a6b457468657265756d416464726573733a20496e76616c69642050726f6f666044820152607360f81b6064820152608490fd5b1561264d57565b60405162461bcd60e51b815260206004820152602660248201527f7a6b457468657265756d416464726573733a20556e617574686f72697a65642060448201526541636365737360d01b6064820152608490fd5b9060405191828154918282526020928383019160005283600020936000905b8282106126d8575050506126d6925003836122b7565b565b8554845260019586019588955093810193909101906126c0565b8054906000908181558261270557505050565b815260208120918201915b82811061271c57505050565b818155600101612710565b600160401b8211610af45780549180825582811061274457505050565b60009182526020822092830192015b82811061275f57505050565b818155600101612753565b6001600160401b038111610af45760051b60200190565b9061278b8261276a565b61279860405191826122b7565b82815280926127a9601f199161276a565b0190602036910137565b8051821015610ade5760209160051b010190565b91908260409103126101f4576020825192015190565b907f30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001918281101561290c5760408051633f1a118760e01b808252600482019390935260006024820181905260448201819052927f00000000000000000000000000000000000000000000000000000000000000006001600160a01b03168383606481845afa8015612902579184939186979893879388916128e0575b506064939486519889968795865208600484015260248301528760448301525afa9283156128d557926128ab57505090565b6128ca9250803d106128ce575b6128c281836122b7565b8101906127c7565b5090565b503d6128b8565b9051903d90823e3d90fd5b606494506128fb9150863d88116128ce576128c281836122b7565b9093612879565b84513d87823e3d90fd5b606460405162461bcd60e51b815260206004820152602060248201527f5f6c6566742073686f756c6420626520696e7369646520746865206669656c646044820152fd5b7f30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f000000192918382101561290c5783811015612a2a5760018060a01b037f000000000000000000000000000000000000000000000000000000000000000016936040908151633f1a118760e01b94858252600482015260009485602483015285604483015283826064818b5afa978815612a20578697988596979389916128e057506064939486519889968795865208600484015260248301528760448301525afa9283156128d557926128ab57505090565b84513d88823e3d90fd5b60405162461bcd60e51b815260206004820152602160248201527f5f72696768742073686f756c6420626520696e7369646520746865206669656c6044820152601960fa1b6064820152608490fd5b15612a8057565b60405162461bcd60e51b8152602060048201526013602482015272496e646578206f7574206f6620626f756e647360681b6044820152606490fd5b63ffffffff16612acd60208210612a79565b600052600460205260406000205490565b612ae93315156121b8565b6001600160a01b03166000908152600860205260409020805460ff1916600190811790915590565b612b9a90612b203315156121b8565b612bcd600163ffffffff809316926000612b3e828254168610612438565b612b6b827f000000000000000000000000000000000000000000000000000000000000000016151561247c565b84815260209460038652604096879384842084805288528085852054891c168015159182612bd45750506124e4565b81528285528181208180528552818120338252855220935193612bbc8561229c565b612bc5816126a1565b8552016126a1565b9082015290565b7f0000000000000000000000000000000000000000000000000000000000000000161015905038806111e8565b60016040612cad92612c143315156121b8565b63ffffffff80911690600091612c2e828454168210612438565b612c5b827f000000000000000000000000000000000000000000000000000000000000000016151561247c565b808352612c886020926003845285852085805284528086862054851c168015159182612bd45750506124e4565b82528381528282208280528152828220903383525220612ca7816126f2565b016126f2565b60019056fea2646970667358221220a89fdcb744651b5205fdd0457f6cc4c8f799aabc2ba951538ae3cd6ed0b162a264736f6c6343000811003330644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001",
 }
 
@@ -57,7 +80,7 @@ var ZkloginABI = ZkloginMetaData.ABI
 var ZkloginBin = ZkloginMetaData.Bin
 
 // DeployZklogin deploys a new Ethereum contract, binding an instance of Zklogin to it.
-func DeployZklogin(auth *bind.TransactOpts, backend bind.ContractBackend, _trees uint32, _subtrees []uint32, _levels []uint32, _hasher common.Address, _foodBankVerifier common.Address, _foodBanks []common.Address) (common.Address, *types.Transaction, *Zklogin, error) {
+func DeployZklogin(auth *bind.TransactOpts, backend bind.ContractBackend, _trees uint32, _subtrees []uint32, _levels []uint32, _hasher common.Address, _foodBankVerifier common.Address, _foodBanks []common.Address, _roles []ZkLoginRoleDefinition, _permissions []ZkLoginRolePermission, _foodBankQuorum uint32, _proposalLifetime uint64) (common.Address, *types.Transaction, *Zklogin, error) {
 	parsed, err := ZkloginMetaData.GetAbi()
 	if err != nil {
 		return common.Address{}, nil, nil, err
@@ -66,7 +89,7 @@ func DeployZklogin(auth *bind.TransactOpts, backend bind.ContractBackend, _trees
 		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
 	}
 
-	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(ZkloginBin), backend, _trees, _subtrees, _levels, _hasher, _foodBankVerifier, _foodBanks)
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(ZkloginBin), backend, _trees, _subtrees, _levels, _hasher, _foodBankVerifier, _foodBanks, _roles, _permissions, _foodBankQuorum, _proposalLifetime)
 	if err != nil {
 		return common.Address{}, nil, nil, err
 	}
@@ -246,6 +269,195 @@ func (_Zklogin *ZkloginCallerSession) FetchFoodBankMerkleProofs(_foodBankEthereu
 	return _Zklogin.Contract.FetchFoodBankMerkleProofs(&_Zklogin.CallOpts, _foodBankEthereumAddressProof, _foodBankEthereumAddressPublicSignals)
 }
 
+// FetchGovernance is a free data retrieval call binding the contract method 0x7c48a350.
+//
+// Solidity: function fetchGovernance() view returns(uint32, uint64)
+func (_Zklogin *ZkloginCaller) FetchGovernance(opts *bind.CallOpts) (uint32, uint64, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "fetchGovernance")
+
+	if err != nil {
+		return *new(uint32), *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+	out1 := *abi.ConvertType(out[1], new(uint64)).(*uint64)
+
+	return out0, out1, err
+
+}
+
+// FetchGovernance is a free data retrieval call binding the contract method 0x7c48a350.
+//
+// Solidity: function fetchGovernance() view returns(uint32, uint64)
+func (_Zklogin *ZkloginSession) FetchGovernance() (uint32, uint64, error) {
+	return _Zklogin.Contract.FetchGovernance(&_Zklogin.CallOpts)
+}
+
+// FetchGovernance is a free data retrieval call binding the contract method 0x7c48a350.
+//
+// Solidity: function fetchGovernance() view returns(uint32, uint64)
+func (_Zklogin *ZkloginCallerSession) FetchGovernance() (uint32, uint64, error) {
+	return _Zklogin.Contract.FetchGovernance(&_Zklogin.CallOpts)
+}
+
+// FetchKeyRotation is a free data retrieval call binding the contract method 0xf0a16bfc.
+//
+// Solidity: function fetchKeyRotation(uint256 _hashedAddress) view returns(uint256, uint256)
+func (_Zklogin *ZkloginCaller) FetchKeyRotation(opts *bind.CallOpts, _hashedAddress *big.Int) (*big.Int, *big.Int, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "fetchKeyRotation", _hashedAddress)
+
+	if err != nil {
+		return *new(*big.Int), *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	out1 := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return out0, out1, err
+
+}
+
+// FetchKeyRotation is a free data retrieval call binding the contract method 0xf0a16bfc.
+//
+// Solidity: function fetchKeyRotation(uint256 _hashedAddress) view returns(uint256, uint256)
+func (_Zklogin *ZkloginSession) FetchKeyRotation(_hashedAddress *big.Int) (*big.Int, *big.Int, error) {
+	return _Zklogin.Contract.FetchKeyRotation(&_Zklogin.CallOpts, _hashedAddress)
+}
+
+// FetchKeyRotation is a free data retrieval call binding the contract method 0xf0a16bfc.
+//
+// Solidity: function fetchKeyRotation(uint256 _hashedAddress) view returns(uint256, uint256)
+func (_Zklogin *ZkloginCallerSession) FetchKeyRotation(_hashedAddress *big.Int) (*big.Int, *big.Int, error) {
+	return _Zklogin.Contract.FetchKeyRotation(&_Zklogin.CallOpts, _hashedAddress)
+}
+
+// FetchMemberMerkleProofs is a free data retrieval call binding the contract method 0xa65d3543.
+//
+// Solidity: function fetchMemberMerkleProofs(uint32 _role, (uint256[2],uint256[2][2],uint256[2]) _memberEthereumAddressProof, uint256[2] _memberEthereumAddressPublicSignals) view returns((uint256[],uint256[]))
+func (_Zklogin *ZkloginCaller) FetchMemberMerkleProofs(opts *bind.CallOpts, _role uint32, _memberEthereumAddressProof ZkLoginGroth16Proof, _memberEthereumAddressPublicSignals [2]*big.Int) (MerkleTreeWithHistoryMerkleProof, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "fetchMemberMerkleProofs", _role, _memberEthereumAddressProof, _memberEthereumAddressPublicSignals)
+
+	if err != nil {
+		return *new(MerkleTreeWithHistoryMerkleProof), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(MerkleTreeWithHistoryMerkleProof)).(*MerkleTreeWithHistoryMerkleProof)
+
+	return out0, err
+
+}
+
+// FetchMemberMerkleProofs is a free data retrieval call binding the contract method 0xa65d3543.
+//
+// Solidity: function fetchMemberMerkleProofs(uint32 _role, (uint256[2],uint256[2][2],uint256[2]) _memberEthereumAddressProof, uint256[2] _memberEthereumAddressPublicSignals) view returns((uint256[],uint256[]))
+func (_Zklogin *ZkloginSession) FetchMemberMerkleProofs(_role uint32, _memberEthereumAddressProof ZkLoginGroth16Proof, _memberEthereumAddressPublicSignals [2]*big.Int) (MerkleTreeWithHistoryMerkleProof, error) {
+	return _Zklogin.Contract.FetchMemberMerkleProofs(&_Zklogin.CallOpts, _role, _memberEthereumAddressProof, _memberEthereumAddressPublicSignals)
+}
+
+// FetchMemberMerkleProofs is a free data retrieval call binding the contract method 0xa65d3543.
+//
+// Solidity: function fetchMemberMerkleProofs(uint32 _role, (uint256[2],uint256[2][2],uint256[2]) _memberEthereumAddressProof, uint256[2] _memberEthereumAddressPublicSignals) view returns((uint256[],uint256[]))
+func (_Zklogin *ZkloginCallerSession) FetchMemberMerkleProofs(_role uint32, _memberEthereumAddressProof ZkLoginGroth16Proof, _memberEthereumAddressPublicSignals [2]*big.Int) (MerkleTreeWithHistoryMerkleProof, error) {
+	return _Zklogin.Contract.FetchMemberMerkleProofs(&_Zklogin.CallOpts, _role, _memberEthereumAddressProof, _memberEthereumAddressPublicSignals)
+}
+
+// FetchProposal is a free data retrieval call binding the contract method 0x444a04a1.
+//
+// Solidity: function fetchProposal(bytes32 _proposal) view returns((uint8,address,uint256,uint64,uint32,bool))
+func (_Zklogin *ZkloginCaller) FetchProposal(opts *bind.CallOpts, _proposal [32]byte) (ZkLoginProposal, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "fetchProposal", _proposal)
+
+	if err != nil {
+		return *new(ZkLoginProposal), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(ZkLoginProposal)).(*ZkLoginProposal)
+
+	return out0, err
+
+}
+
+// FetchProposal is a free data retrieval call binding the contract method 0x444a04a1.
+//
+// Solidity: function fetchProposal(bytes32 _proposal) view returns((uint8,address,uint256,uint64,uint32,bool))
+func (_Zklogin *ZkloginSession) FetchProposal(_proposal [32]byte) (ZkLoginProposal, error) {
+	return _Zklogin.Contract.FetchProposal(&_Zklogin.CallOpts, _proposal)
+}
+
+// FetchProposal is a free data retrieval call binding the contract method 0x444a04a1.
+//
+// Solidity: function fetchProposal(bytes32 _proposal) view returns((uint8,address,uint256,uint64,uint32,bool))
+func (_Zklogin *ZkloginCallerSession) FetchProposal(_proposal [32]byte) (ZkLoginProposal, error) {
+	return _Zklogin.Contract.FetchProposal(&_Zklogin.CallOpts, _proposal)
+}
+
+// FetchProposals is a free data retrieval call binding the contract method 0x7e320d2f.
+//
+// Solidity: function fetchProposals() view returns(bytes32[])
+func (_Zklogin *ZkloginCaller) FetchProposals(opts *bind.CallOpts) ([][32]byte, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "fetchProposals")
+
+	if err != nil {
+		return *new([][32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([][32]byte)).(*[][32]byte)
+
+	return out0, err
+
+}
+
+// FetchProposals is a free data retrieval call binding the contract method 0x7e320d2f.
+//
+// Solidity: function fetchProposals() view returns(bytes32[])
+func (_Zklogin *ZkloginSession) FetchProposals() ([][32]byte, error) {
+	return _Zklogin.Contract.FetchProposals(&_Zklogin.CallOpts)
+}
+
+// FetchProposals is a free data retrieval call binding the contract method 0x7e320d2f.
+//
+// Solidity: function fetchProposals() view returns(bytes32[])
+func (_Zklogin *ZkloginCallerSession) FetchProposals() ([][32]byte, error) {
+	return _Zklogin.Contract.FetchProposals(&_Zklogin.CallOpts)
+}
+
+// FetchRole is a free data retrieval call binding the contract method 0x03726236.
+//
+// Solidity: function fetchRole(uint32 _role) view returns(uint32, uint32)
+func (_Zklogin *ZkloginCaller) FetchRole(opts *bind.CallOpts, _role uint32) (uint32, uint32, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "fetchRole", _role)
+
+	if err != nil {
+		return *new(uint32), *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+	out1 := *abi.ConvertType(out[1], new(uint32)).(*uint32)
+
+	return out0, out1, err
+
+}
+
+// FetchRole is a free data retrieval call binding the contract method 0x03726236.
+//
+// Solidity: function fetchRole(uint32 _role) view returns(uint32, uint32)
+func (_Zklogin *ZkloginSession) FetchRole(_role uint32) (uint32, uint32, error) {
+	return _Zklogin.Contract.FetchRole(&_Zklogin.CallOpts, _role)
+}
+
+// FetchRole is a free data retrieval call binding the contract method 0x03726236.
+//
+// Solidity: function fetchRole(uint32 _role) view returns(uint32, uint32)
+func (_Zklogin *ZkloginCallerSession) FetchRole(_role uint32) (uint32, uint32, error) {
+	return _Zklogin.Contract.FetchRole(&_Zklogin.CallOpts, _role)
+}
+
 // FetchUserMerkleProofs is a free data retrieval call binding the contract method 0xc74a6344.
 //
 // Solidity: function fetchUserMerkleProofs((uint256[2],uint256[2][2],uint256[2]) _userEthereumAddressProof, uint256[2] _userEthereumAddressPublicSignals) view returns((uint256[],uint256[]))
@@ -308,6 +520,130 @@ func (_Zklogin *ZkloginCallerSession) FetchUsersAsFoodBank(_foodBankMerkleProof
 	return _Zklogin.Contract.FetchUsersAsFoodBank(&_Zklogin.CallOpts, _foodBankMerkleProof, _foodBankPublicSignals)
 }
 
+// HasApproved is a free data retrieval call binding the contract method 0xdb69d806.
+//
+// Solidity: function hasApproved(bytes32 _proposal, uint256 _hashedFoodBank) view returns(bool)
+func (_Zklogin *ZkloginCaller) HasApproved(opts *bind.CallOpts, _proposal [32]byte, _hashedFoodBank *big.Int) (bool, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "hasApproved", _proposal, _hashedFoodBank)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasApproved is a free data retrieval call binding the contract method 0xdb69d806.
+//
+// Solidity: function hasApproved(bytes32 _proposal, uint256 _hashedFoodBank) view returns(bool)
+func (_Zklogin *ZkloginSession) HasApproved(_proposal [32]byte, _hashedFoodBank *big.Int) (bool, error) {
+	return _Zklogin.Contract.HasApproved(&_Zklogin.CallOpts, _proposal, _hashedFoodBank)
+}
+
+// HasApproved is a free data retrieval call binding the contract method 0xdb69d806.
+//
+// Solidity: function hasApproved(bytes32 _proposal, uint256 _hashedFoodBank) view returns(bool)
+func (_Zklogin *ZkloginCallerSession) HasApproved(_proposal [32]byte, _hashedFoodBank *big.Int) (bool, error) {
+	return _Zklogin.Contract.HasApproved(&_Zklogin.CallOpts, _proposal, _hashedFoodBank)
+}
+
+// IsPermitted is a free data retrieval call binding the contract method 0x0c9dfc8b.
+//
+// Solidity: function isPermitted(uint32 _registrarRole, uint32 _role) view returns(bool)
+func (_Zklogin *ZkloginCaller) IsPermitted(opts *bind.CallOpts, _registrarRole uint32, _role uint32) (bool, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "isPermitted", _registrarRole, _role)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsPermitted is a free data retrieval call binding the contract method 0x0c9dfc8b.
+//
+// Solidity: function isPermitted(uint32 _registrarRole, uint32 _role) view returns(bool)
+func (_Zklogin *ZkloginSession) IsPermitted(_registrarRole uint32, _role uint32) (bool, error) {
+	return _Zklogin.Contract.IsPermitted(&_Zklogin.CallOpts, _registrarRole, _role)
+}
+
+// IsPermitted is a free data retrieval call binding the contract method 0x0c9dfc8b.
+//
+// Solidity: function isPermitted(uint32 _registrarRole, uint32 _role) view returns(bool)
+func (_Zklogin *ZkloginCallerSession) IsPermitted(_registrarRole uint32, _role uint32) (bool, error) {
+	return _Zklogin.Contract.IsPermitted(&_Zklogin.CallOpts, _registrarRole, _role)
+}
+
+// IsRevoked is a free data retrieval call binding the contract method 0x5ccc561e.
+//
+// Solidity: function isRevoked(uint256 _hashedAddress) view returns(bool)
+func (_Zklogin *ZkloginCaller) IsRevoked(opts *bind.CallOpts, _hashedAddress *big.Int) (bool, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "isRevoked", _hashedAddress)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsRevoked is a free data retrieval call binding the contract method 0x5ccc561e.
+//
+// Solidity: function isRevoked(uint256 _hashedAddress) view returns(bool)
+func (_Zklogin *ZkloginSession) IsRevoked(_hashedAddress *big.Int) (bool, error) {
+	return _Zklogin.Contract.IsRevoked(&_Zklogin.CallOpts, _hashedAddress)
+}
+
+// IsRevoked is a free data retrieval call binding the contract method 0x5ccc561e.
+//
+// Solidity: function isRevoked(uint256 _hashedAddress) view returns(bool)
+func (_Zklogin *ZkloginCallerSession) IsRevoked(_hashedAddress *big.Int) (bool, error) {
+	return _Zklogin.Contract.IsRevoked(&_Zklogin.CallOpts, _hashedAddress)
+}
+
+// KeyRotationHash is a free data retrieval call binding the contract method 0x46c74443.
+//
+// Solidity: function keyRotationHash(uint32 _role, address _newMember) view returns(bytes32)
+func (_Zklogin *ZkloginCaller) KeyRotationHash(opts *bind.CallOpts, _role uint32, _newMember common.Address) ([32]byte, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "keyRotationHash", _role, _newMember)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// KeyRotationHash is a free data retrieval call binding the contract method 0x46c74443.
+//
+// Solidity: function keyRotationHash(uint32 _role, address _newMember) view returns(bytes32)
+func (_Zklogin *ZkloginSession) KeyRotationHash(_role uint32, _newMember common.Address) ([32]byte, error) {
+	return _Zklogin.Contract.KeyRotationHash(&_Zklogin.CallOpts, _role, _newMember)
+}
+
+// KeyRotationHash is a free data retrieval call binding the contract method 0x46c74443.
+//
+// Solidity: function keyRotationHash(uint32 _role, address _newMember) view returns(bytes32)
+func (_Zklogin *ZkloginCallerSession) KeyRotationHash(_role uint32, _newMember common.Address) ([32]byte, error) {
+	return _Zklogin.Contract.KeyRotationHash(&_Zklogin.CallOpts, _role, _newMember)
+}
+
 // VerifyProof is a free data retrieval call binding the contract method 0x9f29f352.
 //
 // Solidity: function verifyProof((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address _user, (uint256[2],uint256[2][2],uint256[2]) _userMerkleProof, uint256[2] _userMerklePublicSignals) view returns(bool)
@@ -339,6 +675,58 @@ func (_Zklogin *ZkloginCallerSession) VerifyProof(_foodBankMerkleProof ZkLoginGr
 	return _Zklogin.Contract.VerifyProof(&_Zklogin.CallOpts, _foodBankMerkleProof, _foodBankPublicSignals, _user, _userMerkleProof, _userMerklePublicSignals)
 }
 
+// VerifyProofs is a free data retrieval call binding the contract method 0xec99b435.
+//
+// Solidity: function verifyProofs((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address[] _users, (uint256[2],uint256[2][2],uint256[2])[] _userMerkleProofs, uint256[2][] _userMerklePublicSignals) view returns(uint256[])
+func (_Zklogin *ZkloginCaller) VerifyProofs(opts *bind.CallOpts, _foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _users []common.Address, _userMerkleProofs []ZkLoginGroth16Proof, _userMerklePublicSignals [][2]*big.Int) ([]*big.Int, error) {
+	var out []interface{}
+	err := _Zklogin.contract.Call(opts, &out, "verifyProofs", _foodBankMerkleProof, _foodBankPublicSignals, _users, _userMerkleProofs, _userMerklePublicSignals)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// VerifyProofs is a free data retrieval call binding the contract method 0xec99b435.
+//
+// Solidity: function verifyProofs((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address[] _users, (uint256[2],uint256[2][2],uint256[2])[] _userMerkleProofs, uint256[2][] _userMerklePublicSignals) view returns(uint256[])
+func (_Zklogin *ZkloginSession) VerifyProofs(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _users []common.Address, _userMerkleProofs []ZkLoginGroth16Proof, _userMerklePublicSignals [][2]*big.Int) ([]*big.Int, error) {
+	return _Zklogin.Contract.VerifyProofs(&_Zklogin.CallOpts, _foodBankMerkleProof, _foodBankPublicSignals, _users, _userMerkleProofs, _userMerklePublicSignals)
+}
+
+// VerifyProofs is a free data retrieval call binding the contract method 0xec99b435.
+//
+// Solidity: function verifyProofs((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address[] _users, (uint256[2],uint256[2][2],uint256[2])[] _userMerkleProofs, uint256[2][] _userMerklePublicSignals) view returns(uint256[])
+func (_Zklogin *ZkloginCallerSession) VerifyProofs(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _users []common.Address, _userMerkleProofs []ZkLoginGroth16Proof, _userMerklePublicSignals [][2]*big.Int) ([]*big.Int, error) {
+	return _Zklogin.Contract.VerifyProofs(&_Zklogin.CallOpts, _foodBankMerkleProof, _foodBankPublicSignals, _users, _userMerkleProofs, _userMerklePublicSignals)
+}
+
+// ApproveProposal is a paid mutator transaction binding the contract method 0xebdd6844.
+//
+// Solidity: function approveProposal((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, bytes32 _proposal) returns(bool)
+func (_Zklogin *ZkloginTransactor) ApproveProposal(opts *bind.TransactOpts, _foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _proposal [32]byte) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "approveProposal", _foodBankMerkleProof, _foodBankPublicSignals, _proposal)
+}
+
+// ApproveProposal is a paid mutator transaction binding the contract method 0xebdd6844.
+//
+// Solidity: function approveProposal((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, bytes32 _proposal) returns(bool)
+func (_Zklogin *ZkloginSession) ApproveProposal(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _proposal [32]byte) (*types.Transaction, error) {
+	return _Zklogin.Contract.ApproveProposal(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _proposal)
+}
+
+// ApproveProposal is a paid mutator transaction binding the contract method 0xebdd6844.
+//
+// Solidity: function approveProposal((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, bytes32 _proposal) returns(bool)
+func (_Zklogin *ZkloginTransactorSession) ApproveProposal(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _proposal [32]byte) (*types.Transaction, error) {
+	return _Zklogin.Contract.ApproveProposal(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _proposal)
+}
+
 // DeleteFoodBankMerkleProofs is a paid mutator transaction binding the contract method 0x3767c934.
 //
 // Solidity: function deleteFoodBankMerkleProofs((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals) returns(bool)
@@ -360,6 +748,27 @@ func (_Zklogin *ZkloginTransactorSession) DeleteFoodBankMerkleProofs(_foodBankMe
 	return _Zklogin.Contract.DeleteFoodBankMerkleProofs(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals)
 }
 
+// DeleteMemberMerkleProofs is a paid mutator transaction binding the contract method 0x96545142.
+//
+// Solidity: function deleteMemberMerkleProofs(uint32 _role, (uint256[2],uint256[2][2],uint256[2]) _memberMerkleProof, uint256[2] _memberPublicSignals) returns(bool)
+func (_Zklogin *ZkloginTransactor) DeleteMemberMerkleProofs(opts *bind.TransactOpts, _role uint32, _memberMerkleProof ZkLoginGroth16Proof, _memberPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "deleteMemberMerkleProofs", _role, _memberMerkleProof, _memberPublicSignals)
+}
+
+// DeleteMemberMerkleProofs is a paid mutator transaction binding the contract method 0x96545142.
+//
+// Solidity: function deleteMemberMerkleProofs(uint32 _role, (uint256[2],uint256[2][2],uint256[2]) _memberMerkleProof, uint256[2] _memberPublicSignals) returns(bool)
+func (_Zklogin *ZkloginSession) DeleteMemberMerkleProofs(_role uint32, _memberMerkleProof ZkLoginGroth16Proof, _memberPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.DeleteMemberMerkleProofs(&_Zklogin.TransactOpts, _role, _memberMerkleProof, _memberPublicSignals)
+}
+
+// DeleteMemberMerkleProofs is a paid mutator transaction binding the contract method 0x96545142.
+//
+// Solidity: function deleteMemberMerkleProofs(uint32 _role, (uint256[2],uint256[2][2],uint256[2]) _memberMerkleProof, uint256[2] _memberPublicSignals) returns(bool)
+func (_Zklogin *ZkloginTransactorSession) DeleteMemberMerkleProofs(_role uint32, _memberMerkleProof ZkLoginGroth16Proof, _memberPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.DeleteMemberMerkleProofs(&_Zklogin.TransactOpts, _role, _memberMerkleProof, _memberPublicSignals)
+}
+
 // DeleteUserMerkleProofs is a paid mutator transaction binding the contract method 0x115445a3.
 //
 // Solidity: function deleteUserMerkleProofs((uint256[2],uint256[2][2],uint256[2]) _userMerkleProof, uint256[2] _userPublicSignals) returns(bool)
@@ -381,6 +790,69 @@ func (_Zklogin *ZkloginTransactorSession) DeleteUserMerkleProofs(_userMerkleProo
 	return _Zklogin.Contract.DeleteUserMerkleProofs(&_Zklogin.TransactOpts, _userMerkleProof, _userPublicSignals)
 }
 
+// ExecuteProposal is a paid mutator transaction binding the contract method 0xf9bb3429.
+//
+// Solidity: function executeProposal((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, bytes32 _proposal) returns(bool)
+func (_Zklogin *ZkloginTransactor) ExecuteProposal(opts *bind.TransactOpts, _foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _proposal [32]byte) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "executeProposal", _foodBankMerkleProof, _foodBankPublicSignals, _proposal)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0xf9bb3429.
+//
+// Solidity: function executeProposal((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, bytes32 _proposal) returns(bool)
+func (_Zklogin *ZkloginSession) ExecuteProposal(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _proposal [32]byte) (*types.Transaction, error) {
+	return _Zklogin.Contract.ExecuteProposal(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _proposal)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0xf9bb3429.
+//
+// Solidity: function executeProposal((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, bytes32 _proposal) returns(bool)
+func (_Zklogin *ZkloginTransactorSession) ExecuteProposal(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _proposal [32]byte) (*types.Transaction, error) {
+	return _Zklogin.Contract.ExecuteProposal(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _proposal)
+}
+
+// ProposeFoodBank is a paid mutator transaction binding the contract method 0x56a4c663.
+//
+// Solidity: function proposeFoodBank((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address _newFoodBank, (uint256[2],uint256[2][2],uint256[2]) _newFoodBankEthereumAddressProof, uint256[2] _newFoodBankPublicSignals) returns(bytes32)
+func (_Zklogin *ZkloginTransactor) ProposeFoodBank(opts *bind.TransactOpts, _foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _newFoodBank common.Address, _newFoodBankEthereumAddressProof ZkLoginGroth16Proof, _newFoodBankPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "proposeFoodBank", _foodBankMerkleProof, _foodBankPublicSignals, _newFoodBank, _newFoodBankEthereumAddressProof, _newFoodBankPublicSignals)
+}
+
+// ProposeFoodBank is a paid mutator transaction binding the contract method 0x56a4c663.
+//
+// Solidity: function proposeFoodBank((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address _newFoodBank, (uint256[2],uint256[2][2],uint256[2]) _newFoodBankEthereumAddressProof, uint256[2] _newFoodBankPublicSignals) returns(bytes32)
+func (_Zklogin *ZkloginSession) ProposeFoodBank(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _newFoodBank common.Address, _newFoodBankEthereumAddressProof ZkLoginGroth16Proof, _newFoodBankPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.ProposeFoodBank(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _newFoodBank, _newFoodBankEthereumAddressProof, _newFoodBankPublicSignals)
+}
+
+// ProposeFoodBank is a paid mutator transaction binding the contract method 0x56a4c663.
+//
+// Solidity: function proposeFoodBank((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address _newFoodBank, (uint256[2],uint256[2][2],uint256[2]) _newFoodBankEthereumAddressProof, uint256[2] _newFoodBankPublicSignals) returns(bytes32)
+func (_Zklogin *ZkloginTransactorSession) ProposeFoodBank(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _newFoodBank common.Address, _newFoodBankEthereumAddressProof ZkLoginGroth16Proof, _newFoodBankPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.ProposeFoodBank(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _newFoodBank, _newFoodBankEthereumAddressProof, _newFoodBankPublicSignals)
+}
+
+// ProposeFoodBankTermination is a paid mutator transaction binding the contract method 0xa28e499b.
+//
+// Solidity: function proposeFoodBankTermination((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, uint256 _hashedFoodBank) returns(bytes32)
+func (_Zklogin *ZkloginTransactor) ProposeFoodBankTermination(opts *bind.TransactOpts, _foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _hashedFoodBank *big.Int) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "proposeFoodBankTermination", _foodBankMerkleProof, _foodBankPublicSignals, _hashedFoodBank)
+}
+
+// ProposeFoodBankTermination is a paid mutator transaction binding the contract method 0xa28e499b.
+//
+// Solidity: function proposeFoodBankTermination((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, uint256 _hashedFoodBank) returns(bytes32)
+func (_Zklogin *ZkloginSession) ProposeFoodBankTermination(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _hashedFoodBank *big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.ProposeFoodBankTermination(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _hashedFoodBank)
+}
+
+// ProposeFoodBankTermination is a paid mutator transaction binding the contract method 0xa28e499b.
+//
+// Solidity: function proposeFoodBankTermination((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, uint256 _hashedFoodBank) returns(bytes32)
+func (_Zklogin *ZkloginTransactorSession) ProposeFoodBankTermination(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _hashedFoodBank *big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.ProposeFoodBankTermination(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _hashedFoodBank)
+}
+
 // RegisterFoodBank is a paid mutator transaction binding the contract method 0x23ffd3d8.
 //
 // Solidity: function registerFoodBank((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address _newFoodBank, (uint256[2],uint256[2][2],uint256[2]) _newFoodBankEthereumAddressProof, uint256[2] _newFoodBankPublicSignals) returns(bool)
@@ -402,6 +874,27 @@ func (_Zklogin *ZkloginTransactorSession) RegisterFoodBank(_foodBankMerkleProof
 	return _Zklogin.Contract.RegisterFoodBank(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _newFoodBank, _newFoodBankEthereumAddressProof, _newFoodBankPublicSignals)
 }
 
+// RegisterMember is a paid mutator transaction binding the contract method 0xeb9d8aad.
+//
+// Solidity: function registerMember(uint32 _registrarRole, (uint256[2],uint256[2][2],uint256[2]) _registrarMerkleProof, uint256[2] _registrarPublicSignals, uint32 _role, address _newMember, (uint256[2],uint256[2][2],uint256[2]) _newMemberEthereumAddressProof, uint256[2] _newMemberPublicSignals) returns(bool)
+func (_Zklogin *ZkloginTransactor) RegisterMember(opts *bind.TransactOpts, _registrarRole uint32, _registrarMerkleProof ZkLoginGroth16Proof, _registrarPublicSignals [2]*big.Int, _role uint32, _newMember common.Address, _newMemberEthereumAddressProof ZkLoginGroth16Proof, _newMemberPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "registerMember", _registrarRole, _registrarMerkleProof, _registrarPublicSignals, _role, _newMember, _newMemberEthereumAddressProof, _newMemberPublicSignals)
+}
+
+// RegisterMember is a paid mutator transaction binding the contract method 0xeb9d8aad.
+//
+// Solidity: function registerMember(uint32 _registrarRole, (uint256[2],uint256[2][2],uint256[2]) _registrarMerkleProof, uint256[2] _registrarPublicSignals, uint32 _role, address _newMember, (uint256[2],uint256[2][2],uint256[2]) _newMemberEthereumAddressProof, uint256[2] _newMemberPublicSignals) returns(bool)
+func (_Zklogin *ZkloginSession) RegisterMember(_registrarRole uint32, _registrarMerkleProof ZkLoginGroth16Proof, _registrarPublicSignals [2]*big.Int, _role uint32, _newMember common.Address, _newMemberEthereumAddressProof ZkLoginGroth16Proof, _newMemberPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.RegisterMember(&_Zklogin.TransactOpts, _registrarRole, _registrarMerkleProof, _registrarPublicSignals, _role, _newMember, _newMemberEthereumAddressProof, _newMemberPublicSignals)
+}
+
+// RegisterMember is a paid mutator transaction binding the contract method 0xeb9d8aad.
+//
+// Solidity: function registerMember(uint32 _registrarRole, (uint256[2],uint256[2][2],uint256[2]) _registrarMerkleProof, uint256[2] _registrarPublicSignals, uint32 _role, address _newMember, (uint256[2],uint256[2][2],uint256[2]) _newMemberEthereumAddressProof, uint256[2] _newMemberPublicSignals) returns(bool)
+func (_Zklogin *ZkloginTransactorSession) RegisterMember(_registrarRole uint32, _registrarMerkleProof ZkLoginGroth16Proof, _registrarPublicSignals [2]*big.Int, _role uint32, _newMember common.Address, _newMemberEthereumAddressProof ZkLoginGroth16Proof, _newMemberPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.RegisterMember(&_Zklogin.TransactOpts, _registrarRole, _registrarMerkleProof, _registrarPublicSignals, _role, _newMember, _newMemberEthereumAddressProof, _newMemberPublicSignals)
+}
+
 // RegisterUser is a paid mutator transaction binding the contract method 0xaad559e9.
 //
 // Solidity: function registerUser((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address _newUser, (uint256[2],uint256[2][2],uint256[2]) _newUserEthereumAddressProof, uint256[2] _newUserPublicSignals) returns(bool)
@@ -423,6 +916,69 @@ func (_Zklogin *ZkloginTransactorSession) RegisterUser(_foodBankMerkleProof ZkLo
 	return _Zklogin.Contract.RegisterUser(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _newUser, _newUserEthereumAddressProof, _newUserPublicSignals)
 }
 
+// RegisterUsers is a paid mutator transaction binding the contract method 0xe1b37d23.
+//
+// Solidity: function registerUsers((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address[] _newUsers, (uint256[2],uint256[2][2],uint256[2])[] _newUserEthereumAddressProofs, uint256[2][] _newUserPublicSignals) returns(bool)
+func (_Zklogin *ZkloginTransactor) RegisterUsers(opts *bind.TransactOpts, _foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _newUsers []common.Address, _newUserEthereumAddressProofs []ZkLoginGroth16Proof, _newUserPublicSignals [][2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "registerUsers", _foodBankMerkleProof, _foodBankPublicSignals, _newUsers, _newUserEthereumAddressProofs, _newUserPublicSignals)
+}
+
+// RegisterUsers is a paid mutator transaction binding the contract method 0xe1b37d23.
+//
+// Solidity: function registerUsers((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address[] _newUsers, (uint256[2],uint256[2][2],uint256[2])[] _newUserEthereumAddressProofs, uint256[2][] _newUserPublicSignals) returns(bool)
+func (_Zklogin *ZkloginSession) RegisterUsers(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _newUsers []common.Address, _newUserEthereumAddressProofs []ZkLoginGroth16Proof, _newUserPublicSignals [][2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.RegisterUsers(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _newUsers, _newUserEthereumAddressProofs, _newUserPublicSignals)
+}
+
+// RegisterUsers is a paid mutator transaction binding the contract method 0xe1b37d23.
+//
+// Solidity: function registerUsers((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals, address[] _newUsers, (uint256[2],uint256[2][2],uint256[2])[] _newUserEthereumAddressProofs, uint256[2][] _newUserPublicSignals) returns(bool)
+func (_Zklogin *ZkloginTransactorSession) RegisterUsers(_foodBankMerkleProof ZkLoginGroth16Proof, _foodBankPublicSignals [2]*big.Int, _newUsers []common.Address, _newUserEthereumAddressProofs []ZkLoginGroth16Proof, _newUserPublicSignals [][2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.RegisterUsers(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals, _newUsers, _newUserEthereumAddressProofs, _newUserPublicSignals)
+}
+
+// RevokeMember is a paid mutator transaction binding the contract method 0x9b92de65.
+//
+// Solidity: function revokeMember(uint32 _revokerRole, (uint256[2],uint256[2][2],uint256[2]) _revokerMerkleProof, uint256[2] _revokerPublicSignals, uint32 _role, uint256 _hashedAddress) returns(bool)
+func (_Zklogin *ZkloginTransactor) RevokeMember(opts *bind.TransactOpts, _revokerRole uint32, _revokerMerkleProof ZkLoginGroth16Proof, _revokerPublicSignals [2]*big.Int, _role uint32, _hashedAddress *big.Int) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "revokeMember", _revokerRole, _revokerMerkleProof, _revokerPublicSignals, _role, _hashedAddress)
+}
+
+// RevokeMember is a paid mutator transaction binding the contract method 0x9b92de65.
+//
+// Solidity: function revokeMember(uint32 _revokerRole, (uint256[2],uint256[2][2],uint256[2]) _revokerMerkleProof, uint256[2] _revokerPublicSignals, uint32 _role, uint256 _hashedAddress) returns(bool)
+func (_Zklogin *ZkloginSession) RevokeMember(_revokerRole uint32, _revokerMerkleProof ZkLoginGroth16Proof, _revokerPublicSignals [2]*big.Int, _role uint32, _hashedAddress *big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.RevokeMember(&_Zklogin.TransactOpts, _revokerRole, _revokerMerkleProof, _revokerPublicSignals, _role, _hashedAddress)
+}
+
+// RevokeMember is a paid mutator transaction binding the contract method 0x9b92de65.
+//
+// Solidity: function revokeMember(uint32 _revokerRole, (uint256[2],uint256[2][2],uint256[2]) _revokerMerkleProof, uint256[2] _revokerPublicSignals, uint32 _role, uint256 _hashedAddress) returns(bool)
+func (_Zklogin *ZkloginTransactorSession) RevokeMember(_revokerRole uint32, _revokerMerkleProof ZkLoginGroth16Proof, _revokerPublicSignals [2]*big.Int, _role uint32, _hashedAddress *big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.RevokeMember(&_Zklogin.TransactOpts, _revokerRole, _revokerMerkleProof, _revokerPublicSignals, _role, _hashedAddress)
+}
+
+// RotateKey is a paid mutator transaction binding the contract method 0xf702592b.
+//
+// Solidity: function rotateKey(uint32 _role, address _oldMember, (uint256[2],uint256[2][2],uint256[2]) _oldMemberMerkleProof, uint256[2] _oldMemberPublicSignals, (uint256[2],uint256[2][2],uint256[2]) _newMemberEthereumAddressProof, uint256[2] _newMemberPublicSignals, bytes _signature) returns(bool)
+func (_Zklogin *ZkloginTransactor) RotateKey(opts *bind.TransactOpts, _role uint32, _oldMember common.Address, _oldMemberMerkleProof ZkLoginGroth16Proof, _oldMemberPublicSignals [2]*big.Int, _newMemberEthereumAddressProof ZkLoginGroth16Proof, _newMemberPublicSignals [2]*big.Int, _signature []byte) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "rotateKey", _role, _oldMember, _oldMemberMerkleProof, _oldMemberPublicSignals, _newMemberEthereumAddressProof, _newMemberPublicSignals, _signature)
+}
+
+// RotateKey is a paid mutator transaction binding the contract method 0xf702592b.
+//
+// Solidity: function rotateKey(uint32 _role, address _oldMember, (uint256[2],uint256[2][2],uint256[2]) _oldMemberMerkleProof, uint256[2] _oldMemberPublicSignals, (uint256[2],uint256[2][2],uint256[2]) _newMemberEthereumAddressProof, uint256[2] _newMemberPublicSignals, bytes _signature) returns(bool)
+func (_Zklogin *ZkloginSession) RotateKey(_role uint32, _oldMember common.Address, _oldMemberMerkleProof ZkLoginGroth16Proof, _oldMemberPublicSignals [2]*big.Int, _newMemberEthereumAddressProof ZkLoginGroth16Proof, _newMemberPublicSignals [2]*big.Int, _signature []byte) (*types.Transaction, error) {
+	return _Zklogin.Contract.RotateKey(&_Zklogin.TransactOpts, _role, _oldMember, _oldMemberMerkleProof, _oldMemberPublicSignals, _newMemberEthereumAddressProof, _newMemberPublicSignals, _signature)
+}
+
+// RotateKey is a paid mutator transaction binding the contract method 0xf702592b.
+//
+// Solidity: function rotateKey(uint32 _role, address _oldMember, (uint256[2],uint256[2][2],uint256[2]) _oldMemberMerkleProof, uint256[2] _oldMemberPublicSignals, (uint256[2],uint256[2][2],uint256[2]) _newMemberEthereumAddressProof, uint256[2] _newMemberPublicSignals, bytes _signature) returns(bool)
+func (_Zklogin *ZkloginTransactorSession) RotateKey(_role uint32, _oldMember common.Address, _oldMemberMerkleProof ZkLoginGroth16Proof, _oldMemberPublicSignals [2]*big.Int, _newMemberEthereumAddressProof ZkLoginGroth16Proof, _newMemberPublicSignals [2]*big.Int, _signature []byte) (*types.Transaction, error) {
+	return _Zklogin.Contract.RotateKey(&_Zklogin.TransactOpts, _role, _oldMember, _oldMemberMerkleProof, _oldMemberPublicSignals, _newMemberEthereumAddressProof, _newMemberPublicSignals, _signature)
+}
+
 // TerminateFoodBank is a paid mutator transaction binding the contract method 0x1e524575.
 //
 // Solidity: function terminateFoodBank((uint256[2],uint256[2][2],uint256[2]) _foodBankMerkleProof, uint256[2] _foodBankPublicSignals) returns(bool)
@@ -444,6 +1000,27 @@ func (_Zklogin *ZkloginTransactorSession) TerminateFoodBank(_foodBankMerkleProof
 	return _Zklogin.Contract.TerminateFoodBank(&_Zklogin.TransactOpts, _foodBankMerkleProof, _foodBankPublicSignals)
 }
 
+// TerminateMember is a paid mutator transaction binding the contract method 0xc3ed7ddd.
+//
+// Solidity: function terminateMember(uint32 _role, (uint256[2],uint256[2][2],uint256[2]) _memberMerkleProof, uint256[2] _memberPublicSignals) returns(bool)
+func (_Zklogin *ZkloginTransactor) TerminateMember(opts *bind.TransactOpts, _role uint32, _memberMerkleProof ZkLoginGroth16Proof, _memberPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.contract.Transact(opts, "terminateMember", _role, _memberMerkleProof, _memberPublicSignals)
+}
+
+// TerminateMember is a paid mutator transaction binding the contract method 0xc3ed7ddd.
+//
+// Solidity: function terminateMember(uint32 _role, (uint256[2],uint256[2][2],uint256[2]) _memberMerkleProof, uint256[2] _memberPublicSignals) returns(bool)
+func (_Zklogin *ZkloginSession) TerminateMember(_role uint32, _memberMerkleProof ZkLoginGroth16Proof, _memberPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.TerminateMember(&_Zklogin.TransactOpts, _role, _memberMerkleProof, _memberPublicSignals)
+}
+
+// TerminateMember is a paid mutator transaction binding the contract method 0xc3ed7ddd.
+//
+// Solidity: function terminateMember(uint32 _role, (uint256[2],uint256[2][2],uint256[2]) _memberMerkleProof, uint256[2] _memberPublicSignals) returns(bool)
+func (_Zklogin *ZkloginTransactorSession) TerminateMember(_role uint32, _memberMerkleProof ZkLoginGroth16Proof, _memberPublicSignals [2]*big.Int) (*types.Transaction, error) {
+	return _Zklogin.Contract.TerminateMember(&_Zklogin.TransactOpts, _role, _memberMerkleProof, _memberPublicSignals)
+}
+
 // TerminateUser is a paid mutator transaction binding the contract method 0x3186cad6.
 //
 // Solidity: function terminateUser((uint256[2],uint256[2][2],uint256[2]) _userMerkleProof, uint256[2] _userMerklePublicSignals) returns(bool)
@@ -2,7 +2,7 @@ package accounts
 
 import (
 	"deployer/internal/directory"
-	"deployer/internal/mimc"
+	"deployer/internal/hasher"
 	"deployer/internal/types"
 	"deployer/internal/validator"
 	"encoding/hex"
@@ -16,8 +16,8 @@ import (
 )
 
 type Accounts struct {
-	mu sync.RWMutex
-	*mimc.MiMCSponge
+	mu     sync.RWMutex
+	hasher hasher.Hasher
 	*types.Accounts
 }
 
@@ -31,11 +31,11 @@ func NewAccounts(name string) *Accounts {
 	}
 }
 
-// SetMiMC sets the MiMC pointer
-func (a *Accounts) SetMiMC(mimc *mimc.MiMCSponge) {
+// SetHasher sets the hasher of the addresses (MiMC or Poseidon)
+func (a *Accounts) SetHasher(h hasher.Hasher) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
-	a.setMiMC(mimc)
+	a.setHasher(h)
 }
 
 // CreateAccounts generates `number` Ethereum accounts and writes them to a JSON file.
@@ -84,7 +84,7 @@ func (a *Accounts) ExtractAddresses() []*common.Address {
 }
 
 // ExtractHashedAddresses returns a slice of hashed addresses for all accounts.
-// It uses the MiMC hash function to hash each account's address (as a big.Int),
+// It uses the hasher to hash each account's address (as a big.Int),
 // producing a unique *big.Int for each address. The function ensures thread safety
 // and returns a copy of each hash to avoid pointer reuse issues.
 func (a *Accounts) ExtractHashedAddresses() []*big.Int {
@@ -109,9 +109,9 @@ func (a *Accounts) GetPrivateKey(index int) (string, error) {
 	return account.PrivateKeyHex, nil
 }
 
-// GetHashedAddress returns the MiMC hash of the checksum address for the account at the specified index.
+// GetHashedAddress returns the hash of the checksum address for the account at the specified index.
 // It acquires a read lock to ensure thread-safe access to the accounts data.
-// If the account does not exist, has no checksum address, or the MiMC hasher is not set, an error is returned.
+// If the account does not exist, has no checksum address, or the hasher is not set, an error is returned.
 // On success, it returns the hashed address as a *big.Int.
 func (a *Accounts) GetHashedAddress(index int) (*big.Int, error) {
 	a.mu.RLock()
@@ -124,9 +124,9 @@ func (a *Accounts) GetHashedAddress(index int) (*big.Int, error) {
 	if account.ChecksumAddress == (common.Address{}) {
 		return nil, fmt.Errorf("account at index %d has no checksum address", index)
 	}
-	// Hash the address using MiMC
-	if a.getMiMC() == nil {
-		return nil, fmt.Errorf("mimc not set")
+	// Hash the address
+	if a.getHasher() == nil {
+		return nil, fmt.Errorf("hasher not set")
 	}
 	return a.hashAddress(&account.ChecksumAddress)
 }
@@ -178,7 +178,7 @@ func (a *Accounts) extractHashedAddresses() []*big.Int {
 		if account == nil {
 			continue // Skip nil addresses
 		}
-		// Hash the address using MiMC
+		// Hash the address
 		accountBigInt, _ := a.hashAddress(account)
 
 		// Append a **copy** of resultBigInt (because it's reused)
@@ -230,26 +230,26 @@ func (a *Accounts) getAccounts() *types.Accounts {
 	return a.Accounts
 }
 
-// hashAddress hashes the given Ethereum address using the MiMC hash function.
+// hashAddress hashes the given Ethereum address with the configured hasher (MiMC or Poseidon).
 func (a *Accounts) hashAddress(address *common.Address) (*big.Int, error) {
-	if a.getMiMC() == nil {
-		return big.NewInt(0), fmt.Errorf("mimc not set")
+	if a.getHasher() == nil {
+		return big.NewInt(0), fmt.Errorf("hasher not set")
 	}
-	// Use MiMC to hash the address
-	return a.getMiMC().HashAddress(address), nil
+	// Use the hasher to hash the address
+	return a.getHasher().HashAddress(address), nil
 }
 
-// SetMimc sets the MiMCSponge pointer
+// setAccount sets the account at index
 func (a *Accounts) setAccount(index int, account *types.Account) {
 	a.Accounts.Accounts[index] = account
 }
 
-// SetMimc sets the MiMCSponge pointer
-func (a *Accounts) setMiMC(mimc *mimc.MiMCSponge) {
-	a.MiMCSponge = mimc
+// setHasher sets the hasher
+func (a *Accounts) setHasher(h hasher.Hasher) {
+	a.hasher = h
 }
 
-// GetMiMC returns the mimc (read-only)
-func (a *Accounts) getMiMC() *mimc.MiMCSponge {
-	return a.MiMCSponge
+// getHasher returns the hasher (read-only)
+func (a *Accounts) getHasher() hasher.Hasher {
+	return a.hasher
 }
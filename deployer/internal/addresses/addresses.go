@@ -26,20 +26,31 @@ func NewAddresses() *Addresses {
 
 // AddContract appends a new contract with its name and address to the list.
 func (a *Addresses) AddContract(name string, address common.Address) error {
-	a.mu.Lock()
-	defer a.mu.Unlock()
-
-	contract := &types.Contract{
+	return a.addContract(&types.Contract{
 		Name:    name,
 		Address: address,
-	}
+	})
+}
+
+// AddVerifier appends a Groth16 verifier with the SHA-256 of the verification key it embeds.
+func (a *Addresses) AddVerifier(name string, address common.Address, vkeyHash string) error {
+	return a.addContract(&types.Contract{
+		Name:     name,
+		Address:  address,
+		VkeyHash: vkeyHash,
+	})
+}
+
+func (a *Addresses) addContract(contract *types.Contract) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 
 	// Validate the structs
 	if err := validator.ValidateStruct(contract); err != nil {
 		return fmt.Errorf("failed to validate contract: %w", err)
 	}
 
-	a.Contracts.Contracts[name] = contract
+	a.Contracts.Contracts[contract.Name] = contract
 
 	// Validate the structs
 	if err := validator.ValidateStruct(a.Contracts); err != nil {
@@ -90,3 +101,21 @@ func (a *Addresses) GetContractAddressByName(name string) (common.Address, error
 
 	return contract.Address, nil
 }
+
+// GetVerificationKeyHash retrieves the verification key hash of a verifier by its name,
+// empty when the deployment did not record it.
+func (a *Addresses) GetVerificationKeyHash(name string) (string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.Contracts == nil || a.Contracts.Contracts == nil {
+		return "", errors.New("no contracts loaded")
+	}
+
+	contract, ok := a.Contracts.Contracts[name]
+	if !ok || contract == nil {
+		return "", errors.New("contract not found: " + name)
+	}
+
+	return contract.VkeyHash, nil
+}
@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"deployer/internal/logger"
+	"deployer/internal/membership"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// anchorPrefix tags anchor transactions, so they can be told apart from other operator transactions.
+var anchorPrefix = []byte("pinacle-audit:")
+
+// Anchorer publishes data on-chain (implemented by membership.Service).
+type Anchorer interface {
+	Anchor(ctx context.Context, data []byte) (*membership.Receipt, error)
+}
+
+// AnchorData returns the calldata of the transaction anchoring hash.
+func AnchorData(hash common.Hash) []byte {
+	return append(append([]byte{}, anchorPrefix...), hash.Bytes()...)
+}
+
+// Anchor publishes the current head on-chain and appends an anchor entry referencing the
+// transaction and the hash it anchors. Nothing is sent when the log is empty or already anchored.
+// The log is only locked to read the head and to append the entry, so the indexer keeps recording
+// while the transaction is mined, and entries may sit between the anchored hash and the anchor.
+func (l *Log) Anchor(ctx context.Context, anchorer Anchorer) error {
+	l.anchorMu.Lock()
+	defer l.anchorMu.Unlock()
+
+	l.mu.Lock()
+	seq, head, anchored := l.seq, l.head, l.anchored
+	l.mu.Unlock()
+	if seq == 0 || anchored {
+		return nil
+	}
+
+	receipt, err := anchorer.Anchor(ctx, AnchorData(head))
+	if err != nil {
+		return fmt.Errorf("failed to anchor audit log: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	covered := l.head == head
+	if err := l.append(&Entry{
+		Time:        time.Now().UTC().Truncate(time.Second),
+		Action:      ActionAnchor,
+		Anchored:    &head,
+		BlockNumber: receipt.BlockNumber,
+		TxHash:      receipt.Hash,
+	}); err != nil {
+		return err
+	}
+	// Entries recorded while the transaction was mined are left for the next anchor
+	l.anchored = covered
+	return nil
+}
+
+// RunAnchors anchors the log every interval until the context is cancelled.
+func (l *Log) RunAnchors(ctx context.Context, anchorer Anchorer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Anchor(ctx, anchorer); err != nil {
+				logger.Logger.Error().Err(err).Msg("Audit anchor failed")
+				continue
+			}
+			seq, head := l.Head()
+			logger.Logger.Debug().Uint64("entries", seq).Str("head", head.Hex()).Msg("Audit log anchored")
+		}
+	}
+}
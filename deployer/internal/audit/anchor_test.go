@@ -0,0 +1,201 @@
+package audit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"deployer/internal/indexer"
+	"deployer/internal/membership"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var testChainID = big.NewInt(1337)
+
+// chain mines anchor transactions signed by key and serves them as a TransactionBackend.
+type chain struct {
+	t        *testing.T
+	key      *ecdsa.PrivateKey
+	block    uint64
+	txs      map[common.Hash]*ethtypes.Transaction
+	receipts map[common.Hash]*ethtypes.Receipt
+}
+
+func newChain(t *testing.T) *chain {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &chain{
+		t:        t,
+		key:      key,
+		block:    100,
+		txs:      make(map[common.Hash]*ethtypes.Transaction),
+		receipts: make(map[common.Hash]*ethtypes.Receipt),
+	}
+}
+
+func (c *chain) operator() common.Address {
+	return crypto.PubkeyToAddress(c.key.PublicKey)
+}
+
+// send mines a transaction carrying data, signed by key.
+func (c *chain) send(key *ecdsa.PrivateKey, data []byte) *ethtypes.Transaction {
+	c.t.Helper()
+	to := crypto.PubkeyToAddress(key.PublicKey)
+	tx, err := ethtypes.SignNewTx(key, ethtypes.LatestSignerForChainID(testChainID), &ethtypes.DynamicFeeTx{
+		ChainID: testChainID,
+		Nonce:   uint64(len(c.txs)),
+		To:      &to,
+		Gas:     30_000,
+		Data:    data,
+	})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.block++
+	c.txs[tx.Hash()] = tx
+	c.receipts[tx.Hash()] = &ethtypes.Receipt{
+		Status:      ethtypes.ReceiptStatusSuccessful,
+		TxHash:      tx.Hash(),
+		BlockNumber: new(big.Int).SetUint64(c.block),
+	}
+	return tx
+}
+
+// Anchor implements Anchorer.
+func (c *chain) Anchor(_ context.Context, data []byte) (*membership.Receipt, error) {
+	tx := c.send(c.key, data)
+	return &membership.Receipt{Hash: tx.Hash(), BlockNumber: c.block}, nil
+}
+
+func (c *chain) TransactionByHash(_ context.Context, hash common.Hash) (*ethtypes.Transaction, bool, error) {
+	tx, ok := c.txs[hash]
+	if !ok {
+		return nil, false, errors.New("not found")
+	}
+	return tx, false, nil
+}
+
+func (c *chain) TransactionReceipt(_ context.Context, hash common.Hash) (*ethtypes.Receipt, error) {
+	receipt, ok := c.receipts[hash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return receipt, nil
+}
+
+// anchoredLog returns the report of a log of two blocks anchored by c.
+func anchoredLog(t *testing.T, c *chain) *Report {
+	t.Helper()
+	path := writeLog(t, 2)
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	_, head := l.Head()
+	if err := l.Anchor(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	// Nothing new to anchor
+	if err := l.Anchor(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := VerifyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Anchors) != 1 || report.Anchors[0].AnchoredHash() != head || report.Entries != 3 || report.LastBlock != 2 {
+		t.Fatalf("unexpected anchors %+v", report)
+	}
+	return report
+}
+
+func TestVerifyAnchors(t *testing.T) {
+	c := newChain(t)
+	report := anchoredLog(t, c)
+	if err := VerifyAnchors(context.Background(), c, report.Anchors, c.operator()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyAnchorsTampered(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(c *chain, anchor *Entry)
+	}{
+		{"failed", func(c *chain, anchor *Entry) {
+			c.receipts[anchor.TxHash].Status = ethtypes.ReceiptStatusFailed
+		}},
+		{"other block", func(c *chain, anchor *Entry) {
+			anchor.BlockNumber++
+		}},
+		{"other hash", func(c *chain, anchor *Entry) {
+			anchored := common.Hash{1}
+			anchor.Anchored = &anchored
+		}},
+		{"other sender", func(c *chain, anchor *Entry) {
+			key, err := crypto.GenerateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tx := c.send(key, AnchorData(anchor.AnchoredHash()))
+			anchor.TxHash, anchor.BlockNumber = tx.Hash(), c.block
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newChain(t)
+			report := anchoredLog(t, c)
+			tt.tamper(c, report.Anchors[0])
+			if err := VerifyAnchors(context.Background(), c, report.Anchors, c.operator()); !errors.Is(err, ErrBrokenChain) {
+				t.Fatalf("got %v, want %v", err, ErrBrokenChain)
+			}
+		})
+	}
+}
+
+func TestAnchorWhileRecording(t *testing.T) {
+	c := newChain(t)
+	l, err := Open(writeLog(t, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// A block recorded while the anchor transaction is mined
+	recording := &recordingAnchorer{chain: c, log: l}
+	if err := l.Anchor(context.Background(), recording); err != nil {
+		t.Fatal(err)
+	}
+	seq, _ := l.Head()
+	if err := l.Anchor(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if next, _ := l.Head(); next != seq+1 {
+		t.Fatal("entries recorded during the anchor left unanchored")
+	}
+}
+
+// recordingAnchorer records a block in log before anchoring.
+type recordingAnchorer struct {
+	*chain
+	log *Log
+}
+
+func (r *recordingAnchorer) Anchor(ctx context.Context, data []byte) (*membership.Receipt, error) {
+	if err := r.log.Record([]*indexer.Event{registration(2, types.RoleUser, 2)}); err != nil {
+		return nil, err
+	}
+	return r.chain.Anchor(ctx, data)
+}
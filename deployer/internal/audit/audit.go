@@ -0,0 +1,229 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"deployer/internal/indexer"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrBrokenChain  = errors.New("audit log hash chain is broken")
+	ErrInvalidEntry = errors.New("invalid audit log entry")
+)
+
+type Action string
+
+const (
+	ActionRegisterFoodBank     Action = "register_food_bank"
+	ActionRegisterUser         Action = "register_user"
+	ActionTerminateFoodBank    Action = "terminate_food_bank"
+	ActionTerminateUser        Action = "terminate_user"
+	ActionDeleteFoodBankProofs Action = "delete_food_bank_proofs"
+	ActionDeleteUserProofs     Action = "delete_user_proofs"
+	ActionRegisterMember       Action = "register_member"
+	ActionTerminateMember      Action = "terminate_member"
+	ActionDeleteMemberProofs   Action = "delete_member_proofs"
+	ActionAnchor               Action = "anchor"
+)
+
+// Entry is a line of the audit log. Accounts only appear as MiMC hashed addresses.
+//
+// Hash is the keccak256 of the JSON encoding of the entry with a zero Hash, and Prev is the
+// Hash of the previous entry (zero for the first one), so editing, removing or reordering a
+// line breaks every following entry.
+type Entry struct {
+	Seq         uint64       `json:"seq"`
+	Time        time.Time    `json:"time"`
+	Action      Action       `json:"action"`
+	Actor       string       `json:"actor,omitempty"`    // Hashed address of the sender, empty for the deployment
+	Subject     string       `json:"subject,omitempty"`  // Hashed address of the account acted upon
+	Role        string       `json:"role,omitempty"`     // Role of the subject, empty for an anchor
+	Root        string       `json:"root,omitempty"`     // New root after a registration
+	Anchored    *common.Hash `json:"anchored,omitempty"` // Hash published by an anchor, Prev when absent
+	BlockNumber uint64       `json:"blockNumber"`
+	TxHash      common.Hash  `json:"txHash"`
+	Prev        common.Hash  `json:"prev"`
+	Hash        common.Hash  `json:"hash"`
+}
+
+// Digest computes the hash of the entry.
+func (e *Entry) Digest() (common.Hash, error) {
+	unhashed := *e
+	unhashed.Hash = common.Hash{}
+
+	data, err := json.Marshal(&unhashed)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode entry %d: %w", e.Seq, err)
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+// AnchoredHash returns the hash published by an anchor entry. Anchors written before the
+// anchored hash was recorded published the previous entry.
+func (e *Entry) AnchoredHash() common.Hash {
+	if e.Anchored != nil {
+		return *e.Anchored
+	}
+	return e.Prev
+}
+
+// Log is an append-only, hash-chained JSONL audit log.
+type Log struct {
+	mu        sync.Mutex
+	anchorMu  sync.Mutex // one anchor at a time, held while the transaction is mined
+	file      *os.File
+	seq       uint64
+	head      common.Hash
+	lastBlock uint64 // last block with indexed entries, anchors excluded
+	anchored  bool   // the last entry is an anchor
+}
+
+// Open opens or creates the audit log at path. An existing log is verified first, so new
+// entries are never chained to a tampered history.
+func Open(path string) (*Log, error) {
+	l := &Log{}
+
+	existing, err := os.Open(path)
+	switch {
+	case err == nil:
+		report, err := Verify(existing)
+		existing.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify %s: %w", path, err)
+		}
+		if report.Entries > 0 {
+			l.seq = report.Entries
+			l.head = report.Head
+			l.lastBlock = report.LastBlock
+			l.anchored = report.Last.Action == ActionAnchor && report.Last.AnchoredHash() == report.Last.Prev
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	l.file = file
+
+	return l, nil
+}
+
+// Head returns the number of entries and the hash of the last one.
+func (l *Log) Head() (uint64, common.Hash) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.seq, l.head
+}
+
+// Record appends the events of an indexed block. It implements indexer.Recorder.
+// Blocks that are already in the log (the indexer replays from its start block after a
+// restart) are skipped.
+func (l *Log) Record(events []*indexer.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.seq > 0 && events[0].BlockNumber <= l.lastBlock {
+		return nil
+	}
+
+	entries := make([]*Entry, 0, len(events))
+	for _, event := range events {
+		entry, err := entryFromEvent(event)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := l.append(entries...); err != nil {
+		return err
+	}
+	l.lastBlock = events[len(events)-1].BlockNumber
+	return nil
+}
+
+// Close closes the underlying file.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// append chains the entries to the head and writes them with a single write followed by a sync.
+func (l *Log) append(entries ...*Entry) error {
+	var buf bytes.Buffer
+	seq, head := l.seq, l.head
+
+	for _, entry := range entries {
+		entry.Seq = seq
+		entry.Prev = head
+
+		hash, err := entry.Digest()
+		if err != nil {
+			return err
+		}
+		entry.Hash = hash
+
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode entry %d: %w", entry.Seq, err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+
+		seq, head = seq+1, hash
+	}
+
+	if _, err := l.file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+
+	l.seq, l.head = seq, head
+	l.anchored = entries[len(entries)-1].Action == ActionAnchor
+	return nil
+}
+
+// entryFromEvent maps an indexer event to an audit entry.
+func entryFromEvent(event *indexer.Event) (*Entry, error) {
+	entry := &Entry{
+		Time:        time.Unix(int64(event.BlockTime), 0).UTC(),
+		Actor:       hashString(event.Actor),
+		BlockNumber: event.BlockNumber,
+		TxHash:      event.TxHash,
+	}
+
+	switch event.Type {
+	case indexer.EventRootChanged:
+		entry.Action = pick(event.Role, ActionRegisterFoodBank, ActionRegisterUser, ActionRegisterMember)
+		entry.Subject = hashString(event.Leaf)
+		entry.Root = hashString(event.Root)
+	case indexer.EventRevoked:
+		entry.Action = pick(event.Role, ActionTerminateFoodBank, ActionTerminateUser, ActionTerminateMember)
+		entry.Subject = hashString(event.HashedAddress)
+	case indexer.EventProofsDeleted:
+		entry.Action = pick(event.Role, ActionDeleteFoodBankProofs, ActionDeleteUserProofs, ActionDeleteMemberProofs)
+		entry.Subject = hashString(event.HashedAddress)
+	default:
+		return nil, fmt.Errorf("%w: unknown event type %d", ErrInvalidEntry, event.Type)
+	}
+	entry.Role = event.Role.String()
+	return entry, nil
+}
@@ -0,0 +1,198 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"deployer/internal/indexer"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// registration returns the event of the registration of leaf with role in block.
+func registration(block uint64, role types.Role, leaf int64) *indexer.Event {
+	return &indexer.Event{
+		Type:        indexer.EventRootChanged,
+		Role:        role,
+		Root:        big.NewInt(1000 + leaf),
+		Leaf:        big.NewInt(leaf),
+		Actor:       big.NewInt(1),
+		BlockNumber: block,
+		BlockTime:   1_700_000_000 + block,
+		TxHash:      common.BigToHash(big.NewInt(int64(block))),
+	}
+}
+
+func TestEntryRole(t *testing.T) {
+	tests := []struct {
+		event  indexer.EventType
+		role   types.Role
+		action Action
+	}{
+		{indexer.EventRootChanged, types.RoleFoodBank, ActionRegisterFoodBank},
+		{indexer.EventRootChanged, types.RoleUser, ActionRegisterUser},
+		{indexer.EventRootChanged, types.RoleVolunteer, ActionRegisterMember},
+		{indexer.EventRevoked, types.RoleFoodBank, ActionTerminateFoodBank},
+		{indexer.EventRevoked, types.RoleUser, ActionTerminateUser},
+		{indexer.EventRevoked, types.RoleAuditor, ActionTerminateMember},
+		{indexer.EventProofsDeleted, types.RoleFoodBank, ActionDeleteFoodBankProofs},
+		{indexer.EventProofsDeleted, types.RoleUser, ActionDeleteUserProofs},
+		{indexer.EventProofsDeleted, types.RoleIntermediary, ActionDeleteMemberProofs},
+	}
+	for _, tt := range tests {
+		event := registration(1, tt.role, 7)
+		event.Type, event.HashedAddress = tt.event, big.NewInt(7)
+		entry, err := entryFromEvent(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.Action != tt.action || entry.Role != tt.role.String() {
+			t.Fatalf("event %d of %s: got %s of %q, want %s", tt.event, tt.role, entry.Action, entry.Role, tt.action)
+		}
+	}
+}
+
+// writeLog records a registration per block in a new log and returns its path.
+func writeLog(t *testing.T, blocks int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	for block := 1; block <= blocks; block++ {
+		if err := l.Record([]*indexer.Event{registration(uint64(block), types.RoleUser, int64(block))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+// readLines returns the lines of the log, newlines included.
+func readLines(t *testing.T, path string) [][]byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.SplitAfter(data, []byte("\n"))
+}
+
+func requireBrokenLog(t *testing.T, lines [][]byte, expected error) {
+	t.Helper()
+	if _, err := Verify(bytes.NewReader(bytes.Join(lines, nil))); !errors.Is(err, expected) {
+		t.Fatalf("got %v, want %v", err, expected)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	path := writeLog(t, 3)
+	report, err := VerifyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Entries != 3 || report.LastBlock != 3 || report.Last.Hash != report.Head || report.Last.Subject != "3" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	// Reopening the log chains the new entries to the existing ones
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq, head := l.Head(); seq != 3 || head != report.Head {
+		t.Fatalf("reopened at entry %d %s, expected 3 %s", seq, head.Hex(), report.Head.Hex())
+	}
+	if err := l.Record([]*indexer.Event{registration(4, types.RoleFoodBank, 4)}); err != nil {
+		t.Fatal(err)
+	}
+	l.Close()
+	if report, err = VerifyFile(path); err != nil || report.Entries != 4 {
+		t.Fatalf("log reopened: %d entries (%v)", report.Entries, err)
+	}
+}
+
+func TestVerifyEdited(t *testing.T) {
+	lines := readLines(t, writeLog(t, 3))
+	lines[1] = bytes.Replace(lines[1], []byte(`"subject":"2"`), []byte(`"subject":"9"`), 1)
+	requireBrokenLog(t, lines, ErrBrokenChain)
+
+	// Rehashing the edited entry breaks the link of the next one
+	lines = readLines(t, writeLog(t, 3))
+	entry := &Entry{}
+	if err := json.Unmarshal(lines[1], entry); err != nil {
+		t.Fatal(err)
+	}
+	entry.Subject = "9"
+	hash, err := entry.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry.Hash = hash
+	line, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines[1] = append(line, '\n')
+	requireBrokenLog(t, lines, ErrBrokenChain)
+}
+
+func TestVerifyReordered(t *testing.T) {
+	lines := readLines(t, writeLog(t, 3))
+	lines[0], lines[1] = lines[1], lines[0]
+	requireBrokenLog(t, lines, ErrBrokenChain)
+}
+
+func TestVerifyRemoved(t *testing.T) {
+	lines := readLines(t, writeLog(t, 3))
+	requireBrokenLog(t, append(lines[:1:1], lines[2:]...), ErrBrokenChain)
+	requireBrokenLog(t, lines[1:], ErrBrokenChain)
+
+	// A torn write of the last entry
+	lines[2] = lines[2][:len(lines[2])/2]
+	requireBrokenLog(t, lines, ErrInvalidEntry)
+}
+
+func TestOpenTampered(t *testing.T) {
+	path := writeLog(t, 3)
+	lines := readLines(t, path)
+	lines[0], lines[1] = lines[1], lines[0]
+	if err := os.WriteFile(path, bytes.Join(lines, nil), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); !errors.Is(err, ErrBrokenChain) {
+		t.Fatalf("tampered log opened: got %v, want %v", err, ErrBrokenChain)
+	}
+}
+
+func TestRecordReplayedBlocks(t *testing.T) {
+	path := writeLog(t, 3)
+
+	// The indexer replays from its start block after a restart
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for block := 1; block <= 4; block++ {
+		if err := l.Record([]*indexer.Event{registration(uint64(block), types.RoleUser, int64(block))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l.Close()
+
+	report, err := VerifyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Entries != 4 || report.LastBlock != 4 {
+		t.Fatalf("replayed blocks recorded again: %d entries up to block %d", report.Entries, report.LastBlock)
+	}
+}
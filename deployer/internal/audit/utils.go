@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"math/big"
+
+	"deployer/internal/types"
+)
+
+// hashString encodes a hashed address in decimal, like the public signals.
+func hashString(hash *big.Int) string {
+	if hash == nil {
+		return ""
+	}
+	return hash.String()
+}
+
+// pick returns the action of role: the food bank or user action, the member action for the other roles.
+func pick(role types.Role, foodBankAction, userAction, memberAction Action) Action {
+	switch role {
+	case types.RoleFoodBank:
+		return foodBankAction
+	case types.RoleUser:
+		return userAction
+	default:
+		return memberAction
+	}
+}
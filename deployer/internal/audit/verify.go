@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Report summarises a verified audit log.
+type Report struct {
+	Entries   uint64
+	Head      common.Hash
+	Last      *Entry
+	LastBlock uint64   // last block with indexed entries, anchors excluded
+	Anchors   []*Entry // anchor entries, to be checked against the chain with VerifyAnchors
+}
+
+// TransactionBackend fetches anchor transactions and their receipts (implemented by ethclient.Client).
+type TransactionBackend interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (*ethtypes.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*ethtypes.Receipt, error)
+}
+
+// Verify reads the whole log and checks the sequence numbers, the hashes and the chaining of every
+// entry, and that every anchor publishes the hash of an earlier entry.
+func Verify(r io.Reader) (*Report, error) {
+	report := &Report{}
+	hashes := make(map[common.Hash]struct{})
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read audit log: %w", err)
+		}
+		if err == io.EOF {
+			// Every entry is written with its newline, a partial line is a torn write
+			return nil, fmt.Errorf("%w: entry %d is truncated", ErrInvalidEntry, report.Entries)
+		}
+
+		entry := &Entry{}
+		if err := json.Unmarshal(line, entry); err != nil {
+			return nil, fmt.Errorf("%w: entry %d: %v", ErrInvalidEntry, report.Entries, err)
+		}
+
+		if entry.Seq != report.Entries {
+			return nil, fmt.Errorf("%w: expected entry %d, found %d", ErrBrokenChain, report.Entries, entry.Seq)
+		}
+		if entry.Prev != report.Head {
+			return nil, fmt.Errorf("%w: entry %d does not follow %s", ErrBrokenChain, entry.Seq, report.Head.Hex())
+		}
+
+		digest, err := entry.Digest()
+		if err != nil {
+			return nil, err
+		}
+		if digest != entry.Hash {
+			return nil, fmt.Errorf("%w: entry %d has been modified", ErrBrokenChain, entry.Seq)
+		}
+
+		if entry.Action == ActionAnchor {
+			if _, ok := hashes[entry.AnchoredHash()]; !ok {
+				return nil, fmt.Errorf("%w: anchor %d publishes %s, which is not an earlier entry", ErrBrokenChain, entry.Seq, entry.AnchoredHash().Hex())
+			}
+			report.Anchors = append(report.Anchors, entry)
+		} else {
+			report.LastBlock = entry.BlockNumber
+		}
+		hashes[entry.Hash] = struct{}{}
+		report.Entries++
+		report.Head = entry.Hash
+		report.Last = entry
+	}
+
+	return report, nil
+}
+
+// VerifyFile verifies the audit log at path.
+func VerifyFile(path string) (*Report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return Verify(file)
+}
+
+// VerifyAnchors checks that every anchor transaction has been mined successfully, was sent by the
+// operator that anchors the log and carries the hash it anchors.
+func VerifyAnchors(ctx context.Context, backend TransactionBackend, anchors []*Entry, operator common.Address) error {
+	for _, anchor := range anchors {
+		tx, pending, err := backend.TransactionByHash(ctx, anchor.TxHash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch anchor %d (%s): %w", anchor.Seq, anchor.TxHash.Hex(), err)
+		}
+		if pending {
+			return fmt.Errorf("%w: anchor %d (%s) is still pending", ErrBrokenChain, anchor.Seq, anchor.TxHash.Hex())
+		}
+		if string(tx.Data()) != string(AnchorData(anchor.AnchoredHash())) {
+			return fmt.Errorf("%w: anchor %d (%s) does not carry %s", ErrBrokenChain, anchor.Seq, anchor.TxHash.Hex(), anchor.AnchoredHash().Hex())
+		}
+
+		sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return fmt.Errorf("failed to recover the sender of anchor %d (%s): %w", anchor.Seq, anchor.TxHash.Hex(), err)
+		}
+		if sender != operator {
+			return fmt.Errorf("%w: anchor %d (%s) was sent by %s, not the operator %s", ErrBrokenChain, anchor.Seq, anchor.TxHash.Hex(), sender.Hex(), operator.Hex())
+		}
+
+		receipt, err := backend.TransactionReceipt(ctx, anchor.TxHash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch the receipt of anchor %d (%s): %w", anchor.Seq, anchor.TxHash.Hex(), err)
+		}
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			return fmt.Errorf("%w: anchor %d (%s) failed", ErrBrokenChain, anchor.Seq, anchor.TxHash.Hex())
+		}
+		if receipt.BlockNumber.Uint64() != anchor.BlockNumber {
+			return fmt.Errorf("%w: anchor %d (%s) was mined in block %d, not %d", ErrBrokenChain, anchor.Seq, anchor.TxHash.Hex(), receipt.BlockNumber.Uint64(), anchor.BlockNumber)
+		}
+	}
+	return nil
+}
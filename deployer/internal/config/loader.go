@@ -4,6 +4,7 @@ import (
 	"deployer/internal/types"
 	"deployer/internal/validator"
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -15,7 +16,17 @@ type Config struct {
 func NewConfig() *Config {
 	return &Config{
 		&types.Config{
-			LoggerMode: "development",
+			LoggerMode:          "development",
+			WitnessBackend:      types.WitnessBackendWasm,
+			SessionIssuer:       "pinacle",
+			SessionAccessTTL:    5 * time.Minute,
+			SessionRefreshTTL:   24 * time.Hour,
+			SessionVerification: types.LoginVerificationOnChain,
+			SessionChallengeTTL: 2 * time.Minute,
+			IndexerPollInterval: 2 * time.Second,
+			FoodBankQuorum:      1,
+			ProposalLifetime:    7 * 24 * time.Hour,
+			Hasher:              types.HasherMiMC,
 		},
 	}
 }
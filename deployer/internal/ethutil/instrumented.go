@@ -0,0 +1,124 @@
+package ethutil
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"deployer/internal/metrics"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// InstrumentedClient records the latency and the failures of every RPC method used by the
+// contract bindings and the indexer. Other ethclient methods are passed through unmeasured.
+type InstrumentedClient struct {
+	*ethclient.Client
+}
+
+// NewInstrumentedClient wraps an ethclient.Client.
+func NewInstrumentedClient(client *ethclient.Client) *InstrumentedClient {
+	return &InstrumentedClient{Client: client}
+}
+
+func (c *InstrumentedClient) ChainID(ctx context.Context) (*big.Int, error) {
+	start := time.Now()
+	result, err := c.Client.ChainID(ctx)
+	observe("eth_chainId", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	start := time.Now()
+	result, err := c.Client.BlockByNumber(ctx, number)
+	observe("eth_getBlockByNumber", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	start := time.Now()
+	result, err := c.Client.HeaderByNumber(ctx, number)
+	observe("eth_getBlockByNumber", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	start := time.Now()
+	result, err := c.Client.TransactionReceipt(ctx, txHash)
+	observe("eth_getTransactionReceipt", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	start := time.Now()
+	result, err := c.Client.CodeAt(ctx, account, blockNumber)
+	observe("eth_getCode", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	start := time.Now()
+	result, err := c.Client.PendingCodeAt(ctx, account)
+	observe("eth_getCode", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	start := time.Now()
+	result, err := c.Client.PendingNonceAt(ctx, account)
+	observe("eth_getTransactionCount", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	start := time.Now()
+	result, err := c.Client.CallContract(ctx, msg, blockNumber)
+	observe("eth_call", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	start := time.Now()
+	result, err := c.Client.SuggestGasPrice(ctx)
+	observe("eth_gasPrice", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	start := time.Now()
+	result, err := c.Client.SuggestGasTipCap(ctx)
+	observe("eth_maxPriorityFeePerGas", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	start := time.Now()
+	result, err := c.Client.EstimateGas(ctx, msg)
+	observe("eth_estimateGas", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	start := time.Now()
+	result, err := c.Client.FilterLogs(ctx, q)
+	observe("eth_getLogs", start, err)
+	return result, err
+}
+
+func (c *InstrumentedClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	start := time.Now()
+	err := c.Client.SendTransaction(ctx, tx)
+	observe("eth_sendRawTransaction", start, err)
+	return err
+}
+
+// observe records the latency and the outcome of an RPC call started at start.
+func observe(method string, start time.Time, err error) {
+	metrics.RPCTimer(method).UpdateSince(start)
+	if err != nil {
+		metrics.RPCError(method).Inc(1)
+	}
+}
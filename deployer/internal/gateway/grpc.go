@@ -0,0 +1,377 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"deployer/internal/indexer"
+	"deployer/internal/logger"
+	"deployer/internal/membership"
+	pinaclev1 "deployer/internal/pb/pinacle/v1"
+	"deployer/internal/session"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/common"
+	rapidsnark "github.com/iden3/go-rapidsnark/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const watchBuffer = 64 // events buffered per WatchMembership stream
+
+type GRPCServer struct {
+	address string
+	server  *grpc.Server
+}
+
+// NewGRPCServer creates the gRPC API next to the REST gateway. It shares the TLS material
+// (reloaded on SIGHUP) and the session tokens of the HTTP server.
+func NewGRPCServer(address string, s *Server, issuer *session.Issuer, service *membership.Service, idx *indexer.Indexer) (*GRPCServer, error) {
+	var opts []grpc.ServerOption
+	if s.reloader != nil {
+		tlsConfig, err := NewTLSConfig(s.reloader, s.cfg.TLSMinVersion, s.cfg.TLSCipherSuites, s.cfg.TLSClientAuthRequired)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create TLS config: %w", err)
+		}
+		// Per-handshake configs are cloned from this one, so ALPN has to be set here
+		tlsConfig.NextProtos = []string{"h2"}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	return &GRPCServer{
+		address: address,
+		server: newGRPCServer(issuer, &membershipServer{
+			service: service,
+			issuer:  issuer,
+			events:  idx,
+		}, opts...),
+	}, nil
+}
+
+// newGRPCServer serves the membership API behind the session token interceptors.
+func newGRPCServer(issuer *session.Issuer, m *membershipServer, opts ...grpc.ServerOption) *grpc.Server {
+	verifier := issuer.Verifier()
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryAuth(verifier)),
+		grpc.ChainStreamInterceptor(streamAuth(verifier)),
+	)
+
+	server := grpc.NewServer(opts...)
+	pinaclev1.RegisterMembershipServiceServer(server, m)
+	return server
+}
+
+// Run serves until the context is cancelled, then stops gracefully.
+func (g *GRPCServer) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", g.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", g.address, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		g.server.GracefulStop()
+	}()
+
+	logger.Logger.Info().Str("address", g.address).Msg("gRPC listening")
+	if err := g.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("gRPC stopped: %w", err)
+	}
+	return nil
+}
+
+// eventSource streams the indexed membership changes, the indexer.
+type eventSource interface {
+	Subscribe(buffer int) (<-chan *indexer.Event, func())
+}
+
+type membershipServer struct {
+	pinaclev1.UnimplementedMembershipServiceServer
+	service *membership.Service
+	issuer  *session.Issuer
+	events  eventSource
+}
+
+func (m *membershipServer) RegisterFoodBank(ctx context.Context, req *pinaclev1.RegisterFoodBankRequest) (*pinaclev1.RegisterFoodBankResponse, error) {
+	receipt, err := m.register(ctx, types.RoleFoodBank, req.GetAddress(), req.GetEthereumAddressProof())
+	if err != nil {
+		return nil, err
+	}
+	return &pinaclev1.RegisterFoodBankResponse{Transaction: transactionMessage(receipt)}, nil
+}
+
+func (m *membershipServer) RegisterUser(ctx context.Context, req *pinaclev1.RegisterUserRequest) (*pinaclev1.RegisterUserResponse, error) {
+	receipt, err := m.register(ctx, types.RoleUser, req.GetAddress(), req.GetEthereumAddressProof())
+	if err != nil {
+		return nil, err
+	}
+	return &pinaclev1.RegisterUserResponse{Transaction: transactionMessage(receipt)}, nil
+}
+
+func (m *membershipServer) VerifyUser(ctx context.Context, req *pinaclev1.VerifyUserRequest) (*pinaclev1.VerifyUserResponse, error) {
+	if err := requireRole(ctx, types.RoleFoodBank); err != nil {
+		return nil, err
+	}
+
+	address, proof, err := membershipArgs(req.GetAddress(), req.GetMerkleTreeProof())
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.service.VerifyUser(ctx, address, proof)
+	switch {
+	case err == nil:
+		return &pinaclev1.VerifyUserResponse{Valid: true}, nil
+	case errors.Is(err, membership.ErrProofRejected):
+		return &pinaclev1.VerifyUserResponse{Valid: false, Reason: err.Error()}, nil
+	default:
+		return nil, membershipError(err)
+	}
+}
+
+func (m *membershipServer) Terminate(ctx context.Context, req *pinaclev1.TerminateRequest) (*pinaclev1.TerminateResponse, error) {
+	termination, err := m.service.Terminate(ctx, req.GetRawTransaction())
+	if err != nil {
+		return nil, membershipError(err)
+	}
+
+	// The indexer revokes as well once it sees the block, this closes the gap in between
+	m.issuer.Revoke(termination.HashedAddress)
+
+	return &pinaclev1.TerminateResponse{
+		Transaction:   transactionMessage(termination.Receipt),
+		Role:          roleMessage(termination.Role),
+		HashedAddress: termination.HashedAddress.String(),
+	}, nil
+}
+
+func (m *membershipServer) WatchMembership(req *pinaclev1.WatchMembershipRequest, stream grpc.ServerStreamingServer[pinaclev1.WatchMembershipResponse]) error {
+	roles := make(map[types.Role]bool)
+	for _, role := range req.GetRoles() {
+		r, err := roleFromMessage(role)
+		if err != nil {
+			return err
+		}
+		roles[r] = true
+	}
+
+	events, unsubscribe := m.events.Subscribe(watchBuffer)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "stream fell behind the indexer, subscribe again")
+			}
+			if len(roles) > 0 && !roles[event.Role] {
+				continue
+			}
+			message := eventMessage(event)
+			if message == nil {
+				continue
+			}
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// register is shared by RegisterFoodBank and RegisterUser.
+func (m *membershipServer) register(ctx context.Context, role types.Role, address string, proof *pinaclev1.ZKProof) (*membership.Receipt, error) {
+	if err := requireRole(ctx, types.RoleFoodBank); err != nil {
+		return nil, err
+	}
+
+	account, zkProof, err := membershipArgs(address, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := m.service.Register(ctx, role, account, zkProof)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Uint8("role", uint8(role)).Msg("Registration failed")
+		return nil, membershipError(err)
+	}
+	return receipt, nil
+}
+
+// unaryAuth authenticates unary calls with the session access token.
+func unaryAuth(verifier *session.Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, verifier)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamAuth authenticates streaming calls with the session access token.
+func streamAuth(verifier *session.Verifier) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), verifier)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate verifies the "authorization: Bearer <token>" metadata and stores the claims in the context.
+func authenticate(ctx context.Context, verifier *session.Verifier) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, session.ErrMissingAuthToken.Error())
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, status.Error(codes.Unauthenticated, session.ErrMissingAuthToken.Error())
+	}
+
+	claims, err := verifier.Verify(strings.TrimSpace(token), types.AccessToken)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return session.NewContext(ctx, claims), nil
+}
+
+func requireRole(ctx context.Context, role types.Role) error {
+	claims, ok := session.ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, session.ErrMissingAuthToken.Error())
+	}
+	if claims.Role != role {
+		return status.Error(codes.PermissionDenied, "role not allowed")
+	}
+	return nil
+}
+
+// membershipArgs validates and converts the address and the proof of a request.
+func membershipArgs(address string, proof *pinaclev1.ZKProof) (common.Address, *zkp.ZKProof, error) {
+	if !common.IsHexAddress(address) {
+		return common.Address{}, nil, status.Error(codes.InvalidArgument, "invalid Ethereum address")
+	}
+	if proof.GetProof() == nil || len(proof.GetPublicSignals()) != types.PINACLE_PUBLIC_SIGNALS {
+		return common.Address{}, nil, status.Errorf(codes.InvalidArgument, "a proof with %d public signals is required", types.PINACLE_PUBLIC_SIGNALS)
+	}
+
+	piB := make([][]string, len(proof.GetProof().GetPiB()))
+	for i, point := range proof.GetProof().GetPiB() {
+		piB[i] = point.GetCoordinates()
+	}
+
+	zkProof := zkp.NewZKProof()
+	zkProof.SetProof(&rapidsnark.ProofData{
+		A:        proof.GetProof().GetPiA(),
+		B:        piB,
+		C:        proof.GetProof().GetPiC(),
+		Protocol: proof.GetProof().GetProtocol(),
+	})
+	zkProof.SetPublicSignals(proof.GetPublicSignals())
+
+	return common.HexToAddress(address), zkProof, nil
+}
+
+// membershipError maps membership errors to gRPC status codes.
+func membershipError(err error) error {
+	switch {
+	case errors.Is(err, membership.ErrInvalidTransaction), errors.Is(err, membership.ErrUnsupportedRole):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, membership.ErrProofRejected), errors.Is(err, membership.ErrTransactionFailed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+func transactionMessage(receipt *membership.Receipt) *pinaclev1.Transaction {
+	return &pinaclev1.Transaction{
+		Hash:        receipt.Hash.Hex(),
+		BlockNumber: receipt.BlockNumber,
+		GasUsed:     receipt.GasUsed,
+	}
+}
+
+func roleMessage(role types.Role) pinaclev1.Role {
+	switch role {
+	case types.RoleFoodBank:
+		return pinaclev1.Role_ROLE_FOOD_BANK
+	case types.RoleUser:
+		return pinaclev1.Role_ROLE_USER
+	case types.RoleVolunteer:
+		return pinaclev1.Role_ROLE_VOLUNTEER
+	case types.RoleIntermediary:
+		return pinaclev1.Role_ROLE_INTERMEDIARY
+	case types.RoleAuditor:
+		return pinaclev1.Role_ROLE_AUDITOR
+	default:
+		return pinaclev1.Role_ROLE_UNSPECIFIED
+	}
+}
+
+func roleFromMessage(role pinaclev1.Role) (types.Role, error) {
+	switch role {
+	case pinaclev1.Role_ROLE_FOOD_BANK:
+		return types.RoleFoodBank, nil
+	case pinaclev1.Role_ROLE_USER:
+		return types.RoleUser, nil
+	case pinaclev1.Role_ROLE_VOLUNTEER:
+		return types.RoleVolunteer, nil
+	case pinaclev1.Role_ROLE_INTERMEDIARY:
+		return types.RoleIntermediary, nil
+	case pinaclev1.Role_ROLE_AUDITOR:
+		return types.RoleAuditor, nil
+	default:
+		return 0, status.Errorf(codes.InvalidArgument, "unsupported role %s", role)
+	}
+}
+
+// eventMessage converts an indexer event, it returns nil for events that are not streamed.
+func eventMessage(event *indexer.Event) *pinaclev1.WatchMembershipResponse {
+	response := &pinaclev1.WatchMembershipResponse{
+		BlockNumber:     event.BlockNumber,
+		TransactionHash: event.TxHash.Hex(),
+	}
+
+	switch event.Type {
+	case indexer.EventRootChanged:
+		response.Event = &pinaclev1.WatchMembershipResponse_RootChanged{RootChanged: &pinaclev1.RootChanged{
+			Role:      roleMessage(event.Role),
+			Root:      event.Root.String(),
+			Leaf:      event.Leaf.String(),
+			LeafIndex: event.LeafIndex,
+		}}
+	case indexer.EventRevoked:
+		response.Event = &pinaclev1.WatchMembershipResponse_Revoked{Revoked: &pinaclev1.Revoked{
+			Role:          roleMessage(event.Role),
+			HashedAddress: event.HashedAddress.String(),
+		}}
+	default:
+		return nil // Not a membership change
+	}
+	return response
+}
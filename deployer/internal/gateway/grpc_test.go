@@ -0,0 +1,297 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ed25519"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"deployer/internal/indexer"
+	pinaclev1 "deployer/internal/pb/pinacle/v1"
+	"deployer/internal/session"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	rapidsnark "github.com/iden3/go-rapidsnark/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRoleMessage(t *testing.T) {
+	for _, def := range types.Roles() {
+		message := roleMessage(def.Role)
+		if message == pinaclev1.Role_ROLE_UNSPECIFIED {
+			t.Fatalf("role %s has no message", def.Role)
+		}
+		role, err := roleFromMessage(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if role != def.Role {
+			t.Fatalf("role %s comes back as %s", def.Role, role)
+		}
+	}
+	if _, err := roleFromMessage(pinaclev1.Role_ROLE_UNSPECIFIED); err == nil {
+		t.Fatal("unspecified role accepted")
+	}
+}
+
+// fakeEvents hands each WatchMembership subscription to the test, which feeds and closes it.
+type fakeEvents struct {
+	subscriptions chan chan *indexer.Event
+}
+
+func (f *fakeEvents) Subscribe(buffer int) (<-chan *indexer.Event, func()) {
+	ch := make(chan *indexer.Event, buffer)
+	f.subscriptions <- ch
+	return ch, func() {}
+}
+
+type testGRPC struct {
+	client pinaclev1.MembershipServiceClient
+	issuer *session.Issuer
+	events *fakeEvents
+}
+
+// newTestGRPC serves the membership API over an in-memory connection, without a membership
+// service: the calls tested fail before reaching it.
+func newTestGRPC(t *testing.T) *testGRPC {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := session.NewIssuer(key, "pinacle", time.Minute, time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := &fakeEvents{subscriptions: make(chan chan *indexer.Event, 1)}
+
+	listener := bufconn.Listen(1 << 20)
+	server := newGRPCServer(issuer, &membershipServer{issuer: issuer, events: events})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &testGRPC{client: pinaclev1.NewMembershipServiceClient(conn), issuer: issuer, events: events}
+}
+
+// tokens issues the token pair of a member of role with the given hashed address.
+func (g *testGRPC) tokens(t *testing.T, role types.Role, hashedAddress int64) *types.TokenPair {
+	t.Helper()
+	pair, err := g.issuer.Issue(role, big.NewInt(hashedAddress))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pair
+}
+
+func withAuthorization(authorization string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", authorization)
+}
+
+func requireCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != code {
+		t.Fatalf("got %v (%v), want %v", got, err, code)
+	}
+}
+
+func TestGRPCAuth(t *testing.T) {
+	g := newTestGRPC(t)
+	revoked := g.tokens(t, types.RoleFoodBank, 2)
+	g.issuer.Revoke(big.NewInt(2))
+	pair := g.tokens(t, types.RoleFoodBank, 1)
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"no token", context.Background()},
+		{"basic", withAuthorization("Basic " + pair.AccessToken)},
+		{"no scheme", withAuthorization(pair.AccessToken)},
+		{"invalid", withAuthorization("Bearer invalid")},
+		{"refresh token", withAuthorization("Bearer " + pair.RefreshToken)},
+		{"revoked", withAuthorization("Bearer " + revoked.AccessToken)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := g.client.VerifyUser(tc.ctx, &pinaclev1.VerifyUserRequest{})
+			requireCode(t, err, codes.Unauthenticated)
+
+			stream, err := g.client.WatchMembership(tc.ctx, &pinaclev1.WatchMembershipRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = stream.Recv()
+			requireCode(t, err, codes.Unauthenticated)
+		})
+	}
+
+	// The scheme is case insensitive; an empty request gets past the authentication
+	_, err := g.client.VerifyUser(withAuthorization("bearer "+pair.AccessToken), &pinaclev1.VerifyUserRequest{})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+func TestGRPCRequireRole(t *testing.T) {
+	g := newTestGRPC(t)
+	calls := map[string]func(ctx context.Context) error{
+		"RegisterFoodBank": func(ctx context.Context) error {
+			_, err := g.client.RegisterFoodBank(ctx, &pinaclev1.RegisterFoodBankRequest{})
+			return err
+		},
+		"RegisterUser": func(ctx context.Context) error {
+			_, err := g.client.RegisterUser(ctx, &pinaclev1.RegisterUserRequest{})
+			return err
+		},
+		"VerifyUser": func(ctx context.Context) error {
+			_, err := g.client.VerifyUser(ctx, &pinaclev1.VerifyUserRequest{})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			for _, def := range types.Roles() {
+				ctx := withAuthorization("Bearer " + g.tokens(t, def.Role, 1).AccessToken)
+				// Food banks get to the argument checks, the other roles are denied
+				want := codes.PermissionDenied
+				if def.Role == types.RoleFoodBank {
+					want = codes.InvalidArgument
+				}
+				if err := call(ctx); status.Code(err) != want {
+					t.Fatalf("%s: got %v, want %v", def.Role, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMembershipArgs(t *testing.T) {
+	const address = "0x00000000000000000000000000000000000000aa"
+	valid := func() *pinaclev1.ZKProof {
+		return &pinaclev1.ZKProof{
+			Proof: &pinaclev1.Groth16Proof{
+				PiA:      []string{"1", "2", "1"},
+				PiB:      []*pinaclev1.G2Point{{Coordinates: []string{"3", "4"}}, {Coordinates: []string{"5", "6"}}, {Coordinates: []string{"1", "0"}}},
+				PiC:      []string{"7", "8", "1"},
+				Protocol: "groth16",
+			},
+			PublicSignals: []string{"9", "10"},
+		}
+	}
+
+	account, proof, err := membershipArgs(address, valid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account != common.HexToAddress(address) {
+		t.Fatalf("got %s, want %s", account.Hex(), address)
+	}
+	want := &rapidsnark.ProofData{A: []string{"1", "2", "1"}, B: [][]string{{"3", "4"}, {"5", "6"}, {"1", "0"}}, C: []string{"7", "8", "1"}, Protocol: "groth16"}
+	if !reflect.DeepEqual(proof.GetProof(), want) {
+		t.Fatalf("got proof %+v, want %+v", proof.GetProof(), want)
+	}
+	if !reflect.DeepEqual(proof.GetPublicSignals(), []string{"9", "10"}) {
+		t.Fatalf("got public signals %v", proof.GetPublicSignals())
+	}
+
+	for _, tc := range []struct {
+		name    string
+		address string
+		proof   func() *pinaclev1.ZKProof
+	}{
+		{"empty address", "", valid},
+		{"short address", "0xaa", valid},
+		{"not hex", "0x" + strings.Repeat("z", 40), valid},
+		{"no proof", address, func() *pinaclev1.ZKProof { return nil }},
+		{"no groth16 proof", address, func() *pinaclev1.ZKProof {
+			p := valid()
+			p.Proof = nil
+			return p
+		}},
+		{"one public signal", address, func() *pinaclev1.ZKProof {
+			p := valid()
+			p.PublicSignals = p.PublicSignals[:1]
+			return p
+		}},
+		{"three public signals", address, func() *pinaclev1.ZKProof {
+			p := valid()
+			p.PublicSignals = append(p.PublicSignals, "11")
+			return p
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := membershipArgs(tc.address, tc.proof())
+			requireCode(t, err, codes.InvalidArgument)
+		})
+	}
+}
+
+func TestWatchMembership(t *testing.T) {
+	g := newTestGRPC(t)
+	ctx := withAuthorization("Bearer " + g.tokens(t, types.RoleAuditor, 1).AccessToken)
+
+	t.Run("invalid role", func(t *testing.T) {
+		stream, err := g.client.WatchMembership(ctx, &pinaclev1.WatchMembershipRequest{Roles: []pinaclev1.Role{pinaclev1.Role_ROLE_UNSPECIFIED}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = stream.Recv()
+		requireCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("roles", func(t *testing.T) {
+		stream, err := g.client.WatchMembership(ctx, &pinaclev1.WatchMembershipRequest{
+			Roles: []pinaclev1.Role{pinaclev1.Role_ROLE_USER, pinaclev1.Role_ROLE_VOLUNTEER},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		events := <-g.events.subscriptions
+		for i, event := range []*indexer.Event{
+			{Type: indexer.EventRootChanged, Role: types.RoleFoodBank, Root: big.NewInt(1), Leaf: big.NewInt(2)},
+			{Type: indexer.EventRootChanged, Role: types.RoleUser, Root: big.NewInt(3), Leaf: big.NewInt(4), LeafIndex: 1},
+			{Type: indexer.EventProofsDeleted, Role: types.RoleUser, HashedAddress: big.NewInt(5)},
+			{Type: indexer.EventRevoked, Role: types.RoleAuditor, HashedAddress: big.NewInt(6)},
+			{Type: indexer.EventRevoked, Role: types.RoleVolunteer, HashedAddress: big.NewInt(7)},
+		} {
+			event.BlockNumber = uint64(i)
+			events <- event
+		}
+
+		first, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if root := first.GetRootChanged(); root == nil || root.GetRole() != pinaclev1.Role_ROLE_USER || root.GetRoot() != "3" || root.GetLeafIndex() != 1 || first.GetBlockNumber() != 1 {
+			t.Fatalf("got %v, want the user root of block 1", first)
+		}
+		second, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revoked := second.GetRevoked(); revoked == nil || revoked.GetRole() != pinaclev1.Role_ROLE_VOLUNTEER || revoked.GetHashedAddress() != "7" || second.GetBlockNumber() != 4 {
+			t.Fatalf("got %v, want the volunteer revocation of block 4", second)
+		}
+
+		// The indexer closes the channel of a subscriber that fell behind
+		close(events)
+		_, err = stream.Recv()
+		requireCode(t, err, codes.ResourceExhausted)
+	})
+}
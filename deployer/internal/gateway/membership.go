@@ -0,0 +1,195 @@
+package gateway
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"deployer/internal/logger"
+	"deployer/internal/membership"
+	"deployer/internal/session"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type MembershipHandler struct {
+	service *membership.Service
+	issuer  *session.Issuer
+}
+
+// NewMembershipHandler creates the REST endpoints of the membership service.
+func NewMembershipHandler(service *membership.Service, issuer *session.Issuer) *MembershipHandler {
+	return &MembershipHandler{
+		service: service,
+		issuer:  issuer,
+	}
+}
+
+// Register mounts the membership endpoints on the server. All of them require an access token:
+//   - POST /v1/membership/foodbanks     registers a food bank (food bank role)
+//   - POST /v1/membership/users         registers a user (food bank role)
+//   - POST /v1/membership/users/verify  verifies a user's zkMerkleTree proof (food bank role)
+//   - POST /v1/membership/roles/{role}  registers a member of any role a food bank may register
+//   - POST /v1/membership/roles/{role}/revoke  revokes a member the food bank registered, by hashed address
+//   - POST /v1/membership/terminate     relays a signed terminateUser/terminateFoodBank/terminateMember transaction
+func (h *MembershipHandler) Register(s *Server) {
+	authenticated := session.Middleware(h.issuer.Verifier())
+	foodBank := func(handler http.HandlerFunc) http.Handler {
+		return authenticated(session.RequireRole(types.RoleFoodBank, handler))
+	}
+
+	s.Handle("POST /v1/membership/foodbanks", foodBank(h.register(types.RoleFoodBank)))
+	s.Handle("POST /v1/membership/users", foodBank(h.register(types.RoleUser)))
+	s.Handle("POST /v1/membership/users/verify", foodBank(h.verifyUser))
+	s.Handle("POST /v1/membership/roles/{role}", foodBank(h.registerMember))
+	s.Handle("POST /v1/membership/roles/{role}/revoke", foodBank(h.revoke))
+	s.Handle("POST /v1/membership/terminate", authenticated(http.HandlerFunc(h.terminate)))
+}
+
+func (h *MembershipHandler) register(role types.Role) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &types.MembershipRequest{}
+		if !decodeJSON(w, r, req) {
+			return
+		}
+
+		receipt, err := h.service.Register(r.Context(), role, req.Address, membershipProof(req))
+		if err != nil {
+			logger.Logger.Warn().Err(err).Uint8("role", uint8(role)).Msg("Registration failed")
+			http.Error(w, err.Error(), membershipStatus(err))
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, transactionResponse(receipt))
+	}
+}
+
+// registerMember registers a member of the role of the path, by name or ID.
+func (h *MembershipHandler) registerMember(w http.ResponseWriter, r *http.Request) {
+	role, err := types.ParseRole(r.PathValue("role"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	h.register(role)(w, r)
+}
+
+// revoke revokes the member of the role of the path with the hashed address of the request.
+func (h *MembershipHandler) revoke(w http.ResponseWriter, r *http.Request) {
+	role, err := types.ParseRole(r.PathValue("role"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	req := &types.RevokeRequest{}
+	if !decodeJSON(w, r, req) {
+		return
+	}
+	hashedAddress, ok := new(big.Int).SetString(req.HashedAddress, 10)
+	if !ok {
+		http.Error(w, "invalid hashed address", http.StatusBadRequest)
+		return
+	}
+
+	receipt, err := h.service.Revoke(r.Context(), role, hashedAddress)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Uint8("role", uint8(role)).Msg("Revocation failed")
+		http.Error(w, err.Error(), membershipStatus(err))
+		return
+	}
+
+	// The indexer revokes as well once it sees the block, this closes the gap in between
+	h.issuer.Revoke(hashedAddress)
+
+	writeJSON(w, http.StatusOK, &types.TerminateResponse{
+		Transaction:   transactionResponse(receipt),
+		Role:          role,
+		HashedAddress: hashedAddress.String(),
+	})
+}
+
+func (h *MembershipHandler) verifyUser(w http.ResponseWriter, r *http.Request) {
+	req := &types.MembershipRequest{}
+	if !decodeJSON(w, r, req) {
+		return
+	}
+
+	err := h.service.VerifyUser(r.Context(), req.Address, membershipProof(req))
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, &types.VerifyResponse{Valid: true})
+	case errors.Is(err, membership.ErrProofRejected):
+		writeJSON(w, http.StatusOK, &types.VerifyResponse{Valid: false, Reason: err.Error()})
+	default:
+		logger.Logger.Error().Err(err).Msg("Verification failed")
+		http.Error(w, err.Error(), membershipStatus(err))
+	}
+}
+
+func (h *MembershipHandler) terminate(w http.ResponseWriter, r *http.Request) {
+	req := &types.TerminateRequest{}
+	if !decodeJSON(w, r, req) {
+		return
+	}
+
+	rawTx, err := hexutil.Decode(ensureHexPrefix(req.RawTransaction))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	termination, err := h.service.Terminate(r.Context(), rawTx)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Msg("Termination failed")
+		http.Error(w, err.Error(), membershipStatus(err))
+		return
+	}
+
+	// The indexer revokes as well once it sees the block, this closes the gap in between
+	h.issuer.Revoke(termination.HashedAddress)
+
+	writeJSON(w, http.StatusOK, &types.TerminateResponse{
+		Transaction:   transactionResponse(termination.Receipt),
+		Role:          termination.Role,
+		HashedAddress: termination.HashedAddress.String(),
+	})
+}
+
+// membershipProof wraps the request proof for the membership service.
+func membershipProof(req *types.MembershipRequest) *zkp.ZKProof {
+	proof := zkp.NewZKProof()
+	proof.SetProof(req.Proof)
+	proof.SetPublicSignals(req.PublicSignals)
+	return proof
+}
+
+// membershipStatus maps membership errors to HTTP status codes.
+func membershipStatus(err error) int {
+	switch {
+	case errors.Is(err, membership.ErrInvalidTransaction), errors.Is(err, membership.ErrUnsupportedRole):
+		return http.StatusBadRequest
+	case errors.Is(err, membership.ErrProofRejected), errors.Is(err, membership.ErrTransactionFailed):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func transactionResponse(receipt *membership.Receipt) *types.TransactionResponse {
+	return &types.TransactionResponse{
+		Hash:        receipt.Hash.Hex(),
+		BlockNumber: receipt.BlockNumber,
+		GasUsed:     receipt.GasUsed,
+	}
+}
+
+func ensureHexPrefix(s string) string {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return s
+	}
+	return "0x" + s
+}
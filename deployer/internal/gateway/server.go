@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"deployer/internal/config"
+	"deployer/internal/logger"
+)
+
+const (
+	defaultAddress  = "0.0.0.0:8080"
+	shutdownTimeout = 10 * time.Second
+)
+
+type Server struct {
+	cfg      *config.Config
+	mux      *http.ServeMux
+	server   *http.Server
+	reloader *CertReloader
+}
+
+// NewServer creates the HTTP gateway from the configuration.
+// When TLS is enabled the certificates are loaded eagerly, so a misconfiguration fails at startup.
+func NewServer(cfg *config.Config) (*Server, error) {
+	if cfg.TLSClientAuthRequired && !cfg.TLSEnabled {
+		return nil, ErrTLSNotEnabled
+	}
+
+	address := cfg.GatewayAddress
+	if address == "" {
+		address = defaultAddress
+	}
+
+	mux := http.NewServeMux()
+	s := &Server{
+		cfg: cfg,
+		mux: mux,
+		server: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+
+	if cfg.TLSEnabled {
+		caFilename := ""
+		if cfg.TLSClientAuthRequired {
+			caFilename = cfg.TLSClientCAFilename
+		}
+
+		reloader, err := NewCertReloader(cfg.TLSCertFilename, cfg.TLSKeyFilename, caFilename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificates: %w", err)
+		}
+
+		tlsConfig, err := NewTLSConfig(reloader, cfg.TLSMinVersion, cfg.TLSCipherSuites, cfg.TLSClientAuthRequired)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create TLS config: %w", err)
+		}
+
+		s.reloader = reloader
+		s.server.TLSConfig = tlsConfig
+	}
+
+	return s, nil
+}
+
+// Handle registers the handler for the given pattern.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// HandleFunc registers the handler function for the given pattern.
+func (s *Server) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// Run starts serving and blocks until the context is cancelled or the listener fails.
+// On cancellation the server is shut down gracefully. With TLS enabled, SIGHUP reloads the certificates.
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		var err error
+		if s.reloader != nil {
+			go s.reloader.WatchSignals(ctx)
+			logger.Logger.Info().Str("address", s.server.Addr).Bool("mTLS", s.cfg.TLSClientAuthRequired).Msg("Gateway listening (TLS)")
+			// Certificates are served through TLSConfig.GetCertificate
+			err = s.server.ListenAndServeTLS("", "")
+		} else {
+			logger.Logger.Warn().Str("address", s.server.Addr).Msg("Gateway listening without TLS")
+			err = s.server.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return fmt.Errorf("gateway stopped: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shutdown gateway: %w", err)
+	}
+	logger.Logger.Info().Msg("Gateway stopped")
+	return nil
+}
@@ -0,0 +1,162 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"deployer/internal/logger"
+	"deployer/internal/session"
+	"deployer/internal/types"
+	"deployer/internal/validator"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const maxRequestBodySize = 1 << 20 // 1 MiB, proofs are a few hundred bytes
+
+type SessionHandler struct {
+	issuer     *session.Issuer
+	challenges *session.Challenges
+	verifier   session.ProofVerifier
+}
+
+// NewSessionHandler creates the challenge/login/refresh/logout endpoints. verifier should check
+// the challenges (session.ChallengeVerifier).
+func NewSessionHandler(issuer *session.Issuer, challenges *session.Challenges, verifier session.ProofVerifier) *SessionHandler {
+	return &SessionHandler{
+		issuer:     issuer,
+		challenges: challenges,
+		verifier:   verifier,
+	}
+}
+
+// Register mounts the session endpoints on the server:
+//   - POST /v1/session/challenge address -> one-time nonce and the message to sign
+//   - POST /v1/session/login    zkMerkleTree proof and signed challenge -> token pair
+//   - POST /v1/session/refresh  refresh token -> new token pair
+//   - POST /v1/session/logout   revokes the presented access token
+//   - POST /v1/session/revoke   revokes every token of the caller (e.g. after termination)
+//   - GET  /v1/session/key      PEM verification key for downstream services
+func (h *SessionHandler) Register(s *Server) {
+	authenticated := session.Middleware(h.issuer.Verifier())
+
+	s.HandleFunc("POST /v1/session/challenge", h.challenge)
+	s.HandleFunc("POST /v1/session/login", h.login)
+	s.HandleFunc("POST /v1/session/refresh", h.refresh)
+	s.Handle("POST /v1/session/logout", authenticated(http.HandlerFunc(h.logout)))
+	s.Handle("POST /v1/session/revoke", authenticated(http.HandlerFunc(h.revoke)))
+	s.HandleFunc("GET /v1/session/key", h.key)
+}
+
+func (h *SessionHandler) challenge(w http.ResponseWriter, r *http.Request) {
+	req := &types.ChallengeRequest{}
+	if !decodeJSON(w, r, req) {
+		return
+	}
+	if req.Address == (common.Address{}) {
+		http.Error(w, session.ErrAddressRequired.Error(), http.StatusBadRequest)
+		return
+	}
+
+	challenge, err := h.challenges.Issue(req.Address)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to issue login challenge")
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, http.StatusOK, challenge)
+}
+
+func (h *SessionHandler) login(w http.ResponseWriter, r *http.Request) {
+	req := &types.LoginRequest{}
+	if !decodeJSON(w, r, req) {
+		return
+	}
+
+	hashedAddress, err := h.verifier.VerifyLogin(r.Context(), req)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Uint8("role", uint8(req.Role)).Msg("Login rejected")
+		status := http.StatusUnauthorized
+		if errors.Is(err, session.ErrAddressRequired) || errors.Is(err, session.ErrUnsupportedRole) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	pair, err := h.issuer.Issue(req.Role, hashedAddress)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to issue session tokens")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Logger.Info().Uint8("role", uint8(req.Role)).Str("hashedAddress", hashedAddress.String()).Msg("Session issued")
+	writeJSON(w, http.StatusOK, pair)
+}
+
+func (h *SessionHandler) refresh(w http.ResponseWriter, r *http.Request) {
+	req := &types.RefreshRequest{}
+	if !decodeJSON(w, r, req) {
+		return
+	}
+
+	pair, err := h.issuer.Refresh(req.RefreshToken)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, pair)
+}
+
+func (h *SessionHandler) logout(w http.ResponseWriter, r *http.Request) {
+	claims, _ := session.ClaimsFromContext(r.Context())
+	h.issuer.RevokeToken(claims)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SessionHandler) revoke(w http.ResponseWriter, r *http.Request) {
+	claims, _ := session.ClaimsFromContext(r.Context())
+	hashedAddress, err := claims.HashedAddress()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	h.issuer.Revoke(hashedAddress)
+	logger.Logger.Info().Str("hashedAddress", claims.Subject).Msg("Sessions revoked")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SessionHandler) key(w http.ResponseWriter, r *http.Request) {
+	pem, err := session.EncodeVerificationKey(h.issuer.PublicKey())
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.Write(pem)
+}
+
+// decodeJSON decodes and validates the request body. On failure the response is written and false is returned.
+func decodeJSON[T any](w http.ResponseWriter, r *http.Request, v T) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		http.Error(w, "malformed request body", http.StatusBadRequest)
+		return false
+	}
+	if err := validator.ValidateStruct(v); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to write response")
+	}
+}
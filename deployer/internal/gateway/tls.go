@@ -0,0 +1,167 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"deployer/internal/logger"
+	"deployer/internal/validator"
+)
+
+var (
+	ErrTLSNotEnabled        = errors.New("client authentication requires TLS to be enabled")
+	ErrInvalidTLSVersion    = validator.ErrInvalidTLSVersion
+	ErrInvalidCipherSuite   = validator.ErrInvalidCipherSuite
+	ErrEmptyClientCABundle  = errors.New("client CA bundle contains no certificates")
+	ErrCertificateNotLoaded = errors.New("TLS certificate not loaded")
+)
+
+// CertReloader keeps the server certificate and the client CA bundle in memory
+// and swaps them atomically when Reload is called, so certificates can be rotated
+// without restarting the gateway.
+type CertReloader struct {
+	mu           sync.RWMutex
+	certFilename string
+	keyFilename  string
+	caFilename   string
+	cert         *tls.Certificate
+	clientCAs    *x509.CertPool
+}
+
+// NewCertReloader creates a CertReloader and performs the initial load.
+// caFilename may be empty when client certificates are not required.
+func NewCertReloader(certFilename, keyFilename, caFilename string) (*CertReloader, error) {
+	r := &CertReloader{
+		certFilename: certFilename,
+		keyFilename:  keyFilename,
+		caFilename:   caFilename,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the certificate, key and client CA bundle from disk.
+// On failure the previously loaded material is kept and an error is returned.
+func (r *CertReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFilename, r.keyFilename)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.caFilename != "" {
+		clientCAs, err = loadCertPool(r.caFilename)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	return nil
+}
+
+// GetCertificate returns the current server certificate. It is meant to be used as tls.Config.GetCertificate.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.cert == nil {
+		return nil, ErrCertificateNotLoaded
+	}
+	return r.cert, nil
+}
+
+// GetClientCAs returns the current client CA bundle (nil when not configured).
+func (r *CertReloader) GetClientCAs() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clientCAs
+}
+
+// WatchSignals reloads the certificates every time the process receives SIGHUP.
+// It blocks until the context is cancelled.
+func (r *CertReloader) WatchSignals(ctx context.Context) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			if err := r.Reload(); err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to reload TLS certificates, keeping the previous ones")
+				continue
+			}
+			logger.Logger.Info().Str("cert", r.certFilename).Str("clientCA", r.caFilename).Msg("TLS certificates reloaded")
+		}
+	}
+}
+
+// NewTLSConfig builds the gateway tls.Config. The server certificate and the client CA bundle
+// are resolved through the reloader on every handshake, so a SIGHUP reload applies to new connections.
+//
+// Parameters:
+//   - reloader:          the certificate source.
+//   - minVersion:        "1.2" or "1.3" (defaults to "1.2" when empty).
+//   - cipherSuites:      comma separated cipher suite names, only honoured for TLS 1.2.
+//   - clientAuthRequired: when true, clients must present a certificate signed by the client CA bundle.
+func NewTLSConfig(reloader *CertReloader, minVersion, cipherSuites string, clientAuthRequired bool) (*tls.Config, error) {
+	version, err := validator.ParseTLSVersion(minVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	suites, err := validator.ParseTLSCipherSuites(cipherSuites)
+	if err != nil {
+		return nil, err
+	}
+
+	clientAuth := tls.NoClientCert
+	if clientAuthRequired {
+		clientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	base := &tls.Config{
+		MinVersion:     version,
+		CipherSuites:   suites,
+		ClientAuth:     clientAuth,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	// ClientCAs is read once per tls.Config, so hand out a per-handshake copy
+	// carrying the latest bundle.
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cfg := base.Clone()
+		cfg.GetConfigForClient = nil
+		cfg.ClientCAs = reloader.GetClientCAs()
+		return cfg, nil
+	}
+
+	return base, nil
+}
+
+// loadCertPool reads a PEM bundle into a new certificate pool.
+func loadCertPool(filename string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: %s", ErrEmptyClientCABundle, filename)
+	}
+	return pool, nil
+}
@@ -0,0 +1,413 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// testCA signs the certificates of a test, written as PEM files in its temporary directory.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+	pem  []byte
+}
+
+var testSerial = big.NewInt(1)
+
+func nextSerial() *big.Int {
+	testSerial = new(big.Int).Add(testSerial, big.NewInt(1))
+	return testSerial
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          nextSerial(),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue signs a localhost certificate usable by servers and clients.
+func (ca *testCA) issue(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: nextSerial(),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// clientCert returns the key pair of a client certificate signed by ca.
+func (ca *testCA) clientCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// testFiles are the certificate, key and client CA bundle files of a reloader.
+type testFiles struct {
+	cert, key, ca string
+}
+
+func newTestFiles(t *testing.T) *testFiles {
+	dir := t.TempDir()
+	return &testFiles{cert: filepath.Join(dir, "cert.pem"), key: filepath.Join(dir, "key.pem"), ca: filepath.Join(dir, "ca.pem")}
+}
+
+// write issues a server certificate of serverCA and trusts the clients of clientCA.
+func (f *testFiles) write(t *testing.T, serverCA, clientCA *testCA) {
+	t.Helper()
+	certPEM, keyPEM := serverCA.issue(t)
+	for filename, data := range map[string][]byte{f.cert: certPEM, f.key: keyPEM, f.ca: clientCA.pem} {
+		if err := os.WriteFile(filename, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func serialOf(t *testing.T, r *CertReloader) *big.Int {
+	t.Helper()
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.SerialNumber
+}
+
+// handshake connects client to a server configured by config, and returns the connection state
+// of the server and the first error of either side.
+func handshake(t *testing.T, config *tls.Config, client *tls.Config) (tls.ConnectionState, error) {
+	t.Helper()
+	serverConn, clientConn := net.Pipe()
+	server := tls.Server(serverConn, config)
+	serverErr := make(chan error, 1)
+	go func() {
+		defer serverConn.Close()
+		serverErr <- server.Handshake()
+	}()
+
+	clientErr := tls.Client(clientConn, client).Handshake()
+	// In TLS 1.3 the client is done before the server has verified its certificate
+	clientConn.Close()
+	if err := <-serverErr; err != nil {
+		return tls.ConnectionState{}, err
+	}
+	return server.ConnectionState(), clientErr
+}
+
+func TestCertReloader(t *testing.T) {
+	serverCA, clientCA := newTestCA(t, "server CA"), newTestCA(t, "client CA")
+	files := newTestFiles(t)
+	files.write(t, serverCA, clientCA)
+
+	r, err := NewCertReloader(files.cert, files.key, files.ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.GetClientCAs().Equal(clientCA.pool) {
+		t.Fatal("client CA bundle not loaded")
+	}
+	serial := serialOf(t, r)
+
+	// Without a bundle, client certificates are not verified
+	noCA, err := NewCertReloader(files.cert, files.key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if noCA.GetClientCAs() != nil {
+		t.Fatal("got a client CA bundle, want none")
+	}
+
+	rotatedCA := newTestCA(t, "rotated client CA")
+	files.write(t, serverCA, rotatedCA)
+	if err := r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if serialOf(t, r).Cmp(serial) == 0 || !r.GetClientCAs().Equal(rotatedCA.pool) {
+		t.Fatal("reload kept the previous certificates")
+	}
+	serial = serialOf(t, r)
+
+	// A failed reload keeps the loaded certificates
+	if err := os.WriteFile(files.ca, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); !errors.Is(err, ErrEmptyClientCABundle) {
+		t.Fatalf("got %v, want %v", err, ErrEmptyClientCABundle)
+	}
+	if serialOf(t, r).Cmp(serial) != 0 || !r.GetClientCAs().Equal(rotatedCA.pool) {
+		t.Fatal("failed reload replaced the certificates")
+	}
+
+	for _, tc := range []struct {
+		name          string
+		cert, key, ca string
+		target        error
+	}{
+		{"missing certificate", filepath.Join(t.TempDir(), "missing.pem"), files.key, "", os.ErrNotExist},
+		{"missing key", files.cert, newTestFiles(t).key, "", os.ErrNotExist},
+		{"missing CA", files.cert, files.key, filepath.Join(t.TempDir(), "missing.pem"), os.ErrNotExist},
+		{"empty CA", files.cert, files.key, files.ca, ErrEmptyClientCABundle}, // still the invalid bundle,
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewCertReloader(tc.cert, tc.key, tc.ca); !errors.Is(err, tc.target) {
+				t.Fatalf("got %v, want %v", err, tc.target)
+			}
+		})
+	}
+
+	t.Run("key of another certificate", func(t *testing.T) {
+		other := newTestFiles(t)
+		other.write(t, serverCA, clientCA)
+		if _, err := NewCertReloader(files.cert, other.key, ""); err == nil {
+			t.Fatal("got no error for a key that does not match the certificate")
+		}
+	})
+}
+
+func TestCertReloaderSIGHUP(t *testing.T) {
+	serverCA, clientCA := newTestCA(t, "server CA"), newTestCA(t, "client CA")
+	files := newTestFiles(t)
+	files.write(t, serverCA, clientCA)
+	r, err := NewCertReloader(files.cert, files.key, files.ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial := serialOf(t, r)
+
+	// Until WatchSignals subscribes, SIGHUP would terminate the test binary
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.WatchSignals(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	files.write(t, serverCA, clientCA)
+	deadline := time.Now().Add(5 * time.Second)
+	for serialOf(t, r).Cmp(serial) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("certificate not reloaded on SIGHUP")
+		}
+		// Resent, as WatchSignals may not have subscribed yet
+		if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTLSConfigVersion(t *testing.T) {
+	serverCA := newTestCA(t, "server CA")
+	files := newTestFiles(t)
+	files.write(t, serverCA, serverCA)
+	r, err := NewCertReloader(files.cert, files.key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		minVersion string
+		client     uint16 // maximum version of the client
+		want       uint16 // negotiated version, 0 when the handshake fails
+	}{
+		{"default", "", tls.VersionTLS12, tls.VersionTLS12},
+		{"1.2", "1.2", tls.VersionTLS13, tls.VersionTLS13},
+		{"1.3", "1.3", tls.VersionTLS13, tls.VersionTLS13},
+		{"1.3 rejects 1.2", "1.3", tls.VersionTLS12, 0},
+		{"1.2 rejects 1.1", "1.2", tls.VersionTLS11, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := NewTLSConfig(r, tc.minVersion, "", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state, err := handshake(t, config, &tls.Config{RootCAs: serverCA.pool, ServerName: "localhost", MinVersion: tls.VersionTLS10, MaxVersion: tc.client})
+			if tc.want == 0 {
+				if err == nil {
+					t.Fatalf("got version %x, want a failed handshake", state.Version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state.Version != tc.want {
+				t.Fatalf("got version %x, want %x", state.Version, tc.want)
+			}
+		})
+	}
+
+	if _, err := NewTLSConfig(r, "1.1", "", false); !errors.Is(err, ErrInvalidTLSVersion) {
+		t.Fatalf("got %v, want %v", err, ErrInvalidTLSVersion)
+	}
+}
+
+func TestTLSConfigCipherSuites(t *testing.T) {
+	serverCA := newTestCA(t, "server CA")
+	files := newTestFiles(t)
+	files.write(t, serverCA, serverCA)
+	r, err := NewCertReloader(files.cert, files.key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := NewTLSConfig(r, "1.2", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := func(suites ...uint16) *tls.Config {
+		return &tls.Config{RootCAs: serverCA.pool, ServerName: "localhost", MaxVersion: tls.VersionTLS12, CipherSuites: suites}
+	}
+
+	state, err := handshake(t, config, client(tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.CipherSuite != tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256 {
+		t.Fatalf("got %s, want %s", tls.CipherSuiteName(state.CipherSuite), tls.CipherSuiteName(tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256))
+	}
+	if _, err := handshake(t, config, client(tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256)); err == nil {
+		t.Fatal("got a handshake without a configured cipher suite")
+	}
+
+	for _, suites := range []string{"TLS_RSA_WITH_RC4_128_SHA", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,unknown"} {
+		if _, err := NewTLSConfig(r, "1.2", suites, false); !errors.Is(err, ErrInvalidCipherSuite) {
+			t.Fatalf("%s: got %v, want %v", suites, err, ErrInvalidCipherSuite)
+		}
+	}
+}
+
+func TestTLSConfigClientAuth(t *testing.T) {
+	serverCA, clientCA, otherCA := newTestCA(t, "server CA"), newTestCA(t, "client CA"), newTestCA(t, "other CA")
+	files := newTestFiles(t)
+	files.write(t, serverCA, clientCA)
+	r, err := NewCertReloader(files.cert, files.key, files.ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := func(certs ...tls.Certificate) *tls.Config {
+		return &tls.Config{RootCAs: serverCA.pool, ServerName: "localhost", Certificates: certs}
+	}
+	trusted, untrusted := clientCA.clientCert(t), otherCA.clientCert(t)
+
+	optional, err := NewTLSConfig(r, "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handshake(t, optional, client()); err != nil {
+		t.Fatalf("got %v, want no client certificate required", err)
+	}
+
+	required, err := NewTLSConfig(r, "", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name   string
+		client *tls.Config
+		ok     bool
+	}{
+		{"trusted", client(trusted), true},
+		{"no certificate", client(), false},
+		{"untrusted", client(untrusted), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state, err := handshake(t, required, tc.client)
+			if !tc.ok {
+				if err == nil {
+					t.Fatal("got a handshake, want the client certificate rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(state.PeerCertificates) == 0 {
+				t.Fatal("got no verified client certificate")
+			}
+		})
+	}
+
+	// The reloaded bundle applies to the next handshakes of the same config
+	files.write(t, serverCA, otherCA)
+	if err := r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handshake(t, required, client(untrusted)); err != nil {
+		t.Fatalf("got %v, want the client of the reloaded CA accepted", err)
+	}
+	if _, err := handshake(t, required, client(trusted)); err == nil {
+		t.Fatal("got a handshake, want the client of the previous CA rejected")
+	}
+}
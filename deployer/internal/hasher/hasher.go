@@ -0,0 +1,45 @@
+package hasher
+
+import (
+	"errors"
+	"math/big"
+
+	"deployer/internal/mimc"
+	"deployer/internal/poseidon"
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrUnknownHasher = errors.New("hasher should be mimc or poseidon")
+
+// Hasher is the hash of the Merkle tree nodes and of the account addresses (the leaves).
+// It must match the hasher contract zkLogin is deployed with.
+type Hasher interface {
+	// HashLeftRight mirrors hashLeftRight in MerkleTreeWithHistory.
+	HashLeftRight(left, right *big.Int) (fr.Element, error)
+	// HashAddress mirrors hashAddress in zkLogin.
+	HashAddress(address *common.Address) *big.Int
+}
+
+var (
+	_ Hasher = (*mimc.MiMCSponge)(nil)
+	_ Hasher = (*poseidon.Poseidon)(nil)
+)
+
+// New returns the hasher of kind, MiMC with the parameters of the mimc contract.
+func New(kind types.HasherKind) (Hasher, error) {
+	switch kind {
+	case types.HasherMiMC:
+		sponge, err := mimc.NewMiMCSponge(mimc.Seed, mimc.MimcNbRounds)
+		if err != nil {
+			return nil, err
+		}
+		return sponge, nil
+	case types.HasherPoseidon:
+		return poseidon.NewPoseidon(), nil
+	default:
+		return nil, ErrUnknownHasher
+	}
+}
@@ -0,0 +1,73 @@
+package indexer
+
+import (
+	"math/big"
+
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type EventType uint8
+
+const (
+	EventRootChanged   EventType = iota // A leaf has been inserted
+	EventRevoked                        // An account has been terminated
+	EventProofsDeleted                  // An account deleted its stored merkle proofs
+)
+
+// Event is a membership change seen by the indexer.
+type Event struct {
+	Type          EventType
+	Role          types.Role
+	Root          *big.Int // EventRootChanged
+	Leaf          *big.Int // EventRootChanged
+	LeafIndex     uint32   // EventRootChanged
+	HashedAddress *big.Int // EventRevoked, EventProofsDeleted
+	Actor         *big.Int // Hashed sender, nil for the initial food banks of the deployment
+	BlockNumber   uint64
+	BlockTime     uint64
+	TxHash        common.Hash
+}
+
+// Recorder receives the events of every indexed block before the block is marked as processed.
+// A failing recorder stops the indexer.
+type Recorder interface {
+	Record(events []*Event) error
+}
+
+// Subscribe returns a channel receiving every event indexed from now on and a function to unsubscribe.
+// A subscriber that falls more than buffer events behind is dropped and its channel closed,
+// so a slow consumer never stalls the indexer; it should subscribe again and refetch its state.
+func (i *Indexer) Subscribe(buffer int) (<-chan *Event, func()) {
+	ch := make(chan *Event, buffer)
+
+	i.subMu.Lock()
+	i.subscribers[ch] = struct{}{}
+	i.subMu.Unlock()
+
+	unsubscribe := func() {
+		i.subMu.Lock()
+		defer i.subMu.Unlock()
+		if _, ok := i.subscribers[ch]; ok {
+			delete(i.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// publish fans the event out to every subscriber.
+func (i *Indexer) publish(event *Event) {
+	i.subMu.Lock()
+	defer i.subMu.Unlock()
+
+	for ch := range i.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(i.subscribers, ch)
+			close(ch)
+		}
+	}
+}
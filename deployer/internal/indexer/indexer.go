@@ -0,0 +1,617 @@
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/hasher"
+	"deployer/internal/logger"
+	"deployer/internal/membership"
+	"deployer/internal/merkletree"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrDeploymentNotIndexed = errors.New("zkLogin deployment has not been indexed, the start block must not be after the deployment block")
+	ErrUnknownBytecode      = errors.New("zkLogin deployment does not match the compiled bytecode")
+	ErrInvalidConstructor   = errors.New("unexpected zkLogin constructor arguments")
+	ErrRegistryMismatch     = errors.New("zkLogin role registry differs from types.Roles, regenerate it with contract-cli gen-roles")
+	ErrReplicaDiverged      = errors.New("merkle tree replica diverged from the contract")
+	ErrRecorderFailed       = errors.New("failed to record indexed events")
+	ErrReorg                = errors.New("chain reorganised below the indexed blocks, raise the indexer confirmations")
+)
+
+// Backend is the chain access needed by the indexer (implemented by ethclient.Client).
+type Backend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*ethtypes.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error)
+}
+
+// Indexer follows the zkLogin contract and keeps an off-chain replica of the tree of every role.
+//
+// zkLogin emits no events, so the indexer decodes the calldata of every successful transaction
+// sent directly to the contract (and the deployment transaction). Calls made through other
+// contracts are not seen. Food bank proposals are remembered until executed, their ID being
+// derived from the proposal block time.
+type Indexer struct {
+	mu            sync.RWMutex
+	backend       Backend
+	address       common.Address
+	abi           *abi.ABI
+	bytecode      []byte
+	signer        ethtypes.Signer
+	hasher        hasher.Hasher
+	trees         map[types.Role]*merkletree.Tree
+	revoked       map[string]bool // hashed addresses terminated or revoked
+	next          uint64
+	head          common.Hash               // hash of the last indexed block, zero before the first one
+	confirmations uint64                    // blocks left between the chain head and the last indexed block
+	lifetime      uint64                    // proposal lifetime in seconds
+	proposals     map[common.Hash]*proposal // only accessed by the indexing goroutine
+	pollInterval  time.Duration
+	recorder      Recorder
+
+	subMu       sync.Mutex
+	subscribers map[chan *Event]struct{}
+}
+
+// New creates an indexer for the zkLogin contract at address, starting at startBlock.
+// startBlock must not be after the deployment block, since the initial food banks are only
+// known from the constructor arguments.
+func New(ctx context.Context, backend Backend, address common.Address, hasher hasher.Hasher, startBlock uint64, pollInterval time.Duration) (*Indexer, error) {
+	parsed, err := zklogin.ZkloginMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse zkLogin ABI: %w", err)
+	}
+
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve chainID: %w", err)
+	}
+
+	return &Indexer{
+		backend:      backend,
+		address:      address,
+		abi:          parsed,
+		bytecode:     common.FromHex(zklogin.ZkloginMetaData.Bin),
+		signer:       ethtypes.LatestSignerForChainID(chainID),
+		hasher:       hasher,
+		trees:        make(map[types.Role]*merkletree.Tree),
+		revoked:      make(map[string]bool),
+		proposals:    make(map[common.Hash]*proposal),
+		next:         startBlock,
+		pollInterval: pollInterval,
+		subscribers:  make(map[chan *Event]struct{}),
+	}, nil
+}
+
+// SetRecorder sets the recorder of the indexed events. It must be called before Run.
+func (i *Indexer) SetRecorder(recorder Recorder) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.recorder = recorder
+}
+
+// SetConfirmations makes the indexer stay confirmations blocks behind the chain head, so the blocks
+// it indexes are unlikely to be reorganised. It must be called before Run.
+func (i *Indexer) SetConfirmations(confirmations uint64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.confirmations = confirmations
+}
+
+// Run indexes blocks up to the chain head, then polls for new blocks until the context is cancelled.
+func (i *Indexer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(i.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := i.Sync(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// A diverged replica, a lost record or a reorganised block is fatal, transient RPC failures
+			// are retried on the next tick
+			if errors.Is(err, ErrReplicaDiverged) || errors.Is(err, ErrRecorderFailed) || errors.Is(err, ErrReorg) {
+				return err
+			}
+			logger.Logger.Error().Err(err).Uint64("block", i.NextBlock()).Msg("Indexer sync failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync indexes every block from the next unprocessed one up to the current head, minus the
+// confirmations.
+func (i *Indexer) Sync(ctx context.Context) error {
+	head, err := i.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch head: %w", err)
+	}
+
+	i.mu.RLock()
+	confirmations := i.confirmations
+	i.mu.RUnlock()
+	if head.Number.Uint64() < confirmations {
+		return nil
+	}
+
+	for number := i.NextBlock(); number <= head.Number.Uint64()-confirmations; number++ {
+		if err := i.processBlock(ctx, number); err != nil {
+			return fmt.Errorf("block %d: %w", number, err)
+		}
+	}
+	return nil
+}
+
+// NextBlock returns the next block to be indexed.
+func (i *Indexer) NextBlock() uint64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.next
+}
+
+// IsKnownRoot reports whether root is a root of the tree of the given role.
+// It implements session.RootChecker.
+func (i *Indexer) IsKnownRoot(role types.Role, root *big.Int) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	tree, ok := i.trees[role]
+	return ok && tree.IsKnownRoot(root)
+}
+
+// IsRevoked reports whether the account of the hashed address has been terminated or revoked.
+// It implements session.RootChecker.
+func (i *Indexer) IsRevoked(hashedAddress *big.Int) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.revoked[hashedAddress.String()]
+}
+
+// Root returns the current root of the tree of the given role.
+func (i *Indexer) Root(role types.Role) (*big.Int, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	tree, ok := i.trees[role]
+	if !ok {
+		return nil, false
+	}
+	return tree.Root(), true
+}
+
+// processBlock applies every zkLogin transaction of the block. Receipts are fetched first,
+// so a transient RPC failure leaves the replica untouched at the previous block. A block that
+// does not follow the last indexed one is refused, the replica and the recorder holding the
+// blocks it replaced.
+func (i *Indexer) processBlock(ctx context.Context, number uint64) error {
+	block, err := i.backend.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return fmt.Errorf("failed to fetch block: %w", err)
+	}
+
+	i.mu.RLock()
+	parent := i.head
+	i.mu.RUnlock()
+	if parent != (common.Hash{}) && block.ParentHash() != parent {
+		return fmt.Errorf("%w: parent %s, indexed %s", ErrReorg, block.ParentHash().Hex(), parent.Hex())
+	}
+
+	var applied []*ethtypes.Transaction
+	var deployment *ethtypes.Transaction
+	for _, tx := range block.Transactions() {
+		if tx.To() != nil && *tx.To() != i.address {
+			continue
+		}
+
+		receipt, err := i.backend.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to fetch receipt of %s: %w", tx.Hash().Hex(), err)
+		}
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			continue
+		}
+		if tx.To() == nil {
+			if receipt.ContractAddress != i.address {
+				continue
+			}
+			deployment = tx
+		}
+		applied = append(applied, tx)
+	}
+
+	var events []*Event
+	for _, tx := range applied {
+		var txEvents []*Event
+		if tx == deployment {
+			txEvents, err = i.applyDeployment(tx)
+		} else {
+			txEvents, err = i.applyCall(tx, block.Time())
+		}
+		if err != nil {
+			// The replica may be partially updated, it cannot be trusted anymore
+			return fmt.Errorf("%w: tx %s: %v", ErrReplicaDiverged, tx.Hash().Hex(), err)
+		}
+
+		for _, event := range txEvents {
+			event.BlockNumber = number
+			event.BlockTime = block.Time()
+			event.TxHash = tx.Hash()
+		}
+		events = append(events, txEvents...)
+	}
+
+	i.mu.RLock()
+	recorder := i.recorder
+	i.mu.RUnlock()
+	if recorder != nil && len(events) > 0 {
+		if err := recorder.Record(events); err != nil {
+			return fmt.Errorf("%w: %v", ErrRecorderFailed, err)
+		}
+	}
+
+	i.mu.Lock()
+	i.next = number + 1
+	i.head = block.Hash()
+	for _, event := range events {
+		if event.Type == EventRevoked {
+			i.revoked[event.HashedAddress.String()] = true
+		}
+	}
+	i.mu.Unlock()
+
+	for _, event := range events {
+		i.publish(event)
+	}
+	return nil
+}
+
+// applyDeployment creates the tree of every role from the constructor arguments and inserts the
+// initial food banks.
+func (i *Indexer) applyDeployment(tx *ethtypes.Transaction) ([]*Event, error) {
+	if !bytes.HasPrefix(tx.Data(), i.bytecode) {
+		return nil, ErrUnknownBytecode
+	}
+
+	args, err := i.abi.Constructor.Inputs.Unpack(tx.Data()[len(i.bytecode):])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConstructor, err)
+	}
+	levels, ok := args[2].([]uint32)
+	if !ok || len(args) != 10 {
+		return nil, ErrInvalidConstructor
+	}
+	foodBanks, ok := args[5].([]common.Address)
+	if !ok {
+		return nil, ErrInvalidConstructor
+	}
+	definitions, err := convertArgument[[]zklogin.ZkLoginRoleDefinition](args[6])
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := convertArgument[[]zklogin.ZkLoginRolePermission](args[7])
+	if err != nil {
+		return nil, err
+	}
+	lifetime, ok := args[9].(uint64)
+	if !ok {
+		return nil, ErrInvalidConstructor
+	}
+
+	// The gateway interprets the roles with types.Role, which must be the deployed registry
+	wantDefinitions, wantPermissions := membership.RoleRegistry()
+	if !slices.Equal(definitions, wantDefinitions) || !slices.Equal(permissions, wantPermissions) {
+		return nil, ErrRegistryMismatch
+	}
+
+	trees := make(map[types.Role]*merkletree.Tree, len(definitions))
+	for _, def := range definitions {
+		if int(def.Tree) >= len(levels) {
+			return nil, ErrInvalidConstructor
+		}
+		tree, err := merkletree.NewTree(levels[def.Tree], i.hasher)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConstructor, err)
+		}
+		trees[types.Role(def.Id)] = tree
+	}
+
+	i.mu.Lock()
+	i.trees = trees
+	i.mu.Unlock()
+	i.lifetime = lifetime
+
+	var events []*Event
+	for _, foodBank := range foodBanks {
+		// The constructor skips zero addresses
+		if foodBank == (common.Address{}) {
+			continue
+		}
+		event, err := i.insert(types.RoleFoodBank, foodBank)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// applyCall replays registrations, terminations and executed proposals, and reports proof deletions.
+func (i *Indexer) applyCall(tx *ethtypes.Transaction, blockTime uint64) ([]*Event, error) {
+	if len(tx.Data()) < 4 {
+		return nil, nil
+	}
+	method, err := i.abi.MethodById(tx.Data()[:4])
+	if err != nil {
+		return nil, nil // Not a zkLogin function (e.g. plain transfer)
+	}
+
+	var events []*Event
+	switch method.Name {
+	case "registerFoodBank", "registerUser":
+		args := make(map[string]interface{})
+		if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", method.Name, err)
+		}
+
+		role, key := types.RoleFoodBank, "_newFoodBank"
+		if method.Name == "registerUser" {
+			role, key = types.RoleUser, "_newUser"
+		}
+		account, ok := args[key].(common.Address)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing %s", method.Name, key)
+		}
+
+		event, err := i.insert(role, account)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+
+	case "registerUsers":
+		args := make(map[string]interface{})
+		if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", method.Name, err)
+		}
+		accounts, ok := args["_newUsers"].([]common.Address)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing _newUsers", method.Name)
+		}
+
+		// The leaves are inserted in order, each root of the batch becoming known
+		for _, account := range accounts {
+			event, err := i.insert(types.RoleUser, account)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+
+	case "registerMember":
+		args := make(map[string]interface{})
+		if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", method.Name, err)
+		}
+		role, ok := args["_role"].(uint32)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing _role", method.Name)
+		}
+		account, ok := args["_newMember"].(common.Address)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing _newMember", method.Name)
+		}
+
+		event, err := i.insert(types.Role(role), account)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+
+	case "terminateMember", "deleteMemberMerkleProofs":
+		args := make(map[string]interface{})
+		if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", method.Name, err)
+		}
+		role, ok := args["_role"].(uint32)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing _role", method.Name)
+		}
+
+		eventType := EventRevoked
+		if method.Name == "deleteMemberMerkleProofs" {
+			eventType = EventProofsDeleted
+		}
+		events = append(events, &Event{Type: eventType, Role: types.Role(role)})
+
+	case "proposeFoodBank", "proposeFoodBankTermination":
+		args := make(map[string]interface{})
+		if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", method.Name, err)
+		}
+
+		pending := &proposal{action: membership.ProposalAddFoodBank}
+		if method.Name == "proposeFoodBank" {
+			account, ok := args["_newFoodBank"].(common.Address)
+			if !ok {
+				return nil, fmt.Errorf("failed to decode %s: missing _newFoodBank", method.Name)
+			}
+			pending.account = account
+		} else {
+			hashedAddress, ok := args["_hashedFoodBank"].(*big.Int)
+			if !ok {
+				return nil, fmt.Errorf("failed to decode %s: missing _hashedFoodBank", method.Name)
+			}
+			pending.action, pending.hashedAddress = membership.ProposalTerminateFoodBank, hashedAddress
+		}
+
+		// Nothing changes until the proposal is executed, expired proposals never will be
+		for id, other := range i.proposals {
+			if other.expiresAt <= blockTime {
+				delete(i.proposals, id)
+			}
+		}
+		pending.expiresAt = blockTime + i.lifetime
+		i.proposals[membership.ProposalID(pending.action, pending.account, pending.hashedAddress, pending.expiresAt)] = pending
+		return nil, nil
+
+	case "executeProposal":
+		args := make(map[string]interface{})
+		if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", method.Name, err)
+		}
+		id, ok := args["_proposal"].([32]byte)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing _proposal", method.Name)
+		}
+		executed, ok := i.proposals[id]
+		if !ok {
+			return nil, fmt.Errorf("executed proposal %s was never proposed", common.Hash(id).Hex())
+		}
+		delete(i.proposals, id)
+
+		if executed.action == membership.ProposalAddFoodBank {
+			event, err := i.insert(types.RoleFoodBank, executed.account)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		} else {
+			events = append(events, &Event{
+				Type:          EventRevoked,
+				Role:          types.RoleFoodBank,
+				HashedAddress: executed.hashedAddress,
+			})
+		}
+
+	case "revokeMember":
+		args := make(map[string]interface{})
+		if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", method.Name, err)
+		}
+		role, ok := args["_role"].(uint32)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing _role", method.Name)
+		}
+		hashedAddress, ok := args["_hashedAddress"].(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing _hashedAddress", method.Name)
+		}
+		events = append(events, &Event{Type: EventRevoked, Role: types.Role(role), HashedAddress: hashedAddress})
+
+	case "rotateKey":
+		args := make(map[string]interface{})
+		if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", method.Name, err)
+		}
+		role, ok := args["_role"].(uint32)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing _role", method.Name)
+		}
+		oldPublicSignals, ok := args["_oldMemberPublicSignals"].([2]*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("failed to decode %s: missing _oldMemberPublicSignals", method.Name)
+		}
+		// The old key is revoked, the sender being the new key
+		sender, err := ethtypes.Sender(i.signer, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover sender: %w", err)
+		}
+		events = append(events, &Event{Type: EventRevoked, Role: types.Role(role), HashedAddress: oldPublicSignals[0]})
+		event, err := i.insert(types.Role(role), sender)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+
+	case "terminateFoodBank", "terminateUser":
+		role := types.RoleFoodBank
+		if method.Name == "terminateUser" {
+			role = types.RoleUser
+		}
+		events = append(events, &Event{Type: EventRevoked, Role: role})
+
+	case "deleteFoodBankMerkleProofs", "deleteUserMerkleProofs":
+		role := types.RoleFoodBank
+		if method.Name == "deleteUserMerkleProofs" {
+			role = types.RoleUser
+		}
+		events = append(events, &Event{Type: EventProofsDeleted, Role: role})
+
+	default:
+		return nil, nil
+	}
+
+	sender, err := ethtypes.Sender(i.signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender: %w", err)
+	}
+	actor := i.hasher.HashAddress(&sender)
+	for _, event := range events {
+		event.Actor = actor
+		if event.Type != EventRootChanged && event.HashedAddress == nil {
+			// Terminations and deletions act on the sender's own account, unless revoked by others
+			event.HashedAddress = event.Actor
+		}
+	}
+	return events, nil
+}
+
+// proposal is a food bank proposal not executed yet.
+type proposal struct {
+	action        membership.ProposalAction
+	account       common.Address // ProposalAddFoodBank
+	hashedAddress *big.Int       // ProposalTerminateFoodBank
+	expiresAt     uint64
+}
+
+// convertArgument converts an unpacked constructor tuple to its binding type.
+func convertArgument[T any](arg interface{}) (out T, err error) {
+	defer func() {
+		if recover() != nil {
+			err = ErrInvalidConstructor
+		}
+	}()
+	return *abi.ConvertType(arg, new(T)).(*T), nil
+}
+
+// insert adds hashAddress(account) to the tree of the role.
+func (i *Indexer) insert(role types.Role, account common.Address) (*Event, error) {
+	i.mu.RLock()
+	tree, ok := i.trees[role]
+	i.mu.RUnlock()
+	if !ok {
+		return nil, ErrDeploymentNotIndexed
+	}
+
+	leaf := i.hasher.HashAddress(&account)
+	root, index, err := tree.Insert(leaf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert leaf: %w", err)
+	}
+
+	return &Event{
+		Type:      EventRootChanged,
+		Role:      role,
+		Root:      root,
+		Leaf:      leaf,
+		LeafIndex: index,
+	}, nil
+}
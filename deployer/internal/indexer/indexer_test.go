@@ -0,0 +1,396 @@
+package indexer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/hasher"
+	"deployer/internal/membership"
+	"deployer/internal/merkletree"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+const testLevels = 10
+
+var (
+	testChainID = big.NewInt(1337)
+	zkLogin     = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+)
+
+// chain is an in-memory Backend whose blocks can be replaced to simulate a reorganisation.
+type chain struct {
+	t        *testing.T
+	blocks   []*ethtypes.Block
+	receipts map[common.Hash]*ethtypes.Receipt
+	forks    uint64 // makes the blocks mined after a reorganisation differ from the ones they replace
+}
+
+func newChain(t *testing.T) *chain {
+	t.Helper()
+	genesis := ethtypes.NewBlock(&ethtypes.Header{Number: big.NewInt(0), Time: 1_700_000_000}, nil, nil, trie.NewStackTrie(nil))
+	return &chain{t: t, blocks: []*ethtypes.Block{genesis}, receipts: make(map[common.Hash]*ethtypes.Receipt)}
+}
+
+// mine appends a block with the successful transactions.
+func (c *chain) mine(txs ...*ethtypes.Transaction) {
+	c.t.Helper()
+	parent := c.blocks[len(c.blocks)-1]
+	header := &ethtypes.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), big.NewInt(1)),
+		Time:       parent.Time() + 12,
+		Extra:      []byte(fmt.Sprint(c.forks)),
+	}
+	for _, tx := range txs {
+		receipt := &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, TxHash: tx.Hash(), BlockNumber: header.Number}
+		if tx.To() == nil {
+			receipt.ContractAddress = zkLogin
+		}
+		c.receipts[tx.Hash()] = receipt
+	}
+	c.blocks = append(c.blocks, ethtypes.NewBlock(header, &ethtypes.Body{Transactions: txs}, nil, trie.NewStackTrie(nil)))
+}
+
+// reorg drops the last depth blocks, the next mined ones replacing them.
+func (c *chain) reorg(depth int) {
+	c.blocks = c.blocks[:len(c.blocks)-depth]
+	c.forks++
+}
+
+func (c *chain) ChainID(context.Context) (*big.Int, error) {
+	return testChainID, nil
+}
+
+func (c *chain) HeaderByNumber(_ context.Context, number *big.Int) (*ethtypes.Header, error) {
+	if number == nil {
+		return c.blocks[len(c.blocks)-1].Header(), nil
+	}
+	block, err := c.BlockByNumber(context.Background(), number)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+func (c *chain) BlockByNumber(_ context.Context, number *big.Int) (*ethtypes.Block, error) {
+	if !number.IsUint64() || number.Uint64() >= uint64(len(c.blocks)) {
+		return nil, errors.New("not found")
+	}
+	return c.blocks[number.Uint64()], nil
+}
+
+func (c *chain) TransactionReceipt(_ context.Context, hash common.Hash) (*ethtypes.Receipt, error) {
+	receipt, ok := c.receipts[hash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return receipt, nil
+}
+
+// recorder keeps the recorded events.
+type recorder struct {
+	events []*Event
+}
+
+func (r *recorder) Record(events []*Event) error {
+	r.events = append(r.events, events...)
+	return nil
+}
+
+type account struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+	nonce   uint64
+}
+
+func newAccount(t *testing.T) *account {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &account{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// send signs a transaction of acc carrying data, a deployment when to is nil.
+func (a *account) send(t *testing.T, to *common.Address, data []byte) *ethtypes.Transaction {
+	t.Helper()
+	tx, err := ethtypes.SignNewTx(a.key, ethtypes.LatestSignerForChainID(testChainID), &ethtypes.DynamicFeeTx{
+		ChainID: testChainID,
+		Nonce:   a.nonce,
+		To:      to,
+		Gas:     5_000_000,
+		Data:    data,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.nonce++
+	return tx
+}
+
+// deploy returns the deployment of zkLogin by foodBank as its only initial food bank.
+func deploy(t *testing.T, foodBank *account) *ethtypes.Transaction {
+	t.Helper()
+	parsed, err := zklogin.ZkloginMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subtrees := types.RoleTrees()
+	levels := make([]uint32, len(subtrees))
+	for i := range levels {
+		levels[i] = testLevels
+	}
+	definitions, permissions := membership.RoleRegistry()
+	args, err := parsed.Constructor.Inputs.Pack(uint32(len(subtrees)), subtrees, levels, common.Address{1}, common.Address{2}, []common.Address{foodBank.address}, definitions, permissions, uint32(1), uint64(3600))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return foodBank.send(t, nil, append(common.FromHex(zklogin.ZkloginMetaData.Bin), args...))
+}
+
+// call returns the call of method by acc.
+func call(t *testing.T, acc *account, method string, args ...interface{}) *ethtypes.Transaction {
+	t.Helper()
+	parsed, err := zklogin.ZkloginMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := zkLogin
+	return acc.send(t, &to, data)
+}
+
+func zeroProof() (zklogin.ZkLoginGroth16Proof, [2]*big.Int) {
+	zero := big.NewInt(0)
+	return zklogin.ZkLoginGroth16Proof{
+		PiA: [2]*big.Int{zero, zero},
+		PiB: [2][2]*big.Int{{zero, zero}, {zero, zero}},
+		PiC: [2]*big.Int{zero, zero},
+	}, [2]*big.Int{zero, zero}
+}
+
+func registerUser(t *testing.T, foodBank, user *account) *ethtypes.Transaction {
+	t.Helper()
+	proof, signals := zeroProof()
+	return call(t, foodBank, "registerUser", proof, signals, user.address, proof, signals)
+}
+
+func terminateUser(t *testing.T, user *account) *ethtypes.Transaction {
+	t.Helper()
+	proof, signals := zeroProof()
+	return call(t, user, "terminateUser", proof, signals)
+}
+
+func newIndexer(t *testing.T, c *chain, h hasher.Hasher) *Indexer {
+	t.Helper()
+	i, err := New(context.Background(), c, zkLogin, h, 0, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return i
+}
+
+func newHasher(t *testing.T) hasher.Hasher {
+	t.Helper()
+	h, err := hasher.New(types.HasherMiMC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+// expectedRoot returns the root of a tree holding the accounts.
+func expectedRoot(t *testing.T, h hasher.Hasher, accounts ...*account) *big.Int {
+	t.Helper()
+	tree, err := merkletree.NewTree(testLevels, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, acc := range accounts {
+		if _, _, err := tree.Insert(h.HashAddress(&acc.address)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tree.Root()
+}
+
+func requireRoot(t *testing.T, i *Indexer, role types.Role, expected *big.Int) {
+	t.Helper()
+	root, ok := i.Root(role)
+	if !ok {
+		t.Fatalf("no tree for %s", role)
+	}
+	if root.Cmp(expected) != 0 {
+		t.Fatalf("root of %s is %s, expected %s", role, root, expected)
+	}
+}
+
+func TestSync(t *testing.T) {
+	h := newHasher(t)
+	c := newChain(t)
+	foodBank, user, other := newAccount(t), newAccount(t), newAccount(t)
+	c.mine(deploy(t, foodBank))
+	c.mine(registerUser(t, foodBank, user), registerUser(t, foodBank, other))
+	c.mine(terminateUser(t, user))
+
+	i := newIndexer(t, c, h)
+	recorded := &recorder{}
+	i.SetRecorder(recorded)
+	if err := i.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if next := i.NextBlock(); next != 4 {
+		t.Fatalf("next block is %d, expected 4", next)
+	}
+	requireRoot(t, i, types.RoleFoodBank, expectedRoot(t, h, foodBank))
+	requireRoot(t, i, types.RoleUser, expectedRoot(t, h, user, other))
+	if !i.IsRevoked(h.HashAddress(&user.address)) || i.IsRevoked(h.HashAddress(&other.address)) {
+		t.Fatal("terminated user not revoked")
+	}
+	if len(recorded.events) != 4 || recorded.events[3].Type != EventRevoked || recorded.events[3].BlockNumber != 3 {
+		t.Fatalf("unexpected events %+v", recorded.events)
+	}
+}
+
+func TestSyncRestart(t *testing.T) {
+	h := newHasher(t)
+	c := newChain(t)
+	foodBank, user, other := newAccount(t), newAccount(t), newAccount(t)
+	c.mine(deploy(t, foodBank))
+	c.mine(registerUser(t, foodBank, user))
+	c.mine(terminateUser(t, user))
+
+	i := newIndexer(t, c, h)
+	if err := i.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	c.mine(registerUser(t, foodBank, other))
+
+	// The replica lives in memory, a restarted indexer replays the chain from its start block
+	restarted := newIndexer(t, c, h)
+	if err := restarted.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if next := restarted.NextBlock(); next != 5 {
+		t.Fatalf("next block is %d, expected 5", next)
+	}
+	requireRoot(t, restarted, types.RoleUser, expectedRoot(t, h, user, other))
+	if !restarted.IsRevoked(h.HashAddress(&user.address)) {
+		t.Fatal("terminated user not revoked after a restart")
+	}
+
+	// A start block after the deployment misses the trees
+	late, err := New(context.Background(), c, zkLogin, h, 2, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := late.Sync(context.Background()); !errors.Is(err, ErrReplicaDiverged) {
+		t.Fatalf("start after the deployment: got %v, want %v", err, ErrReplicaDiverged)
+	}
+}
+
+func TestSyncConfirmations(t *testing.T) {
+	h := newHasher(t)
+	c := newChain(t)
+	foodBank, user := newAccount(t), newAccount(t)
+	c.mine(deploy(t, foodBank))
+	c.mine(registerUser(t, foodBank, user))
+	c.mine()
+
+	i := newIndexer(t, c, h)
+	i.SetConfirmations(2)
+	if err := i.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if next := i.NextBlock(); next != 2 {
+		t.Fatalf("next block is %d, expected 2", next)
+	}
+	requireRoot(t, i, types.RoleUser, expectedRoot(t, h))
+
+	c.mine()
+	if err := i.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	requireRoot(t, i, types.RoleUser, expectedRoot(t, h, user))
+}
+
+func TestSyncReorg(t *testing.T) {
+	h := newHasher(t)
+	c := newChain(t)
+	foodBank, user, orphan, other := newAccount(t), newAccount(t), newAccount(t), newAccount(t)
+	c.mine(deploy(t, foodBank))
+	c.mine(registerUser(t, foodBank, user))
+	c.mine(registerUser(t, foodBank, orphan))
+
+	i := newIndexer(t, c, h)
+	if err := i.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The last block is replaced by one registering another user
+	c.reorg(1)
+	foodBank.nonce--
+	c.mine(registerUser(t, foodBank, other))
+	c.mine()
+
+	err := i.Sync(context.Background())
+	if !errors.Is(err, ErrReorg) {
+		t.Fatalf("reorganised block: got %v, want %v", err, ErrReorg)
+	}
+	if next := i.NextBlock(); next != 4 {
+		t.Fatalf("next block is %d, expected 4", next)
+	}
+	requireRoot(t, i, types.RoleUser, expectedRoot(t, h, user, orphan))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := i.Run(ctx); !errors.Is(err, ErrReorg) {
+		t.Fatalf("run after a reorganisation: got %v, want %v", err, ErrReorg)
+	}
+
+	// A restart indexes the canonical chain
+	restarted := newIndexer(t, c, h)
+	if err := restarted.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	requireRoot(t, restarted, types.RoleUser, expectedRoot(t, h, user, other))
+}
+
+func TestSyncReorgWithinConfirmations(t *testing.T) {
+	h := newHasher(t)
+	c := newChain(t)
+	foodBank, user, orphan := newAccount(t), newAccount(t), newAccount(t)
+	c.mine(deploy(t, foodBank))
+	c.mine(registerUser(t, foodBank, user))
+
+	i := newIndexer(t, c, h)
+	i.SetConfirmations(1)
+	c.mine(registerUser(t, foodBank, orphan))
+	if err := i.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The unconfirmed block is replaced before being indexed
+	c.reorg(1)
+	c.mine()
+	c.mine()
+	if err := i.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	requireRoot(t, i, types.RoleUser, expectedRoot(t, h, user))
+}
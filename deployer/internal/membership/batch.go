@@ -0,0 +1,181 @@
+package membership
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/hasher"
+	"deployer/internal/metrics"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInvalidRegistration = errors.New("invalid registration")
+
+// Registration is a new user and the zkEthereumAddress proof of its address.
+type Registration struct {
+	Line    int // line in the CSV, 0 when built otherwise
+	Account common.Address
+	Proof   *zkp.ZKProof
+}
+
+// ReadRegistrations reads a CSV of new users, one "address,proof" row each, an optional header
+// row first. The proof is the snarkjs JSON, the snarkjs soliditycalldata, the 0x-hex compact
+// encoding, or the path of a snarkjs JSON file relative to the CSV. Every proof must be the
+// zkEthereumAddress proof of its address and no address may repeat: registerUsers reverts the
+// whole batch otherwise.
+func ReadRegistrations(path string, h hasher.Hasher) ([]Registration, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open registrations: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+
+	var registrations []Registration
+	seen := make(map[common.Address]int)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRegistration, err)
+		}
+		line, _ := r.FieldPos(0)
+
+		if !common.IsHexAddress(record[0]) {
+			if line == 1 && strings.EqualFold(record[0], "address") {
+				continue // Header
+			}
+			return nil, fmt.Errorf("%w: line %d: invalid address %q", ErrInvalidRegistration, line, record[0])
+		}
+		account := common.HexToAddress(record[0])
+		if first, ok := seen[account]; ok {
+			return nil, fmt.Errorf("%w: line %d: %s already on line %d", ErrInvalidRegistration, line, account.Hex(), first)
+		}
+		seen[account] = line
+
+		proof, err := parseProofCell(record[1], filepath.Dir(path))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidRegistration, line, err)
+		}
+		if err := checkAddressProof(h, account, proof); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidRegistration, line, err)
+		}
+		registrations = append(registrations, Registration{Line: line, Account: account, Proof: proof})
+	}
+	return registrations, nil
+}
+
+// ReadAddressProof decodes a proof in any of the encodings of ReadRegistrations, a path being
+// relative to the working directory, and checks it is the zkEthereumAddress proof of account.
+func ReadAddressProof(s string, h hasher.Hasher, account common.Address) (*zkp.ZKProof, error) {
+	proof, err := parseProofCell(s, ".")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAddressProof(h, account, proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// parseProofCell decodes a proof in any of the encodings of ReadRegistrations.
+func parseProofCell(cell, dir string) (*zkp.ZKProof, error) {
+	cell = strings.TrimSpace(cell)
+	switch {
+	case strings.HasPrefix(cell, "{"):
+		return zkp.ParseSnarkJS([]byte(cell))
+	case strings.HasPrefix(cell, "["):
+		return zkp.ParseSolidityCallData(cell)
+	case strings.HasPrefix(cell, "0x"):
+		data, err := hex.DecodeString(cell[2:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", zkp.ErrInvalidProof, err)
+		}
+		proof := zkp.NewZKProof()
+		if err := proof.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return proof, nil
+	default:
+		if !filepath.IsAbs(cell) {
+			cell = filepath.Join(dir, cell)
+		}
+		data, err := os.ReadFile(cell)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read proof: %w", err)
+		}
+		return zkp.ParseSnarkJS(data)
+	}
+}
+
+// checkAddressProof checks the public signals the contract requires of a zkEthereumAddress
+// proof of account: its hashed address, then zero.
+func checkAddressProof(h hasher.Hasher, account common.Address, proof *zkp.ZKProof) error {
+	var signals [types.PINACLE_PUBLIC_SIGNALS]*big.Int
+	if err := proof.ConvertPublicSignalsTo(signals[:]); err != nil {
+		return err
+	}
+	if signals[1].Sign() != 0 {
+		return fmt.Errorf("not a zkEthereumAddress proof")
+	}
+	if signals[0].Cmp(h.HashAddress(&account)) != 0 {
+		return fmt.Errorf("proof of another address than %s", account.Hex())
+	}
+	return nil
+}
+
+// RegisterUsers adds many users with one registerUsers transaction, all or none.
+func (s *Service) RegisterUsers(ctx context.Context, registrations []Registration) (*Receipt, error) {
+	if len(registrations) == 0 {
+		return nil, fmt.Errorf("%w: empty batch", ErrInvalidRegistration)
+	}
+
+	accounts := make([]common.Address, len(registrations))
+	accountProofs := make([]zklogin.ZkLoginGroth16Proof, len(registrations))
+	accountPublicSignals := make([][2]*big.Int, len(registrations))
+	for i, registration := range registrations {
+		proof, publicSignals, err := convert(registration.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", registration.Account.Hex(), err)
+		}
+		accounts[i], accountProofs[i], accountPublicSignals[i] = registration.Account, *proof, publicSignals
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	operatorProof, operatorPublicSignals, err := s.operator.MerkleTreeProof(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove operator membership: %w", err)
+	}
+
+	trOpts, err := s.transactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.contract.RegisterUsers(trOpts, *operatorProof, operatorPublicSignals, accounts, accountProofs, accountPublicSignals)
+	metrics.ObserveVerification("register_batch", err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+
+	return s.waitMined(ctx, tx)
+}
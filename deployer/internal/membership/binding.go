@@ -0,0 +1,52 @@
+package membership
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrStaleBinding is returned when the bytecode of the zkLogin binding was not compiled from the
+// zkLogin.sol its ABI was generated from. Regenerate it with `go run cmd/main.go compile` and
+// `go run cmd/main.go abigen`.
+var ErrStaleBinding = errors.New("zkLogin binding bytecode does not implement its ABI")
+
+// MissingMethods returns the methods of the zkLogin ABI whose selector the dispatcher of the
+// binding bytecode does not push, sorted by name.
+func MissingMethods() ([]string, error) {
+	parsed, err := zklogin.ZkloginMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse zkLogin ABI: %w", err)
+	}
+	bin := common.FromHex(zklogin.ZkloginMetaData.Bin)
+
+	var missing []string
+	for name, method := range parsed.Methods {
+		// solc pushes a selector with its leading zero bytes trimmed, PUSHn being 0x5f + n
+		selector := bytes.TrimLeft(method.ID, "\x00")
+		push := append([]byte{0x5f + byte(len(selector))}, selector...)
+		if !bytes.Contains(bin, push) {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// CheckBinding returns ErrStaleBinding, with the missing methods, when the zkLogin binding is stale.
+func CheckBinding() error {
+	missing, err := MissingMethods()
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing %s", ErrStaleBinding, strings.Join(missing, ", "))
+	}
+	return nil
+}
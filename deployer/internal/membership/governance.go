@@ -0,0 +1,203 @@
+package membership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrUnknownProposal = errors.New("unknown proposal")
+
+// ProposalAction is what a food bank proposal does once executed.
+type ProposalAction uint8
+
+const (
+	ProposalAddFoodBank       ProposalAction = iota // ADD_FOODBANK
+	ProposalTerminateFoodBank                       // TERMINATE_FOODBANK
+)
+
+func (a ProposalAction) String() string {
+	switch a {
+	case ProposalAddFoodBank:
+		return "add"
+	case ProposalTerminateFoodBank:
+		return "terminate"
+	default:
+		return fmt.Sprintf("ProposalAction(%d)", uint8(a))
+	}
+}
+
+// Proposal is a food bank proposal as stored by zkLogin. A food bank is added by address and
+// terminated by hashed address.
+type Proposal struct {
+	ID            common.Hash
+	Action        ProposalAction
+	Account       common.Address // ProposalAddFoodBank
+	HashedAddress *big.Int       // ProposalTerminateFoodBank
+	ExpiresAt     time.Time
+	Approvals     uint32 // approvals of the food banks still active
+	Executed      bool
+	Approved      bool // approved by the operator
+}
+
+// Expired reports whether the proposal can no longer be approved or executed at now.
+func (p *Proposal) Expired(now time.Time) bool {
+	return !now.Before(p.ExpiresAt)
+}
+
+// Governance is the number of approvals a proposal needs and how long it stays open.
+type Governance struct {
+	Quorum   uint32
+	Lifetime time.Duration
+}
+
+// Proposed is the outcome of a proposal transaction.
+type Proposed struct {
+	*Receipt
+	ID common.Hash
+}
+
+var proposalArguments = abi.Arguments{
+	{Type: mustType("uint8")},
+	{Type: mustType("address")},
+	{Type: mustType("uint256")},
+	{Type: mustType("uint64")},
+}
+
+func mustType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// ProposalID returns the ID zkLogin gives a proposal: keccak256(abi.encode(action, account,
+// hashedAddress, expiresAt)), expiresAt being the proposal block timestamp plus the proposal
+// lifetime. The unused subject is zero, a nil hashedAddress included.
+func ProposalID(action ProposalAction, account common.Address, hashedAddress *big.Int, expiresAt uint64) common.Hash {
+	if hashedAddress == nil {
+		hashedAddress = new(big.Int)
+	}
+	packed, err := proposalArguments.Pack(uint8(action), account, hashedAddress, expiresAt)
+	if err != nil {
+		panic(err) // The arguments always match their types
+	}
+	return crypto.Keccak256Hash(packed)
+}
+
+// Governance returns the food bank governance zkLogin was deployed with.
+func (s *Service) Governance(ctx context.Context) (*Governance, error) {
+	quorum, lifetime, err := s.contract.FetchGovernance(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch governance: %w", err)
+	}
+	return &Governance{Quorum: quorum, Lifetime: time.Duration(lifetime) * time.Second}, nil
+}
+
+// ProposeFoodBank proposes to add account as a food bank, given its zkEthereumAddress proof.
+// The proposal counts the operator's approval.
+func (s *Service) ProposeFoodBank(ctx context.Context, account common.Address, proof *zkp.ZKProof) (*Proposed, error) {
+	accountProof, accountPublicSignals, err := convert(proof)
+	if err != nil {
+		return nil, err
+	}
+	return s.propose(ctx, ProposalAddFoodBank, account, nil, func(trOpts *bind.TransactOpts, operatorProof zklogin.ZkLoginGroth16Proof, operatorPublicSignals [2]*big.Int) (*ethtypes.Transaction, error) {
+		return s.contract.ProposeFoodBank(trOpts, operatorProof, operatorPublicSignals, account, *accountProof, accountPublicSignals)
+	})
+}
+
+// ProposeFoodBankTermination proposes to terminate the food bank of the hashed address.
+// The proposal counts the operator's approval.
+func (s *Service) ProposeFoodBankTermination(ctx context.Context, hashedAddress *big.Int) (*Proposed, error) {
+	return s.propose(ctx, ProposalTerminateFoodBank, common.Address{}, hashedAddress, func(trOpts *bind.TransactOpts, operatorProof zklogin.ZkLoginGroth16Proof, operatorPublicSignals [2]*big.Int) (*ethtypes.Transaction, error) {
+		return s.contract.ProposeFoodBankTermination(trOpts, operatorProof, operatorPublicSignals, hashedAddress)
+	})
+}
+
+// ApproveProposal adds the operator's approval to a pending proposal.
+func (s *Service) ApproveProposal(ctx context.Context, id common.Hash) (*Receipt, error) {
+	return s.transactAsOperator(ctx, "approve_proposal", func(trOpts *bind.TransactOpts, operatorProof zklogin.ZkLoginGroth16Proof, operatorPublicSignals [2]*big.Int) (*ethtypes.Transaction, error) {
+		return s.contract.ApproveProposal(trOpts, operatorProof, operatorPublicSignals, id)
+	})
+}
+
+// ExecuteProposal adds or terminates the food bank of a proposal approved by the quorum.
+func (s *Service) ExecuteProposal(ctx context.Context, id common.Hash) (*Receipt, error) {
+	return s.transactAsOperator(ctx, "execute_proposal", func(trOpts *bind.TransactOpts, operatorProof zklogin.ZkLoginGroth16Proof, operatorPublicSignals [2]*big.Int) (*ethtypes.Transaction, error) {
+		return s.contract.ExecuteProposal(trOpts, operatorProof, operatorPublicSignals, id)
+	})
+}
+
+// Proposal returns a proposal, ErrUnknownProposal if zkLogin has none with that ID.
+func (s *Service) Proposal(ctx context.Context, id common.Hash) (*Proposal, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	stored, err := s.contract.FetchProposal(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrUnknownProposal, id.Hex(), err)
+	}
+
+	operator := s.operator.Address()
+	approved, err := s.contract.HasApproved(opts, id, s.hasher.HashAddress(&operator))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch approval of %s: %w", id.Hex(), err)
+	}
+
+	return &Proposal{
+		ID:            id,
+		Action:        ProposalAction(stored.Action),
+		Account:       stored.Account,
+		HashedAddress: stored.HashedAddress,
+		ExpiresAt:     time.Unix(int64(stored.ExpiresAt), 0).UTC(),
+		Approvals:     stored.Approvals,
+		Executed:      stored.Executed,
+		Approved:      approved,
+	}, nil
+}
+
+// Proposals returns every proposal, oldest first.
+func (s *Service) Proposals(ctx context.Context) ([]*Proposal, error) {
+	ids, err := s.contract.FetchProposals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch proposals: %w", err)
+	}
+
+	proposals := make([]*Proposal, len(ids))
+	for i, id := range ids {
+		if proposals[i], err = s.Proposal(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return proposals, nil
+}
+
+// propose sends a proposal and derives its ID from the timestamp of the block it was mined in.
+func (s *Service) propose(ctx context.Context, action ProposalAction, account common.Address, hashedAddress *big.Int, send operatorTransaction) (*Proposed, error) {
+	governance, err := s.Governance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := s.transactAsOperator(ctx, "propose", send)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := s.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(receipt.BlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block %d of the proposal: %w", receipt.BlockNumber, err)
+	}
+	id := ProposalID(action, account, hashedAddress, header.Time+uint64(governance.Lifetime/time.Second))
+	return &Proposed{Receipt: receipt, ID: id}, nil
+}
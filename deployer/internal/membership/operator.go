@@ -0,0 +1,199 @@
+package membership
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/logger"
+	"deployer/internal/sign"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Operator is the registered food bank account the gateway acts as.
+// Every protected zkLogin function requires the transactor's own zkMerkleTree proof,
+// so the operator generates it with the prover before each operation.
+type Operator struct {
+	mu        sync.Mutex
+	key       *ecdsa.PrivateKey
+	address   common.Address
+	registers *types.Registers
+	prover    *zkp.Prover
+	contract  *zklogin.Zklogin
+
+	// Optional cache of the zkMerkleTree proof, looked up with the current food bank root
+	cache         *zkp.ProofCache
+	roots         RootSource
+	hashedAddress *big.Int
+}
+
+// RootSource gives the current root of the tree of a role, e.g. the indexer.
+type RootSource interface {
+	Root(role types.Role) (*big.Int, bool)
+}
+
+// NewOperator creates an Operator from the food bank private key.
+func NewOperator(key *ecdsa.PrivateKey, prover *zkp.Prover, contract *zklogin.Zklogin) *Operator {
+	return &Operator{
+		key:       key,
+		address:   crypto.PubkeyToAddress(key.PublicKey),
+		registers: sign.BigIntToRegisters(key.D),
+		prover:    prover,
+		contract:  contract,
+	}
+}
+
+// Address returns the operator address.
+func (o *Operator) Address() common.Address {
+	return o.address
+}
+
+// PrivateKey returns the operator key used to sign transactions.
+func (o *Operator) PrivateKey() *ecdsa.PrivateKey {
+	return o.key
+}
+
+// UseProofCache makes MerkleTreeProof reuse the cached proof of the current root of roots.
+func (o *Operator) UseProofCache(cache *zkp.ProofCache, roots RootSource, hashedAddress *big.Int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.cache, o.roots, o.hashedAddress = cache, roots, hashedAddress
+}
+
+// MerkleTreeProof generates the operator's zkMerkleTree proof:
+// zkEthereumAddress proof -> fetchFoodBankMerkleProofs -> zkMerkleTree proof.
+// With a proof cache, a proof of the current food bank root is reused.
+func (o *Operator) MerkleTreeProof(ctx context.Context) (*zklogin.ZkLoginGroth16Proof, [2]*big.Int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.cache != nil {
+		if root, ok := o.roots.Root(types.RoleFoodBank); ok {
+			if proof, ok := o.cache.Get(zkp.NewProofKey(o.prover.CircuitHash(), o.hashedAddress, root)); ok {
+				return convertOwn(proof)
+			}
+		}
+	}
+
+	proofs, err := o.merkleTreeProof(ctx, "fetchFoodBankMerkleProofs", o.contract.FetchFoodBankMerkleProofs)
+	if err != nil {
+		return nil, [2]*big.Int{}, err
+	}
+	merkleTreeProof, merkleTreePublicSignals, err := convertOwn(proofs)
+	if err != nil {
+		return nil, [2]*big.Int{}, fmt.Errorf("zkMerkleTree: %w", err)
+	}
+
+	if o.cache != nil {
+		key := zkp.NewProofKey(o.prover.CircuitHash(), merkleTreePublicSignals[0], merkleTreePublicSignals[1])
+		if err := o.cache.Put(key, proofs); err != nil {
+			logger.Logger.Warn().Err(err).Msg("Failed to cache the operator proof")
+		}
+	}
+
+	return merkleTreeProof, merkleTreePublicSignals, nil
+}
+
+// MemberMerkleTreeProof generates the zkMerkleTree proof of the account as a member of role:
+// zkEthereumAddress proof -> fetchMemberMerkleProofs -> zkMerkleTree proof. The proof cache,
+// kept for the food bank tree, is not used.
+func (o *Operator) MemberMerkleTreeProof(ctx context.Context, role types.Role) (*zklogin.ZkLoginGroth16Proof, [2]*big.Int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	proofs, err := o.merkleTreeProof(ctx, "fetchMemberMerkleProofs", func(opts *bind.CallOpts, proof zklogin.ZkLoginGroth16Proof, publicSignals [2]*big.Int) (zklogin.MerkleTreeWithHistoryMerkleProof, error) {
+		return o.contract.FetchMemberMerkleProofs(opts, uint32(role), proof, publicSignals)
+	})
+	if err != nil {
+		return nil, [2]*big.Int{}, err
+	}
+	merkleTreeProof, merkleTreePublicSignals, err := convertOwn(proofs)
+	if err != nil {
+		return nil, [2]*big.Int{}, fmt.Errorf("zkMerkleTree: %w", err)
+	}
+	return merkleTreeProof, merkleTreePublicSignals, nil
+}
+
+// EthereumAddressProof generates the zkEthereumAddress proof of the account.
+func (o *Operator) EthereumAddressProof() (*zklogin.ZkLoginGroth16Proof, [2]*big.Int, error) {
+	proof, publicSignals, err := o.prove(zkp.NewZKP())
+	if err != nil {
+		return nil, [2]*big.Int{}, fmt.Errorf("zkEthereumAddress: %w", err)
+	}
+	return proof, publicSignals, nil
+}
+
+// merkleTreeProof fetches the merkle path of the account with its zkEthereumAddress proof and
+// generates the zkMerkleTree proof of the path.
+func (o *Operator) merkleTreeProof(ctx context.Context, method string, fetch func(*bind.CallOpts, zklogin.ZkLoginGroth16Proof, [2]*big.Int) (zklogin.MerkleTreeWithHistoryMerkleProof, error)) (*zkp.ZKProof, error) {
+	// zkEthereumAddress (empty path)
+	addressProof, addressPublicSignals, err := o.EthereumAddressProof()
+	if err != nil {
+		return nil, err
+	}
+
+	merkleProofs, err := fetch(&bind.CallOpts{From: o.address, Context: ctx}, *addressProof, addressPublicSignals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	if len(merkleProofs.PathElements) != types.LEVELS || len(merkleProofs.PathIndices) != types.LEVELS {
+		return nil, fmt.Errorf("unexpected merkle proof length %d", len(merkleProofs.PathElements))
+	}
+
+	// zkMerkleTree
+	input := zkp.NewZKP()
+	input.SetPathElement([types.LEVELS]*big.Int(merkleProofs.PathElements))
+	input.SetPathIndices([types.LEVELS]*big.Int(merkleProofs.PathIndices))
+	proofs, err := o.generate(input)
+	if err != nil {
+		return nil, fmt.Errorf("zkMerkleTree: %w", err)
+	}
+	return proofs, nil
+}
+
+// prove generates a proof of the input and converts it for the contract.
+func (o *Operator) prove(input *zkp.PinacleZKP) (*zklogin.ZkLoginGroth16Proof, [2]*big.Int, error) {
+	proofs, err := o.generate(input)
+	if err != nil {
+		return nil, [2]*big.Int{}, err
+	}
+	return convertOwn(proofs)
+}
+
+// generate sets the operator key on the input and runs the prover.
+func (o *Operator) generate(input *zkp.PinacleZKP) (*zkp.ZKProof, error) {
+	input.SetPrivateKey(o.registers)
+	inputJSON, err := input.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert zkp input to bytes: %w", err)
+	}
+
+	proofs, err := o.prover.GenerateProofs(inputJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate zk proofs: %w", err)
+	}
+	return proofs, nil
+}
+
+// convertOwn converts a proof of the operator for the contract.
+func convertOwn(proofs *zkp.ZKProof) (*zklogin.ZkLoginGroth16Proof, [2]*big.Int, error) {
+	proofConverted, err := proofs.ConvertProof()
+	if err != nil {
+		return nil, [2]*big.Int{}, fmt.Errorf("failed to convert zk proofs: %w", err)
+	}
+
+	var publicSignals [types.PINACLE_PUBLIC_SIGNALS]*big.Int
+	if err := proofs.ConvertPublicSignalsTo(publicSignals[:]); err != nil {
+		return nil, [2]*big.Int{}, fmt.Errorf("failed to convert zk public signals: %w", err)
+	}
+
+	return proofConverted, publicSignals, nil
+}
@@ -0,0 +1,43 @@
+package membership
+
+import (
+	"time"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// RoleRegistry returns types.Roles as the _roles and _permissions of the zkLogin constructor.
+func RoleRegistry() ([]zklogin.ZkLoginRoleDefinition, []zklogin.ZkLoginRolePermission) {
+	var (
+		definitions []zklogin.ZkLoginRoleDefinition
+		permissions []zklogin.ZkLoginRolePermission
+	)
+	for _, def := range types.Roles() {
+		definitions = append(definitions, zklogin.ZkLoginRoleDefinition{Id: uint32(def.Role), Tree: def.Tree, Subtree: def.Subtree})
+		for _, role := range def.Registers {
+			permissions = append(permissions, zklogin.ZkLoginRolePermission{Registrar: uint32(def.Role), Role: uint32(role)})
+		}
+	}
+	return definitions, permissions
+}
+
+// DeployZkLogin deploys zkLogin with the trees of the role registry, each levels deep, the
+// initial food banks, and the approvals and lifetime of the food bank proposals. A stale binding
+// is refused, its bytecode lacking methods the Go code calls.
+func DeployZkLogin(auth *bind.TransactOpts, backend bind.ContractBackend, levels uint32, hasher, verifier common.Address, foodBanks []common.Address, quorum uint32, lifetime time.Duration) (common.Address, *ethtypes.Transaction, *zklogin.Zklogin, error) {
+	if err := CheckBinding(); err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	subtrees := types.RoleTrees()
+	treeLevels := make([]uint32, len(subtrees))
+	for i := range treeLevels {
+		treeLevels[i] = levels
+	}
+	definitions, permissions := RoleRegistry()
+	return zklogin.DeployZklogin(auth, backend, uint32(len(subtrees)), subtrees, treeLevels, hasher, verifier, foodBanks, definitions, permissions, quorum, uint64(lifetime/time.Second))
+}
@@ -0,0 +1,113 @@
+package membership
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"deployer/internal/metrics"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Rotation is the outcome of a key rotation.
+type Rotation struct {
+	*Receipt
+	Role             types.Role
+	OldHashedAddress *big.Int
+	NewHashedAddress *big.Int
+}
+
+var keyRotationArguments = abi.Arguments{
+	{Type: mustType("address")},
+	{Type: mustType("uint256")},
+	{Type: mustType("uint32")},
+	{Type: mustType("address")},
+}
+
+// KeyRotationHash returns the hash the old key signs to rotate its membership of role to
+// newMember, as keyRotationHash of zkLogin: the EIP-191 hash of abi.encode(zkLogin, chainID,
+// role, newMember).
+func KeyRotationHash(zkLogin common.Address, chainID *big.Int, role types.Role, newMember common.Address) []byte {
+	packed, err := keyRotationArguments.Pack(zkLogin, chainID, uint32(role), newMember)
+	if err != nil {
+		panic(err) // The arguments always match their types
+	}
+	return accounts.TextHash(crypto.Keccak256(packed))
+}
+
+// RotateKey moves the membership of role from oldKey to the operator key, e.g. after a change of
+// device. The old key proves its membership and signs over the operator address, the operator
+// proves its address and sends the transaction. zkLogin revokes the old key and keeps the link
+// between both keys, the new one joining the list of the food bank of the old one.
+// Food banks cannot rotate their keys.
+func (s *Service) RotateKey(ctx context.Context, role types.Role, oldKey *ecdsa.PrivateKey) (*Rotation, error) {
+	if !role.Valid() || role == types.RoleFoodBank {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedRole, role)
+	}
+
+	old := NewOperator(oldKey, s.operator.prover, s.contract)
+	oldProof, oldPublicSignals, err := old.MemberMerkleTreeProof(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove membership of the old key: %w", err)
+	}
+
+	signature, err := crypto.Sign(KeyRotationHash(s.address, s.chainID, role, s.operator.Address()), oldKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the key rotation: %w", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	newProof, newPublicSignals, err := s.operator.EthereumAddressProof()
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove the new key: %w", err)
+	}
+
+	trOpts, err := s.transactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gas estimation simulates the call, so a rejected proof or signature surfaces here with the revert reason
+	tx, err := s.contract.RotateKey(trOpts, uint32(role), old.Address(), *oldProof, oldPublicSignals, *newProof, newPublicSignals, signature)
+	metrics.ObserveVerification("rotate_key", err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+
+	receipt, err := s.waitMined(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &Rotation{
+		Receipt:          receipt,
+		Role:             role,
+		OldHashedAddress: oldPublicSignals[0],
+		NewHashedAddress: newPublicSignals[0],
+	}, nil
+}
+
+// KeyRotation returns the hashed addresses of the previous and next keys of the hashed address,
+// nil where the key has not been rotated.
+func (s *Service) KeyRotation(ctx context.Context, hashedAddress *big.Int) (previous, next *big.Int, err error) {
+	previous, next, err = s.contract.FetchKeyRotation(&bind.CallOpts{Context: ctx}, hashedAddress)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch key rotation of %s: %w", hashedAddress, err)
+	}
+	if previous.Sign() == 0 {
+		previous = nil
+	}
+	if next.Sign() == 0 {
+		next = nil
+	}
+	return previous, next, nil
+}
@@ -0,0 +1,376 @@
+package membership
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/ethutil"
+	"deployer/internal/hasher"
+	"deployer/internal/metrics"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrProofRejected      = errors.New("proof rejected by zkLogin")
+	ErrTransactionFailed  = errors.New("transaction reverted")
+	ErrInvalidTransaction = errors.New("invalid termination transaction")
+	ErrUnsupportedRole    = errors.New("unsupported role")
+)
+
+// Backend is the chain access needed to send and track zkLogin transactions.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// Receipt summarises a mined zkLogin transaction.
+type Receipt struct {
+	Hash        common.Hash
+	BlockNumber uint64
+	GasUsed     uint64
+}
+
+// Termination is the outcome of a relayed terminateUser/terminateFoodBank transaction.
+type Termination struct {
+	*Receipt
+	Role          types.Role
+	HashedAddress *big.Int
+}
+
+// Service implements the register/verify/terminate operations shared by the REST and gRPC APIs.
+type Service struct {
+	mu       sync.Mutex // the operator sends one transaction at a time
+	backend  Backend
+	chainID  *big.Int
+	address  common.Address
+	contract *zklogin.Zklogin
+	abi      *abi.ABI
+	operator *Operator
+	hasher   hasher.Hasher
+}
+
+// NewService binds the service to a deployed zkLogin contract.
+func NewService(backend Backend, chainID *big.Int, address common.Address, operatorKey *ecdsa.PrivateKey, prover *zkp.Prover, hasher hasher.Hasher) (*Service, error) {
+	contract, err := zklogin.NewZklogin(address, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind zkLogin: %w", err)
+	}
+
+	parsed, err := zklogin.ZkloginMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse zkLogin ABI: %w", err)
+	}
+
+	return &Service{
+		backend:  backend,
+		chainID:  chainID,
+		address:  address,
+		contract: contract,
+		abi:      parsed,
+		operator: NewOperator(operatorKey, prover, contract),
+		hasher:   hasher,
+	}, nil
+}
+
+// Operator returns the food bank account the service acts as.
+func (s *Service) Operator() *Operator {
+	return s.operator
+}
+
+// UseProofCache caches the operator zkMerkleTree proof, reused while the food bank root of roots
+// does not change.
+func (s *Service) UseProofCache(cache *zkp.ProofCache, roots RootSource) {
+	address := s.operator.Address()
+	s.operator.UseProofCache(cache, roots, s.hasher.HashAddress(&address))
+}
+
+// Register adds a member of role, given the new account's zkEthereumAddress proof. The operator,
+// a food bank, must be permitted to register the role.
+func (s *Service) Register(ctx context.Context, role types.Role, account common.Address, proof *zkp.ZKProof) (*Receipt, error) {
+	accountProof, accountPublicSignals, err := convert(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	operatorProof, operatorPublicSignals, err := s.operator.MerkleTreeProof(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove operator membership: %w", err)
+	}
+
+	trOpts, err := s.transactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gas estimation simulates the call, so a rejected proof surfaces here with the revert reason
+	var tx *ethtypes.Transaction
+	switch role {
+	case types.RoleFoodBank:
+		tx, err = s.contract.RegisterFoodBank(trOpts, *operatorProof, operatorPublicSignals, account, *accountProof, accountPublicSignals)
+	case types.RoleUser:
+		tx, err = s.contract.RegisterUser(trOpts, *operatorProof, operatorPublicSignals, account, *accountProof, accountPublicSignals)
+	default:
+		if !types.RoleFoodBank.CanRegister(role) {
+			return nil, fmt.Errorf("%w: %d", ErrUnsupportedRole, role)
+		}
+		tx, err = s.contract.RegisterMember(trOpts, uint32(types.RoleFoodBank), *operatorProof, operatorPublicSignals, uint32(role), account, *accountProof, accountPublicSignals)
+	}
+	metrics.ObserveVerification("register", err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+
+	return s.waitMined(ctx, tx)
+}
+
+// VerifyUser checks a user's zkMerkleTree proof with the contract's verifyProof.
+// A nil error means the proof is valid; a rejection wraps ErrProofRejected with the revert reason.
+func (s *Service) VerifyUser(ctx context.Context, user common.Address, proof *zkp.ZKProof) error {
+	userProof, userPublicSignals, err := convert(proof)
+	if err != nil {
+		return err
+	}
+
+	operatorProof, operatorPublicSignals, err := s.operator.MerkleTreeProof(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prove operator membership: %w", err)
+	}
+
+	ok, err := s.contract.VerifyProof(&bind.CallOpts{From: s.operator.Address(), Context: ctx}, *operatorProof, operatorPublicSignals, user, *userProof, userPublicSignals)
+	metrics.ObserveVerification("contract", err)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+	if !ok {
+		return ErrProofRejected
+	}
+	return nil
+}
+
+// VerifyUsers checks the zkMerkleTree proofs of many users with one verifyProofs call and returns
+// the result of each. A proof that cannot be converted is invalid without being sent.
+func (s *Service) VerifyUsers(ctx context.Context, users []common.Address, proofs []*zkp.ZKProof) ([]bool, error) {
+	if len(users) != len(proofs) {
+		return nil, fmt.Errorf("%d users for %d proofs", len(users), len(proofs))
+	}
+
+	sent := make([]int, 0, len(users))
+	var (
+		batchUsers         []common.Address
+		batchProofs        []zklogin.ZkLoginGroth16Proof
+		batchPublicSignals [][2]*big.Int
+	)
+	for i, proof := range proofs {
+		userProof, userPublicSignals, err := convert(proof)
+		if err != nil {
+			continue
+		}
+		sent = append(sent, i)
+		batchUsers = append(batchUsers, users[i])
+		batchProofs = append(batchProofs, *userProof)
+		batchPublicSignals = append(batchPublicSignals, userPublicSignals)
+	}
+
+	results := make([]bool, len(users))
+	if len(sent) == 0 {
+		return results, nil
+	}
+
+	operatorProof, operatorPublicSignals, err := s.operator.MerkleTreeProof(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove operator membership: %w", err)
+	}
+
+	bitmap, err := s.contract.VerifyProofs(&bind.CallOpts{From: s.operator.Address(), Context: ctx}, *operatorProof, operatorPublicSignals, batchUsers, batchProofs, batchPublicSignals)
+	metrics.ObserveVerification("contract_batch", err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+	if len(bitmap) != (len(sent)+255)/256 {
+		return nil, fmt.Errorf("unexpected result bitmap of %d words for %d proofs", len(bitmap), len(sent))
+	}
+	for j, i := range sent {
+		results[i] = bitmap[j/256].Bit(j%256) == 1
+	}
+	return results, nil
+}
+
+// Terminate relays a terminateUser/terminateFoodBank/terminateMember transaction signed by the account owner
+// (the contract terminates msg.sender, so the gateway cannot sign it on the owner's behalf).
+func (s *Service) Terminate(ctx context.Context, rawTx []byte) (*Termination, error) {
+	tx := new(ethtypes.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+
+	if tx.To() == nil || *tx.To() != s.address {
+		return nil, fmt.Errorf("%w: not a zkLogin transaction", ErrInvalidTransaction)
+	}
+	if len(tx.Data()) < 4 {
+		return nil, fmt.Errorf("%w: missing method selector", ErrInvalidTransaction)
+	}
+
+	method, err := s.abi.MethodById(tx.Data()[:4])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+
+	var role types.Role
+	switch method.Name {
+	case "terminateFoodBank":
+		role = types.RoleFoodBank
+	case "terminateUser":
+		role = types.RoleUser
+	case "terminateMember":
+		args, err := method.Inputs.Unpack(tx.Data()[4:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+		}
+		id, ok := args[0].(uint32)
+		if !ok || id != uint32(types.Role(id)) || !types.Role(id).Valid() {
+			return nil, fmt.Errorf("%w: unknown role %v", ErrInvalidTransaction, args[0])
+		}
+		role = types.Role(id)
+	default:
+		return nil, fmt.Errorf("%w: unexpected method %s", ErrInvalidTransaction, method.Name)
+	}
+
+	sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(s.chainID), tx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+
+	if err := s.backend.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	receipt, err := s.waitMined(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Termination{
+		Receipt:       receipt,
+		Role:          role,
+		HashedAddress: s.hasher.HashAddress(&sender),
+	}, nil
+}
+
+// Revoke revokes the member of role with the hashed address, which the operator food bank must have
+// registered. Food banks added by proposal or at deployment are only terminated by proposal
+// (ProposeFoodBankTermination).
+func (s *Service) Revoke(ctx context.Context, role types.Role, hashedAddress *big.Int) (*Receipt, error) {
+	if !types.RoleFoodBank.CanRegister(role) {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedRole, role)
+	}
+	return s.transactAsOperator(ctx, "revoke", func(trOpts *bind.TransactOpts, operatorProof zklogin.ZkLoginGroth16Proof, operatorPublicSignals [2]*big.Int) (*ethtypes.Transaction, error) {
+		return s.contract.RevokeMember(trOpts, uint32(types.RoleFoodBank), operatorProof, operatorPublicSignals, uint32(role), hashedAddress)
+	})
+}
+
+// Anchor publishes data on-chain with a transaction from the operator to itself.
+func (s *Service) Anchor(ctx context.Context, data []byte) (*Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trOpts, err := s.transactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	self := bind.NewBoundContract(s.operator.Address(), abi.ABI{}, s.backend, s.backend, s.backend)
+	tx, err := self.RawTransact(trOpts, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send anchor transaction: %w", err)
+	}
+
+	return s.waitMined(ctx, tx)
+}
+
+// operatorTransaction sends a transaction authorised by the operator's zkMerkleTree proof.
+type operatorTransaction func(trOpts *bind.TransactOpts, operatorProof zklogin.ZkLoginGroth16Proof, operatorPublicSignals [2]*big.Int) (*ethtypes.Transaction, error)
+
+// transactAsOperator proves the operator membership, sends the transaction and waits for it.
+func (s *Service) transactAsOperator(ctx context.Context, metric string, send operatorTransaction) (*Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	operatorProof, operatorPublicSignals, err := s.operator.MerkleTreeProof(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove operator membership: %w", err)
+	}
+
+	trOpts, err := s.transactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gas estimation simulates the call, so a rejected transaction surfaces here with the revert reason
+	tx, err := send(trOpts, *operatorProof, operatorPublicSignals)
+	metrics.ObserveVerification(metric, err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+
+	return s.waitMined(ctx, tx)
+}
+
+// transactor creates transaction options for the operator.
+func (s *Service) transactor(ctx context.Context) (*bind.TransactOpts, error) {
+	trOpts, err := ethutil.NewTransactorFromKeystore(s.operator.PrivateKey(), s.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new Transactor: %w", err)
+	}
+	trOpts.Context = ctx
+	trOpts.Nonce = nil
+	return trOpts, nil
+}
+
+// waitMined waits for the receipt and fails on a reverted transaction.
+func (s *Service) waitMined(ctx context.Context, tx *ethtypes.Transaction) (*Receipt, error) {
+	start := time.Now()
+	receipt, err := bind.WaitMined(ctx, s.backend, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mine tx %s: %w", tx.Hash().Hex(), err)
+	}
+	metrics.TxConfirmationTimer.UpdateSince(start)
+	metrics.ObserveReceipt(receipt.Status == ethtypes.ReceiptStatusSuccessful, receipt.GasUsed)
+
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionFailed, tx.Hash().Hex())
+	}
+
+	return &Receipt{
+		Hash:        receipt.TxHash,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		GasUsed:     receipt.GasUsed,
+	}, nil
+}
+
+// convert turns a snarkjs proof into the contract representation.
+func convert(proof *zkp.ZKProof) (*zklogin.ZkLoginGroth16Proof, [2]*big.Int, error) {
+	proofConverted, err := proof.ConvertProof()
+	if err != nil {
+		return nil, [2]*big.Int{}, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+	var publicSignals [types.PINACLE_PUBLIC_SIGNALS]*big.Int
+	if err := proof.ConvertPublicSignalsTo(publicSignals[:]); err != nil {
+		return nil, [2]*big.Int{}, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+	return proofConverted, publicSignals, nil
+}
@@ -0,0 +1,179 @@
+package merkletree
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"deployer/internal/hasher"
+	"deployer/internal/mimc"
+)
+
+const MaxLevels = 32 // MAXIMUM_ALLOWED_LEVELS in MerkleTreeWithHistory
+
+var (
+	ErrInvalidLevels = errors.New("levels should be (0, 32]")
+	ErrTreeFull      = errors.New("merkle tree is full")
+	ErrZeroLeaf      = errors.New("leaf cannot be zero")
+	ErrLeafNotFound  = errors.New("leaf index out of range")
+
+	// ZeroValue equals keccak256("tornado") % FIELD_SIZE (ZERO_VALUE in MerkleTreeWithHistory)
+	ZeroValue, _ = new(big.Int).SetString("21663839004416932945382355908790599225266501822907911457504978515578255421292", 10)
+)
+
+// Tree is an off-chain replica of a MerkleTreeWithHistory subtree.
+// Insertions follow `_insert` step by step so the roots are identical to the on-chain ones.
+type Tree struct {
+	mu             sync.RWMutex
+	hasher         hasher.Hasher
+	levels         uint32
+	nextIndex      uint32
+	zeros          []*big.Int
+	filledSubtrees []*big.Int
+	leaves         []*big.Int
+	roots          map[string]struct{}
+	root           *big.Int
+}
+
+// NewTree creates an empty tree. Like createTreeWithSubtrees, the initial known root is zeros(levels - 1).
+// Nodes are hashed with h, the zero values are the MiMC chain hard-coded in the contract whatever the hasher.
+func NewTree(levels uint32, h hasher.Hasher) (*Tree, error) {
+	if levels == 0 || levels > MaxLevels {
+		return nil, ErrInvalidLevels
+	}
+
+	zeros, err := contractZeros()
+	if err != nil {
+		return nil, err
+	}
+
+	filledSubtrees := make([]*big.Int, levels)
+	for i := range filledSubtrees {
+		filledSubtrees[i] = zeros[i]
+	}
+
+	root := zeros[levels-1]
+	return &Tree{
+		hasher:         h,
+		levels:         levels,
+		zeros:          zeros,
+		filledSubtrees: filledSubtrees,
+		roots:          map[string]struct{}{root.String(): {}},
+		root:           root,
+	}, nil
+}
+
+var (
+	mimcZerosOnce sync.Once
+	mimcZeros     []*big.Int
+	mimcZerosErr  error
+)
+
+// contractZeros returns the zeroValues of MerkleTreeWithHistory, computed once.
+func contractZeros() ([]*big.Int, error) {
+	mimcZerosOnce.Do(func() {
+		var sponge *mimc.MiMCSponge
+		sponge, mimcZerosErr = mimc.NewMiMCSponge(mimc.Seed, mimc.MimcNbRounds)
+		if mimcZerosErr != nil {
+			return
+		}
+		mimcZeros, mimcZerosErr = Zeros(sponge, MaxLevels)
+	})
+	return mimcZeros, mimcZerosErr
+}
+
+// Zeros returns the zero value of each level: zeros[0] = ZeroValue, zeros[i] = hasher(zeros[i-1], zeros[i-1]).
+func Zeros(h hasher.Hasher, levels int) ([]*big.Int, error) {
+	zeros := make([]*big.Int, levels)
+	current := new(big.Int).Set(ZeroValue)
+	for i := 0; i < levels; i++ {
+		zeros[i] = current
+
+		hash, err := h.HashLeftRight(current, current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash zero value at level %d: %w", i, err)
+		}
+		current = hash.BigInt(new(big.Int))
+	}
+	return zeros, nil
+}
+
+// Insert appends a leaf and returns the new root and the leaf index.
+func (t *Tree) Insert(leaf *big.Int) (*big.Int, uint32, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if leaf == nil || leaf.Sign() == 0 {
+		return nil, 0, ErrZeroLeaf
+	}
+	// The contract compares against pow2(levels) which saturates at 2^32 - 1
+	if uint64(t.nextIndex) >= uint64(1)<<t.levels {
+		return nil, 0, ErrTreeFull
+	}
+
+	index := t.nextIndex
+	currentIndex := index
+	currentLevelHash := new(big.Int).Set(leaf)
+
+	for i := uint32(0); i < t.levels; i++ {
+		var left, right *big.Int
+		if currentIndex%2 == 0 {
+			left = currentLevelHash
+			right = t.zeros[i]
+			t.filledSubtrees[i] = currentLevelHash
+		} else {
+			left = t.filledSubtrees[i]
+			right = currentLevelHash
+		}
+
+		hash, err := t.hasher.HashLeftRight(left, right)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to hash level %d: %w", i, err)
+		}
+		currentLevelHash = hash.BigInt(new(big.Int))
+		currentIndex >>= 1
+	}
+
+	t.roots[currentLevelHash.String()] = struct{}{}
+	t.root = currentLevelHash
+	t.leaves = append(t.leaves, new(big.Int).Set(leaf))
+	t.nextIndex++
+
+	return new(big.Int).Set(currentLevelHash), index, nil
+}
+
+// IsKnownRoot reports whether the root has ever been the root of the tree (the contract never forgets roots).
+func (t *Tree) IsKnownRoot(root *big.Int) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if root == nil || root.Sign() == 0 {
+		return false
+	}
+	_, ok := t.roots[root.String()]
+	return ok
+}
+
+// Root returns the current root.
+func (t *Tree) Root() *big.Int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return new(big.Int).Set(t.root)
+}
+
+// Size returns the number of inserted leaves.
+func (t *Tree) Size() uint32 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.nextIndex
+}
+
+// Leaf returns the leaf at the given index.
+func (t *Tree) Leaf(index uint32) (*big.Int, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if index >= t.nextIndex {
+		return nil, ErrLeafNotFound
+	}
+	return new(big.Int).Set(t.leaves[index]), nil
+}
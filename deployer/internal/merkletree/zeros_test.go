@@ -0,0 +1,67 @@
+package merkletree
+
+import (
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"deployer/internal/mimc"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const treeSource = "../../../contracts/MerkleTree/MerkleTreeWithHistory.sol"
+
+var (
+	solidityZeroValue = regexp.MustCompile(`zeroValues\[\s*(\d+)\s*\]\s*=\s*(\d+);`)
+	solidityConstant  = regexp.MustCompile(`uint256 private constant ZERO_VALUE =\s*(\d+);`)
+)
+
+// TestZeroValuesMatchContract regenerates the zero chain from keccak256("tornado") and checks it
+// against ZERO_VALUE and the zeroValues hard-coded in initZeros.
+func TestZeroValuesMatchContract(t *testing.T) {
+	data, err := os.ReadFile(treeSource)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", treeSource, err)
+	}
+
+	seed := new(big.Int).SetBytes(crypto.Keccak256([]byte("tornado")))
+	seed.Mod(seed, fr.Modulus())
+	if seed.Cmp(ZeroValue) != 0 {
+		t.Fatalf("ZeroValue is %s, keccak256(\"tornado\") %% FIELD_SIZE is %s", ZeroValue, seed)
+	}
+
+	match := solidityConstant.FindSubmatch(data)
+	if match == nil {
+		t.Fatal("ZERO_VALUE not found in the contract")
+	}
+	if string(match[1]) != ZeroValue.String() {
+		t.Fatalf("ZERO_VALUE is %s in the contract, %s in Go", match[1], ZeroValue)
+	}
+
+	hasher, err := mimc.NewMiMCSponge(mimc.Seed, mimc.MimcNbRounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zeros, err := Zeros(hasher, MaxLevels)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches := solidityZeroValue.FindAllSubmatch(data, -1)
+	if len(matches) != MaxLevels {
+		t.Fatalf("found %d zeroValues in the contract, expected %d", len(matches), MaxLevels)
+	}
+	for _, match := range matches {
+		level, err := strconv.Atoi(string(match[1]))
+		if err != nil || level >= MaxLevels {
+			t.Fatalf("invalid zeroValues index %q", match[1])
+		}
+		if string(match[2]) != zeros[level].String() {
+			t.Fatalf("zeroValues[%d] is %s in the contract, %s in Go", level, match[2], zeros[level])
+		}
+	}
+}
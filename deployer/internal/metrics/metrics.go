@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const maxNameLength = 64 // keeps a caller from creating arbitrarily long metric names
+
+// revertReasons maps the zkLogin revert reasons of a rejected proof to their label. The label set is
+// fixed, so a node or contract cannot create metrics with the reasons it returns.
+var revertReasons = map[string]string{
+	"zkMerkleTree: Invalid Proofs":              "invalid_proof",
+	"zkEthereumAddress: Invalid Proofs":         "invalid_proof",
+	"zkMerkleTree: Invalid Public Signals":      "invalid_public_signals",
+	"zkEthereumAddress: Invalid Public Signals": "invalid_public_signals",
+	"zkMerkleTree: Unauthorized Access":         "unauthorized",
+	"zkEthereumAddress: Unauthorized Access":    "unauthorized",
+	"zkMerkleTree: Unknown Root Detected":       "unknown_root",
+	"zkMerkleTree: Revoked Account Detected":    "revoked",
+	"Revoked Account Detected":                  "revoked",
+	"Blacklisted User Detected":                 "blacklisted",
+	"User is already Registered":                "already_registered",
+}
+
+// Registry holds every Pinacle metric, separate from the go-ethereum default registry.
+var Registry = metrics.NewRegistry()
+
+// Durations are recorded in nanoseconds and exported as Prometheus summaries.
+var (
+	WitnessTimer = metrics.NewRegisteredTimer("pinacle/zkp/witness", Registry)
+	ProvingTimer = metrics.NewRegisteredTimer("pinacle/zkp/prove", Registry)
+	ProvingError = metrics.NewRegisteredCounter("pinacle/zkp/prove/errors", Registry)
+	VerifyTimer  = metrics.NewRegisteredTimer("pinacle/zkp/verify", Registry)
+
+	ProofCacheHit  = metrics.NewRegisteredCounter("pinacle/zkp/cache/hit", Registry)
+	ProofCacheMiss = metrics.NewRegisteredCounter("pinacle/zkp/cache/miss", Registry)
+
+	TxConfirmationTimer = metrics.NewRegisteredTimer("pinacle/tx/confirmation", Registry)
+	TxConfirmed         = metrics.NewRegisteredCounter("pinacle/tx/confirmed", Registry)
+	TxReverted          = metrics.NewRegisteredCounter("pinacle/tx/reverted", Registry)
+	TxGasUsed           = metrics.NewRegisteredHistogram("pinacle/tx/gas_used", Registry, metrics.NewExpDecaySample(1028, 0.015))
+)
+
+// Enable starts the meter ticker. It must be called once at startup, before any metric is updated.
+func Enable() {
+	metrics.Enable()
+}
+
+// Handler serves the registry in the Prometheus text format.
+func Handler() http.Handler {
+	return prometheus.Handler(Registry)
+}
+
+// RPCTimer returns the latency timer of an RPC method (e.g. eth_call).
+func RPCTimer(method string) *metrics.Timer {
+	return metrics.GetOrRegisterTimer("pinacle/rpc/"+sanitize(method), Registry)
+}
+
+// RPCError counts the failed calls of an RPC method.
+func RPCError(method string) *metrics.Counter {
+	return metrics.GetOrRegisterCounter("pinacle/rpc/"+sanitize(method)+"/errors", Registry)
+}
+
+// ObserveVerification counts a proof verification outcome of the given source (local, onchain, ...).
+// Rejections are broken down by the label of their revert reason.
+func ObserveVerification(source string, err error) {
+	name := "pinacle/verify/" + sanitize(source)
+	if err == nil {
+		metrics.GetOrRegisterCounter(name+"/valid", Registry).Inc(1)
+		return
+	}
+	metrics.GetOrRegisterCounter(name+"/rejected/"+RevertReason(err), Registry).Inc(1)
+}
+
+// ObserveReceipt records the outcome of a mined transaction.
+func ObserveReceipt(successful bool, gasUsed uint64) {
+	if successful {
+		TxConfirmed.Inc(1)
+	} else {
+		TxReverted.Inc(1)
+	}
+	TxGasUsed.Update(int64(gasUsed))
+}
+
+// RevertReason labels the Solidity revert reason of a failed call as a metric name segment.
+// Reasons missing from revertReasons are reported as "other", reverts without a reason as
+// "reverted" and errors that are not reverts as "error".
+func RevertReason(err error) string {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, err := abi.UnpackRevert(common.FromHex(data)); err == nil {
+				return revertLabel(reason)
+			}
+		}
+	}
+
+	const prefix = "execution reverted: "
+	message := err.Error()
+	if i := strings.Index(message, prefix); i >= 0 {
+		return revertLabel(message[i+len(prefix):])
+	}
+	if strings.Contains(message, "execution reverted") {
+		return "reverted"
+	}
+	return "error"
+}
+
+func revertLabel(reason string) string {
+	if label, ok := revertReasons[reason]; ok {
+		return label
+	}
+	return "other"
+}
+
+// sanitize turns free text into a Prometheus friendly name segment ([a-z0-9_]).
+func sanitize(s string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+		if b.Len() >= maxNameLength {
+			break
+		}
+	}
+
+	name := strings.TrimSuffix(b.String(), "_")
+	if name == "" {
+		return "unknown"
+	}
+	return name
+}
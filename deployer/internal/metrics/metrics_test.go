@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// revertError is the JSON-RPC error of a reverted call, with the ABI encoded reason as data.
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+// revertData encodes reason like Solidity's Error(string).
+func revertData(t *testing.T, reason string) string {
+	t.Helper()
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hexutil.Encode(append(crypto.Keccak256([]byte("Error(string)"))[:4], packed...))
+}
+
+func TestRevertReason(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"data", &revertError{revertData(t, "zkMerkleTree: Invalid Proofs")}, "invalid_proof"},
+		{"wrapped data", fmt.Errorf("failed to register: %w", &revertError{revertData(t, "zkMerkleTree: Unknown Root Detected")}), "unknown_root"},
+		{"message", errors.New("execution reverted: zkEthereumAddress: Invalid Public Signals"), "invalid_public_signals"},
+		{"wrapped message", fmt.Errorf("proof rejected: %w", errors.New("execution reverted: User is already Registered")), "already_registered"},
+		{"unknown reason", &revertError{revertData(t, "Proposal Expired")}, "other"},
+		{"arbitrary reason", errors.New("execution reverted: " + strings.Repeat("spam ", 100)), "other"},
+		{"undecodable data", &revertError{"0x1234"}, "reverted"},
+		{"no reason", errors.New("execution reverted"), "reverted"},
+		{"not a revert", errors.New("connection refused"), "error"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := RevertReason(tc.err); got != tc.want {
+				t.Fatalf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+// Whatever a node returns, the verification metrics stay within the fixed labels.
+func TestObserveVerificationLabels(t *testing.T) {
+	labels := map[string]bool{"other": true, "reverted": true, "error": true}
+	for _, label := range revertReasons {
+		labels[label] = true
+	}
+
+	ObserveVerification("labels_test", nil)
+	for i := 0; i < 100; i++ {
+		ObserveVerification("labels_test", fmt.Errorf("execution reverted: reason %d", i))
+	}
+	ObserveVerification("labels_test", errors.New("execution reverted: zkMerkleTree: Unauthorized Access"))
+	ObserveVerification("labels_test", errors.New("timeout"))
+
+	const prefix = "pinacle/verify/labels_test/"
+	counts := make(map[string]int64)
+	Registry.Each(func(name string, metric interface{}) {
+		if !strings.HasPrefix(name, prefix) {
+			return
+		}
+		label := strings.TrimPrefix(name, prefix)
+		if rejected, ok := strings.CutPrefix(label, "rejected/"); ok && !labels[rejected] {
+			t.Errorf("unexpected label %s", rejected)
+		}
+		counts[label] = metric.(*metrics.Counter).Snapshot().Count()
+	})
+
+	want := map[string]int64{"valid": 1, "rejected/other": 100, "rejected/unauthorized": 1, "rejected/error": 1}
+	if len(counts) != len(want) {
+		t.Fatalf("got metrics %v, want %v", counts, want)
+	}
+	for label, count := range want {
+		if counts[label] != count {
+			t.Errorf("%s: got %d, want %d", label, counts[label], count)
+		}
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"eth_call", "eth_call"},
+		{"Register Batch", "register_batch"},
+		{"  --a--b--  ", "a_b"},
+		{"!!!", "unknown"},
+		{strings.Repeat("x", 100), strings.Repeat("x", maxNameLength)},
+	} {
+		if got := sanitize(tc.in); got != tc.want {
+			t.Errorf("sanitize(%q): got %s, want %s", tc.in, got, tc.want)
+		}
+	}
+}
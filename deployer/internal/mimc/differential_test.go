@@ -0,0 +1,284 @@
+package mimc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	mimccontract "deployer/internal/abigen/mimc"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// contractArtifact is the truffle artifact of the MiMC sponge the zkLogin deployments call.
+const contractArtifact = "../../../contracts/mimc/mimc.json"
+
+// randomCases is the number of random inputs of each property test.
+const randomCases = 64
+
+// evmMiMC runs the deployed MiMC contract on a simulated chain.
+type evmMiMC struct {
+	contract *mimccontract.Mimc
+}
+
+func newEVMMiMC(t testing.TB) *evmMiMC {
+	t.Helper()
+
+	data, err := os.ReadFile(contractArtifact)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", contractArtifact, err)
+	}
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode string          `json:"bytecode"`
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		t.Fatalf("failed to decode %s: %v", contractArtifact, err)
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	if err != nil {
+		t.Fatalf("failed to parse MiMC ABI: %v", err)
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	deployer := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := simulated.NewBackend(ethtypes.GenesisAlloc{deployer: {Balance: big.NewInt(params.Ether)}})
+	t.Cleanup(func() { backend.Close() })
+	client := backend.Client()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		t.Fatalf("failed to create transactor: %v", err)
+	}
+	address, _, _, err := bind.DeployContract(opts, parsed, common.FromHex(artifact.Bytecode), client)
+	if err != nil {
+		t.Fatalf("failed to deploy MiMC: %v", err)
+	}
+	backend.Commit()
+
+	contract, err := mimccontract.NewMimc(address, client)
+	if err != nil {
+		t.Fatalf("failed to bind MiMC: %v", err)
+	}
+	return &evmMiMC{contract: contract}
+}
+
+// permute calls MiMCSponge(xL, xR, k), the Feistel permutation Encrypt implements.
+func (e *evmMiMC) permute(t testing.TB, xL, xR, k *big.Int) (*big.Int, *big.Int) {
+	t.Helper()
+
+	out, err := e.contract.MiMCSponge(&bind.CallOpts{Context: context.Background()}, xL, xR, k)
+	if err != nil {
+		t.Fatalf("MiMCSponge(%s, %s, %s) failed: %v", xL, xR, k, err)
+	}
+	return out.XL, out.XR
+}
+
+// multiHash absorbs the inputs and squeezes numOutputs elements like circomlib's
+// MiMCSponge template, with one contract call per permutation.
+func (e *evmMiMC) multiHash(t testing.TB, inputs []*big.Int, k *big.Int, numOutputs int) []*big.Int {
+	t.Helper()
+
+	r, c := big.NewInt(0), big.NewInt(0)
+	for _, input := range inputs {
+		r = new(big.Int).Add(r, input)
+		r.Mod(r, fr.Modulus())
+		r, c = e.permute(t, r, c, k)
+	}
+
+	outputs := []*big.Int{r}
+	for i := 1; i < numOutputs; i++ {
+		r, c = e.permute(t, r, c, k)
+		outputs = append(outputs, r)
+	}
+	return outputs
+}
+
+func newTestSponge(t testing.TB) *MiMCSponge {
+	t.Helper()
+
+	m, err := NewMiMCSponge(Seed, MimcNbRounds)
+	if err != nil {
+		t.Fatalf("failed to initialize MiMC: %v", err)
+	}
+	return m
+}
+
+// randomElement returns a canonical field element, biased towards the edges of the field.
+func randomElement(rng *rand.Rand) *big.Int {
+	switch rng.Intn(8) {
+	case 0:
+		return big.NewInt(rng.Int63n(4))
+	case 1:
+		return new(big.Int).Sub(fr.Modulus(), big.NewInt(rng.Int63n(4)+1))
+	default:
+		return new(big.Int).Rand(rng, fr.Modulus())
+	}
+}
+
+func element(v *big.Int) *fr.Element {
+	var e fr.Element
+	e.SetBigInt(v)
+	return &e
+}
+
+func bigInt(e fr.Element) *big.Int {
+	return e.BigInt(new(big.Int))
+}
+
+func requireEqual(t testing.TB, what string, got, expected *big.Int) {
+	t.Helper()
+
+	if got.Cmp(expected) != 0 {
+		t.Fatalf("%s: Go returned %s, the contract %s", what, got, expected)
+	}
+}
+
+func TestEncryptMatchesContract(t *testing.T) {
+	m, evm := newTestSponge(t), newEVMMiMC(t)
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < randomCases; i++ {
+		xL, xR, k := randomElement(rng), randomElement(rng), randomElement(rng)
+
+		goL, goR := m.Encrypt(element(xL), element(xR), element(k))
+		evmL, evmR := evm.permute(t, xL, xR, k)
+		requireEqual(t, "Encrypt xL", bigInt(goL), evmL)
+		requireEqual(t, "Encrypt xR", bigInt(goR), evmR)
+	}
+}
+
+func TestDecryptInvertsContract(t *testing.T) {
+	m, evm := newTestSponge(t), newEVMMiMC(t)
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < randomCases; i++ {
+		xL, xR, k := randomElement(rng), randomElement(rng), randomElement(rng)
+
+		decL, decR := m.Decrypt(element(xL), element(xR), element(k))
+		evmL, evmR := evm.permute(t, bigInt(decL), bigInt(decR), k)
+		requireEqual(t, "MiMCSponge(Decrypt(x)) xL", xL, evmL)
+		requireEqual(t, "MiMCSponge(Decrypt(x)) xR", xR, evmR)
+	}
+}
+
+func TestMultiHashMatchesContract(t *testing.T) {
+	m, evm := newTestSponge(t), newEVMMiMC(t)
+	rng := rand.New(rand.NewSource(3))
+
+	for i := 0; i < randomCases; i++ {
+		inputs := make([]*big.Int, 1+rng.Intn(4))
+		for j := range inputs {
+			inputs[j] = randomElement(rng)
+		}
+		numOutputs := 1 + rng.Intn(3)
+
+		// A nil key is the zero key
+		var key *fr.Element
+		k := big.NewInt(0)
+		if rng.Intn(2) == 0 {
+			k = randomElement(rng)
+			key = element(k)
+		}
+
+		outputs, err := m.MultiHash(inputs, key, numOutputs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := evm.multiHash(t, inputs, k, numOutputs)
+		if len(outputs) != len(expected) {
+			t.Fatalf("MultiHash returned %d outputs, expected %d", len(outputs), len(expected))
+		}
+		for j := range outputs {
+			requireEqual(t, "MultiHash", bigInt(outputs[j]), expected[j])
+		}
+	}
+}
+
+func TestHashLeftRightMatchesContract(t *testing.T) {
+	m, evm := newTestSponge(t), newEVMMiMC(t)
+	rng := rand.New(rand.NewSource(4))
+
+	for i := 0; i < randomCases; i++ {
+		left, right := randomElement(rng), randomElement(rng)
+
+		hash, err := m.HashLeftRight(left, right)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// hashLeftRight in MerkleTreeWithHistory
+		expected := evm.multiHash(t, []*big.Int{left, right}, big.NewInt(0), 1)[0]
+		requireEqual(t, "HashLeftRight", bigInt(hash), expected)
+	}
+}
+
+func TestHashAddressMatchesContract(t *testing.T) {
+	m, evm := newTestSponge(t), newEVMMiMC(t)
+
+	for i := 0; i < randomCases/4; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		address := crypto.PubkeyToAddress(key.PublicKey)
+
+		// hashAddress in zkLogin: hashLeftRight(uint160(address), 0)
+		expected := evm.multiHash(t, []*big.Int{address.Big(), big.NewInt(0)}, big.NewInt(0), 1)[0]
+		requireEqual(t, "HashAddress", m.HashAddress(&address), expected)
+	}
+}
+
+func FuzzHashLeftRight(f *testing.F) {
+	f.Add([]byte{}, []byte{})
+	f.Add([]byte{1}, []byte{0})
+	f.Add(fr.Modulus().Bytes(), []byte{0xff})
+
+	m, evm := newTestSponge(f), newEVMMiMC(f)
+	f.Fuzz(func(t *testing.T, left, right []byte) {
+		// hashLeftRight requires both inputs inside the field
+		l := new(big.Int).Mod(new(big.Int).SetBytes(left), fr.Modulus())
+		r := new(big.Int).Mod(new(big.Int).SetBytes(right), fr.Modulus())
+
+		hash, err := m.HashLeftRight(l, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requireEqual(t, "HashLeftRight", bigInt(hash), evm.multiHash(t, []*big.Int{l, r}, big.NewInt(0), 1)[0])
+	})
+}
+
+func FuzzEncrypt(f *testing.F) {
+	f.Add([]byte{}, []byte{}, []byte{})
+	f.Add([]byte{1}, []byte{2}, []byte{3})
+
+	m, evm := newTestSponge(f), newEVMMiMC(f)
+	f.Fuzz(func(t *testing.T, xL, xR, k []byte) {
+		l := new(big.Int).Mod(new(big.Int).SetBytes(xL), fr.Modulus())
+		r := new(big.Int).Mod(new(big.Int).SetBytes(xR), fr.Modulus())
+		key := new(big.Int).Mod(new(big.Int).SetBytes(k), fr.Modulus())
+
+		goL, goR := m.Encrypt(element(l), element(r), element(key))
+		evmL, evmR := evm.permute(t, l, r, key)
+		requireEqual(t, "Encrypt xL", bigInt(goL), evmL)
+		requireEqual(t, "Encrypt xR", bigInt(goR), evmR)
+
+		decL, decR := m.Decrypt(&goL, &goR, element(key))
+		requireEqual(t, "Decrypt(Encrypt(x)) xL", bigInt(decL), l)
+		requireEqual(t, "Decrypt(Encrypt(x)) xR", bigInt(decR), r)
+	})
+}
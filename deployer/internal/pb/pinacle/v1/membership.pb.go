@@ -0,0 +1,1239 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: pinacle/v1/membership.proto
+
+package pinaclev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Role mirrors the zkLogin role registry (roles.json), each value being the role ID plus one.
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED  Role = 0
+	Role_ROLE_FOOD_BANK    Role = 1
+	Role_ROLE_USER         Role = 2
+	Role_ROLE_VOLUNTEER    Role = 3
+	Role_ROLE_INTERMEDIARY Role = 4
+	Role_ROLE_AUDITOR      Role = 5
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_FOOD_BANK",
+		2: "ROLE_USER",
+		3: "ROLE_VOLUNTEER",
+		4: "ROLE_INTERMEDIARY",
+		5: "ROLE_AUDITOR",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED":  0,
+		"ROLE_FOOD_BANK":    1,
+		"ROLE_USER":         2,
+		"ROLE_VOLUNTEER":    3,
+		"ROLE_INTERMEDIARY": 4,
+		"ROLE_AUDITOR":      5,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_pinacle_v1_membership_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_pinacle_v1_membership_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{0}
+}
+
+// Groth16Proof is a proof in the snarkjs/rapidsnark JSON layout (decimal strings).
+type Groth16Proof struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PiA           []string               `protobuf:"bytes,1,rep,name=pi_a,json=piA,proto3" json:"pi_a,omitempty"`
+	PiB           []*G2Point             `protobuf:"bytes,2,rep,name=pi_b,json=piB,proto3" json:"pi_b,omitempty"`
+	PiC           []string               `protobuf:"bytes,3,rep,name=pi_c,json=piC,proto3" json:"pi_c,omitempty"`
+	Protocol      string                 `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Groth16Proof) Reset() {
+	*x = Groth16Proof{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Groth16Proof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Groth16Proof) ProtoMessage() {}
+
+func (x *Groth16Proof) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Groth16Proof.ProtoReflect.Descriptor instead.
+func (*Groth16Proof) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Groth16Proof) GetPiA() []string {
+	if x != nil {
+		return x.PiA
+	}
+	return nil
+}
+
+func (x *Groth16Proof) GetPiB() []*G2Point {
+	if x != nil {
+		return x.PiB
+	}
+	return nil
+}
+
+func (x *Groth16Proof) GetPiC() []string {
+	if x != nil {
+		return x.PiC
+	}
+	return nil
+}
+
+func (x *Groth16Proof) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+type G2Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coordinates   []string               `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *G2Point) Reset() {
+	*x = G2Point{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *G2Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*G2Point) ProtoMessage() {}
+
+func (x *G2Point) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use G2Point.ProtoReflect.Descriptor instead.
+func (*G2Point) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *G2Point) GetCoordinates() []string {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// ZKProof is a proof with its two public signals [hashedAddress, root].
+type ZKProof struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Proof         *Groth16Proof          `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	PublicSignals []string               `protobuf:"bytes,2,rep,name=public_signals,json=publicSignals,proto3" json:"public_signals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZKProof) Reset() {
+	*x = ZKProof{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZKProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZKProof) ProtoMessage() {}
+
+func (x *ZKProof) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZKProof.ProtoReflect.Descriptor instead.
+func (*ZKProof) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ZKProof) GetProof() *Groth16Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *ZKProof) GetPublicSignals() []string {
+	if x != nil {
+		return x.PublicSignals
+	}
+	return nil
+}
+
+// Transaction describes a mined zkLogin transaction.
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	BlockNumber   uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	GasUsed       uint64                 `protobuf:"varint,3,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Transaction) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Transaction) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Transaction) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+type RegisterFoodBankRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Address of the new food bank (0x prefixed hex).
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// zkEthereumAddress proof of the new food bank.
+	EthereumAddressProof *ZKProof `protobuf:"bytes,2,opt,name=ethereum_address_proof,json=ethereumAddressProof,proto3" json:"ethereum_address_proof,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RegisterFoodBankRequest) Reset() {
+	*x = RegisterFoodBankRequest{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterFoodBankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterFoodBankRequest) ProtoMessage() {}
+
+func (x *RegisterFoodBankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterFoodBankRequest.ProtoReflect.Descriptor instead.
+func (*RegisterFoodBankRequest) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RegisterFoodBankRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RegisterFoodBankRequest) GetEthereumAddressProof() *ZKProof {
+	if x != nil {
+		return x.EthereumAddressProof
+	}
+	return nil
+}
+
+type RegisterFoodBankResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterFoodBankResponse) Reset() {
+	*x = RegisterFoodBankResponse{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterFoodBankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterFoodBankResponse) ProtoMessage() {}
+
+func (x *RegisterFoodBankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterFoodBankResponse.ProtoReflect.Descriptor instead.
+func (*RegisterFoodBankResponse) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RegisterFoodBankResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type RegisterUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Address of the new user (0x prefixed hex).
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// zkEthereumAddress proof of the new user.
+	EthereumAddressProof *ZKProof `protobuf:"bytes,2,opt,name=ethereum_address_proof,json=ethereumAddressProof,proto3" json:"ethereum_address_proof,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RegisterUserRequest) Reset() {
+	*x = RegisterUserRequest{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterUserRequest) ProtoMessage() {}
+
+func (x *RegisterUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterUserRequest.ProtoReflect.Descriptor instead.
+func (*RegisterUserRequest) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RegisterUserRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RegisterUserRequest) GetEthereumAddressProof() *ZKProof {
+	if x != nil {
+		return x.EthereumAddressProof
+	}
+	return nil
+}
+
+type RegisterUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterUserResponse) Reset() {
+	*x = RegisterUserResponse{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterUserResponse) ProtoMessage() {}
+
+func (x *RegisterUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterUserResponse.ProtoReflect.Descriptor instead.
+func (*RegisterUserResponse) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RegisterUserResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type VerifyUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Address of the user (0x prefixed hex).
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// zkMerkleTree proof of the user.
+	MerkleTreeProof *ZKProof `protobuf:"bytes,2,opt,name=merkle_tree_proof,json=merkleTreeProof,proto3" json:"merkle_tree_proof,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VerifyUserRequest) Reset() {
+	*x = VerifyUserRequest{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyUserRequest) ProtoMessage() {}
+
+func (x *VerifyUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyUserRequest.ProtoReflect.Descriptor instead.
+func (*VerifyUserRequest) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VerifyUserRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *VerifyUserRequest) GetMerkleTreeProof() *ZKProof {
+	if x != nil {
+		return x.MerkleTreeProof
+	}
+	return nil
+}
+
+type VerifyUserResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Valid bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// Revert reason when the proof is not valid.
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyUserResponse) Reset() {
+	*x = VerifyUserResponse{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyUserResponse) ProtoMessage() {}
+
+func (x *VerifyUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyUserResponse.ProtoReflect.Descriptor instead.
+func (*VerifyUserResponse) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyUserResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyUserResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type TerminateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RLP/EIP-2718 encoded transaction signed by the account being terminated.
+	RawTransaction []byte `protobuf:"bytes,1,opt,name=raw_transaction,json=rawTransaction,proto3" json:"raw_transaction,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TerminateRequest) Reset() {
+	*x = TerminateRequest{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateRequest) ProtoMessage() {}
+
+func (x *TerminateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateRequest.ProtoReflect.Descriptor instead.
+func (*TerminateRequest) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TerminateRequest) GetRawTransaction() []byte {
+	if x != nil {
+		return x.RawTransaction
+	}
+	return nil
+}
+
+type TerminateResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Transaction *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Role        Role                   `protobuf:"varint,2,opt,name=role,proto3,enum=pinacle.v1.Role" json:"role,omitempty"`
+	// Hashed address of the terminated account (decimal).
+	HashedAddress string `protobuf:"bytes,3,opt,name=hashed_address,json=hashedAddress,proto3" json:"hashed_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerminateResponse) Reset() {
+	*x = TerminateResponse{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateResponse) ProtoMessage() {}
+
+func (x *TerminateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateResponse.ProtoReflect.Descriptor instead.
+func (*TerminateResponse) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TerminateResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *TerminateResponse) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *TerminateResponse) GetHashedAddress() string {
+	if x != nil {
+		return x.HashedAddress
+	}
+	return ""
+}
+
+type WatchMembershipRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream events of these roles, all roles when empty.
+	Roles         []Role `protobuf:"varint,1,rep,packed,name=roles,proto3,enum=pinacle.v1.Role" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMembershipRequest) Reset() {
+	*x = WatchMembershipRequest{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMembershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMembershipRequest) ProtoMessage() {}
+
+func (x *WatchMembershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMembershipRequest.ProtoReflect.Descriptor instead.
+func (*WatchMembershipRequest) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchMembershipRequest) GetRoles() []Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type WatchMembershipResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*WatchMembershipResponse_RootChanged
+	//	*WatchMembershipResponse_Revoked
+	Event           isWatchMembershipResponse_Event `protobuf_oneof:"event"`
+	BlockNumber     uint64                          `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TransactionHash string                          `protobuf:"bytes,4,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchMembershipResponse) Reset() {
+	*x = WatchMembershipResponse{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMembershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMembershipResponse) ProtoMessage() {}
+
+func (x *WatchMembershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMembershipResponse.ProtoReflect.Descriptor instead.
+func (*WatchMembershipResponse) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchMembershipResponse) GetEvent() isWatchMembershipResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchMembershipResponse) GetRootChanged() *RootChanged {
+	if x != nil {
+		if x, ok := x.Event.(*WatchMembershipResponse_RootChanged); ok {
+			return x.RootChanged
+		}
+	}
+	return nil
+}
+
+func (x *WatchMembershipResponse) GetRevoked() *Revoked {
+	if x != nil {
+		if x, ok := x.Event.(*WatchMembershipResponse_Revoked); ok {
+			return x.Revoked
+		}
+	}
+	return nil
+}
+
+func (x *WatchMembershipResponse) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *WatchMembershipResponse) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+type isWatchMembershipResponse_Event interface {
+	isWatchMembershipResponse_Event()
+}
+
+type WatchMembershipResponse_RootChanged struct {
+	RootChanged *RootChanged `protobuf:"bytes,1,opt,name=root_changed,json=rootChanged,proto3,oneof"`
+}
+
+type WatchMembershipResponse_Revoked struct {
+	Revoked *Revoked `protobuf:"bytes,2,opt,name=revoked,proto3,oneof"`
+}
+
+func (*WatchMembershipResponse_RootChanged) isWatchMembershipResponse_Event() {}
+
+func (*WatchMembershipResponse_Revoked) isWatchMembershipResponse_Event() {}
+
+// RootChanged is sent when a leaf has been inserted. Clients holding a Merkle path
+// of the same tree should fetch a fresh one.
+type RootChanged struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Role  Role                   `protobuf:"varint,1,opt,name=role,proto3,enum=pinacle.v1.Role" json:"role,omitempty"`
+	// New root (decimal).
+	Root string `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+	// Inserted leaf (hashed address, decimal).
+	Leaf          string `protobuf:"bytes,3,opt,name=leaf,proto3" json:"leaf,omitempty"`
+	LeafIndex     uint32 `protobuf:"varint,4,opt,name=leaf_index,json=leafIndex,proto3" json:"leaf_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RootChanged) Reset() {
+	*x = RootChanged{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RootChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RootChanged) ProtoMessage() {}
+
+func (x *RootChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RootChanged.ProtoReflect.Descriptor instead.
+func (*RootChanged) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RootChanged) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *RootChanged) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *RootChanged) GetLeaf() string {
+	if x != nil {
+		return x.Leaf
+	}
+	return ""
+}
+
+func (x *RootChanged) GetLeafIndex() uint32 {
+	if x != nil {
+		return x.LeafIndex
+	}
+	return 0
+}
+
+// Revoked is sent when an account has been terminated.
+type Revoked struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Role  Role                   `protobuf:"varint,1,opt,name=role,proto3,enum=pinacle.v1.Role" json:"role,omitempty"`
+	// Hashed address of the terminated account (decimal).
+	HashedAddress string `protobuf:"bytes,2,opt,name=hashed_address,json=hashedAddress,proto3" json:"hashed_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Revoked) Reset() {
+	*x = Revoked{}
+	mi := &file_pinacle_v1_membership_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revoked) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revoked) ProtoMessage() {}
+
+func (x *Revoked) ProtoReflect() protoreflect.Message {
+	mi := &file_pinacle_v1_membership_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revoked.ProtoReflect.Descriptor instead.
+func (*Revoked) Descriptor() ([]byte, []int) {
+	return file_pinacle_v1_membership_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Revoked) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *Revoked) GetHashedAddress() string {
+	if x != nil {
+		return x.HashedAddress
+	}
+	return ""
+}
+
+var File_pinacle_v1_membership_proto protoreflect.FileDescriptor
+
+var file_pinacle_v1_membership_proto_rawDesc = string([]byte{
+	0x0a, 0x1b, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x70,
+	0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x22, 0x78, 0x0a, 0x0c, 0x47, 0x72, 0x6f,
+	0x74, 0x68, 0x31, 0x36, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x11, 0x0a, 0x04, 0x70, 0x69, 0x5f,
+	0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x41, 0x12, 0x26, 0x0a, 0x04,
+	0x70, 0x69, 0x5f, 0x62, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x6e,
+	0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x32, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x03, 0x70, 0x69, 0x42, 0x12, 0x11, 0x0a, 0x04, 0x70, 0x69, 0x5f, 0x63, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x43, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x22, 0x2b, 0x0a, 0x07, 0x47, 0x32, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x20,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73,
+	0x22, 0x60, 0x0a, 0x07, 0x5a, 0x4b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x2e, 0x0a, 0x05, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x6e,
+	0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x6f, 0x74, 0x68, 0x31, 0x36, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x25, 0x0a, 0x0e, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x73, 0x22, 0x5f, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x61, 0x73, 0x5f,
+	0x75, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x61, 0x73, 0x55,
+	0x73, 0x65, 0x64, 0x22, 0x7e, 0x0a, 0x17, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46,
+	0x6f, 0x6f, 0x64, 0x42, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x49, 0x0a, 0x16, 0x65, 0x74, 0x68, 0x65,
+	0x72, 0x65, 0x75, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63,
+	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x5a, 0x4b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x14, 0x65,
+	0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x22, 0x55, 0x0a, 0x18, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46,
+	0x6f, 0x6f, 0x64, 0x42, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x39, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x7a, 0x0a, 0x13, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x49, 0x0a, 0x16, 0x65,
+	0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69,
+	0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x5a, 0x4b, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x14, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x51, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39,
+	0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x6e, 0x0a, 0x11, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3f, 0x0a, 0x11, 0x6d, 0x65, 0x72, 0x6b,
+	0x6c, 0x65, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x5a, 0x4b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x0f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
+	0x54, 0x72, 0x65, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x42, 0x0a, 0x12, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x3b, 0x0a,
+	0x10, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x61, 0x77, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x72, 0x61, 0x77, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9b, 0x01, 0x0a, 0x11, 0x54,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x39, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x69, 0x6e, 0x61,
+	0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c,
+	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x65,
+	0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x40, 0x0a, 0x16, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x10, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0xdf, 0x01, 0x0a, 0x17, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70,
+	0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x6f, 0x6f, 0x74, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x61, 0x73, 0x68, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x7a, 0x0a, 0x0b,
+	0x52, 0x6f, 0x6f, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x69, 0x6e, 0x61,
+	0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61,
+	0x66, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6c,
+	0x65, 0x61, 0x66, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x56, 0x0a, 0x07, 0x52, 0x65, 0x76, 0x6f,
+	0x6b, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x10, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x61, 0x73,
+	0x68, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x2a, 0x7c, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x4f, 0x4c, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12,
+	0x0a, 0x0e, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x46, 0x4f, 0x4f, 0x44, 0x5f, 0x42, 0x41, 0x4e, 0x4b,
+	0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10,
+	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x56, 0x4f, 0x4c, 0x55, 0x4e, 0x54,
+	0x45, 0x45, 0x52, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x49, 0x4e,
+	0x54, 0x45, 0x52, 0x4d, 0x45, 0x44, 0x49, 0x41, 0x52, 0x59, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c,
+	0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x41, 0x55, 0x44, 0x49, 0x54, 0x4f, 0x52, 0x10, 0x05, 0x32, 0xba,
+	0x03, 0x0a, 0x11, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x5d, 0x0a, 0x10, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x46, 0x6f, 0x6f, 0x64, 0x42, 0x61, 0x6e, 0x6b, 0x12, 0x23, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63,
+	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46, 0x6f,
+	0x6f, 0x64, 0x42, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
+	0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x46, 0x6f, 0x6f, 0x64, 0x42, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55,
+	0x73, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x55, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x09, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65,
+	0x12, 0x1c, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65,
+	0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x72, 0x6d,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a,
+	0x0f, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x12, 0x22, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69,
+	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x95, 0x01, 0x0a, 0x0e,
+	0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0f,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
+	0x01, 0x5a, 0x29, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x2f, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2f,
+	0x76, 0x31, 0x3b, 0x70, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x50,
+	0x58, 0x58, 0xaa, 0x02, 0x0a, 0x50, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x56, 0x31, 0xca,
+	0x02, 0x0a, 0x50, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x16, 0x50,
+	0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0b, 0x50, 0x69, 0x6e, 0x61, 0x63, 0x6c, 0x65, 0x3a,
+	0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_pinacle_v1_membership_proto_rawDescOnce sync.Once
+	file_pinacle_v1_membership_proto_rawDescData []byte
+)
+
+func file_pinacle_v1_membership_proto_rawDescGZIP() []byte {
+	file_pinacle_v1_membership_proto_rawDescOnce.Do(func() {
+		file_pinacle_v1_membership_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pinacle_v1_membership_proto_rawDesc), len(file_pinacle_v1_membership_proto_rawDesc)))
+	})
+	return file_pinacle_v1_membership_proto_rawDescData
+}
+
+var file_pinacle_v1_membership_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pinacle_v1_membership_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_pinacle_v1_membership_proto_goTypes = []any{
+	(Role)(0),                        // 0: pinacle.v1.Role
+	(*Groth16Proof)(nil),             // 1: pinacle.v1.Groth16Proof
+	(*G2Point)(nil),                  // 2: pinacle.v1.G2Point
+	(*ZKProof)(nil),                  // 3: pinacle.v1.ZKProof
+	(*Transaction)(nil),              // 4: pinacle.v1.Transaction
+	(*RegisterFoodBankRequest)(nil),  // 5: pinacle.v1.RegisterFoodBankRequest
+	(*RegisterFoodBankResponse)(nil), // 6: pinacle.v1.RegisterFoodBankResponse
+	(*RegisterUserRequest)(nil),      // 7: pinacle.v1.RegisterUserRequest
+	(*RegisterUserResponse)(nil),     // 8: pinacle.v1.RegisterUserResponse
+	(*VerifyUserRequest)(nil),        // 9: pinacle.v1.VerifyUserRequest
+	(*VerifyUserResponse)(nil),       // 10: pinacle.v1.VerifyUserResponse
+	(*TerminateRequest)(nil),         // 11: pinacle.v1.TerminateRequest
+	(*TerminateResponse)(nil),        // 12: pinacle.v1.TerminateResponse
+	(*WatchMembershipRequest)(nil),   // 13: pinacle.v1.WatchMembershipRequest
+	(*WatchMembershipResponse)(nil),  // 14: pinacle.v1.WatchMembershipResponse
+	(*RootChanged)(nil),              // 15: pinacle.v1.RootChanged
+	(*Revoked)(nil),                  // 16: pinacle.v1.Revoked
+}
+var file_pinacle_v1_membership_proto_depIdxs = []int32{
+	2,  // 0: pinacle.v1.Groth16Proof.pi_b:type_name -> pinacle.v1.G2Point
+	1,  // 1: pinacle.v1.ZKProof.proof:type_name -> pinacle.v1.Groth16Proof
+	3,  // 2: pinacle.v1.RegisterFoodBankRequest.ethereum_address_proof:type_name -> pinacle.v1.ZKProof
+	4,  // 3: pinacle.v1.RegisterFoodBankResponse.transaction:type_name -> pinacle.v1.Transaction
+	3,  // 4: pinacle.v1.RegisterUserRequest.ethereum_address_proof:type_name -> pinacle.v1.ZKProof
+	4,  // 5: pinacle.v1.RegisterUserResponse.transaction:type_name -> pinacle.v1.Transaction
+	3,  // 6: pinacle.v1.VerifyUserRequest.merkle_tree_proof:type_name -> pinacle.v1.ZKProof
+	4,  // 7: pinacle.v1.TerminateResponse.transaction:type_name -> pinacle.v1.Transaction
+	0,  // 8: pinacle.v1.TerminateResponse.role:type_name -> pinacle.v1.Role
+	0,  // 9: pinacle.v1.WatchMembershipRequest.roles:type_name -> pinacle.v1.Role
+	15, // 10: pinacle.v1.WatchMembershipResponse.root_changed:type_name -> pinacle.v1.RootChanged
+	16, // 11: pinacle.v1.WatchMembershipResponse.revoked:type_name -> pinacle.v1.Revoked
+	0,  // 12: pinacle.v1.RootChanged.role:type_name -> pinacle.v1.Role
+	0,  // 13: pinacle.v1.Revoked.role:type_name -> pinacle.v1.Role
+	5,  // 14: pinacle.v1.MembershipService.RegisterFoodBank:input_type -> pinacle.v1.RegisterFoodBankRequest
+	7,  // 15: pinacle.v1.MembershipService.RegisterUser:input_type -> pinacle.v1.RegisterUserRequest
+	9,  // 16: pinacle.v1.MembershipService.VerifyUser:input_type -> pinacle.v1.VerifyUserRequest
+	11, // 17: pinacle.v1.MembershipService.Terminate:input_type -> pinacle.v1.TerminateRequest
+	13, // 18: pinacle.v1.MembershipService.WatchMembership:input_type -> pinacle.v1.WatchMembershipRequest
+	6,  // 19: pinacle.v1.MembershipService.RegisterFoodBank:output_type -> pinacle.v1.RegisterFoodBankResponse
+	8,  // 20: pinacle.v1.MembershipService.RegisterUser:output_type -> pinacle.v1.RegisterUserResponse
+	10, // 21: pinacle.v1.MembershipService.VerifyUser:output_type -> pinacle.v1.VerifyUserResponse
+	12, // 22: pinacle.v1.MembershipService.Terminate:output_type -> pinacle.v1.TerminateResponse
+	14, // 23: pinacle.v1.MembershipService.WatchMembership:output_type -> pinacle.v1.WatchMembershipResponse
+	19, // [19:24] is the sub-list for method output_type
+	14, // [14:19] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_pinacle_v1_membership_proto_init() }
+func file_pinacle_v1_membership_proto_init() {
+	if File_pinacle_v1_membership_proto != nil {
+		return
+	}
+	file_pinacle_v1_membership_proto_msgTypes[13].OneofWrappers = []any{
+		(*WatchMembershipResponse_RootChanged)(nil),
+		(*WatchMembershipResponse_Revoked)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pinacle_v1_membership_proto_rawDesc), len(file_pinacle_v1_membership_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pinacle_v1_membership_proto_goTypes,
+		DependencyIndexes: file_pinacle_v1_membership_proto_depIdxs,
+		EnumInfos:         file_pinacle_v1_membership_proto_enumTypes,
+		MessageInfos:      file_pinacle_v1_membership_proto_msgTypes,
+	}.Build()
+	File_pinacle_v1_membership_proto = out.File
+	file_pinacle_v1_membership_proto_goTypes = nil
+	file_pinacle_v1_membership_proto_depIdxs = nil
+}
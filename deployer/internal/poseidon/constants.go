@@ -0,0 +1,157 @@
+package poseidon
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+const (
+	NbFullRounds = 8  // R_F
+	MaxInputs    = 16 // circomlib supports widths t = 2..17
+	fieldBits    = 254
+)
+
+// NbPartialRounds is R_P for each width t = 2..17, indexed by t - 2 (circomlib)
+var NbPartialRounds = []int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68}
+
+// parameters are the round constants and the MDS matrix of a width.
+type parameters struct {
+	t         int
+	partial   int
+	constants []fr.Element // (R_F + R_P) * t round constants
+	mds       [][]fr.Element
+}
+
+var (
+	mu     sync.Mutex
+	params = map[int]*parameters{}
+)
+
+// getParameters returns the parameters of width t, generated on first use.
+func getParameters(t int) *parameters {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if p, ok := params[t]; ok {
+		return p
+	}
+	p := generateParameters(t)
+	params[t] = p
+	return p
+}
+
+// generateParameters derives the parameters like the reference generate_parameters_grain.sage
+// (field 1, S-box x^5, n = 254), which produced the circomlib constants.
+func generateParameters(t int) *parameters {
+	partial := NbPartialRounds[t-2]
+	g := newGrain(t, NbFullRounds, partial)
+	modulus := fr.Modulus()
+
+	p := &parameters{t: t, partial: partial}
+
+	// Round constants are sampled by rejection
+	p.constants = make([]fr.Element, (NbFullRounds+partial)*t)
+	for i := range p.constants {
+		value := g.bits(fieldBits)
+		for value.Cmp(modulus) >= 0 {
+			value = g.bits(fieldBits)
+		}
+		p.constants[i].SetBigInt(value)
+	}
+
+	// Cauchy matrix M[i][j] = 1 / (x_i + y_j) with 2t distinct values, reduced modulo r
+	for {
+		values := make([]fr.Element, 2*t)
+		for distinct := false; !distinct; {
+			seen := map[fr.Element]struct{}{}
+			for i := range values {
+				values[i].SetBigInt(g.bits(fieldBits))
+				seen[values[i]] = struct{}{}
+			}
+			distinct = len(seen) == len(values)
+		}
+
+		mds, ok := cauchy(values[:t], values[t:])
+		if ok {
+			p.mds = mds
+			return p
+		}
+	}
+}
+
+func cauchy(xs, ys []fr.Element) ([][]fr.Element, bool) {
+	mds := make([][]fr.Element, len(xs))
+	for i := range xs {
+		mds[i] = make([]fr.Element, len(ys))
+		for j := range ys {
+			var sum fr.Element
+			sum.Add(&xs[i], &ys[j])
+			if sum.IsZero() {
+				return nil, false
+			}
+			mds[i][j].Inverse(&sum)
+		}
+	}
+	return mds, true
+}
+
+// grain is the Grain LFSR of the Poseidon reference implementation.
+type grain struct {
+	state [80]uint8
+}
+
+func newGrain(t, fullRounds, partialRounds int) *grain {
+	g := &grain{}
+
+	i := 0
+	push := func(value, width int) {
+		for b := width - 1; b >= 0; b-- {
+			g.state[i] = uint8(value>>b) & 1
+			i++
+		}
+	}
+	push(1, 2)          // prime field
+	push(0, 4)          // x^alpha S-box
+	push(fieldBits, 12) // field size
+	push(t, 12)
+	push(fullRounds, 10)
+	push(partialRounds, 10)
+	for ; i < len(g.state); i++ {
+		g.state[i] = 1
+	}
+
+	for range 160 {
+		g.next()
+	}
+	return g
+}
+
+func (g *grain) next() uint8 {
+	bit := g.state[62] ^ g.state[51] ^ g.state[38] ^ g.state[23] ^ g.state[13] ^ g.state[0]
+	copy(g.state[:], g.state[1:])
+	g.state[len(g.state)-1] = bit
+	return bit
+}
+
+// bit returns the next output bit: bits are drawn in pairs and the second one is kept when
+// the first one is 1.
+func (g *grain) bit() uint8 {
+	for g.next() == 0 {
+		g.next()
+	}
+	return g.next()
+}
+
+// bits returns the next n output bits as a big-endian integer.
+func (g *grain) bits(n int) *big.Int {
+	value := new(big.Int)
+	for range n {
+		value.Lsh(value, 1)
+		if g.bit() == 1 {
+			value.SetBit(value, 0, 1)
+		}
+	}
+	return value
+}
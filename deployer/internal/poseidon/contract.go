@@ -0,0 +1,330 @@
+package poseidon
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MaxContractInputs is the widest contract the generator supports, the mix step reaches
+// DUP16 at t = 7.
+const MaxContractInputs = 6
+
+var ErrInvalidContractInputs = fmt.Errorf("number of contract inputs should be [1, %d]", MaxContractInputs)
+
+// ContractABI returns the ABI of the contract generated for nInputs inputs.
+//
+// Like circomlibjs, the contract exposes poseidon(uint256[n]) and poseidon(bytes32[n]). The
+// two input contract also implements the IHasher interface MerkleTreeWithHistory calls, so it
+// can be deployed in place of mimc: hashLeftRight(left, right) calls MiMCSponge(left, 0, 0),
+// then MiMCSponge(right + xL, xR, 0). The first call (xR_in = 0) returns (0, left) and the
+// second one returns (Poseidon(left, right), 0). It relies on left never being zero, which the
+// tree guarantees (leaves, zero values and hashed addresses are non-zero).
+func ContractABI(nInputs int) (string, error) {
+	if nInputs < 1 || nInputs > MaxContractInputs {
+		return "", ErrInvalidContractInputs
+	}
+
+	functions := []string{
+		fmt.Sprintf(`{"inputs":[{"name":"input","type":"uint256[%d]"}],"name":"poseidon","outputs":[{"name":"","type":"uint256"}],"stateMutability":"pure","type":"function"}`, nInputs),
+		fmt.Sprintf(`{"inputs":[{"name":"input","type":"bytes32[%d]"}],"name":"poseidon","outputs":[{"name":"","type":"bytes32"}],"stateMutability":"pure","type":"function"}`, nInputs),
+	}
+	if nInputs == 2 {
+		functions = append(functions, `{"inputs":[{"name":"xL_in","type":"uint256"},{"name":"xR_in","type":"uint256"},{"name":"k","type":"uint256"}],"name":"MiMCSponge","outputs":[{"name":"xL","type":"uint256"},{"name":"xR","type":"uint256"}],"stateMutability":"pure","type":"function"}`)
+	}
+	return "[" + strings.Join(functions, ",") + "]", nil
+}
+
+// ContractCode returns the creation bytecode of a Poseidon contract for nInputs inputs,
+// assembled directly like circomlibjs' poseidon_gencontract.
+func ContractCode(nInputs int) ([]byte, error) {
+	if nInputs < 1 || nInputs > MaxContractInputs {
+		return nil, ErrInvalidContractInputs
+	}
+
+	t := nInputs + 1
+	p := getParameters(t)
+	a := newAssembler()
+
+	// Dispatch on the selector
+	a.push(big.NewInt(0))
+	a.op(vm.CALLDATALOAD)
+	a.push(big.NewInt(0xe0))
+	a.op(vm.SHR)
+	a.dispatch(fmt.Sprintf("poseidon(uint256[%d])", nInputs), "start")
+	a.dispatch(fmt.Sprintf("poseidon(bytes32[%d])", nInputs), "start")
+	if nInputs == 2 {
+		a.dispatch("MiMCSponge(uint256,uint256,uint256)", "sponge")
+	}
+	a.op(vm.INVALID)
+
+	// poseidon(input): [selector (4)] [input[0] (32)] [input[1] (32)] ...
+	a.label("start")
+	a.op(vm.POP)
+	a.push(big.NewInt(0x20)) // return size
+	a.push(fr.Modulus())
+	for i := nInputs - 1; i >= 0; i-- {
+		a.push(big.NewInt(int64(0x04 + 0x20*i)))
+		a.op(vm.CALLDATALOAD)
+	}
+	a.jump("permute")
+
+	// MiMCSponge(xL, xR, k), see ContractABI
+	if nInputs == 2 {
+		a.label("sponge")
+		a.op(vm.POP)
+		a.push(big.NewInt(0x24))
+		a.op(vm.CALLDATALOAD)
+		a.op(vm.DUP1)
+		a.jumpi("spongeHash")
+		a.op(vm.POP)
+		a.push(big.NewInt(0))
+		a.push(big.NewInt(0))
+		a.op(vm.MSTORE)
+		a.push(big.NewInt(0x04))
+		a.op(vm.CALLDATALOAD)
+		a.push(big.NewInt(0x20))
+		a.op(vm.MSTORE)
+		a.push(big.NewInt(0x40))
+		a.push(big.NewInt(0))
+		a.op(vm.RETURN)
+
+		// Poseidon(xR, xL)
+		a.label("spongeHash")
+		a.op(vm.POP)
+		a.push(big.NewInt(0x40)) // return size
+		a.push(fr.Modulus())
+		a.push(big.NewInt(0x04))
+		a.op(vm.CALLDATALOAD)
+		a.push(big.NewInt(0x24))
+		a.op(vm.CALLDATALOAD)
+		a.jump("permute")
+	}
+
+	// Stack: st[1..t-1], q, return size
+	a.label("permute")
+	for i := 0; i < t; i++ {
+		for j := 0; j < t; j++ {
+			a.push(p.mds[i][j].BigInt(new(big.Int)))
+			a.push(big.NewInt(int64((1 + i*t + j) * 32)))
+			a.op(vm.MSTORE)
+		}
+	}
+	a.push(big.NewInt(0)) // st[0]
+
+	rounds := NbFullRounds + p.partial
+	for r := 0; r < rounds; r++ {
+		a.ark(t, p.constants[r*t:(r+1)*t])
+		if r < NbFullRounds/2 || r >= NbFullRounds/2+p.partial {
+			for i := 0; i < t; i++ {
+				a.sigma(t, i)
+			}
+		} else {
+			a.sigma(t, 0)
+		}
+
+		afterMix := fmt.Sprintf("afterMix%d", r)
+		a.pushLabel(afterMix)
+		a.push(big.NewInt(0))
+		a.op(vm.MSTORE)
+		a.jump("mix")
+		a.label(afterMix)
+	}
+
+	// Return st[0], followed by a zero word for MiMCSponge
+	a.push(big.NewInt(0))
+	a.op(vm.MSTORE)
+	a.push(big.NewInt(0))
+	a.push(big.NewInt(0x20))
+	a.op(vm.MSTORE)
+	a.dup(t)
+	a.push(big.NewInt(0))
+	a.op(vm.RETURN)
+
+	a.mix(t)
+
+	runtime, err := a.bytecode()
+	if err != nil {
+		return nil, err
+	}
+	return creationCode(runtime), nil
+}
+
+// DeployContract deploys the Poseidon contract for nInputs inputs.
+func DeployContract(auth *bind.TransactOpts, backend bind.ContractBackend, nInputs int) (common.Address, *ethtypes.Transaction, *bind.BoundContract, error) {
+	definition, err := ContractABI(nInputs)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		return common.Address{}, nil, nil, fmt.Errorf("failed to parse Poseidon ABI: %w", err)
+	}
+	code, err := ContractCode(nInputs)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return bind.DeployContract(auth, parsed, code, backend)
+}
+
+// creationCode prefixes runtime with the code returning it.
+func creationCode(runtime []byte) []byte {
+	a := newAssembler()
+	size := big.NewInt(int64(len(runtime)))
+
+	a.pushN(size, 2)
+	a.pushN(big.NewInt(0x0e), 1) // offset of runtime, the length of this prefix
+	a.push(big.NewInt(0))
+	a.op(vm.CODECOPY)
+	a.pushN(size, 2)
+	a.push(big.NewInt(0))
+	a.op(vm.RETURN)
+
+	return append(a.code, runtime...)
+}
+
+// assembler emits EVM bytecode with forward labels resolved as PUSH2 operands.
+type assembler struct {
+	code   []byte
+	labels map[string]int
+	refs   map[string][]int
+}
+
+func newAssembler() *assembler {
+	return &assembler{labels: map[string]int{}, refs: map[string][]int{}}
+}
+
+func (a *assembler) op(ops ...vm.OpCode) {
+	for _, op := range ops {
+		a.code = append(a.code, byte(op))
+	}
+}
+
+// push pushes value with the smallest PUSH.
+func (a *assembler) push(value *big.Int) {
+	a.pushN(value, max(1, (value.BitLen()+7)/8))
+}
+
+func (a *assembler) pushN(value *big.Int, size int) {
+	a.op(vm.PUSH1 + vm.OpCode(size-1))
+	a.code = append(a.code, value.FillBytes(make([]byte, size))...)
+}
+
+// dup duplicates the n-th stack item, 0 being the top.
+func (a *assembler) dup(n int) {
+	a.op(vm.DUP1 + vm.OpCode(n))
+}
+
+// swap swaps the top with the n-th stack item.
+func (a *assembler) swap(n int) {
+	a.op(vm.SWAP1 + vm.OpCode(n-1))
+}
+
+func (a *assembler) label(name string) {
+	a.labels[name] = len(a.code)
+	a.op(vm.JUMPDEST)
+}
+
+func (a *assembler) pushLabel(name string) {
+	a.op(vm.PUSH2)
+	a.refs[name] = append(a.refs[name], len(a.code))
+	a.code = append(a.code, 0, 0)
+}
+
+func (a *assembler) jump(name string) {
+	a.pushLabel(name)
+	a.op(vm.JUMP)
+}
+
+func (a *assembler) jumpi(name string) {
+	a.pushLabel(name)
+	a.op(vm.JUMPI)
+}
+
+// dispatch jumps to name when the selector on top of the stack is the one of signature.
+func (a *assembler) dispatch(signature, name string) {
+	a.op(vm.DUP1)
+	a.pushN(new(big.Int).SetBytes(crypto.Keccak256([]byte(signature))[:4]), 4)
+	a.op(vm.EQ)
+	a.jumpi(name)
+}
+
+// ark adds the round constants. Stack: st, q.
+func (a *assembler) ark(t int, constants []fr.Element) {
+	for i := 0; i < t; i++ {
+		a.dup(t)
+		a.push(constants[i].BigInt(new(big.Int)))
+		a.dup(2 + i)
+		a.op(vm.ADDMOD)
+		a.swap(1 + i)
+		a.op(vm.POP)
+	}
+}
+
+// sigma raises st[i] to the fifth power. Stack: st, q.
+func (a *assembler) sigma(t, i int) {
+	a.dup(t)     // q, st, q
+	a.dup(1 + i) // st[i], q, st, q
+	a.dup(1)     // q, st[i], q, st, q
+	a.dup(0)     // q, q, st[i], q, st, q
+	a.dup(2)     // st[i], q, q, st[i], q, st, q
+	a.dup(0)     // st[i], st[i], q, q, st[i], q, st, q
+	a.op(vm.MULMOD, vm.DUP1, vm.MULMOD, vm.MULMOD)
+	a.swap(1 + i)
+	a.op(vm.POP)
+}
+
+// mix multiplies the state by the MDS matrix stored at (1 + i*t + j) * 32, then returns to
+// the address stored at 0. Stack: st, q.
+func (a *assembler) mix(t int) {
+	a.label("mix")
+	for i := 0; i < t; i++ {
+		for j := 0; j < t; j++ {
+			if j == 0 {
+				a.dup(i + t) // q, newSt, oldSt, q
+				a.push(big.NewInt(int64((1 + i*t + j) * 32)))
+				a.op(vm.MLOAD) // M, q, newSt, oldSt, q
+				a.dup(2 + i + j)
+				a.op(vm.MULMOD) // acc, newSt, oldSt, q
+				continue
+			}
+			a.dup(1 + i + t) // q, acc, newSt, oldSt, q
+			a.push(big.NewInt(int64((1 + i*t + j) * 32)))
+			a.op(vm.MLOAD) // M, q, acc, newSt, oldSt, q
+			a.dup(3 + i + j)
+			a.op(vm.MULMOD)  // aux, acc, newSt, oldSt, q
+			a.dup(2 + i + t) // q, aux, acc, newSt, oldSt, q
+			a.swap(2)        // acc, aux, q, newSt, oldSt, q
+			a.op(vm.ADDMOD)  // acc, newSt, oldSt, q
+		}
+	}
+	for i := 0; i < t; i++ {
+		a.swap((t - i) + (t - i - 1))
+		a.op(vm.POP)
+	}
+	a.push(big.NewInt(0))
+	a.op(vm.MLOAD, vm.JUMP)
+}
+
+// bytecode resolves the labels.
+func (a *assembler) bytecode() ([]byte, error) {
+	for name, positions := range a.refs {
+		target, ok := a.labels[name]
+		if !ok {
+			return nil, fmt.Errorf("undefined label %s", name)
+		}
+		for _, position := range positions {
+			a.code[position] = byte(target >> 8)
+			a.code[position+1] = byte(target)
+		}
+	}
+	return a.code, nil
+}
@@ -0,0 +1,166 @@
+package poseidon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	ethparams "github.com/ethereum/go-ethereum/params"
+)
+
+// evmPoseidon runs the generated contracts on a simulated chain.
+type evmPoseidon struct {
+	backend *simulated.Backend
+	opts    *bind.TransactOpts
+}
+
+func newEVMPoseidon(t testing.TB) *evmPoseidon {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	deployer := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := simulated.NewBackend(ethtypes.GenesisAlloc{deployer: {Balance: big.NewInt(ethparams.Ether)}})
+	t.Cleanup(func() { backend.Close() })
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		t.Fatalf("failed to create transactor: %v", err)
+	}
+	return &evmPoseidon{backend: backend, opts: opts}
+}
+
+// deploy deploys the contract of nInputs inputs and returns it with its ABI.
+func (e *evmPoseidon) deploy(t testing.TB, nInputs int) (*bind.BoundContract, abi.ABI) {
+	t.Helper()
+
+	_, _, contract, err := DeployContract(e.opts, e.backend.Client(), nInputs)
+	if err != nil {
+		t.Fatalf("failed to deploy Poseidon(%d): %v", nInputs, err)
+	}
+	e.backend.Commit()
+
+	definition, err := ContractABI(nInputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contract, parsed
+}
+
+// call calls the overloaded method (poseidon, poseidon0, MiMCSponge).
+func call(t testing.TB, contract *bind.BoundContract, method string, params ...interface{}) []interface{} {
+	t.Helper()
+
+	var out []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: context.Background()}, &out, method, params...); err != nil {
+		t.Fatalf("%s failed: %v", method, err)
+	}
+	return out
+}
+
+// array converts inputs to a fixed size array of elem, the argument type of poseidon.
+func array(inputs []*big.Int, elem reflect.Type, convert func(*big.Int) reflect.Value) interface{} {
+	value := reflect.New(reflect.ArrayOf(len(inputs), elem)).Elem()
+	for i, input := range inputs {
+		value.Index(i).Set(convert(input))
+	}
+	return value.Interface()
+}
+
+func TestContractMatchesHash(t *testing.T) {
+	e := newEVMPoseidon(t)
+	p := NewPoseidon()
+	rng := rand.New(rand.NewSource(1))
+
+	for n := 1; n <= MaxContractInputs; n++ {
+		contract, parsed := e.deploy(t, n)
+
+		// Overloads are named poseidon and poseidon0 in the order of the ABI
+		uint256Method, bytes32Method := "poseidon", "poseidon0"
+		if parsed.Methods[uint256Method].Inputs[0].Type.Elem.T != abi.UintTy {
+			uint256Method, bytes32Method = bytes32Method, uint256Method
+		}
+
+		for range randomCases {
+			inputs := randomInputs(rng, n)
+			hash, err := p.Hash(inputs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := bigInt(hash)
+
+			out := call(t, contract, uint256Method, array(inputs, reflect.TypeOf((*big.Int)(nil)), func(v *big.Int) reflect.Value {
+				return reflect.ValueOf(v)
+			}))
+			if got := out[0].(*big.Int); got.Cmp(expected) != 0 {
+				t.Fatalf("poseidon(uint256[%d]) returned %s, Go %s", n, got, expected)
+			}
+
+			out = call(t, contract, bytes32Method, array(inputs, reflect.TypeOf([32]byte{}), func(v *big.Int) reflect.Value {
+				return reflect.ValueOf([32]byte(common.BigToHash(v)))
+			}))
+			if got := out[0].([32]byte); common.Hash(got).Big().Cmp(expected) != 0 {
+				t.Fatalf("poseidon(bytes32[%d]) returned %x, Go %s", n, got, expected)
+			}
+		}
+	}
+}
+
+func TestContractHashLeftRight(t *testing.T) {
+	// hashLeftRight of MerkleTreeWithHistory through the MiMCSponge adapter
+	e := newEVMPoseidon(t)
+	p := NewPoseidon()
+	contract, _ := e.deploy(t, 2)
+	rng := rand.New(rand.NewSource(2))
+
+	sponge := func(xL, xR *big.Int) (*big.Int, *big.Int) {
+		out := call(t, contract, "MiMCSponge", xL, xR, big.NewInt(0))
+		return out[0].(*big.Int), out[1].(*big.Int)
+	}
+
+	for range randomCases {
+		left, right := randomElement(rng), randomElement(rng)
+		if left.Sign() == 0 {
+			continue
+		}
+
+		r, c := sponge(left, big.NewInt(0))
+		r = new(big.Int).Add(r, right)
+		r.Mod(r, fr.Modulus())
+		r, _ = sponge(r, c)
+
+		hash, err := p.HashLeftRight(left, right)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Cmp(bigInt(hash)) != 0 {
+			t.Fatalf("hashLeftRight(%s, %s) returned %s, Go %s", left, right, r, bigInt(hash))
+		}
+	}
+}
+
+func TestContractInvalidInputs(t *testing.T) {
+	for _, n := range []int{0, MaxContractInputs + 1} {
+		if _, err := ContractCode(n); !errors.Is(err, ErrInvalidContractInputs) {
+			t.Fatalf("expected %v for %d inputs, got %v", ErrInvalidContractInputs, n, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package poseidon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidInputs = fmt.Errorf("number of inputs should be [1, %d]", MaxInputs)
+	ErrInputNotField = errors.New("input is not inside the field")
+)
+
+// Poseidon is the circomlib Poseidon hash over BN254: width t = inputs + 1, the state is
+// [0, inputs...] and the hash is the first element of the permuted state.
+type Poseidon struct{}
+
+// NewPoseidon returns a Poseidon hasher. Parameters are generated on first use of each width.
+func NewPoseidon() *Poseidon {
+	return &Poseidon{}
+}
+
+// Hash computes Poseidon(inputs). Like circomlibjs, inputs must be canonical field elements.
+func (p *Poseidon) Hash(inputs []*big.Int) (fr.Element, error) {
+	if len(inputs) == 0 || len(inputs) > MaxInputs {
+		return fr.Element{}, ErrInvalidInputs
+	}
+
+	state := make([]fr.Element, len(inputs)+1)
+	for i, input := range inputs {
+		if input == nil || input.Sign() < 0 || input.Cmp(fr.Modulus()) >= 0 {
+			return fr.Element{}, fmt.Errorf("%w: input %d", ErrInputNotField, i)
+		}
+		state[i+1].SetBigInt(input)
+	}
+
+	permute(state, getParameters(len(state)))
+	return state[0], nil
+}
+
+// HashLeftRight computes Poseidon(left, right), the Merkle tree node hash.
+func (p *Poseidon) HashLeftRight(left, right *big.Int) (fr.Element, error) {
+	return p.Hash([]*big.Int{left, right})
+}
+
+// HashAddress computes Poseidon(address, 0), mirroring hashAddress in zkLogin
+// (hashLeftRight(uint160(address), 0)).
+func (p *Poseidon) HashAddress(address *common.Address) *big.Int {
+	hash, _ := p.HashLeftRight(address.Big(), big.NewInt(0))
+	return hash.BigInt(new(big.Int))
+}
+
+// permute applies the full, partial and full rounds to state.
+func permute(state []fr.Element, params *parameters) {
+	t := params.t
+	rounds := NbFullRounds + params.partial
+	next := make([]fr.Element, t)
+
+	for r := 0; r < rounds; r++ {
+		for i := range state {
+			state[i].Add(&state[i], &params.constants[r*t+i])
+		}
+
+		if r < NbFullRounds/2 || r >= NbFullRounds/2+params.partial {
+			for i := range state {
+				sbox(&state[i])
+			}
+		} else {
+			sbox(&state[0])
+		}
+
+		for i := range next {
+			next[i].SetZero()
+			for j := range state {
+				var tmp fr.Element
+				tmp.Mul(&params.mds[i][j], &state[j])
+				next[i].Add(&next[i], &tmp)
+			}
+		}
+		copy(state, next)
+	}
+}
+
+// sbox sets x = x^5.
+func sbox(x *fr.Element) {
+	var x2 fr.Element
+	x2.Square(x)
+	x2.Square(&x2)
+	x.Mul(x, &x2)
+}
@@ -0,0 +1,124 @@
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/common"
+	iden3 "github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// randomCases is the number of random inputs of each property test.
+const randomCases = 16
+
+// vectors are the outputs of circomlibjs poseidon (test/poseidon.js).
+var vectors = []struct {
+	inputs   []int64
+	expected string
+}{
+	{[]int64{1}, "18586133768512220936620570745912940619677854269274689475585506675881198879027"},
+	{[]int64{1, 2}, "0x115cc0f5e7d690413df64c6b9662e9cf2a3617f2743245519e19607a4417189a"},
+	{[]int64{1, 2, 0, 0, 0}, "0x024058dd1e168f34bac462b6fffe58fd69982807e9884c1c6148182319cee427"},
+	{[]int64{1, 2, 3, 4}, "0x299c867db6c1fdd79dcefa40e4510b9837e60ebb1ce0663dbaa525df65250465"},
+}
+
+// randomElement returns a canonical field element, biased towards the edges of the field.
+func randomElement(rng *rand.Rand) *big.Int {
+	switch rng.Intn(8) {
+	case 0:
+		return big.NewInt(rng.Int63n(4))
+	case 1:
+		return new(big.Int).Sub(fr.Modulus(), big.NewInt(rng.Int63n(4)+1))
+	default:
+		return new(big.Int).Rand(rng, fr.Modulus())
+	}
+}
+
+func randomInputs(rng *rand.Rand, n int) []*big.Int {
+	inputs := make([]*big.Int, n)
+	for i := range inputs {
+		inputs[i] = randomElement(rng)
+	}
+	return inputs
+}
+
+func bigInt(e fr.Element) *big.Int {
+	return e.BigInt(new(big.Int))
+}
+
+func TestHashVectors(t *testing.T) {
+	p := NewPoseidon()
+	for _, vector := range vectors {
+		inputs := make([]*big.Int, len(vector.inputs))
+		for i, input := range vector.inputs {
+			inputs[i] = big.NewInt(input)
+		}
+		expected, ok := new(big.Int).SetString(vector.expected, 0)
+		if !ok {
+			t.Fatalf("invalid vector %s", vector.expected)
+		}
+
+		hash, err := p.Hash(inputs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bigInt(hash).Cmp(expected) != 0 {
+			t.Fatalf("poseidon(%v) = %s, circomlibjs returns %s", vector.inputs, bigInt(hash), expected)
+		}
+	}
+}
+
+func TestHashMatchesIden3(t *testing.T) {
+	// go-iden3-crypto ships the circomlib constants, this checks the Grain derivation of every width
+	p := NewPoseidon()
+	rng := rand.New(rand.NewSource(1))
+	for n := 1; n <= MaxInputs; n++ {
+		for range randomCases {
+			inputs := randomInputs(rng, n)
+
+			hash, err := p.Hash(inputs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, err := iden3.Hash(inputs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bigInt(hash).Cmp(expected) != 0 {
+				t.Fatalf("poseidon(%v) = %s, go-iden3-crypto returns %s", inputs, bigInt(hash), expected)
+			}
+		}
+	}
+}
+
+func TestHashInvalidInputs(t *testing.T) {
+	p := NewPoseidon()
+
+	if _, err := p.Hash(nil); !errors.Is(err, ErrInvalidInputs) {
+		t.Fatalf("expected %v, got %v", ErrInvalidInputs, err)
+	}
+	if _, err := p.Hash(make([]*big.Int, MaxInputs+1)); !errors.Is(err, ErrInvalidInputs) {
+		t.Fatalf("expected %v, got %v", ErrInvalidInputs, err)
+	}
+	for _, input := range []*big.Int{nil, big.NewInt(-1), fr.Modulus()} {
+		if _, err := p.Hash([]*big.Int{input}); !errors.Is(err, ErrInputNotField) {
+			t.Fatalf("expected %v for %v, got %v", ErrInputNotField, input, err)
+		}
+	}
+}
+
+func TestHashAddress(t *testing.T) {
+	p := NewPoseidon()
+	address := common.HexToAddress("0x5B38Da6a701c568545dCfcB03FcB875f56beddC4")
+
+	expected, err := iden3.Hash([]*big.Int{address.Big(), big.NewInt(0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash := p.HashAddress(&address); hash.Cmp(expected) != 0 {
+		t.Fatalf("HashAddress returned %s, expected %s", hash, expected)
+	}
+}
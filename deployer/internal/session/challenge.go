@@ -0,0 +1,160 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"deployer/internal/hasher"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxChallenges bounds the outstanding challenges, each expiring after the challenge TTL.
+const maxChallenges = 100_000
+
+var (
+	ErrUnknownChallenge  = errors.New("unknown, used or expired login challenge")
+	ErrInvalidSignature  = errors.New("login signature does not match the address")
+	ErrTooManyChallenges = errors.New("too many outstanding login challenges")
+)
+
+// Challenges hands out one-time login nonces. The proofs and senders of the zkLogin transactions
+// are public, so a login must also carry a nonce issued to its address, signed by that address.
+type Challenges struct {
+	mu     sync.Mutex
+	issuer string
+	ttl    time.Duration
+	nonces map[string]challenge // nonce -> challenge
+}
+
+type challenge struct {
+	address   common.Address
+	expiresAt time.Time
+}
+
+// NewChallenges creates an empty challenge store. issuer is part of every signed message.
+func NewChallenges(issuer string, ttl time.Duration) (*Challenges, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidTTL
+	}
+	return &Challenges{
+		issuer: issuer,
+		ttl:    ttl,
+		nonces: make(map[string]challenge),
+	}, nil
+}
+
+// ChallengeMessage returns the message the address signs (EIP-191 personal_sign) to log in.
+func ChallengeMessage(issuer string, address common.Address, nonce string) string {
+	return fmt.Sprintf("%s login\naddress: %s\nnonce: %s", issuer, address.Hex(), nonce)
+}
+
+// Issue creates a nonce for the address, valid once until it expires.
+func (c *Challenges) Issue(address common.Address) (*types.Challenge, error) {
+	var raw [32]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate login nonce: %w", err)
+	}
+	nonce := hex.EncodeToString(raw[:])
+	expiresAt := time.Now().Add(c.ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.nonces) >= maxChallenges {
+		return nil, ErrTooManyChallenges
+	}
+	c.nonces[nonce] = challenge{address: address, expiresAt: expiresAt}
+
+	return &types.Challenge{
+		Nonce:     nonce,
+		Message:   ChallengeMessage(c.issuer, address, nonce),
+		ExpiresAt: expiresAt.Unix(),
+	}, nil
+}
+
+// Verify consumes the nonce of the request and checks that the request address signed it.
+// A nonce is consumed even when the signature is wrong, so it cannot be brute forced.
+func (c *Challenges) Verify(req *types.LoginRequest) error {
+	c.mu.Lock()
+	issued, ok := c.nonces[req.Nonce]
+	delete(c.nonces, req.Nonce)
+	c.mu.Unlock()
+
+	if !ok || issued.address != req.Address || time.Now().After(issued.expiresAt) {
+		return ErrUnknownChallenge
+	}
+
+	signature, err := hexutil.Decode(req.Signature)
+	if err != nil || len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+	// Wallets sign with v = 27/28, crypto.SigToPub expects 0/1
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	hash := accounts.TextHash([]byte(ChallengeMessage(c.issuer, req.Address, req.Nonce)))
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil || crypto.PubkeyToAddress(*publicKey) != req.Address {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Prune drops the expired challenges.
+func (c *Challenges) Prune() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for nonce, issued := range c.nonces {
+		if now.After(issued.expiresAt) {
+			delete(c.nonces, nonce)
+		}
+	}
+}
+
+// ChallengeVerifier requires a login to be signed by its address over a challenge before the
+// proof is checked, and the proof to be the one of that address.
+type ChallengeVerifier struct {
+	challenges *Challenges
+	hasher     hasher.Hasher
+	next       ProofVerifier
+}
+
+// NewChallengeVerifier wraps the proof verifier next.
+func NewChallengeVerifier(challenges *Challenges, hasher hasher.Hasher, next ProofVerifier) *ChallengeVerifier {
+	return &ChallengeVerifier{
+		challenges: challenges,
+		hasher:     hasher,
+		next:       next,
+	}
+}
+
+// VerifyLogin implements ProofVerifier.
+func (v *ChallengeVerifier) VerifyLogin(ctx context.Context, req *types.LoginRequest) (*big.Int, error) {
+	if req.Address == (common.Address{}) {
+		return nil, ErrAddressRequired
+	}
+	if err := v.challenges.Verify(req); err != nil {
+		return nil, err
+	}
+
+	hashedAddress, err := v.next.VerifyLogin(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if v.hasher.HashAddress(&req.Address).Cmp(hashedAddress) != 0 {
+		return nil, ErrAddressMismatch
+	}
+	return hashedAddress, nil
+}
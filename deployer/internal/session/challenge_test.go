@@ -0,0 +1,165 @@
+package session
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"deployer/internal/hasher"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// publicProofVerifier accepts any proof as the one of owner, like a zkMerkleTree proof copied
+// from the calldata of a registration.
+type publicProofVerifier struct {
+	owner *big.Int
+}
+
+func (p *publicProofVerifier) VerifyLogin(context.Context, *types.LoginRequest) (*big.Int, error) {
+	return p.owner, nil
+}
+
+type loginSetup struct {
+	hasher     hasher.Hasher
+	challenges *Challenges
+	verifier   *ChallengeVerifier
+	victim     *ecdsa.PrivateKey
+	attacker   *ecdsa.PrivateKey
+}
+
+func newLoginSetup(t *testing.T, ttl time.Duration) *loginSetup {
+	t.Helper()
+	h, err := hasher.New(types.HasherMiMC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenges, err := NewChallenges("pinacle", ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &loginSetup{hasher: h, challenges: challenges, victim: newKey(t), attacker: newKey(t)}
+	victim := address(s.victim)
+	s.verifier = NewChallengeVerifier(challenges, h, &publicProofVerifier{owner: h.HashAddress(&victim)})
+	return s
+}
+
+// login asks a challenge for address and signs it with key.
+func (s *loginSetup) login(t *testing.T, address common.Address, key *ecdsa.PrivateKey) *types.LoginRequest {
+	t.Helper()
+	challenge, err := s.challenges.Issue(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &types.LoginRequest{
+		Role:      types.RoleUser,
+		Address:   address,
+		Nonce:     challenge.Nonce,
+		Signature: sign(t, key, challenge.Message),
+	}
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func address(key *ecdsa.PrivateKey) common.Address {
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
+
+// sign signs message like personal_sign, with v = 27/28.
+func sign(t *testing.T, key *ecdsa.PrivateKey, message string) string {
+	t.Helper()
+	signature, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(signature)
+}
+
+func TestChallengeLogin(t *testing.T) {
+	s := newLoginSetup(t, time.Minute)
+	victim := address(s.victim)
+
+	req := s.login(t, victim, s.victim)
+	hashedAddress, err := s.verifier.VerifyLogin(context.Background(), req)
+	if err != nil {
+		t.Fatalf("login of the key holder rejected: %v", err)
+	}
+	if hashedAddress.Cmp(s.hasher.HashAddress(&victim)) != 0 {
+		t.Fatalf("unexpected hashed address %s", hashedAddress)
+	}
+
+	// The same signed login cannot be replayed
+	if _, err := s.verifier.VerifyLogin(context.Background(), req); !errors.Is(err, ErrUnknownChallenge) {
+		t.Fatalf("replayed login: got %v, want %v", err, ErrUnknownChallenge)
+	}
+}
+
+func TestChallengeLoginReplayedProof(t *testing.T) {
+	s := newLoginSetup(t, time.Minute)
+	victim, attacker := address(s.victim), address(s.attacker)
+
+	// The victim's public proof, presented with the victim's address but signed by the attacker
+	req := s.login(t, victim, s.attacker)
+	if _, err := s.verifier.VerifyLogin(context.Background(), req); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("login signed by another key: got %v, want %v", err, ErrInvalidSignature)
+	}
+
+	// The victim's public proof, presented with the attacker's own address
+	req = s.login(t, attacker, s.attacker)
+	if _, err := s.verifier.VerifyLogin(context.Background(), req); !errors.Is(err, ErrAddressMismatch) {
+		t.Fatalf("proof of another address: got %v, want %v", err, ErrAddressMismatch)
+	}
+
+	// A nonce issued to the attacker, signed by the attacker, presented for the victim
+	req = s.login(t, attacker, s.attacker)
+	req.Address = victim
+	if _, err := s.verifier.VerifyLogin(context.Background(), req); !errors.Is(err, ErrUnknownChallenge) {
+		t.Fatalf("nonce of another address: got %v, want %v", err, ErrUnknownChallenge)
+	}
+
+	// No signature at all
+	req = s.login(t, victim, s.victim)
+	req.Signature = "0x"
+	if _, err := s.verifier.VerifyLogin(context.Background(), req); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("unsigned login: got %v, want %v", err, ErrInvalidSignature)
+	}
+
+	// Without an address
+	req = s.login(t, victim, s.victim)
+	req.Address = common.Address{}
+	if _, err := s.verifier.VerifyLogin(context.Background(), req); !errors.Is(err, ErrAddressRequired) {
+		t.Fatalf("login without address: got %v, want %v", err, ErrAddressRequired)
+	}
+}
+
+func TestChallengeExpiry(t *testing.T) {
+	s := newLoginSetup(t, 10*time.Millisecond)
+
+	req := s.login(t, address(s.victim), s.victim)
+	time.Sleep(20 * time.Millisecond)
+	if _, err := s.verifier.VerifyLogin(context.Background(), req); !errors.Is(err, ErrUnknownChallenge) {
+		t.Fatalf("expired challenge: got %v, want %v", err, ErrUnknownChallenge)
+	}
+
+	s.login(t, address(s.victim), s.victim)
+	time.Sleep(20 * time.Millisecond)
+	s.challenges.Prune()
+	if n := len(s.challenges.nonces); n != 0 {
+		t.Fatalf("%d expired challenges kept after prune", n)
+	}
+}
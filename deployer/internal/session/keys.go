@@ -0,0 +1,91 @@
+package session
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrInvalidPEM        = errors.New("failed to decode PEM block")
+	ErrNotEd25519Key     = errors.New("key is not an Ed25519 key")
+	ErrEmptySigningKey   = errors.New("signing key cannot be empty")
+	ErrEmptyVerifyingKey = errors.New("verification key cannot be empty")
+)
+
+// LoadSigningKey reads a PKCS#8 PEM encoded Ed25519 private key
+// (e.g. `openssl genpkey -algorithm ed25519 -out session.pem`).
+func LoadSigningKey(filename string) (ed25519.PrivateKey, error) {
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrNotEd25519Key
+	}
+	return privateKey, nil
+}
+
+// LoadVerificationKey reads a PKIX PEM encoded Ed25519 public key
+// (e.g. `openssl pkey -in session.pem -pubout -out session.pub.pem`).
+func LoadVerificationKey(filename string) (ed25519.PublicKey, error) {
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verification key: %w", err)
+	}
+	return ParseVerificationKey(raw)
+}
+
+// ParseVerificationKey parses a PKIX PEM encoded Ed25519 public key.
+func ParseVerificationKey(raw []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse verification key: %w", err)
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, ErrNotEd25519Key
+	}
+	return publicKey, nil
+}
+
+// EncodeVerificationKey returns the PKIX PEM encoding of the public key, so it can be
+// distributed to downstream services.
+func EncodeVerificationKey(publicKey ed25519.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal verification key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// GenerateSigningKey creates a random Ed25519 key. Tokens signed with it do not survive a restart.
+func GenerateSigningKey() (ed25519.PrivateKey, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,185 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	zklogin "deployer/internal/abigen/zkLogin"
+	"deployer/internal/hasher"
+	"deployer/internal/metrics"
+	"deployer/internal/types"
+	"deployer/internal/zkp"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrNotMerkleTreeProof = errors.New("login requires a zkMerkleTree proof (non-zero hashed address and root)")
+	ErrProofRejected      = errors.New("login proof rejected")
+	ErrAddressMismatch    = errors.New("hashed address does not match the public signals")
+	ErrAddressRequired    = errors.New("address is required to log in")
+	ErrUnknownRoot        = errors.New("unknown merkle root")
+	ErrAccountRevoked     = errors.New("account has been terminated or revoked")
+	ErrRootNotVerifiable  = errors.New("logins of roles other than users require a root source for local verification")
+)
+
+// ProofVerifier checks a login request and returns the verified hashed address.
+// The proofs of the zkLogin transactions are public, so the gateway wraps it in a
+// ChallengeVerifier to check that the caller holds the key of the address.
+type ProofVerifier interface {
+	VerifyLogin(ctx context.Context, req *types.LoginRequest) (*big.Int, error)
+}
+
+// RootChecker reports whether a root belongs to the tree of the given role, and whether the
+// account of a hashed address has been terminated or revoked since.
+type RootChecker interface {
+	IsKnownRoot(role types.Role, root *big.Int) bool
+	IsRevoked(hashedAddress *big.Int) bool
+}
+
+// LocalProofVerifier verifies the Groth16 proof against the verification key without touching the chain.
+// The circuit alone does not tell the food bank tree from the user tree, so the role is only
+// trusted when a RootChecker is configured. Without one, only user logins are accepted and a
+// terminated account keeps logging in, so it should only be used where the callers are already
+// trusted (e.g. behind mutual TLS).
+type LocalProofVerifier struct {
+	verifier *zkp.Verifier
+	hasher   hasher.Hasher
+	roots    RootChecker
+}
+
+// NewLocalProofVerifier creates a LocalProofVerifier. hasher is used to bind the address to the proof,
+// roots may be nil.
+func NewLocalProofVerifier(verifier *zkp.Verifier, hasher hasher.Hasher, roots RootChecker) *LocalProofVerifier {
+	return &LocalProofVerifier{
+		verifier: verifier,
+		hasher:   hasher,
+		roots:    roots,
+	}
+}
+
+// VerifyLogin implements ProofVerifier.
+func (l *LocalProofVerifier) VerifyLogin(_ context.Context, req *types.LoginRequest) (*big.Int, error) {
+	if req.Address == (common.Address{}) {
+		return nil, ErrAddressRequired
+	}
+
+	proof := newZKProof(req)
+
+	// Shape check of pi_a, pi_b and pi_c before handing the proof to the verifier
+	if _, err := proof.ConvertProof(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+	var publicSignals [types.PINACLE_PUBLIC_SIGNALS]*big.Int
+	if err := proof.ConvertPublicSignalsTo(publicSignals[:]); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+	if publicSignals[0].Sign() == 0 || publicSignals[1].Sign() == 0 {
+		return nil, ErrNotMerkleTreeProof
+	}
+
+	switch {
+	case !req.Role.Valid():
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedRole, req.Role)
+	case l.roots != nil:
+		if !l.roots.IsKnownRoot(req.Role, publicSignals[1]) {
+			return nil, ErrUnknownRoot
+		}
+		if l.roots.IsRevoked(publicSignals[0]) {
+			return nil, ErrAccountRevoked
+		}
+	case req.Role != types.RoleUser:
+		return nil, ErrRootNotVerifiable
+	}
+
+	err := l.verifier.VerifyProofs(proof)
+	metrics.ObserveVerification("local", err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+
+	// Bind the proof to the caller
+	if l.hasher.HashAddress(&req.Address).Cmp(publicSignals[0]) != 0 {
+		return nil, ErrAddressMismatch
+	}
+
+	return publicSignals[0], nil
+}
+
+// ChainProofVerifier verifies the login against the zkLogin contract with an eth_call made on behalf of the caller.
+// The call runs the same checks as every protected function (blacklist, proof, hashed address, known root)
+// but, being a call, never changes state.
+type ChainProofVerifier struct {
+	caller *zklogin.ZkloginCallerRaw
+}
+
+// NewChainProofVerifier binds the verifier to a deployed zkLogin contract.
+func NewChainProofVerifier(address common.Address, backend bind.ContractCaller) (*ChainProofVerifier, error) {
+	caller, err := zklogin.NewZkloginCaller(address, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind zkLogin: %w", err)
+	}
+	return &ChainProofVerifier{
+		caller: &zklogin.ZkloginCallerRaw{Contract: caller},
+	}, nil
+}
+
+// VerifyLogin implements ProofVerifier.
+func (c *ChainProofVerifier) VerifyLogin(ctx context.Context, req *types.LoginRequest) (*big.Int, error) {
+	if req.Address == (common.Address{}) {
+		return nil, ErrAddressRequired
+	}
+
+	// Functions guarded by validMerkleTreeZKP for the caller's own tree
+	var (
+		method string
+		args   []interface{}
+	)
+	switch {
+	case req.Role == types.RoleFoodBank:
+		method = "deleteFoodBankMerkleProofs"
+	case req.Role == types.RoleUser:
+		method = "deleteUserMerkleProofs"
+	case req.Role.Valid():
+		method, args = "deleteMemberMerkleProofs", []interface{}{uint32(req.Role)}
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedRole, req.Role)
+	}
+
+	proof := newZKProof(req)
+	proofConverted, err := proof.ConvertProof()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+	var publicSignals [types.PINACLE_PUBLIC_SIGNALS]*big.Int
+	if err := proof.ConvertPublicSignalsTo(publicSignals[:]); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+
+	var out []interface{}
+	err = c.caller.Call(&bind.CallOpts{From: req.Address, Context: ctx}, &out, method, append(args, *proofConverted, publicSignals)...)
+	metrics.ObserveVerification("onchain", err)
+	if err != nil {
+		// Reverts carry the contract reason, e.g. "zkMerkleTree: Unknown Root Detected"
+		return nil, fmt.Errorf("%w: %v", ErrProofRejected, err)
+	}
+	if len(out) != 1 {
+		return nil, fmt.Errorf("%w: unexpected %s output", ErrProofRejected, method)
+	}
+	if ok, _ := out[0].(bool); !ok {
+		return nil, ErrProofRejected
+	}
+
+	return publicSignals[0], nil
+}
+
+// newZKProof wraps the request into the zkp representation used by the verifier and the converters.
+func newZKProof(req *types.LoginRequest) *zkp.ZKProof {
+	proof := zkp.NewZKProof()
+	proof.SetProof(req.Proof)
+	proof.SetPublicSignals(req.PublicSignals)
+	return proof
+}
@@ -0,0 +1,75 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"deployer/internal/types"
+)
+
+type contextKey struct{}
+
+// Middleware authenticates requests carrying an access token in the Authorization header
+// and stores the claims in the request context. Unauthenticated requests are rejected with 401.
+func Middleware(verifier *Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := bearerToken(r)
+			if err != nil {
+				unauthorized(w, err)
+				return
+			}
+
+			claims, err := verifier.Verify(token, types.AccessToken)
+			if err != nil {
+				unauthorized(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), claims)))
+		})
+	}
+}
+
+// RequireRole rejects authenticated requests whose role differs with 403.
+// It must be wrapped by Middleware.
+func RequireRole(role types.Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			unauthorized(w, ErrMissingAuthToken)
+			return
+		}
+		if claims.Role != role {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// NewContext returns a copy of ctx carrying the claims.
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims stored by Middleware.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok
+}
+
+// bearerToken extracts the token from "Authorization: Bearer <token>".
+func bearerToken(r *http.Request) (string, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", ErrMissingAuthToken
+	}
+	return strings.TrimSpace(token), nil
+}
+
+func unauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	http.Error(w, err.Error(), http.StatusUnauthorized)
+}
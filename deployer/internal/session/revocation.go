@@ -0,0 +1,73 @@
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+// RevocationList reports whether an otherwise valid token must be rejected.
+type RevocationList interface {
+	IsRevoked(claims *Claims) bool
+}
+
+// Revocations is an in-memory RevocationList.
+// A subject (hashed address) is revoked from a point in time, so every token issued before
+// that point is rejected while a later login (e.g. after re-registration) still works.
+// Single tokens are revoked by their ID until they expire.
+type Revocations struct {
+	mu       sync.RWMutex
+	subjects map[string]time.Time // subject -> revoked at
+	tokens   map[string]time.Time // token id -> expires at
+}
+
+// NewRevocations creates an empty revocation list.
+func NewRevocations() *Revocations {
+	return &Revocations{
+		subjects: make(map[string]time.Time),
+		tokens:   make(map[string]time.Time),
+	}
+}
+
+// RevokeSubject rejects every token of the subject issued up to now.
+func (r *Revocations) RevokeSubject(subject string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subjects[subject] = time.Now()
+}
+
+// RevokeToken rejects a single token until its expiry.
+func (r *Revocations) RevokeToken(id string, expiresAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[id] = expiresAt
+}
+
+// IsRevoked implements RevocationList.
+func (r *Revocations) IsRevoked(claims *Claims) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.tokens[claims.ID]; ok {
+		return true
+	}
+
+	revokedAt, ok := r.subjects[claims.Subject]
+	if !ok {
+		return false
+	}
+	// Tokens carry second precision, so a token minted in the same second as the revocation is rejected too
+	return claims.IssuedAt == nil || !claims.IssuedAt.Time.After(revokedAt)
+}
+
+// Prune drops revoked token IDs that are expired anyway.
+func (r *Revocations) Prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, expiresAt := range r.tokens {
+		if now.After(expiresAt) {
+			delete(r.tokens, id)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package session
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"deployer/internal/types"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	ErrInvalidToken     = errors.New("invalid session token")
+	ErrRevokedToken     = errors.New("session token has been revoked")
+	ErrWrongTokenType   = errors.New("unexpected session token type")
+	ErrInvalidTTL       = errors.New("token TTL must be greater than 0")
+	ErrEmptySubject     = errors.New("hashed address cannot be empty")
+	ErrUnsupportedRole  = errors.New("unsupported role")
+	ErrMissingAuthToken = errors.New("missing bearer token")
+)
+
+// Claims are the JWT claims of a Pinacle session.
+// The subject is the hashed address (MiMC(address, 0)) in decimal, exactly as it appears
+// in the first public signal of the circuit. The raw address is never part of a token.
+type Claims struct {
+	Role types.Role      `json:"role"`
+	Type types.TokenType `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// HashedAddress returns the subject as a big integer.
+func (c *Claims) HashedAddress() (*big.Int, error) {
+	hashedAddress, ok := new(big.Int).SetString(c.Subject, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed subject", ErrInvalidToken)
+	}
+	return hashedAddress, nil
+}
+
+type Issuer struct {
+	key         ed25519.PrivateKey
+	issuer      string
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+	revocations *Revocations
+}
+
+// NewIssuer creates a token issuer.
+//
+// Parameters:
+//   - key:         Ed25519 signing key.
+//   - issuer:      value of the "iss" claim, checked on verification.
+//   - accessTTL:   lifetime of access tokens, keep it short since downstream services may not see revocations.
+//   - refreshTTL:  lifetime of refresh tokens.
+//   - revocations: shared revocation list.
+func NewIssuer(key ed25519.PrivateKey, issuer string, accessTTL, refreshTTL time.Duration, revocations *Revocations) (*Issuer, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, ErrEmptySigningKey
+	}
+	if accessTTL <= 0 || refreshTTL <= 0 {
+		return nil, ErrInvalidTTL
+	}
+	if revocations == nil {
+		revocations = NewRevocations()
+	}
+
+	return &Issuer{
+		key:         key,
+		issuer:      issuer,
+		accessTTL:   accessTTL,
+		refreshTTL:  refreshTTL,
+		revocations: revocations,
+	}, nil
+}
+
+// Issue mints an access/refresh token pair for a verified hashed address.
+func (i *Issuer) Issue(role types.Role, hashedAddress *big.Int) (*types.TokenPair, error) {
+	if hashedAddress == nil || hashedAddress.Sign() == 0 {
+		return nil, ErrEmptySubject
+	}
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedRole, role)
+	}
+
+	subject := hashedAddress.String()
+	now := time.Now()
+
+	accessToken, err := i.sign(role, types.AccessToken, subject, now, i.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := i.sign(role, types.RefreshToken, subject, now, i.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TokenPair{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int64(i.accessTTL.Seconds()),
+		RefreshTokenExpiresIn: int64(i.refreshTTL.Seconds()),
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new pair. The presented refresh token is revoked (rotation),
+// so a stolen refresh token can only be used once.
+func (i *Issuer) Refresh(refreshToken string) (*types.TokenPair, error) {
+	claims, err := i.Verifier().Verify(refreshToken, types.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedAddress, err := claims.HashedAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	i.revocations.RevokeToken(claims.ID, claims.ExpiresAt.Time)
+	return i.Issue(claims.Role, hashedAddress)
+}
+
+// Revoke rejects every token issued so far to the hashed address,
+// e.g. after the account has been terminated on-chain.
+func (i *Issuer) Revoke(hashedAddress *big.Int) {
+	i.revocations.RevokeSubject(hashedAddress.String())
+}
+
+// RevokeToken rejects a single token (logout).
+func (i *Issuer) RevokeToken(claims *Claims) {
+	i.revocations.RevokeToken(claims.ID, claims.ExpiresAt.Time)
+}
+
+// Verifier returns a Verifier sharing the issuer's key and revocation list.
+func (i *Issuer) Verifier() *Verifier {
+	return NewVerifier(i.PublicKey(), i.issuer, i.revocations)
+}
+
+// PublicKey returns the verification key for downstream services.
+func (i *Issuer) PublicKey() ed25519.PublicKey {
+	return i.key.Public().(ed25519.PublicKey)
+}
+
+// sign creates a single signed token.
+func (i *Issuer) sign(role types.Role, tokenType types.TokenType, subject string, now time.Time, ttl time.Duration) (string, error) {
+	id, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	claims := &Claims{
+		Role: role,
+		Type: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    i.issuer,
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims).SignedString(i.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign %s token: %w", tokenType, err)
+	}
+	return token, nil
+}
+
+type Verifier struct {
+	key         ed25519.PublicKey
+	issuer      string
+	revocations RevocationList
+}
+
+// NewVerifier creates a token verifier. revocations may be nil for services that only
+// rely on the short access token lifetime.
+func NewVerifier(key ed25519.PublicKey, issuer string, revocations RevocationList) *Verifier {
+	return &Verifier{
+		key:         key,
+		issuer:      issuer,
+		revocations: revocations,
+	}
+}
+
+// Verify checks the signature, the registered claims, the token type and the revocation list.
+func (v *Verifier) Verify(token string, expected types.TokenType) (*Claims, error) {
+	if len(v.key) != ed25519.PublicKeySize {
+		return nil, ErrEmptyVerifyingKey
+	}
+
+	claims := &Claims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}))
+	_, err := parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return v.key, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if !claims.VerifyIssuer(v.issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if claims.Subject == "" || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: missing claims", ErrInvalidToken)
+	}
+	if claims.Type != expected {
+		return nil, fmt.Errorf("%w: got %q want %q", ErrWrongTokenType, claims.Type, expected)
+	}
+	if v.revocations != nil && v.revocations.IsRevoked(claims) {
+		return nil, ErrRevokedToken
+	}
+
+	return claims, nil
+}
+
+// newTokenID returns a random 128-bit token ID.
+func newTokenID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+	return hex.EncodeToString(id[:]), nil
+}
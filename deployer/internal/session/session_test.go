@@ -0,0 +1,179 @@
+package session
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"deployer/internal/types"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func newIssuer(t *testing.T, issuer string, accessTTL, refreshTTL time.Duration) *Issuer {
+	t.Helper()
+	key, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i, err := NewIssuer(key, issuer, accessTTL, refreshTTL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return i
+}
+
+// at moves the clock of the token validation by offset until the end of the test.
+func at(t *testing.T, offset time.Duration) {
+	t.Helper()
+	jwt.TimeFunc = func() time.Time { return time.Now().Add(offset) }
+	t.Cleanup(func() { jwt.TimeFunc = time.Now })
+}
+
+func TestIssue(t *testing.T) {
+	i := newIssuer(t, "pinacle", time.Minute, time.Hour)
+	hashedAddress := big.NewInt(1234567890)
+
+	pair, err := i.Issue(types.RoleVolunteer, hashedAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pair.TokenType != "Bearer" || pair.ExpiresIn != 60 || pair.RefreshTokenExpiresIn != 3600 {
+		t.Fatalf("unexpected token pair %+v", pair)
+	}
+
+	claims, err := i.Verifier().Verify(pair.AccessToken, types.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := claims.HashedAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject.Cmp(hashedAddress) != 0 || claims.Role != types.RoleVolunteer || claims.Issuer != "pinacle" {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+	if ttl := claims.ExpiresAt.Sub(claims.IssuedAt.Time); ttl != time.Minute {
+		t.Fatalf("access token lives %s, expected %s", ttl, time.Minute)
+	}
+
+	// Each token type is only accepted where it is expected
+	if _, err := i.Verifier().Verify(pair.AccessToken, types.RefreshToken); !errors.Is(err, ErrWrongTokenType) {
+		t.Fatalf("access token as refresh token: got %v, want %v", err, ErrWrongTokenType)
+	}
+	if _, err := i.Verifier().Verify(pair.RefreshToken, types.AccessToken); !errors.Is(err, ErrWrongTokenType) {
+		t.Fatalf("refresh token as access token: got %v, want %v", err, ErrWrongTokenType)
+	}
+
+	if _, err := i.Issue(types.RoleUser, nil); !errors.Is(err, ErrEmptySubject) {
+		t.Fatalf("token without subject: got %v, want %v", err, ErrEmptySubject)
+	}
+	if _, err := i.Issue(types.Role(200), hashedAddress); !errors.Is(err, ErrUnsupportedRole) {
+		t.Fatalf("token of an unknown role: got %v, want %v", err, ErrUnsupportedRole)
+	}
+}
+
+func TestVerifyForeignToken(t *testing.T) {
+	i := newIssuer(t, "pinacle", time.Minute, time.Hour)
+	pair, err := i.Issue(types.RoleUser, big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := newIssuer(t, "pinacle", time.Minute, time.Hour)
+	if _, err := other.Verifier().Verify(pair.AccessToken, types.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("token signed by another key: got %v, want %v", err, ErrInvalidToken)
+	}
+
+	verifier := NewVerifier(i.PublicKey(), "another issuer", nil)
+	if _, err := verifier.Verify(pair.AccessToken, types.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("token of another issuer: got %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	i := newIssuer(t, "pinacle", time.Minute, time.Hour)
+	pair, err := i.Issue(types.RoleUser, big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at(t, 2*time.Minute)
+	if _, err := i.Verifier().Verify(pair.AccessToken, types.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expired access token: got %v, want %v", err, ErrInvalidToken)
+	}
+	if _, err := i.Verifier().Verify(pair.RefreshToken, types.RefreshToken); err != nil {
+		t.Fatalf("refresh token expired with the access token: %v", err)
+	}
+
+	at(t, 2*time.Hour)
+	if _, err := i.Refresh(pair.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expired refresh token: got %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	i := newIssuer(t, "pinacle", time.Minute, time.Hour)
+	pair, err := i.Issue(types.RoleIntermediary, big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := i.Refresh(pair.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := i.Verifier().Verify(refreshed.AccessToken, types.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "42" || claims.Role != types.RoleIntermediary {
+		t.Fatalf("refreshed token of %s as %s", claims.Subject, claims.Role)
+	}
+
+	// A refresh token is used once
+	if _, err := i.Refresh(pair.RefreshToken); !errors.Is(err, ErrRevokedToken) {
+		t.Fatalf("reused refresh token: got %v, want %v", err, ErrRevokedToken)
+	}
+	if _, err := i.Refresh(refreshed.AccessToken); !errors.Is(err, ErrWrongTokenType) {
+		t.Fatalf("refresh with an access token: got %v, want %v", err, ErrWrongTokenType)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	i := newIssuer(t, "pinacle", time.Minute, time.Hour)
+	pair, err := i.Issue(types.RoleUser, big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := i.Issue(types.RoleUser, big.NewInt(43))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Logout revokes the presented token only
+	claims, err := i.Verifier().Verify(other.AccessToken, types.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i.RevokeToken(claims)
+	if _, err := i.Verifier().Verify(other.AccessToken, types.AccessToken); !errors.Is(err, ErrRevokedToken) {
+		t.Fatalf("logged out token: got %v, want %v", err, ErrRevokedToken)
+	}
+	if _, err := i.Verifier().Verify(other.RefreshToken, types.RefreshToken); err != nil {
+		t.Fatalf("refresh token revoked by logout: %v", err)
+	}
+
+	// Revoking a hashed address rejects all its tokens, and only its tokens
+	i.Revoke(big.NewInt(42))
+	if _, err := i.Verifier().Verify(pair.AccessToken, types.AccessToken); !errors.Is(err, ErrRevokedToken) {
+		t.Fatalf("access token of a revoked account: got %v, want %v", err, ErrRevokedToken)
+	}
+	if _, err := i.Refresh(pair.RefreshToken); !errors.Is(err, ErrRevokedToken) {
+		t.Fatalf("refresh token of a revoked account: got %v, want %v", err, ErrRevokedToken)
+	}
+	if _, err := i.Verifier().Verify(other.RefreshToken, types.RefreshToken); err != nil {
+		t.Fatalf("token of another account revoked: %v", err)
+	}
+}
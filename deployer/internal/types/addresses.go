@@ -7,6 +7,8 @@ import (
 type Contract struct {
 	Name    string         `json:"name" validate:"required"`
 	Address common.Address `json:"address" validate:"required,eth_addr"`
+	// SHA-256 of the verification key embedded in a Groth16 verifier, empty for other contracts
+	VkeyHash string `json:"vkeyHash,omitempty" validate:"omitempty,len=64,hexadecimal"`
 }
 
 //	type Contracts struct {
@@ -19,19 +21,23 @@ type Contracts struct {
 func (Contract) CustomErrorMessages() map[string]string {
 	return map[string]string{
 		// Contract
-		"Contract.Name.required":    "Contract name is required",
-		"Contract.Address.required": "Contract address is required",
-		"Contract.Address.eth_addr": "Invalid Ethereum address",
+		"Contract.Name.required":        "Contract name is required",
+		"Contract.Address.required":     "Contract address is required",
+		"Contract.Address.eth_addr":     "Invalid Ethereum address",
+		"Contract.VkeyHash.len":         "Verification key hash must be 64 hex characters",
+		"Contract.VkeyHash.hexadecimal": "Verification key hash must be hexadecimal",
 	}
 }
 
 func (Contracts) CustomErrorMessages() map[string]string {
 	return map[string]string{
 		// Contracts
-		"Contracts.Contracts.required":           "Contracts list is required",
-		"Contracts.Contracts[].required":         "Each contract must be non-nil",
-		"Contracts.Contracts[].Address.required": "Contract address is required",
-		"Contracts.Contracts[].Address.eth_addr": "Invalid Ethereum address",
-		"Contracts.Contracts[].Name.required":    "Contract name is required",
+		"Contracts.Contracts.required":               "Contracts list is required",
+		"Contracts.Contracts[].required":             "Each contract must be non-nil",
+		"Contracts.Contracts[].Address.required":     "Contract address is required",
+		"Contracts.Contracts[].Address.eth_addr":     "Invalid Ethereum address",
+		"Contracts.Contracts[].Name.required":        "Contract name is required",
+		"Contracts.Contracts[].VkeyHash.len":         "Verification key hash must be 64 hex characters",
+		"Contracts.Contracts[].VkeyHash.hexadecimal": "Verification key hash must be hexadecimal",
 	}
 }
@@ -0,0 +1,52 @@
+package types
+
+import "encoding/json"
+
+// ArtifactManifest pins the circuit artifacts the prover and the verifier load.
+type ArtifactManifest struct {
+	Circuit string `json:"circuit" validate:"required"`
+	NPublic int    `json:"nPublic" validate:"min=1"`
+	Wasm    string `json:"wasm" validate:"required,len=64,hexadecimal"` // SHA-256
+	Zkey    string `json:"zkey" validate:"required,len=64,hexadecimal"` // SHA-256
+	Vkey    string `json:"vkey" validate:"required,len=64,hexadecimal"` // SHA-256
+	// Witness binary of the native backend and its .dat, SHA-256, empty when not pinned
+	WitnessBinary string `json:"witnessBinary,omitempty" validate:"required_with=WitnessDat,omitempty,len=64,hexadecimal"`
+	WitnessDat    string `json:"witnessDat,omitempty" validate:"required_with=WitnessBinary,omitempty,len=64,hexadecimal"`
+}
+
+// SignedArtifactManifest is the manifest file. The Ed25519 signature covers the compact JSON of
+// the manifest as stored, so only whitespace may change.
+type SignedArtifactManifest struct {
+	Manifest  json.RawMessage `json:"manifest" validate:"required"`
+	Signature []byte          `json:"signature" validate:"required,len=64"` // base64 in JSON
+}
+
+func (ArtifactManifest) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"ArtifactManifest.Circuit.required":            "Manifest circuit name is required",
+		"ArtifactManifest.NPublic.min":                 "Manifest public signal count must be at least 1",
+		"ArtifactManifest.Wasm.required":               "Manifest wasm SHA-256 is required",
+		"ArtifactManifest.Wasm.len":                    "Manifest wasm SHA-256 must be 64 hex characters",
+		"ArtifactManifest.Wasm.hexadecimal":            "Manifest wasm SHA-256 must be hexadecimal",
+		"ArtifactManifest.Zkey.required":               "Manifest zkey SHA-256 is required",
+		"ArtifactManifest.Zkey.len":                    "Manifest zkey SHA-256 must be 64 hex characters",
+		"ArtifactManifest.Zkey.hexadecimal":            "Manifest zkey SHA-256 must be hexadecimal",
+		"ArtifactManifest.Vkey.required":               "Manifest verification key SHA-256 is required",
+		"ArtifactManifest.Vkey.len":                    "Manifest verification key SHA-256 must be 64 hex characters",
+		"ArtifactManifest.Vkey.hexadecimal":            "Manifest verification key SHA-256 must be hexadecimal",
+		"ArtifactManifest.WitnessBinary.required_with": "Manifest witness binary SHA-256 is required with its .dat",
+		"ArtifactManifest.WitnessBinary.len":           "Manifest witness binary SHA-256 must be 64 hex characters",
+		"ArtifactManifest.WitnessBinary.hexadecimal":   "Manifest witness binary SHA-256 must be hexadecimal",
+		"ArtifactManifest.WitnessDat.required_with":    "Manifest witness .dat SHA-256 is required with the binary",
+		"ArtifactManifest.WitnessDat.len":              "Manifest witness .dat SHA-256 must be 64 hex characters",
+		"ArtifactManifest.WitnessDat.hexadecimal":      "Manifest witness .dat SHA-256 must be hexadecimal",
+	}
+}
+
+func (SignedArtifactManifest) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"SignedArtifactManifest.Manifest.required":  "Manifest is required",
+		"SignedArtifactManifest.Signature.required": "Manifest signature is required",
+		"SignedArtifactManifest.Signature.len":      "Manifest signature must be an Ed25519 signature",
+	}
+}
@@ -1,40 +1,103 @@
 package types
 
+import "time"
+
 type Config struct {
-	GethNodeUrl             string `mapstructure:"GETH_NODE_URL" validate:"required,url"`
-	GethNodeKeystore        string `mapstructure:"GETH_NODE_KEYSTORE" validate:"required,file_exists"`
-	GethNodePassword        string `mapstructure:"GETH_NODE_PASSWORD" validate:"required"`
-	AccountsDir             string `mapstructure:"ACCOUNTS_DIR" validate:"required"`
-	AccountsNumber          int    `mapstructure:"ACCOUNTS_NUMBER" validate:"required,min=1"`
-	AddressesDir            string `mapstructure:"CONTRACTS_ADDRESSES_DIR" validate:"required"`
-	LoggerMode              string `mapstructure:"LOGGER_MODE" validate:"required,oneof=production development"`
-	DisableBanner           bool   `mapstructure:"DISABLE_BANNER"`
-	Version                 string `mapstructure:"VERSION" validate:"required,version"`
-	WasmFilename            string `mapstructure:"ZK_WASM_FILENAME" validate:"required,file_exists"`
-	ZkeyFilename            string `mapstructure:"ZK_ZKEY_FILENAME" validate:"required,file_exists"`
-	VerificationKeyFilename string `mapstructure:"ZK_VERIFICATION_KEY_FILENAME" validate:"required,file_exists"`
+	GethNodeUrl               string            `mapstructure:"GETH_NODE_URL" validate:"required,url"`
+	GethNodeKeystore          string            `mapstructure:"GETH_NODE_KEYSTORE" validate:"required,file_exists"`
+	GethNodePassword          string            `mapstructure:"GETH_NODE_PASSWORD" validate:"required"`
+	AccountsDir               string            `mapstructure:"ACCOUNTS_DIR" validate:"required"`
+	AccountsNumber            int               `mapstructure:"ACCOUNTS_NUMBER" validate:"required,min=1"`
+	AddressesDir              string            `mapstructure:"CONTRACTS_ADDRESSES_DIR" validate:"required"`
+	LoggerMode                string            `mapstructure:"LOGGER_MODE" validate:"required,oneof=production development"`
+	DisableBanner             bool              `mapstructure:"DISABLE_BANNER"`
+	Version                   string            `mapstructure:"VERSION" validate:"required,version"`
+	WasmFilename              string            `mapstructure:"ZK_WASM_FILENAME" validate:"required,file_exists"`
+	ZkeyFilename              string            `mapstructure:"ZK_ZKEY_FILENAME" validate:"required,file_exists"`
+	VerificationKeyFilename   string            `mapstructure:"ZK_VERIFICATION_KEY_FILENAME" validate:"required,file_exists"`
+	WitnessBackend            WitnessBackend    `mapstructure:"ZK_WITNESS_BACKEND" validate:"oneof=wasm native"`
+	WitnessBinaryFilename     string            `mapstructure:"ZK_WITNESS_BINARY_FILENAME" validate:"required_if=WitnessBackend native,omitempty,file_exists"`
+	ProofCacheTTL             time.Duration     `mapstructure:"ZK_PROOF_CACHE_TTL" validate:"omitempty,min=1s"`
+	ProofCacheFilename        string            `mapstructure:"ZK_PROOF_CACHE_FILENAME"`
+	ManifestFilename          string            `mapstructure:"ZK_MANIFEST_FILENAME" validate:"omitempty,file_exists"`
+	ManifestKeyFilename       string            `mapstructure:"ZK_MANIFEST_VERIFICATION_KEY_FILENAME" validate:"required_with=ManifestFilename,omitempty,file_exists"`
+	GatewayAddress            string            `mapstructure:"GATEWAY_ADDRESS" validate:"omitempty,hostname_port"`
+	TLSEnabled                bool              `mapstructure:"GATEWAY_TLS_ENABLED"`
+	TLSCertFilename           string            `mapstructure:"GATEWAY_TLS_CERT_FILENAME" validate:"required_if=TLSEnabled true,file_exists_if_tls=TLSEnabled"`
+	TLSKeyFilename            string            `mapstructure:"GATEWAY_TLS_KEY_FILENAME" validate:"required_if=TLSEnabled true,file_exists_if_tls=TLSEnabled"`
+	TLSClientAuthRequired     bool              `mapstructure:"GATEWAY_TLS_CLIENT_AUTH_REQUIRED"`
+	TLSClientCAFilename       string            `mapstructure:"GATEWAY_TLS_CLIENT_CA_FILENAME" validate:"required_if=TLSClientAuthRequired true,file_exists_if_tls=TLSClientAuthRequired"`
+	TLSMinVersion             string            `mapstructure:"GATEWAY_TLS_MIN_VERSION" validate:"tls_version"`
+	TLSCipherSuites           string            `mapstructure:"GATEWAY_TLS_CIPHER_SUITES" validate:"tls_cipher_suites"`
+	SessionIssuer             string            `mapstructure:"SESSION_ISSUER" validate:"required"`
+	SessionSigningKeyFilename string            `mapstructure:"SESSION_SIGNING_KEY_FILENAME" validate:"omitempty,file_exists"`
+	SessionAccessTTL          time.Duration     `mapstructure:"SESSION_ACCESS_TTL" validate:"min=30s"`
+	SessionRefreshTTL         time.Duration     `mapstructure:"SESSION_REFRESH_TTL" validate:"gtfield=SessionAccessTTL"`
+	SessionVerification       LoginVerification `mapstructure:"SESSION_VERIFICATION" validate:"oneof=local onchain"`
+	SessionChallengeTTL       time.Duration     `mapstructure:"SESSION_CHALLENGE_TTL" validate:"min=10s,max=1h"`
+	GRPCAddress               string            `mapstructure:"GATEWAY_GRPC_ADDRESS" validate:"omitempty,hostname_port"`
+	OperatorAccountIndex      int               `mapstructure:"OPERATOR_ACCOUNT_INDEX" validate:"min=0"`
+	IndexerStartBlock         uint64            `mapstructure:"INDEXER_START_BLOCK"`
+	IndexerPollInterval       time.Duration     `mapstructure:"INDEXER_POLL_INTERVAL" validate:"min=100ms"`
+	IndexerConfirmations      uint64            `mapstructure:"INDEXER_CONFIRMATIONS"`
+	FoodBankQuorum            int               `mapstructure:"FOODBANK_QUORUM" validate:"min=1,ltefield=AccountsNumber"`
+	ProposalLifetime          time.Duration     `mapstructure:"FOODBANK_PROPOSAL_LIFETIME" validate:"min=1m"`
+	AuditLogFilename          string            `mapstructure:"AUDIT_LOG_FILENAME"`
+	AuditAnchorInterval       time.Duration     `mapstructure:"AUDIT_ANCHOR_INTERVAL" validate:"omitempty,min=1m"`
+	Hasher                    HasherKind        `mapstructure:"HASHER" validate:"oneof=mimc poseidon"`
 }
 
 func (Config) CustomErrorMessages() map[string]string {
 	return map[string]string{
-		"Config.Config.GethNodeUrl.required":                "Geth node URL is required",
-		"Config.Config.GethNodeUrl.url":                     "Geth node URL must be a valid URL",
-		"Config.Config.GethNodeKeystore.required":           "Geth node keystore path is required",
-		"Config.Config.GethNodeKeystore.file_exists":        "Geth node keystore file must exist",
-		"Config.Config.GethNodePassword.required":           "Geth node password is required",
-		"Config.Config.AccountsDir.required":                "Accounts directory path is required",
-		"Config.Config.AccountsNumber.required":             "Accounts number is required",
-		"Config.Config.AccountsNumber.min":                  "Accounts number must be greater than 0",
-		"Config.Config.AddressesDir.required":               "Addresses directory path is required",
-		"Config.Config.LoggerMode.required":                 "Logger mode is required",
-		"Config.Config.LoggerMode.oneof":                    "Logger mode must be either 'production' or 'development'",
-		"Config.Config.Version.required":                    "Version is required",
-		"Config.Config.Version.version":                     "Version must be a valid version string (e.g., v1.0.0)",
-		"Config.Config.WasmFilename.required":               "ZK wasm filename is required",
-		"Config.Config.WasmFilename.file_exists":            "ZK wasm file must exist",
-		"Config.Config.ZkeyFilename.required":               "ZKey filename is required",
-		"Config.Config.ZkeyFilename.file_exists":            "ZKey file must exist",
-		"Config.Config.VerificationKeyFilename.required":    "Verification key filename is required",
-		"Config.Config.VerificationKeyFilename.file_exists": "Verification key file must exist",
+		"Config.Config.GethNodeUrl.required":                   "Geth node URL is required",
+		"Config.Config.GethNodeUrl.url":                        "Geth node URL must be a valid URL",
+		"Config.Config.GethNodeKeystore.required":              "Geth node keystore path is required",
+		"Config.Config.GethNodeKeystore.file_exists":           "Geth node keystore file must exist",
+		"Config.Config.GethNodePassword.required":              "Geth node password is required",
+		"Config.Config.AccountsDir.required":                   "Accounts directory path is required",
+		"Config.Config.AccountsNumber.required":                "Accounts number is required",
+		"Config.Config.AccountsNumber.min":                     "Accounts number must be greater than 0",
+		"Config.Config.AddressesDir.required":                  "Addresses directory path is required",
+		"Config.Config.LoggerMode.required":                    "Logger mode is required",
+		"Config.Config.LoggerMode.oneof":                       "Logger mode must be either 'production' or 'development'",
+		"Config.Config.Version.required":                       "Version is required",
+		"Config.Config.Version.version":                        "Version must be a valid version string (e.g., v1.0.0)",
+		"Config.Config.WasmFilename.required":                  "ZK wasm filename is required",
+		"Config.Config.WasmFilename.file_exists":               "ZK wasm file must exist",
+		"Config.Config.ZkeyFilename.required":                  "ZKey filename is required",
+		"Config.Config.ZkeyFilename.file_exists":               "ZKey file must exist",
+		"Config.Config.VerificationKeyFilename.required":       "Verification key filename is required",
+		"Config.Config.VerificationKeyFilename.file_exists":    "Verification key file must exist",
+		"Config.Config.WitnessBackend.oneof":                   "ZK witness backend must be either 'wasm' or 'native'",
+		"Config.Config.WitnessBinaryFilename.required_if":      "ZK witness binary filename is required with the native witness backend",
+		"Config.Config.WitnessBinaryFilename.file_exists":      "ZK witness binary file must exist",
+		"Config.Config.ProofCacheTTL.min":                      "ZK proof cache TTL must be at least 1s (0 disables the cache)",
+		"Config.Config.ManifestFilename.file_exists":           "ZK artifact manifest file must exist",
+		"Config.Config.ManifestKeyFilename.required_with":      "ZK artifact manifest verification key is required with a manifest",
+		"Config.Config.ManifestKeyFilename.file_exists":        "ZK artifact manifest verification key file must exist",
+		"Config.Config.GatewayAddress.hostname_port":           "Gateway address must be in host:port format",
+		"Config.Config.TLSCertFilename.required_if":            "TLS certificate filename is required when TLS is enabled",
+		"Config.Config.TLSCertFilename.file_exists_if_tls":     "TLS certificate file must exist",
+		"Config.Config.TLSKeyFilename.required_if":             "TLS key filename is required when TLS is enabled",
+		"Config.Config.TLSKeyFilename.file_exists_if_tls":      "TLS key file must exist",
+		"Config.Config.TLSClientCAFilename.required_if":        "TLS client CA bundle is required when client authentication is required",
+		"Config.Config.TLSClientCAFilename.file_exists_if_tls": "TLS client CA bundle must exist",
+		"Config.Config.TLSMinVersion.tls_version":              "TLS minimum version must be either '1.2' or '1.3'",
+		"Config.Config.TLSCipherSuites.tls_cipher_suites":      "TLS cipher suites must be a comma separated list of secure Go cipher suite names",
+		"Config.Config.SessionIssuer.required":                 "Session issuer is required",
+		"Config.Config.SessionSigningKeyFilename.file_exists":  "Session signing key file must exist",
+		"Config.Config.SessionAccessTTL.min":                   "Session access token TTL must be at least 30s",
+		"Config.Config.SessionRefreshTTL.gtfield":              "Session refresh token TTL must be greater than the access token TTL",
+		"Config.Config.SessionVerification.oneof":              "Session verification must be either 'local' or 'onchain'",
+		"Config.Config.SessionChallengeTTL.min":                "Session login challenge TTL must be at least 10s",
+		"Config.Config.SessionChallengeTTL.max":                "Session login challenge TTL must be at most 1h",
+		"Config.Config.GRPCAddress.hostname_port":              "gRPC address must be in host:port format",
+		"Config.Config.OperatorAccountIndex.min":               "Operator account index must not be negative",
+		"Config.Config.IndexerPollInterval.min":                "Indexer poll interval must be at least 100ms",
+		"Config.Config.FoodBankQuorum.min":                     "Food bank quorum must be at least 1",
+		"Config.Config.FoodBankQuorum.ltefield":                "Food bank quorum must not exceed the number of initial food banks (ACCOUNTS_NUMBER)",
+		"Config.Config.ProposalLifetime.min":                   "Food bank proposal lifetime must be at least 1m",
+		"Config.Config.AuditAnchorInterval.min":                "Audit anchor interval must be at least 1m (0 disables anchoring)",
+		"Config.Config.Hasher.oneof":                           "Hasher must be either 'mimc' or 'poseidon'",
 	}
 }
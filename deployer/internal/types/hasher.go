@@ -0,0 +1,8 @@
+package types
+
+type HasherKind string
+
+const (
+	HasherMiMC     HasherKind = "mimc"     // MiMCSponge, 220 rounds (circomlib mimcsponge)
+	HasherPoseidon HasherKind = "poseidon" // circomlib Poseidon
+)
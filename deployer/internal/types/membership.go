@@ -0,0 +1,64 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	rapidsnark "github.com/iden3/go-rapidsnark/types"
+)
+
+// MembershipRequest carries the proof of an account for register/verify operations:
+// a zkEthereumAddress proof for registrations, a zkMerkleTree proof for verification.
+type MembershipRequest struct {
+	Address       common.Address        `json:"address" validate:"required,eth_addr"`
+	Proof         *rapidsnark.ProofData `json:"proof" validate:"required"`
+	PublicSignals []string              `json:"publicSignals" validate:"required,len=2,dive,numeric"`
+}
+
+type TerminateRequest struct {
+	RawTransaction string `json:"rawTransaction" validate:"required,hexadecimal"`
+}
+
+type RevokeRequest struct {
+	HashedAddress string `json:"hashedAddress" validate:"required,numeric"`
+}
+
+func (MembershipRequest) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"MembershipRequest.Address.required":        "Address is required",
+		"MembershipRequest.Address.eth_addr":        "Invalid Ethereum address",
+		"MembershipRequest.Proof.required":          "Proof is required",
+		"MembershipRequest.PublicSignals.required":  "Public signals are required",
+		"MembershipRequest.PublicSignals.len":       "Exactly 2 public signals are required",
+		"MembershipRequest.PublicSignals[].numeric": "Public signals must be decimal numbers",
+	}
+}
+
+func (TerminateRequest) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"TerminateRequest.RawTransaction.required":    "Raw transaction is required",
+		"TerminateRequest.RawTransaction.hexadecimal": "Raw transaction must be 0x prefixed hex",
+	}
+}
+
+func (RevokeRequest) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"RevokeRequest.HashedAddress.required": "Hashed address is required",
+		"RevokeRequest.HashedAddress.numeric":  "Hashed address must be a decimal number",
+	}
+}
+
+type TransactionResponse struct {
+	Hash        string `json:"hash"`
+	BlockNumber uint64 `json:"blockNumber"`
+	GasUsed     uint64 `json:"gasUsed"`
+}
+
+type VerifyResponse struct {
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type TerminateResponse struct {
+	Transaction   *TransactionResponse `json:"transaction"`
+	Role          Role                 `json:"role"`
+	HashedAddress string               `json:"hashedAddress"`
+}
@@ -1,8 +1,89 @@
 package types
 
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:generate sh -c "cd ../../../go-contracts && go run ./cmd gen-roles"
+
+// Role identifies a zkLogin role. The roles are generated in roles_gen.go from
+// contracts/zkLogin/roles.json, the registry zkLogin is deployed with.
 type Role uint8
 
-const (
-	RoleFoodBank Role = 0 // FoodBank Leader
-	RoleUser     Role = 1 // User
-)
+// RoleDefinition places the members of a role in the zkLogin trees and lists the roles they
+// may register.
+type RoleDefinition struct {
+	Role      Role
+	Name      string
+	Tree      uint32
+	Subtree   uint32
+	Registers []Role
+}
+
+// Roles returns the role registry, in the order of roles.json.
+func Roles() []RoleDefinition {
+	out := make([]RoleDefinition, len(roles))
+	for i, def := range roles {
+		def.Registers = slices.Clone(def.Registers)
+		out[i] = def
+	}
+	return out
+}
+
+// RoleTrees returns the number of subtrees of each tree the roles use, the _subtrees of the
+// zkLogin constructor.
+func RoleTrees() []uint32 {
+	var subtrees []uint32
+	for _, def := range roles {
+		for uint32(len(subtrees)) <= def.Tree {
+			subtrees = append(subtrees, 0)
+		}
+		subtrees[def.Tree] = max(subtrees[def.Tree], def.Subtree+1)
+	}
+	return subtrees
+}
+
+// ParseRole parses a role by name, case-insensitively, or by ID.
+func ParseRole(s string) (Role, error) {
+	for _, def := range roles {
+		if strings.EqualFold(def.Name, s) {
+			return def.Role, nil
+		}
+	}
+	if id, err := strconv.ParseUint(s, 10, 8); err == nil && Role(id).Valid() {
+		return Role(id), nil
+	}
+	return 0, fmt.Errorf("unknown role %q", s)
+}
+
+// Definition returns the definition of the role.
+func (r Role) Definition() (RoleDefinition, bool) {
+	for _, def := range roles {
+		if def.Role == r {
+			return def, true
+		}
+	}
+	return RoleDefinition{}, false
+}
+
+// Valid reports whether the role is defined.
+func (r Role) Valid() bool {
+	_, ok := r.Definition()
+	return ok
+}
+
+// CanRegister reports whether the members of the role may register members of role.
+func (r Role) CanRegister(role Role) bool {
+	def, ok := r.Definition()
+	return ok && slices.Contains(def.Registers, role)
+}
+
+func (r Role) String() string {
+	if def, ok := r.Definition(); ok {
+		return def.Name
+	}
+	return "Role(" + strconv.Itoa(int(r)) + ")"
+}
@@ -0,0 +1,20 @@
+// Code generated by contract-cli gen-roles. DO NOT EDIT.
+
+package types
+
+const (
+	RoleFoodBank     Role = 0 // Food bank leader
+	RoleUser         Role = 1 // End user
+	RoleVolunteer    Role = 2 // Volunteer of a food bank
+	RoleIntermediary Role = 3 // Social services referring users
+	RoleAuditor      Role = 4 // Auditor
+)
+
+// roles is the registry zkLogin is deployed with, in the order of roles.json.
+var roles = []RoleDefinition{
+	{Role: RoleFoodBank, Name: "FoodBank", Tree: 0, Subtree: 0, Registers: []Role{RoleFoodBank, RoleUser, RoleVolunteer, RoleIntermediary, RoleAuditor}},
+	{Role: RoleUser, Name: "User", Tree: 1, Subtree: 0, Registers: nil},
+	{Role: RoleVolunteer, Name: "Volunteer", Tree: 1, Subtree: 1, Registers: nil},
+	{Role: RoleIntermediary, Name: "Intermediary", Tree: 0, Subtree: 1, Registers: []Role{RoleUser}},
+	{Role: RoleAuditor, Name: "Auditor", Tree: 0, Subtree: 2, Registers: nil},
+}
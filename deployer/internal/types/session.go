@@ -0,0 +1,84 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	rapidsnark "github.com/iden3/go-rapidsnark/types"
+)
+
+type TokenType string
+
+const (
+	AccessToken  TokenType = "access"
+	RefreshToken TokenType = "refresh"
+)
+
+type LoginVerification string
+
+const (
+	LoginVerificationLocal   LoginVerification = "local"   // Groth16 verification against the verification key
+	LoginVerificationOnChain LoginVerification = "onchain" // eth_call against the zkLogin contract
+)
+
+// LoginRequest carries a zkMerkleTree proof as produced by snarkjs/rapidsnark, and the signature
+// of the address over a login challenge (EIP-191, 65 bytes of 0x-hex).
+// Address is only used to bind the proof to the caller and is never put into a token.
+type LoginRequest struct {
+	Role          Role                  `json:"role" validate:"role"`
+	Address       common.Address        `json:"address" validate:"omitempty,eth_addr"`
+	Nonce         string                `json:"nonce" validate:"required,hexadecimal"`
+	Signature     string                `json:"signature" validate:"required,hexadecimal"`
+	Proof         *rapidsnark.ProofData `json:"proof" validate:"required"`
+	PublicSignals []string              `json:"publicSignals" validate:"required,len=2,dive,numeric"`
+}
+
+// ChallengeRequest asks for a login challenge of an address.
+type ChallengeRequest struct {
+	Address common.Address `json:"address" validate:"eth_addr"`
+}
+
+// Challenge is a one-time login nonce and the message the address signs with it.
+type Challenge struct {
+	Nonce     string `json:"nonce"`
+	Message   string `json:"message"`
+	ExpiresAt int64  `json:"expiresAt"` // unix seconds
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required,jwt"`
+}
+
+type TokenPair struct {
+	AccessToken           string `json:"accessToken"`
+	RefreshToken          string `json:"refreshToken"`
+	TokenType             string `json:"tokenType"`
+	ExpiresIn             int64  `json:"expiresIn"`             // seconds
+	RefreshTokenExpiresIn int64  `json:"refreshTokenExpiresIn"` // seconds
+}
+
+func (LoginRequest) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"LoginRequest.Role.role":               "Role must be one of the zkLogin roles",
+		"LoginRequest.Address.eth_addr":        "Invalid Ethereum address",
+		"LoginRequest.Nonce.required":          "Login challenge nonce is required",
+		"LoginRequest.Nonce.hexadecimal":       "Login challenge nonce must be hexadecimal",
+		"LoginRequest.Signature.required":      "Signature of the login challenge is required",
+		"LoginRequest.Signature.hexadecimal":   "Signature must be 0x-hex",
+		"LoginRequest.Proof.required":          "Proof is required",
+		"LoginRequest.PublicSignals.required":  "Public signals are required",
+		"LoginRequest.PublicSignals.len":       "Exactly 2 public signals are required",
+		"LoginRequest.PublicSignals[].numeric": "Public signals must be decimal numbers",
+	}
+}
+
+func (ChallengeRequest) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"ChallengeRequest.Address.eth_addr": "Invalid Ethereum address",
+	}
+}
+
+func (RefreshRequest) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"RefreshRequest.RefreshToken.required": "Refresh token is required",
+		"RefreshRequest.RefreshToken.jwt":      "Refresh token must be a JWT",
+	}
+}
@@ -0,0 +1,8 @@
+package types
+
+type WitnessBackend string
+
+const (
+	WitnessBackendWasm   WitnessBackend = "wasm"   // circom wasm, run by wasmer
+	WitnessBackendNative WitnessBackend = "native" // witness binary of circom --c
+)
@@ -1,13 +1,34 @@
 package types
 
-import (
-	"math/big"
-)
-
 const LEVELS = 32
 
-type PinacleZKP struct {
-	PrivateKey   *Registers       `json:"privateKey"`
-	PathElements [LEVELS]*big.Int `json:"pathElements"`
-	PathIndices  [LEVELS]*big.Int `json:"pathIndices"`
+// CircuitDescriptor lists the input signals of a circuit, the witness inputs the prover builds.
+type CircuitDescriptor struct {
+	Circuit string   `json:"circuit" validate:"required"`
+	Inputs  []Signal `json:"inputs" validate:"required,min=1,dive"`
+}
+
+// Signal is an input signal, Dims empty for a single field element.
+type Signal struct {
+	Name string `json:"name" validate:"required"`
+	Dims []int  `json:"dims,omitempty" validate:"dive,min=1"`
+}
+
+// Size is the number of field elements of the signal.
+func (s Signal) Size() int {
+	size := 1
+	for _, d := range s.Dims {
+		size *= d
+	}
+	return size
+}
+
+func (CircuitDescriptor) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"CircuitDescriptor.Circuit.required":       "Circuit name is required",
+		"CircuitDescriptor.Inputs.required":        "Circuit inputs are required",
+		"CircuitDescriptor.Inputs.min":             "Circuit must have at least one input",
+		"CircuitDescriptor.Inputs[].Name.required": "Input signal name is required",
+		"CircuitDescriptor.Inputs[].Dims[].min":    "Input signal dimensions must be at least 1",
+	}
 }
@@ -11,8 +11,26 @@ const (
 
 const PINACLE_PUBLIC_SIGNALS = 2
 
+// PINACLE_CIRCUIT is the name of the circuit, Pinacle.circom
+const PINACLE_CIRCUIT = "Pinacle"
+
+// Groth16Proof holds the affine points of a proof, the coordinates of PiB in the snarkjs order
+// (c0, c1).
 type Groth16Proof struct {
 	PiA [2]*big.Int
 	PiB [2][2]*big.Int
 	PiC [2]*big.Int
 }
+
+// VerificationKey is a snarkjs Groth16 verification_key.json. The points are projective, in
+// decimal: G1 as [x, y, z] and G2 as [[x0, x1], [y0, y1], [z0, z1]].
+type VerificationKey struct {
+	Protocol string     `json:"protocol"`
+	Curve    string     `json:"curve"`
+	NPublic  int        `json:"nPublic"`
+	Alpha1   []string   `json:"vk_alpha_1"`
+	Beta2    [][]string `json:"vk_beta_2"`
+	Gamma2   [][]string `json:"vk_gamma_2"`
+	Delta2   [][]string `json:"vk_delta_2"`
+	IC       [][]string `json:"IC"`
+}
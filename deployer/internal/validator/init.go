@@ -38,5 +38,8 @@ func init() {
 	validate.RegisterValidation("url", gethUrl)
 	validate.RegisterValidation("eth_addr", isEthAddress)
 	validate.RegisterValidation("bigint", isBigint)
+	validate.RegisterValidation("role", isRole)
 	validate.RegisterValidation("bigint_gte_0", isBigintGte0)
+	validate.RegisterValidation("tls_version", tlsVersion)
+	validate.RegisterValidation("tls_cipher_suites", tlsCipherSuites)
 }
@@ -0,0 +1,12 @@
+package validator
+
+import (
+	"deployer/internal/types"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func isRole(fl validator.FieldLevel) bool {
+	role, ok := fl.Field().Interface().(types.Role)
+	return ok && role.Valid()
+}
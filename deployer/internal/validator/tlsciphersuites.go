@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrInvalidCipherSuite = errors.New("invalid or insecure TLS cipher suite")
+
+// ParseTLSCipherSuites converts a comma separated list of cipher suite names into their IDs.
+// Only suites reported as secure by crypto/tls are accepted. An empty list keeps the Go defaults (nil).
+func ParseTLSCipherSuites(raw string) ([]uint16, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	secure := make(map[string]uint16, len(tls.CipherSuites()))
+	for _, suite := range tls.CipherSuites() {
+		secure[suite.Name] = suite.ID
+	}
+
+	var ids []uint16
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		id, ok := secure[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCipherSuite, name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// tlsCipherSuites checks if the field holds a comma separated list of cipher suite
+// names that crypto/tls considers secure (e.g. "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256").
+func tlsCipherSuites(fl validator.FieldLevel) bool {
+	_, err := ParseTLSCipherSuites(fl.Field().String())
+	return err == nil
+}
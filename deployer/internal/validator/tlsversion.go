@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrInvalidTLSVersion = errors.New("invalid TLS minimum version")
+
+// tlsVersions maps the accepted configuration values to their crypto/tls constants.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseTLSVersion converts a minimum TLS version ("1.2" or "1.3") into its crypto/tls constant,
+// TLS 1.2 when empty.
+func ParseTLSVersion(version string) (uint16, error) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return tls.VersionTLS12, nil
+	}
+	v, ok := tlsVersions[version]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTLSVersion, version)
+	}
+	return v, nil
+}
+
+// tlsVersion checks if the field holds a supported minimum TLS version.
+func tlsVersion(fl validator.FieldLevel) bool {
+	_, err := ParseTLSVersion(fl.Field().String()) // Empty is left to required_if
+	return err == nil
+}
@@ -0,0 +1,255 @@
+package zkp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"deployer/internal/metrics"
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// batchKey is the verification key as points: e(A, B) = e(alpha, beta) e(L, gamma) e(C, delta)
+// with L = IC[0] + sum s_j IC[j+1] for a proof (A, B, C) of the public signals s.
+type batchKey struct {
+	alpha              bn254.G1Affine
+	beta, gamma, delta bn254.G2Affine
+	ic                 []bn254.G1Affine
+}
+
+type batchProof struct {
+	a, c    bn254.G1Affine
+	b       bn254.G2Affine
+	signals []fr.Element
+}
+
+// VerifyBatch checks many proofs at once and returns the validity of each, to pre-screen them
+// before the contract. A random linear combination of the Groth16 equations is checked with
+// n+3 pairings instead of 4n; when it fails, the batch is split to find the invalid proofs.
+func (v *Verifier) VerifyBatch(proofs []*ZKProof) ([]bool, error) {
+	key, err := v.batchKey()
+	if err != nil {
+		return nil, err
+	}
+
+	defer metrics.VerifyTimer.UpdateSince(time.Now())
+	results := make([]bool, len(proofs))
+	parsed := make([]*batchProof, 0, len(proofs))
+	indices := make([]int, 0, len(proofs))
+	for i, proof := range proofs {
+		if p, err := key.parse(proof); err == nil {
+			parsed = append(parsed, p)
+			indices = append(indices, i)
+		}
+	}
+	if err := key.bisect(parsed, indices, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// batchKey parses the verification key once.
+func (v *Verifier) batchKey() (*batchKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.batch != nil {
+		return v.batch, nil
+	}
+
+	var vk types.VerificationKey
+	if err := json.Unmarshal(v.getVerificationKey(), &vk); err != nil {
+		return nil, fmt.Errorf("failed to parse verification key: %w", err)
+	}
+	if vk.Curve != BN128_CURVE || vk.NPublic+1 != len(vk.IC) {
+		return nil, fmt.Errorf("unsupported verification key: %s with %d public signals and %d IC", vk.Curve, vk.NPublic, len(vk.IC))
+	}
+
+	key := &batchKey{ic: make([]bn254.G1Affine, len(vk.IC))}
+	var err error
+	if key.alpha, err = g1FromObject(vk.Alpha1); err != nil {
+		return nil, fmt.Errorf("invalid vk_alpha_1: %w", err)
+	}
+	if key.beta, err = g2FromObject(vk.Beta2); err != nil {
+		return nil, fmt.Errorf("invalid vk_beta_2: %w", err)
+	}
+	if key.gamma, err = g2FromObject(vk.Gamma2); err != nil {
+		return nil, fmt.Errorf("invalid vk_gamma_2: %w", err)
+	}
+	if key.delta, err = g2FromObject(vk.Delta2); err != nil {
+		return nil, fmt.Errorf("invalid vk_delta_2: %w", err)
+	}
+	for i := range key.ic {
+		if key.ic[i], err = g1FromObject(vk.IC[i]); err != nil {
+			return nil, fmt.Errorf("invalid IC[%d]: %w", i, err)
+		}
+	}
+	v.batch = key
+	return key, nil
+}
+
+// parse decodes a proof, checking its coordinates and signals are canonical field elements, its
+// points are in their subgroups and its signals are those of the key. A value above the modulus
+// would otherwise be reduced into a proof the contract rejects.
+func (k *batchKey) parse(proof *ZKProof) (*batchProof, error) {
+	groth16, signals, err := proof.parse()
+	if err != nil {
+		return nil, err
+	}
+	if len(signals) != len(k.ic)-1 {
+		return nil, fmt.Errorf("%w: %d public signals, expected %d", ErrInvalidProof, len(signals), len(k.ic)-1)
+	}
+
+	p := &batchProof{signals: make([]fr.Element, len(signals))}
+	coordinates := []struct {
+		dst *fp.Element
+		v   *big.Int
+	}{
+		{&p.a.X, groth16.PiA[0]}, {&p.a.Y, groth16.PiA[1]},
+		{&p.b.X.A0, groth16.PiB[0][0]}, {&p.b.X.A1, groth16.PiB[0][1]},
+		{&p.b.Y.A0, groth16.PiB[1][0]}, {&p.b.Y.A1, groth16.PiB[1][1]},
+		{&p.c.X, groth16.PiC[0]}, {&p.c.Y, groth16.PiC[1]},
+	}
+	for _, coordinate := range coordinates {
+		if !canonical(coordinate.v, fp.Modulus()) {
+			return nil, fmt.Errorf("%w: coordinate %s is not a field element", ErrInvalidProof, coordinate.v)
+		}
+		coordinate.dst.SetBigInt(coordinate.v)
+	}
+	if !p.a.IsInSubGroup() || !p.b.IsInSubGroup() || !p.c.IsInSubGroup() {
+		return nil, fmt.Errorf("%w: point not in the subgroup", ErrInvalidProof)
+	}
+	for i, signal := range signals {
+		if !canonical(signal, fr.Modulus()) {
+			return nil, fmt.Errorf("%w: public signal %d is not a field element", ErrInvalidProof, i)
+		}
+		p.signals[i].SetBigInt(signal)
+	}
+	return p, nil
+}
+
+// canonical reports whether v is the canonical representative of an element of the field of modulus.
+func canonical(v, modulus *big.Int) bool {
+	return v != nil && v.Sign() >= 0 && v.Cmp(modulus) < 0
+}
+
+// bisect sets the results of the valid proofs, splitting a failing batch in halves.
+func (k *batchKey) bisect(proofs []*batchProof, indices []int, results []bool) error {
+	if len(proofs) == 0 {
+		return nil
+	}
+	ok, err := k.check(proofs)
+	if err != nil {
+		return err
+	}
+	if ok {
+		for _, i := range indices {
+			results[i] = true
+		}
+		return nil
+	}
+	if len(proofs) == 1 {
+		return nil
+	}
+	half := len(proofs) / 2
+	if err := k.bisect(proofs[:half], indices[:half], results); err != nil {
+		return err
+	}
+	return k.bisect(proofs[half:], indices[half:], results)
+}
+
+// check verifies prod e(r_i A_i, B_i) = e(sum r_i alpha, beta) e(sum r_i L_i, gamma) e(sum r_i C_i, delta)
+// for random r_i, which a batch holding an invalid proof passes with probability 1/|Fr|.
+func (k *batchKey) check(proofs []*batchProof) (bool, error) {
+	n := len(proofs)
+	g1 := make([]bn254.G1Affine, 0, n+3)
+	g2 := make([]bn254.G2Affine, 0, n+3)
+
+	// sum r_i L_i = (sum r_i) IC[0] + sum_j (sum_i r_i s_ij) IC[j+1]
+	icScalars := make([]fr.Element, len(k.ic))
+	cs := make([]bn254.G1Affine, n)
+	rs := make([]fr.Element, n)
+	for i, p := range proofs {
+		r := &rs[i]
+		if _, err := r.SetRandom(); err != nil {
+			return false, err
+		}
+		var ra bn254.G1Affine
+		ra.ScalarMultiplication(&p.a, r.BigInt(new(big.Int)))
+		g1 = append(g1, ra)
+		g2 = append(g2, p.b)
+
+		icScalars[0].Add(&icScalars[0], r)
+		for j := range p.signals {
+			var rsj fr.Element
+			rsj.Mul(r, &p.signals[j])
+			icScalars[j+1].Add(&icScalars[j+1], &rsj)
+		}
+		cs[i] = p.c
+	}
+
+	var sumR big.Int
+	icScalars[0].BigInt(&sumR)
+	var alpha, l, c bn254.G1Affine
+	alpha.ScalarMultiplication(&k.alpha, &sumR)
+	if _, err := l.MultiExp(k.ic, icScalars, ecc.MultiExpConfig{}); err != nil {
+		return false, err
+	}
+	if _, err := c.MultiExp(cs, rs, ecc.MultiExpConfig{}); err != nil {
+		return false, err
+	}
+	alpha.Neg(&alpha)
+	l.Neg(&l)
+	c.Neg(&c)
+	g1 = append(g1, alpha, l, c)
+	g2 = append(g2, k.beta, k.gamma, k.delta)
+	return bn254.PairingCheck(g1, g2)
+}
+
+// g1FromObject parses the snarkjs JSON of a G1 point, the inverse of g1Object.
+func g1FromObject(object []string) (bn254.G1Affine, error) {
+	var p bn254.G1Affine
+	if len(object) != 3 || object[2] != "1" {
+		return p, fmt.Errorf("not an affine point")
+	}
+	for i, dst := range []*fp.Element{&p.X, &p.Y} {
+		v, err := parseElement(object[i], 10, fp.Modulus())
+		if err != nil {
+			return p, err
+		}
+		dst.SetBigInt(v)
+	}
+	if !p.IsInSubGroup() {
+		return p, fmt.Errorf("point not on the curve")
+	}
+	return p, nil
+}
+
+// g2FromObject parses the snarkjs JSON of a G2 point, the inverse of g2Object.
+func g2FromObject(object [][]string) (bn254.G2Affine, error) {
+	var p bn254.G2Affine
+	if len(object) != 3 || len(object[2]) != 2 || object[2][0] != "1" || object[2][1] != "0" {
+		return p, fmt.Errorf("not an affine point")
+	}
+	for i, dst := range []*[2]*fp.Element{{&p.X.A0, &p.X.A1}, {&p.Y.A0, &p.Y.A1}} {
+		if len(object[i]) != 2 {
+			return p, fmt.Errorf("not an affine point")
+		}
+		for j := range dst {
+			v, err := parseElement(object[i][j], 10, fp.Modulus())
+			if err != nil {
+				return p, err
+			}
+			dst[j].SetBigInt(v)
+		}
+	}
+	if !p.IsInSubGroup() {
+		return p, fmt.Errorf("point not in the subgroup")
+	}
+	return p, nil
+}
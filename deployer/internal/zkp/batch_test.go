@@ -0,0 +1,205 @@
+package zkp
+
+import (
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// testSetup is a Groth16 verification key of known discrete logs, which proves any public
+// signals: e(A, B) = e(alpha, beta) e(L, gamma) e(C, delta) holds for ab = alpha beta + l gamma + c delta.
+type testSetup struct {
+	rng                       *rand.Rand
+	alpha, beta, gamma, delta fr.Element
+	ic                        []fr.Element
+	verifier                  *Verifier
+}
+
+func newTestSetup(t *testing.T, rng *rand.Rand, nPublic int) *testSetup {
+	t.Helper()
+	s := &testSetup{rng: rng, ic: make([]fr.Element, nPublic+1)}
+	for _, e := range append([]*fr.Element{&s.alpha, &s.beta, &s.gamma, &s.delta}, pointers(s.ic)...) {
+		e.SetBigInt(s.scalar())
+	}
+
+	vk := &types.VerificationKey{
+		Protocol: GROTH16_PROTOCOL,
+		Curve:    BN128_CURVE,
+		NPublic:  nPublic,
+		Alpha1:   g1Object(g1(&s.alpha)),
+		Beta2:    g2Object(g2(&s.beta)),
+		Gamma2:   g2Object(g2(&s.gamma)),
+		Delta2:   g2Object(g2(&s.delta)),
+	}
+	for i := range s.ic {
+		vk.IC = append(vk.IC, g1Object(g1(&s.ic[i])))
+	}
+	data, err := json.Marshal(vk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.verifier = &Verifier{vkey: data}
+	return s
+}
+
+func (s *testSetup) scalar() *big.Int {
+	return new(big.Int).Rand(s.rng, fr.Modulus())
+}
+
+// prove returns a valid proof of random public signals.
+func (s *testSetup) prove() *ZKProof {
+	signals := make([]*big.Int, len(s.ic)-1)
+	l := s.ic[0]
+	for j := range signals {
+		signals[j] = s.scalar()
+		var e fr.Element
+		e.SetBigInt(signals[j])
+		e.Mul(&e, &s.ic[j+1])
+		l.Add(&l, &e)
+	}
+
+	var a, b, c, t fr.Element
+	a.SetBigInt(s.scalar())
+	b.SetBigInt(s.scalar())
+	c.Mul(&a, &b)
+	t.Mul(&s.alpha, &s.beta)
+	c.Sub(&c, &t)
+	t.Mul(&l, &s.gamma)
+	c.Sub(&c, &t)
+	t.Inverse(&s.delta)
+	c.Mul(&c, &t)
+
+	pa, pb, pc := g1(&a), g2(&b), g1(&c)
+	return NewZKProofFromGroth16(&types.Groth16Proof{
+		PiA: [2]*big.Int{pa.X.BigInt(new(big.Int)), pa.Y.BigInt(new(big.Int))},
+		PiB: [2][2]*big.Int{
+			{pb.X.A0.BigInt(new(big.Int)), pb.X.A1.BigInt(new(big.Int))},
+			{pb.Y.A0.BigInt(new(big.Int)), pb.Y.A1.BigInt(new(big.Int))},
+		},
+		PiC: [2]*big.Int{pc.X.BigInt(new(big.Int)), pc.Y.BigInt(new(big.Int))},
+	}, signals)
+}
+
+func pointers(elements []fr.Element) []*fr.Element {
+	out := make([]*fr.Element, len(elements))
+	for i := range elements {
+		out[i] = &elements[i]
+	}
+	return out
+}
+
+func g1(e *fr.Element) bn254.G1Affine {
+	_, _, gen, _ := bn254.Generators()
+	var p bn254.G1Affine
+	p.ScalarMultiplication(&gen, e.BigInt(new(big.Int)))
+	return p
+}
+
+func g2(e *fr.Element) bn254.G2Affine {
+	_, _, _, gen := bn254.Generators()
+	var p bn254.G2Affine
+	p.ScalarMultiplication(&gen, e.BigInt(new(big.Int)))
+	return p
+}
+
+func TestVerifyBatch(t *testing.T) {
+	s := newTestSetup(t, rand.New(rand.NewSource(10)), types.PINACLE_PUBLIC_SIGNALS)
+
+	proofs := make([]*ZKProof, 9)
+	for i := range proofs {
+		proofs[i] = s.prove()
+	}
+	// The single proof verifier agrees on the setup
+	if err := s.verifier.VerifyProofs(proofs[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := s.verifier.VerifyBatch(proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ok := range results {
+		if !ok {
+			t.Fatalf("valid proof %d rejected", i)
+		}
+	}
+
+	// Another signal, swapped proofs, a malformed proof and a proof of another key
+	tampered := proofs[2].GetPublicSignals()
+	tampered[1] = "1"
+	proofs[2] = NewZKProofFromGroth16(mustGroth16(t, proofs[2]), mustSignals(t, tampered))
+	swapped := mustGroth16(t, proofs[5])
+	swapped.PiC = mustGroth16(t, proofs[6]).PiC
+	proofs[5] = NewZKProofFromGroth16(swapped, mustSignals(t, proofs[5].GetPublicSignals()))
+	proofs[7] = NewZKProof()
+	proofs[8] = newTestSetup(t, s.rng, types.PINACLE_PUBLIC_SIGNALS).prove()
+
+	results, err = s.verifier.VerifyBatch(proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ok := range results {
+		if want := i != 2 && i != 5 && i != 7 && i != 8; ok != want {
+			t.Fatalf("proof %d: got %v, expected %v", i, ok, want)
+		}
+	}
+}
+
+func TestVerifyBatchSignalCount(t *testing.T) {
+	s := newTestSetup(t, rand.New(rand.NewSource(11)), 3)
+	other := newTestSetup(t, s.rng, types.PINACLE_PUBLIC_SIGNALS)
+	results, err := s.verifier.VerifyBatch([]*ZKProof{s.prove(), other.prove()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0] || results[1] {
+		t.Fatalf("unexpected results %v", results)
+	}
+}
+
+func TestVerifyBatchNonCanonical(t *testing.T) {
+	s := newTestSetup(t, rand.New(rand.NewSource(12)), types.PINACLE_PUBLIC_SIGNALS)
+	valid := s.prove()
+	groth16 := mustGroth16(t, valid)
+	signals := mustSignals(t, valid.GetPublicSignals())
+
+	// The same values plus the modulus reduce to the valid proof
+	coordinate := *groth16
+	coordinate.PiA[0] = new(big.Int).Add(groth16.PiA[0], fp.Modulus())
+	signal := append([]*big.Int{}, signals...)
+	signal[0] = new(big.Int).Add(signals[0], fr.Modulus())
+	proofs := []*ZKProof{valid, NewZKProofFromGroth16(&coordinate, signals), NewZKProofFromGroth16(groth16, signal)}
+
+	results, err := s.verifier.VerifyBatch(proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0] || results[1] || results[2] {
+		t.Fatalf("unexpected results %v", results)
+	}
+}
+
+func mustGroth16(t *testing.T, proof *ZKProof) *types.Groth16Proof {
+	t.Helper()
+	p, err := proof.Groth16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func mustSignals(t *testing.T, signals []string) []*big.Int {
+	t.Helper()
+	out, err := parsePublicSignals(signals, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
@@ -0,0 +1,196 @@
+package zkp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"deployer/internal/metrics"
+)
+
+// proofCacheKeyLabel separates the key of the cache file from other uses of the secret
+const proofCacheKeyLabel = "pinacle proof cache v1"
+
+var ErrInvalidProofCache = errors.New("invalid proof cache")
+
+// ProofKey identifies a proof: the circuit (SHA-256 of its zkey), the hashed address and the
+// root it proves membership of. The zkMerkleTree proofs bind nothing else, so a proof is reused
+// for as long as the root does not change.
+type ProofKey struct {
+	Circuit       string `json:"circuit"`
+	HashedAddress string `json:"hashedAddress"`
+	Root          string `json:"root"`
+}
+
+// NewProofKey returns the key of a proof of circuit.
+func NewProofKey(circuit [32]byte, hashedAddress, root *big.Int) ProofKey {
+	return ProofKey{
+		Circuit:       fmt.Sprintf("%x", circuit),
+		HashedAddress: hashedAddress.String(),
+		Root:          root.String(),
+	}
+}
+
+// identity is the key without the root, a root change replacing the proof of an identity.
+func (k ProofKey) identity() ProofKey {
+	k.Root = ""
+	return k
+}
+
+type proofCacheEntry struct {
+	Key     ProofKey  `json:"key"`
+	Proof   []byte    `json:"proof"` // compact binary encoding
+	Expires time.Time `json:"expires"`
+}
+
+// ProofCache keeps the last proof of each identity until it expires or its root changes, so a
+// prover reuses a proof that is still valid instead of generating it again.
+type ProofCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[ProofKey]*proofCacheEntry // by identity
+	path    string
+	aead    cipher.AEAD
+	now     func() time.Time
+}
+
+// NewProofCache returns an in-memory cache of proofs valid for ttl.
+func NewProofCache(ttl time.Duration) *ProofCache {
+	return &ProofCache{
+		ttl:     ttl,
+		entries: map[ProofKey]*proofCacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// OpenProofCache returns a cache persisted at path, encrypted with AES-256-GCM under a key
+// derived from secret, e.g. the private key of the prover. The proofs still valid are loaded.
+func OpenProofCache(path string, secret []byte, ttl time.Duration) (*ProofCache, error) {
+	key := sha256.Sum256(append([]byte(proofCacheKeyLabel), secret...))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewProofCache(ttl)
+	c.path, c.aead = path, aead
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proof cache: %w", err)
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: truncated file", ErrInvalidProofCache)
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProofCache, err)
+	}
+	var entries []*proofCacheEntry
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProofCache, err)
+	}
+	now := c.now()
+	for _, entry := range entries {
+		if entry.Expires.After(now) {
+			c.entries[entry.Key.identity()] = entry
+		}
+	}
+	return c, nil
+}
+
+// Get returns the proof of key. The proof of the same identity for another root is evicted.
+func (c *ProofCache) Get(key ProofKey) (*ZKProof, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key.identity()]
+	if ok && (entry.Key != key || !entry.Expires.After(c.now())) {
+		delete(c.entries, key.identity())
+		ok = false
+	}
+	if !ok {
+		metrics.ProofCacheMiss.Inc(1)
+		return nil, false
+	}
+
+	proof := NewZKProof()
+	if err := proof.UnmarshalBinary(entry.Proof); err != nil {
+		delete(c.entries, key.identity())
+		metrics.ProofCacheMiss.Inc(1)
+		return nil, false
+	}
+	metrics.ProofCacheHit.Inc(1)
+	return proof, true
+}
+
+// Put caches the proof of key, replacing the proof of its identity, and persists the cache.
+func (c *ProofCache) Put(key ProofKey, proof *ZKProof) error {
+	data, err := proof.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key.identity()] = &proofCacheEntry{Key: key, Proof: data, Expires: c.now().Add(c.ttl)}
+	return c.save()
+}
+
+// save writes the proofs not expired to the cache file, if any, replacing it atomically.
+func (c *ProofCache) save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	now := c.now()
+	entries := make([]*proofCacheEntry, 0, len(c.entries))
+	for identity, entry := range c.entries {
+		if !entry.Expires.After(now) {
+			delete(c.entries, identity)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write proof cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(c.aead.Seal(nonce, nonce, plaintext, nil)); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write proof cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write proof cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write proof cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package zkp
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// clock is a settable time source for the cache expiry.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+func testProofKey(root int64) ProofKey {
+	return NewProofKey([32]byte{1}, big.NewInt(42), big.NewInt(root))
+}
+
+func TestProofCacheHit(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	cache := NewProofCache(time.Hour)
+	proof := randomProof(t, rng, 2)
+
+	if _, ok := cache.Get(testProofKey(1)); ok {
+		t.Fatal("hit on an empty cache")
+	}
+	if err := cache.Put(testProofKey(1), proof); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := cache.Get(testProofKey(1))
+	if !ok {
+		t.Fatal("cached proof missed")
+	}
+	requireSameProof(t, got, proof)
+}
+
+func TestProofCacheMissOnKeyChange(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	cache := NewProofCache(time.Hour)
+	if err := cache.Put(testProofKey(1), randomProof(t, rng, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	other := testProofKey(1)
+	other.HashedAddress = "43"
+	if _, ok := cache.Get(other); ok {
+		t.Fatal("hit for another hashed address")
+	}
+	other = testProofKey(1)
+	other.Circuit = NewProofKey([32]byte{2}, big.NewInt(42), big.NewInt(1)).Circuit
+	if _, ok := cache.Get(other); ok {
+		t.Fatal("hit for another circuit")
+	}
+
+	// A new root replaces the proof of the identity
+	if _, ok := cache.Get(testProofKey(2)); ok {
+		t.Fatal("hit for another root")
+	}
+	if _, ok := cache.Get(testProofKey(1)); ok {
+		t.Fatal("proof of the previous root kept after a root change")
+	}
+}
+
+func TestProofCacheExpiry(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	clock := &clock{now: time.Unix(1_700_000_000, 0)}
+	cache := NewProofCache(time.Minute)
+	cache.now = clock.Now
+
+	if err := cache.Put(testProofKey(1), randomProof(t, rng, 2)); err != nil {
+		t.Fatal(err)
+	}
+	clock.now = clock.now.Add(59 * time.Second)
+	if _, ok := cache.Get(testProofKey(1)); !ok {
+		t.Fatal("proof missed before its expiry")
+	}
+	clock.now = clock.now.Add(time.Second)
+	if _, ok := cache.Get(testProofKey(1)); ok {
+		t.Fatal("hit on an expired proof")
+	}
+}
+
+func TestProofCachePersisted(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	path := filepath.Join(t.TempDir(), "proofs.cache")
+	secret := []byte("prover key")
+	proof := randomProof(t, rng, 2)
+
+	cache, err := OpenProofCache(path, secret, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put(testProofKey(1), proof); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := OpenProofCache(path, secret, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := reopened.Get(testProofKey(1))
+	if !ok {
+		t.Fatal("persisted proof missed")
+	}
+	requireSameProof(t, got, proof)
+
+	if _, err := OpenProofCache(path, []byte("another key"), time.Hour); !errors.Is(err, ErrInvalidProofCache) {
+		t.Fatalf("cache opened with another secret: got %v, want %v", err, ErrInvalidProofCache)
+	}
+}
+
+func TestProofCacheTampered(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	path := filepath.Join(t.TempDir(), "proofs.cache")
+	secret := []byte("prover key")
+
+	cache, err := OpenProofCache(path, secret, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put(testProofKey(1), randomProof(t, rng, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-1] ^= 1
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenProofCache(path, secret, time.Hour); !errors.Is(err, ErrInvalidProofCache) {
+		t.Fatalf("tampered cache: got %v, want %v", err, ErrInvalidProofCache)
+	}
+
+	if err := os.WriteFile(path, data[:4], 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenProofCache(path, secret, time.Hour); !errors.Is(err, ErrInvalidProofCache) {
+		t.Fatalf("truncated cache: got %v, want %v", err, ErrInvalidProofCache)
+	}
+}
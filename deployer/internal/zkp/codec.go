@@ -0,0 +1,320 @@
+package zkp
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	rapidsnark "github.com/iden3/go-rapidsnark/types"
+)
+
+const (
+	GROTH16_PROTOCOL = "groth16"
+
+	// binaryProofVersion is the first byte of the compact encoding
+	binaryProofVersion = 1
+)
+
+var ErrInvalidProof = errors.New("invalid proof")
+
+// NewZKProofFromGroth16 returns the proof in the snarkjs layout, with decimal coordinates and the
+// projective z coordinates snarkjs adds.
+func NewZKProofFromGroth16(p *types.Groth16Proof, publicSignals []*big.Int) *ZKProof {
+	signals := make([]string, len(publicSignals))
+	for i, signal := range publicSignals {
+		signals[i] = signal.String()
+	}
+
+	zkp := NewZKProof()
+	zkp.setProof(&rapidsnark.ProofData{
+		A: []string{p.PiA[0].String(), p.PiA[1].String(), "1"},
+		B: [][]string{
+			{p.PiB[0][0].String(), p.PiB[0][1].String()},
+			{p.PiB[1][0].String(), p.PiB[1][1].String()},
+			{"1", "0"},
+		},
+		C:        []string{p.PiC[0].String(), p.PiC[1].String(), "1"},
+		Protocol: GROTH16_PROTOCOL,
+	})
+	zkp.setPublicSignals(signals)
+	return zkp
+}
+
+// snarkjsProof is the {proof, publicSignals} object of snarkjs groth16 fullprove, as the mobile
+// app sends it.
+type snarkjsProof struct {
+	Proof         *snarkjsProofData `json:"proof"`
+	PublicSignals []string          `json:"publicSignals"`
+}
+
+type snarkjsProofData struct {
+	rapidsnark.ProofData
+	Curve string `json:"curve,omitempty"`
+}
+
+// MarshalSnarkJS encodes the proof as snarkjs groth16 fullprove returns it.
+func (zkp *ZKProof) MarshalSnarkJS() ([]byte, error) {
+	proof, signals, err := zkp.parse()
+	if err != nil {
+		return nil, err
+	}
+	canonical := NewZKProofFromGroth16(proof, signals)
+	return json.Marshal(snarkjsProof{
+		Proof:         &snarkjsProofData{ProofData: *canonical.getProof(), Curve: BN128_CURVE},
+		PublicSignals: canonical.getPublicSignals(),
+	})
+}
+
+// ParseSnarkJS decodes a snarkjs {proof, publicSignals} object.
+func ParseSnarkJS(data []byte) (*ZKProof, error) {
+	var v snarkjsProof
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	if v.Proof == nil {
+		return nil, fmt.Errorf("%w: missing proof", ErrInvalidProof)
+	}
+	if v.Proof.Protocol != "" && v.Proof.Protocol != GROTH16_PROTOCOL {
+		return nil, fmt.Errorf("%w: protocol %s", ErrInvalidProof, v.Proof.Protocol)
+	}
+	if v.Proof.Curve != "" && v.Proof.Curve != BN128_CURVE {
+		return nil, fmt.Errorf("%w: curve %s", ErrInvalidProof, v.Proof.Curve)
+	}
+
+	proof, err := parseGroth16(&v.Proof.ProofData, 10)
+	if err != nil {
+		return nil, err
+	}
+	signals, err := parsePublicSignals(v.PublicSignals, 10)
+	if err != nil {
+		return nil, err
+	}
+	return NewZKProofFromGroth16(proof, signals), nil
+}
+
+// SolidityCallData encodes the proof like snarkjs zkey export soliditycalldata: the arguments
+// of verifyProof, in hex, pi_b in the contract order.
+func (zkp *ZKProof) SolidityCallData() (string, error) {
+	proof, signals, err := zkp.parse()
+	if err != nil {
+		return "", err
+	}
+	p := ContractProof(proof)
+
+	inputs := make([]string, len(signals))
+	for i, signal := range signals {
+		inputs[i] = p256(signal)
+	}
+	return fmt.Sprintf("[%s, %s],[[%s, %s],[%s, %s]],[%s, %s],[%s]",
+		p256(p.PiA[0]), p256(p.PiA[1]),
+		p256(p.PiB[0][0]), p256(p.PiB[0][1]), p256(p.PiB[1][0]), p256(p.PiB[1][1]),
+		p256(p.PiC[0]), p256(p.PiC[1]),
+		strings.Join(inputs, ",")), nil
+}
+
+// ParseSolidityCallData decodes the output of snarkjs zkey export soliditycalldata.
+func ParseSolidityCallData(calldata string) (*ZKProof, error) {
+	var args []json.RawMessage
+	if err := json.Unmarshal([]byte("["+calldata+"]"), &args); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	if len(args) != 4 {
+		return nil, fmt.Errorf("%w: %d calldata arguments, expected 4", ErrInvalidProof, len(args))
+	}
+
+	var (
+		a, c    []string
+		b       [][]string
+		signals []string
+	)
+	for i, v := range []any{&a, &b, &c, &signals} {
+		if err := json.Unmarshal(args[i], v); err != nil {
+			return nil, fmt.Errorf("%w: calldata argument %d: %w", ErrInvalidProof, i, err)
+		}
+	}
+	// Back from the contract order of pi_b
+	for _, coordinate := range b {
+		if len(coordinate) == 2 {
+			coordinate[0], coordinate[1] = coordinate[1], coordinate[0]
+		}
+	}
+
+	proof, err := parseGroth16(&rapidsnark.ProofData{A: a, B: b, C: c}, 16)
+	if err != nil {
+		return nil, err
+	}
+	publicSignals, err := parsePublicSignals(signals, 16)
+	if err != nil {
+		return nil, err
+	}
+	return NewZKProofFromGroth16(proof, publicSignals), nil
+}
+
+// MarshalBinary encodes the proof in 129 bytes plus 32 bytes per public signal: a version byte,
+// the compressed points (pi_a, pi_b, pi_c), the signal count as a uvarint and the signals in
+// big-endian. The points must be on the curve.
+func (zkp *ZKProof) MarshalBinary() ([]byte, error) {
+	proof, signals, err := zkp.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	var a, c bn254.G1Affine
+	var b bn254.G2Affine
+	a.X.SetBigInt(proof.PiA[0])
+	a.Y.SetBigInt(proof.PiA[1])
+	b.X.A0.SetBigInt(proof.PiB[0][0])
+	b.X.A1.SetBigInt(proof.PiB[0][1])
+	b.Y.A0.SetBigInt(proof.PiB[1][0])
+	b.Y.A1.SetBigInt(proof.PiB[1][1])
+	c.X.SetBigInt(proof.PiC[0])
+	c.Y.SetBigInt(proof.PiC[1])
+	if !a.IsOnCurve() || !b.IsOnCurve() || !c.IsOnCurve() {
+		return nil, fmt.Errorf("%w: point not on the curve", ErrInvalidProof)
+	}
+
+	data := []byte{binaryProofVersion}
+	aBytes, bBytes, cBytes := a.Bytes(), b.Bytes(), c.Bytes()
+	data = append(data, aBytes[:]...)
+	data = append(data, bBytes[:]...)
+	data = append(data, cBytes[:]...)
+	data = binary.AppendUvarint(data, uint64(len(signals)))
+	for _, signal := range signals {
+		data = append(data, signal.FillBytes(make([]byte, fr.Bytes))...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes the encoding of MarshalBinary, checking the points are in their
+// subgroups.
+func (zkp *ZKProof) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryProofVersion {
+		return fmt.Errorf("%w: unknown encoding", ErrInvalidProof)
+	}
+	data = data[1:]
+
+	var a, c bn254.G1Affine
+	var b bn254.G2Affine
+	for _, point := range []interface{ SetBytes([]byte) (int, error) }{&a, &b, &c} {
+		n, err := point.SetBytes(data)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+		}
+		data = data[n:]
+	}
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) != count*fr.Bytes {
+		return fmt.Errorf("%w: truncated public signals", ErrInvalidProof)
+	}
+	data = data[n:]
+	signals := make([]*big.Int, count)
+	for i := range signals {
+		signals[i] = new(big.Int).SetBytes(data[i*fr.Bytes : (i+1)*fr.Bytes])
+		if signals[i].Cmp(fr.Modulus()) >= 0 {
+			return fmt.Errorf("%w: public signal %d out of the field", ErrInvalidProof, i)
+		}
+	}
+
+	decoded := NewZKProofFromGroth16(&types.Groth16Proof{
+		PiA: [2]*big.Int{a.X.BigInt(new(big.Int)), a.Y.BigInt(new(big.Int))},
+		PiB: [2][2]*big.Int{
+			{b.X.A0.BigInt(new(big.Int)), b.X.A1.BigInt(new(big.Int))},
+			{b.Y.A0.BigInt(new(big.Int)), b.Y.A1.BigInt(new(big.Int))},
+		},
+		PiC: [2]*big.Int{c.X.BigInt(new(big.Int)), c.Y.BigInt(new(big.Int))},
+	}, signals)
+
+	zkp.mu.Lock()
+	defer zkp.mu.Unlock()
+	zkp.setProof(decoded.getProof())
+	zkp.setPublicSignals(decoded.getPublicSignals())
+	return nil
+}
+
+// parse returns the proof points and the public signals.
+func (zkp *ZKProof) parse() (*types.Groth16Proof, []*big.Int, error) {
+	zkp.mu.RLock()
+	defer zkp.mu.RUnlock()
+
+	proof, err := parseGroth16(zkp.getProof(), 10)
+	if err != nil {
+		return nil, nil, err
+	}
+	signals, err := parsePublicSignals(zkp.getPublicSignals(), 10)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, signals, nil
+}
+
+func parseGroth16(p *rapidsnark.ProofData, base int) (*types.Groth16Proof, error) {
+	if p == nil {
+		return nil, fmt.Errorf("%w: missing proof", ErrInvalidProof)
+	}
+	if len(p.A) < 2 {
+		return nil, fmt.Errorf("%w: invalid PiA length: got %d", ErrInvalidProof, len(p.A))
+	}
+	if len(p.B) < 2 || len(p.B[0]) < 2 || len(p.B[1]) < 2 {
+		return nil, fmt.Errorf("%w: invalid PiB structure", ErrInvalidProof)
+	}
+	if len(p.C) < 2 {
+		return nil, fmt.Errorf("%w: invalid PiC length: got %d", ErrInvalidProof, len(p.C))
+	}
+
+	var proof types.Groth16Proof
+	var err error
+	for i := 0; i < 2; i++ {
+		if proof.PiA[i], err = parseElement(p.A[i], base, fp.Modulus()); err != nil {
+			return nil, fmt.Errorf("%w: invalid PiA[%d]: %w", ErrInvalidProof, i, err)
+		}
+		for j := 0; j < 2; j++ {
+			if proof.PiB[i][j], err = parseElement(p.B[i][j], base, fp.Modulus()); err != nil {
+				return nil, fmt.Errorf("%w: invalid PiB[%d][%d]: %w", ErrInvalidProof, i, j, err)
+			}
+		}
+		if proof.PiC[i], err = parseElement(p.C[i], base, fp.Modulus()); err != nil {
+			return nil, fmt.Errorf("%w: invalid PiC[%d]: %w", ErrInvalidProof, i, err)
+		}
+	}
+	return &proof, nil
+}
+
+func parsePublicSignals(signals []string, base int) ([]*big.Int, error) {
+	out := make([]*big.Int, len(signals))
+	for i, signal := range signals {
+		var err error
+		if out[i], err = parseElement(signal, base, fr.Modulus()); err != nil {
+			return nil, fmt.Errorf("%w: invalid public signal at index %d: %w", ErrInvalidProof, i, err)
+		}
+	}
+	return out, nil
+}
+
+// parseElement parses an element of the field of modulus, decimal or 0x-prefixed hex.
+func parseElement(s string, base int, modulus *big.Int) (*big.Int, error) {
+	if base == 16 {
+		var ok bool
+		if s, ok = strings.CutPrefix(s, "0x"); !ok {
+			return nil, fmt.Errorf("%q is not 0x-prefixed", s)
+		}
+	}
+	v, ok := new(big.Int).SetString(s, base)
+	if !ok || v.Sign() < 0 || v.Cmp(modulus) >= 0 {
+		return nil, fmt.Errorf("%q is not a field element", s)
+	}
+	return v, nil
+}
+
+// p256 is the quoted 0x-prefixed 64 hex digits of v, as snarkjs prints calldata.
+func p256(v *big.Int) string {
+	return fmt.Sprintf("\"0x%064x\"", v)
+}
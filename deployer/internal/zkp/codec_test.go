@@ -0,0 +1,309 @@
+package zkp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// randomProof returns a proof of random points, which the codecs cannot tell from a valid one.
+func randomProof(t *testing.T, rng *rand.Rand, nSignals int) *ZKProof {
+	t.Helper()
+
+	scalar := func() *big.Int { return new(big.Int).Rand(rng, fr.Modulus()) }
+	_, _, g1, g2 := bn254.Generators()
+	var a, c bn254.G1Affine
+	var b bn254.G2Affine
+	a.ScalarMultiplication(&g1, scalar())
+	b.ScalarMultiplication(&g2, scalar())
+	c.ScalarMultiplication(&g1, scalar())
+
+	signals := make([]*big.Int, nSignals)
+	for i := range signals {
+		signals[i] = scalar()
+	}
+	return NewZKProofFromGroth16(&types.Groth16Proof{
+		PiA: [2]*big.Int{a.X.BigInt(new(big.Int)), a.Y.BigInt(new(big.Int))},
+		PiB: [2][2]*big.Int{
+			{b.X.A0.BigInt(new(big.Int)), b.X.A1.BigInt(new(big.Int))},
+			{b.Y.A0.BigInt(new(big.Int)), b.Y.A1.BigInt(new(big.Int))},
+		},
+		PiC: [2]*big.Int{c.X.BigInt(new(big.Int)), c.Y.BigInt(new(big.Int))},
+	}, signals)
+}
+
+func requireSameProof(t *testing.T, got, want *ZKProof) {
+	t.Helper()
+	if !reflect.DeepEqual(got.GetProof(), want.GetProof()) {
+		t.Fatalf("proof mismatch:\n got %+v\nwant %+v", got.GetProof(), want.GetProof())
+	}
+	if !reflect.DeepEqual(got.GetPublicSignals(), want.GetPublicSignals()) {
+		t.Fatalf("public signals mismatch:\n got %v\nwant %v", got.GetPublicSignals(), want.GetPublicSignals())
+	}
+}
+
+func TestSnarkJSRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, nSignals := range []int{0, 1, types.PINACLE_PUBLIC_SIGNALS, 5} {
+		proof := randomProof(t, rng, nSignals)
+		data, err := proof.MarshalSnarkJS()
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := ParseSnarkJS(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requireSameProof(t, decoded, proof)
+	}
+
+	// The fields snarkjs writes
+	data, err := randomProof(t, rng, 2).MarshalSnarkJS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v struct {
+		Proof struct {
+			A        []string   `json:"pi_a"`
+			B        [][]string `json:"pi_b"`
+			Protocol string     `json:"protocol"`
+			Curve    string     `json:"curve"`
+		} `json:"proof"`
+		PublicSignals []string `json:"publicSignals"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Proof.A[2] != "1" || !reflect.DeepEqual(v.Proof.B[2], []string{"1", "0"}) ||
+		v.Proof.Protocol != "groth16" || v.Proof.Curve != "bn128" || len(v.PublicSignals) != 2 {
+		t.Fatalf("unexpected snarkjs JSON: %s", data)
+	}
+}
+
+func TestParseSnarkJSRejects(t *testing.T) {
+	data, err := randomProof(t, rand.New(rand.NewSource(2)), 2).MarshalSnarkJS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, replace := range map[string][2]string{
+		"protocol": {`"groth16"`, `"plonk"`},
+		"curve":    {`"bn128"`, `"bls12381"`},
+		"signal":   {`"publicSignals":["`, `"publicSignals":["` + fr.Modulus().String() + `","`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tampered := strings.Replace(string(data), replace[0], replace[1], 1)
+			if _, err := ParseSnarkJS([]byte(tampered)); !errors.Is(err, ErrInvalidProof) {
+				t.Fatalf("expected %v, got %v", ErrInvalidProof, err)
+			}
+		})
+	}
+}
+
+func TestSolidityCallDataRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, nSignals := range []int{1, types.PINACLE_PUBLIC_SIGNALS, 5} {
+		proof := randomProof(t, rng, nSignals)
+		calldata, err := proof.SolidityCallData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := ParseSolidityCallData(calldata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requireSameProof(t, decoded, proof)
+	}
+}
+
+func TestSolidityCallDataLayout(t *testing.T) {
+	proof := randomProof(t, rand.New(rand.NewSource(4)), 2)
+	calldata, err := proof.SolidityCallData()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var args [4]json.RawMessage
+	if err := json.Unmarshal([]byte("["+calldata+"]"), &args); err != nil {
+		t.Fatal(err)
+	}
+	var b [2][2]string
+	if err := json.Unmarshal(args[1], &b); err != nil {
+		t.Fatal(err)
+	}
+
+	// pi_b is in the contract order, the one ConvertProof produces
+	converted, err := proof.ConvertProof()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range b {
+		for j := range b[i] {
+			if want := strings.Trim(p256(converted.PiB[i][j]), `"`); b[i][j] != want {
+				t.Fatalf("pi_b[%d][%d] = %s, expected %s", i, j, b[i][j], want)
+			}
+		}
+	}
+	if !strings.HasPrefix(calldata, `["0x`) || strings.Count(calldata, "0x") != 10 {
+		t.Fatalf("unexpected calldata: %s", calldata)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for _, nSignals := range []int{0, types.PINACLE_PUBLIC_SIGNALS, 200} {
+		proof := randomProof(t, rng, nSignals)
+		data, err := proof.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		varint := 1
+		if nSignals >= 128 {
+			varint = 2
+		}
+		if want := 129 + varint + 32*nSignals; len(data) != want {
+			t.Fatalf("encoded %d bytes, expected %d", len(data), want)
+		}
+
+		decoded := NewZKProof()
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		requireSameProof(t, decoded, proof)
+	}
+}
+
+func TestBinaryRejects(t *testing.T) {
+	proof := randomProof(t, rand.New(rand.NewSource(6)), 2)
+	data, err := proof.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signal := bytes.Clone(data)
+	copy(signal[len(signal)-32:], fr.Modulus().FillBytes(make([]byte, 32)))
+	// pi_a with an x of no point, y^2 = x^3 + 3 having no root
+	var x, rhs, three fp.Element
+	three.SetUint64(3)
+	for x.SetOne(); ; x.Add(&x, &three) {
+		rhs.Square(&x).Mul(&rhs, &x).Add(&rhs, &three)
+		if rhs.Legendre() == -1 {
+			break
+		}
+	}
+	point := bytes.Clone(data)
+	xBytes := x.Bytes()
+	copy(point[1:33], xBytes[:])
+	point[1] |= data[1] & 0xc0 // compression flags
+	for name, tampered := range map[string][]byte{
+		"empty":     nil,
+		"version":   append([]byte{0}, data[1:]...),
+		"truncated": data[:len(data)-1],
+		"trailing":  append(bytes.Clone(data), 0),
+		"signal":    signal,
+		"point":     point,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := NewZKProof().UnmarshalBinary(tampered); !errors.Is(err, ErrInvalidProof) {
+				t.Fatalf("expected %v, got %v", ErrInvalidProof, err)
+			}
+		})
+	}
+
+	t.Run("off curve", func(t *testing.T) {
+		offCurve := *proof.GetProof()
+		offCurve.A = []string{"1", "3", "1"}
+		p := NewZKProof()
+		p.SetProof(&offCurve)
+		p.SetPublicSignals(proof.GetPublicSignals())
+		if _, err := p.MarshalBinary(); !errors.Is(err, ErrInvalidProof) {
+			t.Fatalf("expected %v, got %v", ErrInvalidProof, err)
+		}
+	})
+}
+
+func TestFormatsAgree(t *testing.T) {
+	// snarkjs JSON -> binary -> calldata -> snarkjs JSON
+	proof := randomProof(t, rand.New(rand.NewSource(7)), types.PINACLE_PUBLIC_SIGNALS)
+	want, err := proof.MarshalSnarkJS()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromJSON, err := ParseSnarkJS(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := fromJSON.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromBinary := NewZKProof()
+	if err := fromBinary.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	calldata, err := fromBinary.SolidityCallData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromCalldata, err := ParseSolidityCallData(calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := fromCalldata.MarshalSnarkJS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("snarkjs JSON changed:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestConvertPublicSignals(t *testing.T) {
+	proof := randomProof(t, rand.New(rand.NewSource(8)), 3)
+
+	dst := make([]*big.Int, 3)
+	if err := proof.ConvertPublicSignalsTo(dst); err != nil {
+		t.Fatal(err)
+	}
+	for i, signal := range proof.GetPublicSignals() {
+		if dst[i].String() != signal {
+			t.Fatalf("signal %d = %s, expected %s", i, dst[i], signal)
+		}
+	}
+
+	signals, err := proof.ConvertPublicSignals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(signals, dst) {
+		t.Fatalf("got %v, want %v", signals, dst)
+	}
+	var pinacle [types.PINACLE_PUBLIC_SIGNALS]*big.Int
+	if err := proof.ConvertPublicSignalsTo(pinacle[:]); err == nil {
+		t.Fatalf("3 public signals converted for the Pinacle circuit")
+	}
+	if err := proof.ConvertPublicSignalsTo(make([]*big.Int, 2)); err == nil {
+		t.Fatalf("3 public signals converted into 2")
+	}
+}
+
+func TestConvertProofRejectsOutOfField(t *testing.T) {
+	proof := randomProof(t, rand.New(rand.NewSource(9)), 2)
+	data := proof.GetProof()
+	data.C = []string{data.C[0], fp.Modulus().String(), "1"}
+	proof.SetProof(data)
+	if _, err := proof.ConvertProof(); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("expected %v, got %v", ErrInvalidProof, err)
+	}
+}
@@ -0,0 +1,230 @@
+package zkp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"deployer/internal/sign"
+	"deployer/internal/types"
+	"deployer/internal/validator"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+var ErrInvalidInput = errors.New("invalid circuit input")
+
+// PinacleCircuit describes the inputs of Pinacle.circom.
+var PinacleCircuit = &types.CircuitDescriptor{
+	Circuit: PINACLE_CIRCUIT,
+	Inputs: []types.Signal{
+		{Name: "privateKey", Dims: []int{types.REGISTERS}},
+		{Name: "pathElements", Dims: []int{LEVELS}},
+		{Name: "pathIndices", Dims: []int{LEVELS}},
+	},
+}
+
+// LoadCircuitDescriptor reads the JSON descriptor of a circuit, the sidecar of its wasm.
+func LoadCircuitDescriptor(path Path) (*types.CircuitDescriptor, error) {
+	data, err := os.ReadFile(string(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read circuit descriptor: %w", err)
+	}
+	var c types.CircuitDescriptor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse circuit descriptor: %w", err)
+	}
+	if err := validator.ValidateStruct(c); err != nil {
+		return nil, fmt.Errorf("failed to validate circuit descriptor: %w", err)
+	}
+	seen := map[string]bool{}
+	for _, signal := range c.Inputs {
+		if seen[signal.Name] {
+			return nil, fmt.Errorf("failed to validate circuit descriptor: input %s listed twice", signal.Name)
+		}
+		seen[signal.Name] = true
+	}
+	return &c, nil
+}
+
+// InputRule checks the elements of an input signal, in row-major order, beyond the field range
+// every input is checked against.
+type InputRule func(signal types.Signal, elements []*big.Int) error
+
+// PinacleRules are the checks of the Pinacle inputs: the private key is a secp256k1 key in
+// 64-bit registers, the path indices are bits.
+var PinacleRules = map[string]InputRule{
+	"privateKey":  RegistersRule(64, sign.SECP256K1_N),
+	"pathIndices": BitsRule,
+}
+
+// Inputs builds the witness inputs of a circuit. A value is a field element (*big.Int, an
+// integer, or a decimal or 0x-prefixed hex string), or an array, slice or pointer to one
+// nesting them along the dimensions of the signal.
+type Inputs struct {
+	mu      sync.RWMutex
+	circuit *types.CircuitDescriptor
+	rules   map[string]InputRule
+	values  map[string]any
+}
+
+// NewInputs returns empty inputs of circuit, whose signals are checked by the rules of their
+// name.
+func NewInputs(circuit *types.CircuitDescriptor, rules map[string]InputRule) *Inputs {
+	return &Inputs{circuit: circuit, rules: rules, values: map[string]any{}}
+}
+
+// Set sets the value of the input signal name. It is checked when marshalling.
+func (in *Inputs) Set(name string, value any) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.values[name] = value
+}
+
+// MarshalJSON returns the inputs witness.ParseInputs takes, failing unless every input of the
+// circuit is set with its dimensions and field elements of BN254 passing the rules. The provers
+// marshal the inputs before computing the witness, so invalid inputs never reach it.
+func (in *Inputs) MarshalJSON() ([]byte, error) {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+
+	out := make(map[string]any, len(in.circuit.Inputs))
+	for _, signal := range in.circuit.Inputs {
+		value, ok := in.values[signal.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not set", ErrInvalidInput, signal.Name)
+		}
+		elements := make([]*big.Int, 0, signal.Size())
+		encoded, err := encodeInput(reflect.ValueOf(value), signal.Dims, signal.Name, &elements)
+		if err != nil {
+			return nil, err
+		}
+		if rule := in.rules[signal.Name]; rule != nil {
+			if err := rule(signal, elements); err != nil {
+				return nil, err
+			}
+		}
+		out[signal.Name] = encoded
+	}
+	for name := range in.values {
+		if _, ok := out[name]; !ok {
+			return nil, fmt.Errorf("%w: %s is not an input of %s", ErrInvalidInput, name, in.circuit.Circuit)
+		}
+	}
+	return json.Marshal(out)
+}
+
+// BitsRule requires every element to be 0 or 1.
+func BitsRule(signal types.Signal, elements []*big.Int) error {
+	for i, element := range elements {
+		if element.Cmp(big.NewInt(1)) > 0 {
+			return fmt.Errorf("%w: %s = %s is not 0 or 1", ErrInvalidInput, elementName(signal, i), element)
+		}
+	}
+	return nil
+}
+
+// RegistersRule requires the elements to be registers of bits bits, least significant first,
+// of a value in [1, max).
+func RegistersRule(bits int, max *big.Int) InputRule {
+	return func(signal types.Signal, elements []*big.Int) error {
+		value := new(big.Int)
+		for i := len(elements) - 1; i >= 0; i-- {
+			if elements[i].BitLen() > bits {
+				return fmt.Errorf("%w: %s = %s exceeds %d bits", ErrInvalidInput, elementName(signal, i), elements[i], bits)
+			}
+			value.Lsh(value, uint(bits)).Or(value, elements[i])
+		}
+		if value.Sign() == 0 || value.Cmp(max) >= 0 {
+			return fmt.Errorf("%w: %s is not in [1, %s)", ErrInvalidInput, signal.Name, max)
+		}
+		return nil
+	}
+}
+
+// elementName names the element at row-major index i of signal, e.g. m[1][2].
+func elementName(signal types.Signal, i int) string {
+	indices := make([]string, len(signal.Dims))
+	for d := len(signal.Dims) - 1; d >= 0; d-- {
+		indices[d] = fmt.Sprintf("[%d]", i%signal.Dims[d])
+		i /= signal.Dims[d]
+	}
+	return signal.Name + strings.Join(indices, "")
+}
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// encodeInput converts v, following dims, to nested arrays of decimal strings, appending the
+// elements to elements.
+func encodeInput(v reflect.Value, dims []int, path string, elements *[]*big.Int) (any, error) {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer && v.Type().Elem() != bigIntType) {
+		if v.IsNil() {
+			return nil, fmt.Errorf("%w: %s is nil", ErrInvalidInput, path)
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, fmt.Errorf("%w: %s is nil", ErrInvalidInput, path)
+	}
+
+	if len(dims) == 0 {
+		element, err := inputElement(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %w", ErrInvalidInput, path, err)
+		}
+		if element.Sign() < 0 || element.Cmp(fr.Modulus()) >= 0 {
+			return nil, fmt.Errorf("%w: %s = %s is not a field element", ErrInvalidInput, path, element)
+		}
+		*elements = append(*elements, element)
+		return element.String(), nil
+	}
+
+	if v.Kind() != reflect.Array && v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%w: %s is a %s, expected %d elements", ErrInvalidInput, path, v.Type(), dims[0])
+	}
+	if v.Len() != dims[0] {
+		return nil, fmt.Errorf("%w: %s has %d elements, expected %d", ErrInvalidInput, path, v.Len(), dims[0])
+	}
+	out := make([]any, v.Len())
+	for i := range out {
+		var err error
+		if out[i], err = encodeInput(v.Index(i), dims[1:], fmt.Sprintf("%s[%d]", path, i), elements); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func inputElement(v reflect.Value) (*big.Int, error) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil, errors.New("is nil")
+		}
+		return v.Interface().(*big.Int), nil
+	case reflect.Struct:
+		if v.Type() == bigIntType {
+			b := v.Interface().(big.Int)
+			return &b, nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	case reflect.String:
+		s, base := v.String(), 10
+		if hex, ok := strings.CutPrefix(s, "0x"); ok {
+			s, base = hex, 16
+		}
+		if element, ok := new(big.Int).SetString(s, base); ok {
+			return element, nil
+		}
+		return nil, fmt.Errorf("%q is not a number", v.String())
+	}
+	return nil, fmt.Errorf("is a %s, expected a field element", v.Type())
+}
@@ -0,0 +1,243 @@
+package zkp
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"deployer/internal/sign"
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func bigInts(values ...int64) []*big.Int {
+	out := make([]*big.Int, len(values))
+	for i, v := range values {
+		out[i] = big.NewInt(v)
+	}
+	return out
+}
+
+func TestBitsRule(t *testing.T) {
+	signal := types.Signal{Name: "pathIndices", Dims: []int{4}}
+	for _, tc := range []struct {
+		name     string
+		elements []*big.Int
+		err      string
+	}{
+		{"bits", bigInts(0, 1, 1, 0), ""},
+		{"two", bigInts(0, 1, 2, 0), "pathIndices[2] = 2 is not 0 or 1"},
+		{"large", []*big.Int{big.NewInt(0), sign.SECP256K1_N, big.NewInt(1), big.NewInt(0)}, "pathIndices[1] = "},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := BitsRule(signal, tc.elements)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("got %v, want %v naming %q", err, ErrInvalidInput, tc.err)
+			}
+		})
+	}
+}
+
+func TestRegistersRule(t *testing.T) {
+	rule := RegistersRule(64, sign.SECP256K1_N)
+	signal := types.Signal{Name: "privateKey", Dims: []int{types.REGISTERS}}
+	registers := func(value *big.Int) []*big.Int {
+		r := sign.BigIntToRegisters(value)
+		return r[:]
+	}
+	nMinusOne := new(big.Int).Sub(sign.SECP256K1_N, big.NewInt(1))
+
+	for _, tc := range []struct {
+		name     string
+		elements []*big.Int
+		err      string
+	}{
+		{"one", registers(big.NewInt(1)), ""},
+		{"n-1", registers(nMinusOne), ""},
+		{"zero", registers(new(big.Int)), "privateKey is not in [1, "},
+		{"n", registers(sign.SECP256K1_N), "privateKey is not in [1, "},
+		{"above n", registers(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))), "privateKey is not in [1, "},
+		{"limb", []*big.Int{big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(0), big.NewInt(0)}, "privateKey[1] = 18446744073709551616 exceeds 64 bits"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := rule(signal, tc.elements)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("got %v, want %v naming %q", err, ErrInvalidInput, tc.err)
+			}
+		})
+	}
+}
+
+func TestElementName(t *testing.T) {
+	for _, tc := range []struct {
+		dims []int
+		i    int
+		want string
+	}{
+		{nil, 0, "s"},
+		{[]int{4}, 3, "s[3]"},
+		{[]int{2, 3}, 0, "s[0][0]"},
+		{[]int{2, 3}, 2, "s[0][2]"},
+		{[]int{2, 3}, 4, "s[1][1]"},
+		{[]int{2, 3, 4}, 23, "s[1][2][3]"},
+	} {
+		if got := elementName(types.Signal{Name: "s", Dims: tc.dims}, tc.i); got != tc.want {
+			t.Errorf("elementName(%v, %d): got %s, want %s", tc.dims, tc.i, got, tc.want)
+		}
+	}
+}
+
+// The provers marshal the inputs before the witness computation, which the rules fail.
+func TestPinacleInputs(t *testing.T) {
+	key := sign.BigIntToRegisters(big.NewInt(42))
+	indices := zeroArray()
+	indices[5] = big.NewInt(2)
+
+	for _, tc := range []struct {
+		name string
+		set  func(zkp *PinacleZKP)
+		err  string
+	}{
+		{"valid", func(zkp *PinacleZKP) { zkp.SetPrivateKey(key) }, ""},
+		{"no key", func(zkp *PinacleZKP) {}, "privateKey is not set"},
+		{"zero key", func(zkp *PinacleZKP) { zkp.SetPrivateKey(sign.BigIntToRegisters(new(big.Int))) }, "privateKey is not in [1, "},
+		{"key n", func(zkp *PinacleZKP) { zkp.SetPrivateKey(sign.BigIntToRegisters(sign.SECP256K1_N)) }, "privateKey is not in [1, "},
+		{"path index", func(zkp *PinacleZKP) {
+			zkp.SetPrivateKey(key)
+			zkp.SetPathIndices(indices)
+		}, "pathIndices[5] = 2 is not 0 or 1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			zkp := NewZKP()
+			tc.set(zkp)
+			_, err := zkp.MarshalJSON()
+			if tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("got %v, want %v naming %q", err, ErrInvalidInput, tc.err)
+			}
+		})
+	}
+}
+
+// testInputs describes a circuit with a scalar input a and a 2x3 matrix m.
+var testInputs = &types.CircuitDescriptor{
+	Circuit: "Test",
+	Inputs:  []types.Signal{{Name: "a"}, {Name: "m", Dims: []int{2, 3}}},
+}
+
+func TestInputsMarshal(t *testing.T) {
+	validM := [2][3]int{{1, 2, 3}, {4, 5, 6}}
+	modulus := fr.Modulus()
+	var nilInt *big.Int
+	var nilRows [][]int
+
+	for _, tc := range []struct {
+		name   string
+		values map[string]any
+		want   string // JSON, or the error naming the input
+		err    bool
+	}{
+		{"ints", map[string]any{"a": 7, "m": validM}, `{"a":"7","m":[["1","2","3"],["4","5","6"]]}`, false},
+		{"pointer", map[string]any{"a": uint8(7), "m": &validM}, `{"a":"7","m":[["1","2","3"],["4","5","6"]]}`, false},
+		{"big.Int", map[string]any{"a": *big.NewInt(7), "m": [][]*big.Int{bigInts(1, 2, 3), bigInts(4, 5, 6)}}, `{"a":"7","m":[["1","2","3"],["4","5","6"]]}`, false},
+		{"strings", map[string]any{"a": "0x1f", "m": []any{[]string{"10", "0x10", "0"}, []any{"1", 2, big.NewInt(3)}}}, `{"a":"31","m":[["10","16","0"],["1","2","3"]]}`, false},
+		{"largest", map[string]any{"a": new(big.Int).Sub(modulus, big.NewInt(1)), "m": validM}, `{"a":"` + new(big.Int).Sub(modulus, big.NewInt(1)).String() + `","m":[["1","2","3"],["4","5","6"]]}`, false},
+
+		{"unset", map[string]any{"a": 7}, "m is not set", true},
+		{"unknown", map[string]any{"a": 7, "m": validM, "b": 1}, "b is not an input of Test", true},
+		{"rows", map[string]any{"a": 7, "m": [3][3]int{}}, "m has 3 elements, expected 2", true},
+		{"columns", map[string]any{"a": 7, "m": [][]int{{1, 2, 3}, {4, 5}}}, "m[1] has 2 elements, expected 3", true},
+		{"scalar matrix", map[string]any{"a": 7, "m": 1}, "m is a int, expected 2 elements", true},
+		{"array scalar", map[string]any{"a": []int{7}, "m": validM}, "a is a []int, expected a field element", true},
+		{"nil", map[string]any{"a": nil, "m": validM}, "a is nil", true},
+		{"nil element", map[string]any{"a": 7, "m": [2][3]*big.Int{{nilInt}}}, "m[0][0] is nil", true},
+		{"nil rows", map[string]any{"a": 7, "m": nilRows}, "m has 0 elements, expected 2", true},
+		{"nil pointer", map[string]any{"a": 7, "m": (*[2][3]int)(nil)}, "m is nil", true},
+		{"modulus", map[string]any{"a": modulus, "m": validM}, "a = " + modulus.String() + " is not a field element", true},
+		{"hex modulus", map[string]any{"a": "0x" + modulus.Text(16), "m": validM}, "is not a field element", true},
+		{"negative", map[string]any{"a": -1, "m": validM}, "a = -1 is not a field element", true},
+		{"negative string", map[string]any{"a": 7, "m": [][]string{{"1", "2", "3"}, {"4", "5", "-6"}}}, "m[1][2] = -6 is not a field element", true},
+		{"not a number", map[string]any{"a": "seven", "m": validM}, `a "seven" is not a number`, true},
+		{"bad hex", map[string]any{"a": "0xzz", "m": validM}, `a "0xzz" is not a number`, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := NewInputs(testInputs, nil)
+			for name, value := range tc.values {
+				in.Set(name, value)
+			}
+			data, err := in.MarshalJSON()
+			if tc.err {
+				if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), tc.want) {
+					t.Fatalf("got %v, want %v naming %q", err, ErrInvalidInput, tc.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tc.want {
+				t.Fatalf("got %s, want %s", data, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadCircuitDescriptor(t *testing.T) {
+	for _, tc := range []struct {
+		name, json string
+		err        string
+	}{
+		{"valid", `{"circuit":"Test","inputs":[{"name":"a"},{"name":"m","dims":[2,3]}]}`, ""},
+		{"duplicate", `{"circuit":"Test","inputs":[{"name":"a"},{"name":"a","dims":[2]}]}`, "input a listed twice"},
+		{"no circuit", `{"inputs":[{"name":"a"}]}`, "failed to validate circuit descriptor"},
+		{"no inputs", `{"circuit":"Test","inputs":[]}`, "failed to validate circuit descriptor"},
+		{"no name", `{"circuit":"Test","inputs":[{"dims":[2]}]}`, "failed to validate circuit descriptor"},
+		{"zero dimension", `{"circuit":"Test","inputs":[{"name":"m","dims":[2,0]}]}`, "failed to validate circuit descriptor"},
+		{"json", `{"circuit":"Test","inputs":{}}`, "failed to parse circuit descriptor"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "inputs.json")
+			if err := os.WriteFile(path, []byte(tc.json), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			c, err := LoadCircuitDescriptor(Path(path))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if c.Circuit != testInputs.Circuit || len(c.Inputs) != 2 || c.Inputs[1].Size() != 6 {
+					t.Fatalf("got %+v, want %+v", c, testInputs)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("got %v, want an error containing %q", err, tc.err)
+			}
+		})
+	}
+
+	if _, err := LoadCircuitDescriptor(Path(filepath.Join(t.TempDir(), "missing.json"))); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("got %v, want %v", err, os.ErrNotExist)
+	}
+}
@@ -0,0 +1,163 @@
+package zkp
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"deployer/internal/types"
+	"deployer/internal/validator"
+)
+
+const PINACLE_CIRCUIT = types.PINACLE_CIRCUIT
+
+var (
+	ErrManifestSignature   = errors.New("artifact manifest signature is invalid")
+	ErrCircuitMismatch     = errors.New("artifact manifest is not the manifest of the Pinacle circuit")
+	ErrArtifactMismatch    = errors.New("artifact does not match the manifest")
+	ErrVerifierKeyMismatch = errors.New("verifier bytecode does not embed the verification key")
+)
+
+// NewManifest hashes the artifacts of circuit, and the witness binary with its .dat unless
+// binary is empty. The public signal count is read from the verification key.
+func NewManifest(circuit string, wasm, zkey, vkey, binary Path) (*types.ArtifactManifest, error) {
+	m := &types.ArtifactManifest{Circuit: circuit}
+	type artifact struct {
+		sum  *string
+		path Path
+	}
+	artifacts := []artifact{{&m.Wasm, wasm}, {&m.Zkey, zkey}, {&m.Vkey, vkey}}
+	if binary != "" {
+		artifacts = append(artifacts, artifact{&m.WitnessBinary, binary}, artifact{&m.WitnessDat, witnessDat(binary)})
+	}
+	for _, artifact := range artifacts {
+		data, err := os.ReadFile(string(artifact.path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read artifact: %w", err)
+		}
+		*artifact.sum = SHA256(data)
+
+		if artifact.path == vkey {
+			key, err := parseVerificationKey(data)
+			if err != nil {
+				return nil, err
+			}
+			m.NPublic = key.NPublic
+		}
+	}
+
+	if err := validator.ValidateStruct(m); err != nil {
+		return nil, fmt.Errorf("failed to validate manifest: %w", err)
+	}
+	return m, nil
+}
+
+// SignManifest signs the compact JSON encoding of the manifest with key.
+func SignManifest(m *types.ArtifactManifest, key ed25519.PrivateKey) (*types.SignedArtifactManifest, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	return &types.SignedArtifactManifest{Manifest: data, Signature: ed25519.Sign(key, data)}, nil
+}
+
+// LoadManifest reads a signed manifest, checks its signature against key and that it is the
+// manifest of the Pinacle circuit.
+func LoadManifest(path Path, key ed25519.PublicKey) (*types.ArtifactManifest, error) {
+	data, err := os.ReadFile(string(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var signed types.SignedArtifactManifest
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if err := validator.ValidateStruct(signed); err != nil {
+		return nil, fmt.Errorf("failed to validate manifest: %w", err)
+	}
+	// The file may be indented, the signature covers the compact encoding
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, signed.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if !ed25519.Verify(key, compact.Bytes(), signed.Signature) {
+		return nil, ErrManifestSignature
+	}
+
+	var m types.ArtifactManifest
+	if err := json.Unmarshal(signed.Manifest, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if err := validator.ValidateStruct(m); err != nil {
+		return nil, fmt.Errorf("failed to validate manifest: %w", err)
+	}
+	if m.Circuit != PINACLE_CIRCUIT || m.NPublic != VOTING_PUBLIC_SIGNALS {
+		return nil, fmt.Errorf("%w: circuit %s with %d public signals", ErrCircuitMismatch, m.Circuit, m.NPublic)
+	}
+	return &m, nil
+}
+
+// CheckArtifact fails unless data hashes to expected, the SHA-256 the manifest pins for kind.
+func CheckArtifact(kind, expected string, data []byte) error {
+	if sum := SHA256(data); sum != expected {
+		return fmt.Errorf("%w: %s has SHA-256 %s, expected %s", ErrArtifactMismatch, kind, sum, expected)
+	}
+	return nil
+}
+
+// SHA256 returns the hex SHA-256 of data, the artifact digest of the manifests.
+func SHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func parseVerificationKey(data []byte) (*types.VerificationKey, error) {
+	var key types.VerificationKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse verification key: %w", err)
+	}
+	if len(key.IC) != key.NPublic+1 {
+		return nil, fmt.Errorf("failed to parse verification key: %d IC points for %d public signals", len(key.IC), key.NPublic)
+	}
+	return &key, nil
+}
+
+// CheckVerifierBytecode fails unless the snarkjs Verifier bytecode pushes every affine
+// coordinate of the verification key, i.e. the deployed verifier checks proofs of that key.
+func CheckVerifierBytecode(bin, vkey []byte) error {
+	key, err := parseVerificationKey(vkey)
+	if err != nil {
+		return err
+	}
+
+	// The projective z coordinates (1 and [1, 0]) are not constants of the contract
+	coordinates := append([]string{}, key.Alpha1[:min(2, len(key.Alpha1))]...)
+	for _, point := range [][][]string{key.Beta2, key.Gamma2, key.Delta2} {
+		for _, c := range point[:min(2, len(point))] {
+			coordinates = append(coordinates, c...)
+		}
+	}
+	for _, point := range key.IC {
+		coordinates = append(coordinates, point[:min(2, len(point))]...)
+	}
+
+	for _, coordinate := range coordinates {
+		value, ok := new(big.Int).SetString(coordinate, 10)
+		if !ok || value.Sign() <= 0 || value.BitLen() > 256 {
+			return fmt.Errorf("failed to parse verification key: invalid coordinate %q", coordinate)
+		}
+		// solc pushes the constant with the shortest PUSHn
+		push := append([]byte{0x5f + byte(len(value.Bytes()))}, value.Bytes()...)
+		if !bytes.Contains(bin, push) {
+			return fmt.Errorf("%w: coordinate %s not found", ErrVerifierKeyMismatch, coordinate)
+		}
+	}
+	return nil
+}
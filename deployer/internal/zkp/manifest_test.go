@@ -0,0 +1,105 @@
+package zkp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"testing"
+
+	verifier "deployer/internal/abigen/Verifier"
+	"deployer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// pinacleVerifier is the source of the Verifier binding, generated by snarkjs for the Pinacle key
+const pinacleVerifier = "../../../contracts/Verifier/Verifier.sol"
+
+var verifierConstant = regexp.MustCompile(`uint256 constant (\w+) =\s*(\d+);`)
+
+// pinacleKey rebuilds the verification key of the Pinacle circuit from the constants of its verifier.
+func pinacleKey(t *testing.T) *types.VerificationKey {
+	t.Helper()
+	source, err := os.ReadFile(pinacleVerifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := map[string]string{}
+	for _, match := range verifierConstant.FindAllStringSubmatch(string(source), -1) {
+		c[match[1]] = match[2]
+	}
+
+	// The verifier stores G2 coordinates as (c1, c0)
+	g2 := func(name string) [][]string {
+		return [][]string{{c[name+"x2"], c[name+"x1"]}, {c[name+"y2"], c[name+"y1"]}, {"1", "0"}}
+	}
+	key := &types.VerificationKey{
+		Protocol: "groth16",
+		Curve:    "bn128",
+		NPublic:  VOTING_PUBLIC_SIGNALS,
+		Alpha1:   []string{c["alphax"], c["alphay"], "1"},
+		Beta2:    g2("beta"),
+		Gamma2:   g2("gamma"),
+		Delta2:   g2("delta"),
+	}
+	for i := 0; i <= key.NPublic; i++ {
+		key.IC = append(key.IC, []string{c[fmt.Sprintf("IC%dx", i)], c[fmt.Sprintf("IC%dy", i)], "1"})
+	}
+	return key
+}
+
+func marshalKey(t *testing.T, key *types.VerificationKey) []byte {
+	t.Helper()
+	data, err := json.Marshal(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCheckVerifierBytecode(t *testing.T) {
+	bin := common.FromHex(verifier.VerifierMetaData.Bin)
+	if err := CheckVerifierBytecode(bin, marshalKey(t, pinacleKey(t))); err != nil {
+		t.Fatalf("Pinacle verifier rejected: %v", err)
+	}
+
+	other := pinacleKey(t)
+	y, _ := new(big.Int).SetString(other.IC[2][1], 10)
+	other.IC[2][1] = y.Add(y, common.Big1).String()
+	if err := CheckVerifierBytecode(bin, marshalKey(t, other)); !errors.Is(err, ErrVerifierKeyMismatch) {
+		t.Fatalf("other key: got %v, want %v", err, ErrVerifierKeyMismatch)
+	}
+
+	if err := CheckVerifierBytecode(bin, []byte(`{"nPublic": 2, "IC": []}`)); err == nil || errors.Is(err, ErrVerifierKeyMismatch) {
+		t.Fatalf("invalid key: got %v", err)
+	}
+}
+
+func TestCheckVerifierBytecodePadded(t *testing.T) {
+	// solc pushes a coordinate with a leading zero byte with PUSH31, never with a padded PUSH32
+	key := pinacleKey(t)
+	short := new(big.Int).Lsh(big.NewInt(0xabcdef), 224)
+	key.Alpha1[0] = short.String()
+
+	var shortest, padded []byte
+	for _, coordinate := range []string{key.Alpha1[0], key.Alpha1[1], key.Beta2[0][0], key.Beta2[0][1], key.Beta2[1][0], key.Beta2[1][1],
+		key.Gamma2[0][0], key.Gamma2[0][1], key.Gamma2[1][0], key.Gamma2[1][1], key.Delta2[0][0], key.Delta2[0][1], key.Delta2[1][0], key.Delta2[1][1],
+		key.IC[0][0], key.IC[0][1], key.IC[1][0], key.IC[1][1], key.IC[2][0], key.IC[2][1]} {
+		value, _ := new(big.Int).SetString(coordinate, 10)
+		shortest = append(append(shortest, 0x5f+byte(len(value.Bytes()))), value.Bytes()...)
+		padded = append(append(padded, 0x7f), value.FillBytes(make([]byte, 32))...)
+	}
+	if len(short.Bytes()) != 31 {
+		t.Fatalf("coordinate has %d bytes, want 31", len(short.Bytes()))
+	}
+
+	if err := CheckVerifierBytecode(shortest, marshalKey(t, key)); err != nil {
+		t.Fatalf("shortest pushes rejected: %v", err)
+	}
+	if err := CheckVerifierBytecode(padded, marshalKey(t, key)); !errors.Is(err, ErrVerifierKeyMismatch) {
+		t.Fatalf("padded push: got %v, want %v", err, ErrVerifierKeyMismatch)
+	}
+}
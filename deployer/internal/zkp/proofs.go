@@ -101,67 +101,54 @@ func (zkp *ZKProof) GetPublicSignalBigInt(index int) (*big.Int, error) {
 	return publicSignalBigInt, nil
 }
 
+// ConvertProof converts the proof to the argument of the zkLogin contract.
 func (zkp *ZKProof) ConvertProof() (*zklogin.ZkLoginGroth16Proof, error) {
-	proof := &zklogin.ZkLoginGroth16Proof{} // allocate struct before use
-
-	// PiA
-	if len(zkp.getProof().A) < 2 {
-		return proof, fmt.Errorf("invalid PiA length: got %d", len(zkp.getProof().A))
-	}
-	for i := 0; i < 2; i++ {
-		bi, ok := new(big.Int).SetString(zkp.getProof().A[i], 10)
-		if !ok {
-			return proof, fmt.Errorf("invalid PiA[%d]: %q", i, zkp.getProof().A[i])
-		}
-		proof.PiA[i] = bi
+	proof, err := zkp.Groth16()
+	if err != nil {
+		return nil, err
 	}
+	return ContractProof(proof), nil
+}
 
-	// PiB
-	if len(zkp.getProof().B) < 2 || len(zkp.getProof().B[0]) < 2 || len(zkp.getProof().B[1]) < 2 {
-		return proof, fmt.Errorf("invalid PiB structure")
-	}
-	for i := 0; i < 2; i++ {
-		for j := 0; j < 2; j++ {
-			// reverse the inner arrays: [b00, b01] becomes [b01, b00]
-			bi, ok := new(big.Int).SetString(zkp.getProof().B[i][1-j], 10)
-			if !ok {
-				return proof, fmt.Errorf("invalid PiB[%d][%d]: %q", i, j, zkp.getProof().B[i][1-j])
-			}
-			proof.PiB[i][j] = bi
-		}
-	}
+// Groth16 parses the proof points, checking every coordinate is a field element. The projective
+// z coordinates of snarkjs are ignored.
+func (zkp *ZKProof) Groth16() (*types.Groth16Proof, error) {
+	zkp.mu.RLock()
+	defer zkp.mu.RUnlock()
+	return parseGroth16(zkp.getProof(), 10)
+}
 
-	// PiC
-	if len(zkp.getProof().C) < 2 {
-		return proof, fmt.Errorf("invalid PiC length: got %d", len(zkp.getProof().C))
+// ContractProof returns the proof as the verifier contract takes it: the coordinates of pi_b
+// are swapped to (c1, c0), the order of the pairing precompile.
+func ContractProof(p *types.Groth16Proof) *zklogin.ZkLoginGroth16Proof {
+	return &zklogin.ZkLoginGroth16Proof{
+		PiA: p.PiA,
+		PiB: [2][2]*big.Int{{p.PiB[0][1], p.PiB[0][0]}, {p.PiB[1][1], p.PiB[1][0]}},
+		PiC: p.PiC,
 	}
-	for i := 0; i < 2; i++ {
-		bi, ok := new(big.Int).SetString(zkp.getProof().C[i], 10)
-		if !ok {
-			return proof, fmt.Errorf("invalid PiC[%d]: %q", i, zkp.getProof().C[i])
-		}
-		proof.PiC[i] = bi
-	}
-
-	return proof, nil
 }
 
-func (zkp *ZKProof) ConvertPublicSignals() ([2]*big.Int, error) {
-	var arr [2]*big.Int
+// ConvertPublicSignals converts the public signals, whatever their number, checking every one is
+// a field element.
+func (zkp *ZKProof) ConvertPublicSignals() ([]*big.Int, error) {
+	zkp.mu.RLock()
+	defer zkp.mu.RUnlock()
+	return parsePublicSignals(zkp.getPublicSignals(), 10)
+}
 
-	if len(zkp.getPublicSignals()) != types.PINACLE_PUBLIC_SIGNALS {
-		return arr, fmt.Errorf("expected %d public signals got %d", types.PINACLE_PUBLIC_SIGNALS, len(zkp.getPublicSignals()))
+// ConvertPublicSignalsTo converts the public signals into dst, whose length is the number of
+// public signals of the circuit, e.g. the [types.PINACLE_PUBLIC_SIGNALS]*big.Int argument of the
+// zkLogin contract.
+func (zkp *ZKProof) ConvertPublicSignalsTo(dst []*big.Int) error {
+	signals, err := zkp.ConvertPublicSignals()
+	if err != nil {
+		return err
 	}
-
-	for i, sig := range zkp.getPublicSignals() {
-		bi, ok := new(big.Int).SetString(sig, 10) // or base 16 if your signals are hex strings
-		if !ok {
-			return arr, fmt.Errorf("invalid public signal at index %d: %s", i, sig)
-		}
-		arr[i] = bi
+	if len(signals) != len(dst) {
+		return fmt.Errorf("expected %d public signals got %d", len(dst), len(signals))
 	}
-
-	return arr, nil
+	copy(dst, signals)
+	return nil
 }
 
 // SetProof sets the proof byte slice.
@@ -1,30 +1,30 @@
 package zkp
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"sync"
+	"time"
+
+	"deployer/internal/metrics"
+	"deployer/internal/types"
 
 	"github.com/iden3/go-rapidsnark/prover"
 	"github.com/iden3/go-rapidsnark/witness/v2"
-	"github.com/iden3/go-rapidsnark/witness/wasmer"
 )
 
 type Prover struct {
-	mu   sync.RWMutex
-	calc witness.Calculator
-	zkey []byte
+	mu      sync.RWMutex
+	calc    witness.Calculator
+	zkey    []byte
+	circuit [32]byte
 }
 
-// NewProve creates a new Prove instance by loading wasm and zkey files.
-func NewProver(wasm, zkey Path) (*Prover, error) {
-	// Load wasm bytes and create calculator
-	wasmBytes, err := os.ReadFile(string(wasm))
-	if err != nil {
-		return nil, fmt.Errorf("failed to read wasm file: %w", err)
-	}
-
-	calc, err := witness.NewCalculator(wasmBytes, witness.WithWasmEngine(wasmer.NewCircom2WitnessCalculator))
+// NewProve creates a new Prove instance by loading the witness calculator of src and the zkey file.
+// With a manifest, it refuses artifacts whose SHA-256 differ from the pinned ones.
+func NewProver(src WitnessSource, zkey Path, manifest *types.ArtifactManifest) (*Prover, error) {
+	calc, err := NewWitnessCalculator(src, manifest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create witness calculator: %w", err)
 	}
@@ -34,13 +34,24 @@ func NewProver(wasm, zkey Path) (*Prover, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read zkey file: %w", err)
 	}
+	if manifest != nil {
+		if err := CheckArtifact("zkey", manifest.Zkey, zkeyBytes); err != nil {
+			return nil, err
+		}
+	}
 
 	return &Prover{
-		calc: calc,
-		zkey: zkeyBytes,
+		calc:    calc,
+		zkey:    zkeyBytes,
+		circuit: sha256.Sum256(zkeyBytes),
 	}, nil
 }
 
+// CircuitHash returns the SHA-256 of the zkey, which identifies the circuit of the proofs.
+func (p *Prover) CircuitHash() [32]byte {
+	return p.circuit
+}
+
 func (p *Prover) GenerateProofs(inputJson []byte) (*ZKProof, error) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -50,16 +61,22 @@ func (p *Prover) GenerateProofs(inputJson []byte) (*ZKProof, error) {
 		return nil, fmt.Errorf("failed to parse inputs from JSON: %v", err)
 	}
 
+	start := time.Now()
 	wtns, err := p.calc.CalculateWTNSBin(inputs, true)
 	if err != nil {
+		metrics.ProvingError.Inc(1)
 		return nil, fmt.Errorf("failed to calculate witness: %w", err)
 	}
+	metrics.WitnessTimer.UpdateSince(start)
 
 	// Prove the proof
+	start = time.Now()
 	proof, err := prover.Groth16Prover(p.zkey, wtns)
 	if err != nil {
+		metrics.ProvingError.Inc(1)
 		return nil, fmt.Errorf("failed to prove: %w", err)
 	}
+	metrics.ProvingTimer.UpdateSince(start)
 
 	zkproof := NewZKProof()
 
@@ -4,22 +4,33 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
+
+	"deployer/internal/metrics"
+	"deployer/internal/types"
 
 	verifier "github.com/iden3/go-rapidsnark/verifier"
 )
 
 type Verifier struct {
-	mu   sync.RWMutex
-	vkey []byte
+	mu    sync.RWMutex
+	vkey  []byte
+	batch *batchKey // parsed by VerifyBatch
 }
 
 // NewProve creates a new Prove instance by loading wasm and zkey files.
-func NewVerifier(vkey Path) (*Verifier, error) {
+// With a manifest, it refuses a verification key whose SHA-256 differs from the pinned one.
+func NewVerifier(vkey Path, manifest *types.ArtifactManifest) (*Verifier, error) {
 	// Load wasm bytes and create calculator
 	verificationKey, err := os.ReadFile(string(vkey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read vkey file: %w", err)
 	}
+	if manifest != nil {
+		if err := CheckArtifact("vkey", manifest.Vkey, verificationKey); err != nil {
+			return nil, err
+		}
+	}
 
 	return &Verifier{
 		vkey: verificationKey,
@@ -34,6 +45,7 @@ func (v *Verifier) VerifyProofs(proofs *ZKProof) error {
 	defer v.mu.RUnlock()
 
 	// Verify the proof
+	defer metrics.VerifyTimer.UpdateSince(time.Now())
 	err := verifier.VerifyGroth16(*proofs.ZKProof, v.getVerificationKey())
 	if err != nil {
 		return fmt.Errorf("failed to verify proof: %v", err)
@@ -0,0 +1,125 @@
+package zkp
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"os"
+
+	"github.com/iden3/wasmer-go/wasmer"
+)
+
+// CircuitWasm is what the circom witness calculator of a circuit expects.
+type CircuitWasm struct {
+	Version     int
+	Prime       *big.Int
+	WitnessSize int
+	InputSize   int            // field elements of all the inputs
+	Inputs      map[string]int // field elements of the inputs looked up, absent when unknown to the circuit
+}
+
+// InspectWasm instantiates the circom wasm at path and reads its prime, its witness size and the
+// sizes of the named input signals. It requires circom 2.0.4 or later.
+func InspectWasm(path Path, inputs []string) (*CircuitWasm, error) {
+	wasmBytes, err := os.ReadFile(string(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wasm file: %w", err)
+	}
+
+	store := wasmer.NewStore(wasmer.NewEngine())
+	defer store.Close()
+	module, err := wasmer.NewModule(store, wasmBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile wasm: %w", err)
+	}
+	defer module.Close()
+
+	// Same memory as the witness calculator, the runtime callbacks only report errors of the
+	// witness computation, never run here
+	limits, err := wasmer.NewLimits(2000, 100000)
+	if err != nil {
+		return nil, err
+	}
+	noop := func() wasmer.IntoExtern {
+		return wasmer.NewFunction(store, wasmer.NewFunctionType(wasmer.NewValueTypes(), wasmer.NewValueTypes()),
+			func([]wasmer.Value) ([]wasmer.Value, error) { return nil, nil })
+	}
+	imports := wasmer.NewImportObject()
+	imports.Register("env", map[string]wasmer.IntoExtern{
+		"memory": wasmer.NewMemory(store, wasmer.NewMemoryType(limits)),
+	})
+	imports.Register("runtime", map[string]wasmer.IntoExtern{
+		"exceptionHandler": wasmer.NewFunction(store, wasmer.NewFunctionType(wasmer.NewValueTypes(wasmer.I32), wasmer.NewValueTypes()),
+			func([]wasmer.Value) ([]wasmer.Value, error) { return nil, nil }),
+		"showSharedRWMemory": noop(),
+		"log":                noop(),
+		"printErrorMessage":  noop(),
+		"writeBufferMessage": noop(),
+	})
+	instance, err := wasmer.NewInstance(module, imports)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate wasm: %w", err)
+	}
+	defer instance.Close()
+
+	call := func(name string, args ...any) (int, error) {
+		f, err := instance.Exports.GetFunction(name)
+		if err != nil {
+			return 0, fmt.Errorf("wasm is not a circom 2 witness calculator: %w", err)
+		}
+		v, err := f(args...)
+		if err != nil {
+			return 0, fmt.Errorf("wasm %s: %w", name, err)
+		}
+		if v == nil {
+			return 0, nil
+		}
+		return int(v.(int32)), nil
+	}
+
+	if _, err := call("init", int32(1)); err != nil {
+		return nil, err
+	}
+	c := &CircuitWasm{Inputs: map[string]int{}}
+	if c.Version, err = call("getVersion"); err != nil {
+		return nil, err
+	}
+	if c.WitnessSize, err = call("getWitnessSize"); err != nil {
+		return nil, err
+	}
+	if c.InputSize, err = call("getInputSize"); err != nil {
+		return nil, err
+	}
+
+	// The prime is written to the shared memory, 32 bits words from the least significant
+	n32, err := call("getFieldNumLen32")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := call("getRawPrime"); err != nil {
+		return nil, err
+	}
+	c.Prime = new(big.Int)
+	for j := n32 - 1; j >= 0; j-- {
+		word, err := call("readSharedRWMemory", int32(j))
+		if err != nil {
+			return nil, err
+		}
+		c.Prime.Lsh(c.Prime, 32).Or(c.Prime, big.NewInt(int64(uint32(word))))
+	}
+
+	for _, input := range inputs {
+		// Signals are looked up by the 64 bits FNV-1a hash of their name, -1 when unknown
+		h := fnv.New64a()
+		h.Write([]byte(input))
+		sum := h.Sum64()
+		size, err := call("getInputSignalSize", int32(sum>>32), int32(sum))
+		if err != nil {
+			return nil, err
+		}
+		if size >= 0 {
+			c.Inputs[input] = size
+		}
+	}
+	return c, nil
+}
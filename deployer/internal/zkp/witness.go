@@ -0,0 +1,265 @@
+package zkp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/iden3/go-rapidsnark/witness/v2"
+	"github.com/iden3/go-rapidsnark/witness/wasmer"
+)
+
+var (
+	ErrUnknownWitnessBackend = errors.New("unknown witness backend")
+	ErrInvalidWitness        = errors.New("invalid witness")
+)
+
+// WitnessSource locates the witness calculator of a circuit, the artifact of its backend.
+type WitnessSource struct {
+	Backend types.WitnessBackend
+	Wasm    Path // circom wasm
+	Binary  Path // witness binary of circom --c, with its .dat next to it
+}
+
+// NewWitnessCalculator returns the witness calculator of the backend of src. With a manifest,
+// the artifact of the backend is checked against the pinned one.
+func NewWitnessCalculator(src WitnessSource, manifest *types.ArtifactManifest) (witness.Calculator, error) {
+	switch src.Backend {
+	case types.WitnessBackendWasm:
+		wasmBytes, err := os.ReadFile(string(src.Wasm))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read wasm file: %w", err)
+		}
+		if manifest != nil {
+			if err := CheckArtifact("wasm", manifest.Wasm, wasmBytes); err != nil {
+				return nil, err
+			}
+		}
+		return witness.NewCalculator(wasmBytes, witness.WithWasmEngine(wasmer.NewCircom2WitnessCalculator))
+	case types.WitnessBackendNative:
+		native, err := NewNativeWitnessCalculator(src.Binary)
+		if err != nil {
+			return nil, err
+		}
+		if manifest != nil {
+			if err := native.Check(manifest); err != nil {
+				return nil, err
+			}
+		}
+		// witness.Calculator builds its engine from the wasm bytes, here unused
+		return witness.NewCalculator(nil, witness.WithWasmEngine(func([]byte) (witness.CalculatorImpl, error) {
+			return native, nil
+		}))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownWitnessBackend, src.Backend)
+	}
+}
+
+// NativeWitnessCalculator runs the witness binary circom --c generates, which checks the
+// constraints of the circuit natively, once per witness.
+type NativeWitnessCalculator struct {
+	binary string
+}
+
+// NewNativeWitnessCalculator returns the calculator running the executable at binary.
+func NewNativeWitnessCalculator(binary Path) (*NativeWitnessCalculator, error) {
+	path, err := filepath.Abs(string(binary))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve witness binary: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read witness binary: %w", err)
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+		return nil, fmt.Errorf("witness binary %s is not executable", path)
+	}
+	return &NativeWitnessCalculator{binary: path}, nil
+}
+
+// witnessDat returns the .dat the witness binary reads its constants from, its own path plus
+// .dat.
+func witnessDat(binary Path) Path {
+	return binary + ".dat"
+}
+
+// Check fails unless the binary and its .dat are the ones the manifest pins.
+func (c *NativeWitnessCalculator) Check(manifest *types.ArtifactManifest) error {
+	if manifest.WitnessBinary == "" {
+		return fmt.Errorf("%w: no witness binary pinned", ErrArtifactMismatch)
+	}
+	for _, artifact := range []struct {
+		kind, sum string
+		path      Path
+	}{
+		{"witness binary", manifest.WitnessBinary, Path(c.binary)},
+		{"witness dat", manifest.WitnessDat, witnessDat(Path(c.binary))},
+	} {
+		data, err := os.ReadFile(string(artifact.path))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", artifact.kind, err)
+		}
+		if err := CheckArtifact(artifact.kind, artifact.sum, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Calculate implements witness.CalculatorImpl. The inputs, which hold the private key, and the
+// witness go through a private temporary directory, removed on return.
+func (c *NativeWitnessCalculator) Calculate(inputs map[string]any, _ bool) (witness.Witness, error) {
+	data, err := json.Marshal(nativeInput(inputs))
+	if err != nil {
+		return witness.Witness{}, fmt.Errorf("failed to encode inputs: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "witness-")
+	if err != nil {
+		return witness.Witness{}, fmt.Errorf("failed to create witness directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input, output := filepath.Join(dir, "input.json"), filepath.Join(dir, "witness.wtns")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return witness.Witness{}, fmt.Errorf("failed to write inputs: %w", err)
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command(c.binary, input, output)
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if err := cmd.Run(); err != nil {
+		return witness.Witness{}, fmt.Errorf("witness binary failed: %w: %s", err, strings.TrimSpace(out.String()))
+	}
+
+	f, err := os.Open(output)
+	if err != nil {
+		return witness.Witness{}, fmt.Errorf("failed to read witness: %w", err)
+	}
+	defer f.Close()
+	wtns, err := ParseWTNS(f)
+	if err != nil {
+		return witness.Witness{}, err
+	}
+	if wtns.Prime.Cmp(fr.Modulus()) != 0 {
+		return witness.Witness{}, fmt.Errorf("%w: prime %s is not the %s scalar field", ErrInvalidWitness, wtns.Prime, BN128_CURVE)
+	}
+	return wtns, nil
+}
+
+// nativeInput converts the inputs of witness.ParseInputs to decimal strings, the JSON numbers
+// of the witness binary being 64-bit.
+func nativeInput(v any) any {
+	switch v := v.(type) {
+	case *big.Int:
+		return v.String()
+	case []any:
+		out := make([]any, len(v))
+		for i := range v {
+			out[i] = nativeInput(v[i])
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for name, value := range v {
+			out[name] = nativeInput(value)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// Sections of the wtns file, the one snarkjs and the circom witness calculators write
+const (
+	wtnsHeaderSection = 1
+	wtnsDataSection   = 2
+)
+
+// ParseWTNS parses a wtns file: the element size and prime, then the witness, little-endian.
+func ParseWTNS(r io.Reader) (witness.Witness, error) {
+	br := bufio.NewReader(r)
+	var preamble struct {
+		Magic     [4]byte
+		Version   uint32
+		NSections uint32
+	}
+	if err := binary.Read(br, binary.LittleEndian, &preamble); err != nil {
+		return witness.Witness{}, fmt.Errorf("%w: %w", ErrInvalidWitness, err)
+	}
+	if string(preamble.Magic[:]) != "wtns" {
+		return witness.Witness{}, fmt.Errorf("%w: not a wtns file", ErrInvalidWitness)
+	}
+	if preamble.Version != 2 {
+		return witness.Witness{}, fmt.Errorf("%w: version %d", ErrInvalidWitness, preamble.Version)
+	}
+
+	var wtns witness.Witness
+	n8, count, done := 0, 0, false
+	for i := uint32(0); i < preamble.NSections && !done; i++ {
+		var section struct {
+			Type uint32
+			Size uint64
+		}
+		if err := binary.Read(br, binary.LittleEndian, &section); err != nil {
+			return witness.Witness{}, fmt.Errorf("%w: %w", ErrInvalidWitness, err)
+		}
+
+		switch section.Type {
+		case wtnsHeaderSection:
+			var size uint32
+			if err := binary.Read(br, binary.LittleEndian, &size); err != nil {
+				return witness.Witness{}, fmt.Errorf("%w: %w", ErrInvalidWitness, err)
+			}
+			if n8 = int(size); n8 == 0 || n8%4 != 0 || section.Size != uint64(8+n8) {
+				return witness.Witness{}, fmt.Errorf("%w: %d bytes elements", ErrInvalidWitness, n8)
+			}
+			prime := make([]byte, n8)
+			if _, err := io.ReadFull(br, prime); err != nil {
+				return witness.Witness{}, fmt.Errorf("%w: %w", ErrInvalidWitness, err)
+			}
+			if err := binary.Read(br, binary.LittleEndian, &size); err != nil {
+				return witness.Witness{}, fmt.Errorf("%w: %w", ErrInvalidWitness, err)
+			}
+			wtns.N32, wtns.Prime, count = n8/4, littleEndian(prime), int(size)
+		case wtnsDataSection:
+			if wtns.Prime == nil {
+				return witness.Witness{}, fmt.Errorf("%w: witness before the header", ErrInvalidWitness)
+			}
+			if section.Size != uint64(count)*uint64(n8) {
+				return witness.Witness{}, fmt.Errorf("%w: %d bytes for %d signals", ErrInvalidWitness, section.Size, count)
+			}
+			wtns.Witness = make([]*big.Int, count)
+			element := make([]byte, n8)
+			for j := range wtns.Witness {
+				if _, err := io.ReadFull(br, element); err != nil {
+					return witness.Witness{}, fmt.Errorf("%w: %w", ErrInvalidWitness, err)
+				}
+				if wtns.Witness[j] = littleEndian(element); wtns.Witness[j].Cmp(wtns.Prime) >= 0 {
+					return witness.Witness{}, fmt.Errorf("%w: signal %d is not a field element", ErrInvalidWitness, j)
+				}
+			}
+			done = true
+		default:
+			if _, err := io.CopyN(io.Discard, br, int64(section.Size)); err != nil {
+				return witness.Witness{}, fmt.Errorf("%w: %w", ErrInvalidWitness, err)
+			}
+		}
+	}
+	if !done {
+		return witness.Witness{}, fmt.Errorf("%w: section %d is missing", ErrInvalidWitness, wtnsDataSection)
+	}
+	return wtns, nil
+}
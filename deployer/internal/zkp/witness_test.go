@@ -0,0 +1,219 @@
+package zkp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/iden3/go-rapidsnark/witness/v2"
+)
+
+// witnessBinary writes a shell script standing for the witness binary, which runs script with
+// the input and the output files as $1 and $2.
+func witnessBinary(t *testing.T, script string) Path {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "witness")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return Path(path)
+}
+
+// writeWTNS writes values as the wtns file of the witness binaries.
+func writeWTNS(t *testing.T, path string, values ...int64) {
+	t.Helper()
+	var buf bytes.Buffer
+	put := func(v any) { binary.Write(&buf, binary.LittleEndian, v) }
+	element := func(v *big.Int) {
+		b := v.FillBytes(make([]byte, fr.Bytes))
+		slices.Reverse(b)
+		buf.Write(b)
+	}
+
+	buf.WriteString("wtns")
+	put(uint32(2))
+	put(uint32(2))
+	put(uint32(wtnsHeaderSection))
+	put(uint64(8 + fr.Bytes))
+	put(uint32(fr.Bytes))
+	element(fr.Modulus())
+	put(uint32(len(values)))
+	put(uint32(wtnsDataSection))
+	put(uint64(len(values) * fr.Bytes))
+	for _, v := range values {
+		element(big.NewInt(v))
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNativeWitness(t *testing.T) {
+	inputs, err := witness.ParseInputs([]byte(`{"a":"3","b":"5"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The binary gets the inputs as decimal strings and writes the witness of out = a * b
+	wtns := filepath.Join(t.TempDir(), "witness.wtns")
+	writeWTNS(t, wtns, 1, 15, 3, 5)
+	binary := witnessBinary(t, `grep -q '"a":"3"' "$1" && cp `+wtns+` "$2"`)
+	calc, err := NewWitnessCalculator(WitnessSource{Backend: types.WitnessBackendNative, Binary: binary}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := calc.CalculateWitness(inputs, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*big.Int{big.NewInt(1), big.NewInt(15), big.NewInt(3), big.NewInt(5)}
+	if len(got) != len(want) {
+		t.Fatalf("got %d signals, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Cmp(want[i]) != 0 {
+			t.Fatalf("signal %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNativeWitnessManifest(t *testing.T) {
+	binary := witnessBinary(t, `exit 1`)
+	if err := os.WriteFile(string(binary)+".dat", []byte("constants"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	wasm, zkey, vkey := Path(filepath.Join(dir, "circuit.wasm")), Path(filepath.Join(dir, "circuit.zkey")), Path(filepath.Join(dir, "verification_key.json"))
+	for _, path := range []Path{wasm, zkey} {
+		if err := os.WriteFile(string(path), []byte(path), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(string(vkey), marshalKey(t, pinacleKey(t)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	src := WitnessSource{Backend: types.WitnessBackendNative, Binary: binary}
+	pinned, err := NewManifest(PINACLE_CIRCUIT, wasm, zkey, vkey, binary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewWitnessCalculator(src, pinned); err != nil {
+		t.Fatalf("pinned binary rejected: %v", err)
+	}
+
+	unpinned, err := NewManifest(PINACLE_CIRCUIT, wasm, zkey, vkey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpinned.WitnessBinary != "" || unpinned.WitnessDat != "" {
+		t.Fatalf("witness binary pinned without a binary: %+v", unpinned)
+	}
+	if _, err := NewWitnessCalculator(src, unpinned); !errors.Is(err, ErrArtifactMismatch) {
+		t.Fatalf("unpinned binary: got %v, want %v", err, ErrArtifactMismatch)
+	}
+
+	for _, path := range []string{string(binary), string(binary) + ".dat"} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, append(data, '\n'), 0o700); err != nil {
+				t.Fatal(err)
+			}
+			defer os.WriteFile(path, data, 0o700)
+			if _, err := NewWitnessCalculator(src, pinned); !errors.Is(err, ErrArtifactMismatch) {
+				t.Fatalf("got %v, want %v", err, ErrArtifactMismatch)
+			}
+		})
+	}
+}
+
+func TestNativeWitnessFailure(t *testing.T) {
+	binary := witnessBinary(t, `echo "Assert Failed" >&2; exit 1`)
+	calc, err := NewWitnessCalculator(WitnessSource{Backend: types.WitnessBackendNative, Binary: binary}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := calc.CalculateWitness(map[string]any{}, true); err == nil || !strings.Contains(err.Error(), "Assert Failed") {
+		t.Fatalf("expected the output of the failed binary, got %v", err)
+	}
+
+	truncated := witnessBinary(t, `printf 'wtns' > "$2"`)
+	calc, err = NewWitnessCalculator(WitnessSource{Backend: types.WitnessBackendNative, Binary: truncated}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := calc.CalculateWitness(map[string]any{}, true); !errors.Is(err, ErrInvalidWitness) {
+		t.Fatalf("expected %v, got %v", ErrInvalidWitness, err)
+	}
+}
+
+func TestWitnessBackendErrors(t *testing.T) {
+	for _, backend := range []types.WitnessBackend{"js", "go"} {
+		if _, err := NewWitnessCalculator(WitnessSource{Backend: backend}, nil); !errors.Is(err, ErrUnknownWitnessBackend) {
+			t.Fatalf("%s: expected %v, got %v", backend, ErrUnknownWitnessBackend, err)
+		}
+	}
+	if _, err := NewNativeWitnessCalculator(Path(t.TempDir())); err == nil {
+		t.Fatalf("directory accepted as witness binary")
+	}
+}
+
+// Artifacts of the e2e test circuit (e2e/testdata/Test.circom), generated with go generate ./e2e
+const (
+	testCircuitWasm   = "../../e2e/testdata/build/Test_js/Test.wasm"
+	testCircuitBinary = "../../e2e/testdata/build/Test_cpp/Test"
+)
+
+// BenchmarkWitnessBackends compares the backends on the test circuit, after checking they compute
+// the same witness.
+func BenchmarkWitnessBackends(b *testing.B) {
+	inputs, err := witness.ParseInputs([]byte(`{"hashedAddr":"1","root":"2"}`))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var calcs []witness.Calculator
+	for _, src := range []WitnessSource{
+		{Backend: types.WitnessBackendWasm, Wasm: testCircuitWasm},
+		{Backend: types.WitnessBackendNative, Binary: testCircuitBinary},
+	} {
+		calc, err := NewWitnessCalculator(src, nil)
+		if errors.Is(err, os.ErrNotExist) {
+			b.Skipf("test circuit not built, run go generate ./e2e: %v", err)
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+		calcs = append(calcs, calc)
+	}
+
+	want, err := calcs[0].CalculateWTNSBin(inputs, true)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if got, err := calcs[1].CalculateWTNSBin(inputs, true); err != nil || !bytes.Equal(got, want) {
+		b.Fatalf("native witness differs from the wasm one: %v", err)
+	}
+
+	for i, backend := range []types.WitnessBackend{types.WitnessBackendWasm, types.WitnessBackendNative} {
+		b.Run(string(backend), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if _, err := calcs[i].CalculateWTNSBin(inputs, true); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
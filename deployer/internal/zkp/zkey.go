@@ -0,0 +1,254 @@
+package zkp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"slices"
+
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// Sections of the snarkjs zkey file the header is read from, the others are skipped
+const (
+	zkeyHeaderSection        = 1
+	zkeyGroth16HeaderSection = 2
+	zkeyICSection            = 3
+
+	zkeyGroth16 = 1
+
+	// maxZkeyHeaderSection bounds the sections read in memory, the IC of 2^18 public signals
+	maxZkeyHeaderSection = 1 << 24
+)
+
+// BN128_CURVE is the snarkjs name of BN254, the curve of the Pinacle circuit
+const BN128_CURVE = "bn128"
+
+var (
+	ErrInvalidZkey     = errors.New("invalid zkey")
+	ErrUnsupportedZkey = errors.New("unsupported zkey")
+)
+
+// ZkeyHeader is the header of a snarkjs Groth16 zkey: the circuit shape and the verification key.
+type ZkeyHeader struct {
+	Protocol        string
+	Curve           string
+	Q               *big.Int // base field modulus
+	R               *big.Int // scalar field modulus, the prime of the circuit
+	NVars           int      // witness size
+	NPublic         int
+	DomainSize      int
+	VerificationKey *types.VerificationKey
+}
+
+// ReadZkeyHeader reads the header of the zkey file at path, without loading the proving key.
+func ReadZkeyHeader(path Path) (*ZkeyHeader, error) {
+	f, err := os.Open(string(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read zkey file: %w", err)
+	}
+	defer f.Close()
+	return ParseZkeyHeader(f)
+}
+
+// ParseZkeyHeader parses the header of a zkey. The points are checked to be on BN254, and the
+// verification key is the one snarkjs zkey export verificationkey writes, without
+// vk_alphabeta_12 which no verifier reads.
+func ParseZkeyHeader(r io.ReadSeeker) (*ZkeyHeader, error) {
+	var preamble struct {
+		Magic     [4]byte
+		Version   uint32
+		NSections uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &preamble); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidZkey, err)
+	}
+	if string(preamble.Magic[:]) != "zkey" {
+		return nil, fmt.Errorf("%w: not a zkey file", ErrInvalidZkey)
+	}
+	if preamble.Version != 1 {
+		return nil, fmt.Errorf("%w: version %d", ErrUnsupportedZkey, preamble.Version)
+	}
+
+	sections := map[uint32]*zkeySection{}
+	for i := uint32(0); i < preamble.NSections && len(sections) < 3; i++ {
+		var section struct {
+			Type uint32
+			Size uint64
+		}
+		if err := binary.Read(r, binary.LittleEndian, &section); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidZkey, err)
+		}
+
+		switch section.Type {
+		case zkeyHeaderSection, zkeyGroth16HeaderSection, zkeyICSection:
+			if sections[section.Type] != nil || section.Size > maxZkeyHeaderSection {
+				return nil, fmt.Errorf("%w: section %d", ErrInvalidZkey, section.Type)
+			}
+			data := make([]byte, section.Size)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return nil, fmt.Errorf("%w: section %d: %w", ErrInvalidZkey, section.Type, err)
+			}
+			sections[section.Type] = &zkeySection{data: data}
+		default:
+			if _, err := r.Seek(int64(section.Size), io.SeekCurrent); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidZkey, err)
+			}
+		}
+	}
+	for _, t := range []uint32{zkeyHeaderSection, zkeyGroth16HeaderSection, zkeyICSection} {
+		if sections[t] == nil {
+			return nil, fmt.Errorf("%w: section %d is missing", ErrInvalidZkey, t)
+		}
+	}
+
+	if protocol := sections[zkeyHeaderSection].uint32(); protocol != zkeyGroth16 {
+		return nil, fmt.Errorf("%w: protocol %d is not groth16", ErrUnsupportedZkey, protocol)
+	}
+
+	s := sections[zkeyGroth16HeaderSection]
+	h := &ZkeyHeader{Protocol: "groth16"}
+	if n8q := s.uint32(); n8q != fp.Bytes {
+		return nil, fmt.Errorf("%w: %d bytes base field", ErrUnsupportedZkey, n8q)
+	}
+	h.Q = s.bigInt(fp.Bytes)
+	if n8r := s.uint32(); n8r != fr.Bytes {
+		return nil, fmt.Errorf("%w: %d bytes scalar field", ErrUnsupportedZkey, n8r)
+	}
+	h.R = s.bigInt(fr.Bytes)
+	if s.err != nil {
+		return nil, s.err
+	}
+	if h.Q.Cmp(fp.Modulus()) != 0 || h.R.Cmp(fr.Modulus()) != 0 {
+		return nil, fmt.Errorf("%w: curve with base field %s is not %s", ErrUnsupportedZkey, h.Q, BN128_CURVE)
+	}
+	h.Curve = BN128_CURVE
+
+	h.NVars = int(s.uint32())
+	h.NPublic = int(s.uint32())
+	h.DomainSize = int(s.uint32())
+	alpha1 := s.g1()
+	s.g1() // beta1
+	beta2 := s.g2()
+	gamma2 := s.g2()
+	s.g1() // delta1
+	delta2 := s.g2()
+	if s.err != nil {
+		return nil, s.err
+	}
+	if h.DomainSize == 0 || h.DomainSize&(h.DomainSize-1) != 0 {
+		return nil, fmt.Errorf("%w: domain size %d is not a power of two", ErrInvalidZkey, h.DomainSize)
+	}
+
+	ic := sections[zkeyICSection]
+	if len(ic.data) != (h.NPublic+1)*2*fp.Bytes {
+		return nil, fmt.Errorf("%w: %d bytes IC section for %d public signals", ErrInvalidZkey, len(ic.data), h.NPublic)
+	}
+
+	h.VerificationKey = &types.VerificationKey{
+		Protocol: h.Protocol,
+		Curve:    h.Curve,
+		NPublic:  h.NPublic,
+		Alpha1:   g1Object(alpha1),
+		Beta2:    g2Object(beta2),
+		Gamma2:   g2Object(gamma2),
+		Delta2:   g2Object(delta2),
+		IC:       make([][]string, h.NPublic+1),
+	}
+	for i := range h.VerificationKey.IC {
+		h.VerificationKey.IC[i] = g1Object(ic.g1())
+	}
+	if ic.err != nil {
+		return nil, ic.err
+	}
+	return h, nil
+}
+
+// zkeySection decodes a section, keeping the first error.
+type zkeySection struct {
+	data []byte
+	err  error
+}
+
+func (s *zkeySection) next(n int) []byte {
+	if s.err != nil {
+		return make([]byte, n)
+	}
+	if len(s.data) < n {
+		s.err = fmt.Errorf("%w: truncated section", ErrInvalidZkey)
+		return make([]byte, n)
+	}
+	b := s.data[:n]
+	s.data = s.data[n:]
+	return b
+}
+
+func (s *zkeySection) uint32() uint32 {
+	return binary.LittleEndian.Uint32(s.next(4))
+}
+
+// bigInt decodes a little-endian integer.
+func (s *zkeySection) bigInt(n int) *big.Int {
+	return littleEndian(s.next(n))
+}
+
+// fq decodes a base field element. snarkjs stores them in Montgomery form, little-endian, which
+// is the limb layout of fp.Element.
+func (s *zkeySection) fq() fp.Element {
+	b := s.next(fp.Bytes)
+	var e fp.Element
+	for i := range e {
+		e[i] = binary.LittleEndian.Uint64(b[8*i:])
+	}
+	if s.err == nil && littleEndian(b).Cmp(fp.Modulus()) >= 0 {
+		s.err = fmt.Errorf("%w: field element out of range", ErrInvalidZkey)
+	}
+	return e
+}
+
+func (s *zkeySection) g1() bn254.G1Affine {
+	p := bn254.G1Affine{X: s.fq(), Y: s.fq()}
+	if s.err == nil && !p.IsOnCurve() {
+		s.err = fmt.Errorf("%w: G1 point not on the curve", ErrInvalidZkey)
+	}
+	return p
+}
+
+func (s *zkeySection) g2() bn254.G2Affine {
+	var p bn254.G2Affine
+	p.X.A0, p.X.A1 = s.fq(), s.fq()
+	p.Y.A0, p.Y.A1 = s.fq(), s.fq()
+	if s.err == nil && (!p.IsOnCurve() || !p.IsInSubGroup()) {
+		s.err = fmt.Errorf("%w: G2 point not in the subgroup", ErrInvalidZkey)
+	}
+	return p
+}
+
+func littleEndian(b []byte) *big.Int {
+	b = slices.Clone(b)
+	slices.Reverse(b)
+	return new(big.Int).SetBytes(b)
+}
+
+// g1Object is the snarkjs JSON of a G1 point, projective with z = 1.
+func g1Object(p bn254.G1Affine) []string {
+	if p.IsInfinity() {
+		return []string{"0", "1", "0"}
+	}
+	return []string{p.X.String(), p.Y.String(), "1"}
+}
+
+// g2Object is the snarkjs JSON of a G2 point, projective with z = 1.
+func g2Object(p bn254.G2Affine) [][]string {
+	if p.IsInfinity() {
+		return [][]string{{"0", "0"}, {"1", "0"}, {"0", "0"}}
+	}
+	return [][]string{{p.X.A0.String(), p.X.A1.String()}, {p.Y.A0.String(), p.Y.A1.String()}, {"1", "0"}}
+}
@@ -0,0 +1,240 @@
+package zkp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"deployer/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// zkeySectionData is a section of a generated zkey, in file order.
+type zkeySectionData struct {
+	typ  uint32
+	data []byte
+}
+
+// testZkey is a zkey header as snarkjs writes it, from its parts.
+type testZkey struct {
+	protocol   uint32
+	q, r       *big.Int
+	nVars      uint32
+	nPublic    uint32
+	domainSize uint32
+	key        *types.VerificationKey
+	nIC        int // IC points written, the key's by default
+}
+
+func newTestZkey(t *testing.T) *testZkey {
+	key := pinacleKey(t)
+	return &testZkey{
+		protocol:   zkeyGroth16,
+		q:          fp.Modulus(),
+		r:          fr.Modulus(),
+		nVars:      100,
+		nPublic:    uint32(key.NPublic),
+		domainSize: 128,
+		key:        key,
+		nIC:        len(key.IC),
+	}
+}
+
+type zkeyWriter struct {
+	t *testing.T
+	bytes.Buffer
+}
+
+func (w *zkeyWriter) uint32(v uint32) {
+	binary.Write(&w.Buffer, binary.LittleEndian, v)
+}
+
+func (w *zkeyWriter) bigInt(v *big.Int) {
+	b := v.FillBytes(make([]byte, fp.Bytes))
+	slices.Reverse(b)
+	w.Write(b)
+}
+
+// fq writes a base field element in Montgomery form, the limbs of fp.Element.
+func (w *zkeyWriter) fq(decimal string) {
+	var e fp.Element
+	if _, err := e.SetString(decimal); err != nil {
+		w.t.Fatal(err)
+	}
+	for _, limb := range e {
+		binary.Write(&w.Buffer, binary.LittleEndian, limb)
+	}
+}
+
+func (w *zkeyWriter) g1(p []string) {
+	w.fq(p[0])
+	w.fq(p[1])
+}
+
+func (w *zkeyWriter) g2(p [][]string) {
+	w.fq(p[0][0])
+	w.fq(p[0][1])
+	w.fq(p[1][0])
+	w.fq(p[1][1])
+}
+
+func (z *testZkey) sections(t *testing.T) []zkeySectionData {
+	_, _, g1, _ := bn254.Generators()
+	generator := g1Object(g1)
+
+	header := &zkeyWriter{t: t}
+	header.uint32(z.protocol)
+
+	groth16 := &zkeyWriter{t: t}
+	groth16.uint32(fp.Bytes)
+	groth16.bigInt(z.q)
+	groth16.uint32(fr.Bytes)
+	groth16.bigInt(z.r)
+	groth16.uint32(z.nVars)
+	groth16.uint32(z.nPublic)
+	groth16.uint32(z.domainSize)
+	groth16.g1(z.key.Alpha1)
+	groth16.g1(generator) // beta1
+	groth16.g2(z.key.Beta2)
+	groth16.g2(z.key.Gamma2)
+	groth16.g1(generator) // delta1
+	groth16.g2(z.key.Delta2)
+
+	ic := &zkeyWriter{t: t}
+	for i := 0; i < z.nIC; i++ {
+		ic.g1(z.key.IC[i%len(z.key.IC)])
+	}
+
+	// The coefficients, the first section the header is not read from, is skipped
+	return []zkeySectionData{
+		{zkeyHeaderSection, header.Bytes()},
+		{zkeyGroth16HeaderSection, groth16.Bytes()},
+		{zkeyICSection, ic.Bytes()},
+		{4, make([]byte, 64)},
+	}
+}
+
+func writeZkey(sections []zkeySectionData) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("zkey")
+	binary.Write(&buf, binary.LittleEndian, uint32(1))
+	binary.Write(&buf, binary.LittleEndian, uint32(len(sections)))
+	for _, s := range sections {
+		binary.Write(&buf, binary.LittleEndian, s.typ)
+		binary.Write(&buf, binary.LittleEndian, uint64(len(s.data)))
+		buf.Write(s.data)
+	}
+	return buf.Bytes()
+}
+
+func TestReadZkeyHeader(t *testing.T) {
+	z := newTestZkey(t)
+	// The skipped section first, as the header sections need not lead
+	sections := z.sections(t)
+	sections = append(sections[3:], sections[:3]...)
+	path := filepath.Join(t.TempDir(), "circuit.zkey")
+	if err := os.WriteFile(path, writeZkey(sections), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := ReadZkeyHeader(Path(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Protocol != "groth16" || h.Curve != BN128_CURVE || h.NVars != 100 || h.NPublic != VOTING_PUBLIC_SIGNALS || h.DomainSize != 128 {
+		t.Fatalf("got header %+v", h)
+	}
+	if h.Q.Cmp(fp.Modulus()) != 0 || h.R.Cmp(fr.Modulus()) != 0 {
+		t.Fatalf("got q %s and r %s, want the %s moduli", h.Q, h.R, BN128_CURVE)
+	}
+	if !reflect.DeepEqual(h.VerificationKey, z.key) {
+		t.Fatalf("verification key mismatch:\n got %+v\nwant %+v", h.VerificationKey, z.key)
+	}
+
+	if _, err := ReadZkeyHeader(Path(filepath.Join(t.TempDir(), "missing.zkey"))); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("got %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestParseZkeyHeaderInvalid(t *testing.T) {
+	// BLS12-381, the other snarkjs curve, whose scalar field also fits 32 bytes
+	bls12381R, _ := new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+	for _, tc := range []struct {
+		name   string
+		zkey   func(t *testing.T, z *testZkey) []byte
+		target error
+	}{
+		{"magic", func(t *testing.T, z *testZkey) []byte {
+			return append([]byte("wtns"), writeZkey(z.sections(t))[4:]...)
+		}, ErrInvalidZkey},
+		{"version", func(t *testing.T, z *testZkey) []byte {
+			data := writeZkey(z.sections(t))
+			data[4] = 2
+			return data
+		}, ErrUnsupportedZkey},
+		{"truncated file", func(t *testing.T, z *testZkey) []byte {
+			data := writeZkey(z.sections(t)[:3])
+			return data[:len(data)-10]
+		}, ErrInvalidZkey},
+		{"truncated section", func(t *testing.T, z *testZkey) []byte {
+			sections := z.sections(t)
+			sections[1].data = sections[1].data[:len(sections[1].data)-fp.Bytes]
+			return writeZkey(sections)
+		}, ErrInvalidZkey},
+		{"missing section", func(t *testing.T, z *testZkey) []byte {
+			sections := z.sections(t)
+			return writeZkey(append(sections[:2:2], sections[3]))
+		}, ErrInvalidZkey},
+		{"duplicate section", func(t *testing.T, z *testZkey) []byte {
+			sections := z.sections(t)
+			return writeZkey(append(sections[:2:2], sections[1], sections[2]))
+		}, ErrInvalidZkey},
+		{"plonk", func(t *testing.T, z *testZkey) []byte {
+			z.protocol = 2
+			return writeZkey(z.sections(t))
+		}, ErrUnsupportedZkey},
+		{"prime", func(t *testing.T, z *testZkey) []byte {
+			z.r = bls12381R
+			return writeZkey(z.sections(t))
+		}, ErrUnsupportedZkey},
+		{"base field", func(t *testing.T, z *testZkey) []byte {
+			z.q = new(big.Int).Sub(fp.Modulus(), big.NewInt(2))
+			return writeZkey(z.sections(t))
+		}, ErrUnsupportedZkey},
+		{"domain size", func(t *testing.T, z *testZkey) []byte {
+			z.domainSize = 96
+			return writeZkey(z.sections(t))
+		}, ErrInvalidZkey},
+		{"more public signals than IC", func(t *testing.T, z *testZkey) []byte {
+			z.nPublic++
+			return writeZkey(z.sections(t))
+		}, ErrInvalidZkey},
+		{"fewer public signals than IC", func(t *testing.T, z *testZkey) []byte {
+			z.nIC++
+			return writeZkey(z.sections(t))
+		}, ErrInvalidZkey},
+		{"point not on the curve", func(t *testing.T, z *testZkey) []byte {
+			y, _ := new(big.Int).SetString(z.key.IC[1][1], 10)
+			z.key.IC[1] = []string{z.key.IC[1][0], y.Add(y, big.NewInt(1)).String(), "1"}
+			return writeZkey(z.sections(t))
+		}, ErrInvalidZkey},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			z := newTestZkey(t)
+			data := tc.zkey(t, z)
+			if _, err := ParseZkeyHeader(bytes.NewReader(data)); !errors.Is(err, tc.target) {
+				t.Fatalf("got %v, want %v", err, tc.target)
+			}
+		})
+	}
+}
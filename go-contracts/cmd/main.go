@@ -1,13 +1,24 @@
 package main
 
 import (
+	"os"
+	"os/signal"
+	"path/filepath"
 	"runtime"
+	"syscall"
 
 	"go-contracts/internal/abigen"
 	"go-contracts/internal/banner"
+	"go-contracts/internal/ceremony"
 	"go-contracts/internal/compiler"
 	"go-contracts/internal/config"
+	"go-contracts/internal/directory"
 	"go-contracts/internal/logger"
+	"go-contracts/internal/mimc"
+	"go-contracts/internal/roles"
+	"go-contracts/internal/verifier"
+	"go-contracts/internal/zeros"
+	"go-contracts/internal/zkbuild"
 
 	"github.com/spf13/cobra"
 )
@@ -37,6 +48,216 @@ var (
 			return abigen.Generate(cfg)
 		},
 	}
+
+	// gen-zeros options
+	zerosOptions = zeros.Options{}
+	zerosOutput  string
+
+	genZerosCMD = &cobra.Command{
+		Use:   "gen-zeros",
+		Short: "Generate the MerkleTreeWithHistory zero values and powers of 2",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger.Logger.Info().Int("levels", zerosOptions.Levels).Str("seed", zerosOptions.Seed).Msg("🔨 Generating zero values...")
+
+			tables, err := zeros.Compute(zerosOptions)
+			if err != nil {
+				return err
+			}
+			source, err := tables.Render()
+			if err != nil {
+				return err
+			}
+
+			if err := directory.CreateDirIfNotExists(filepath.Dir(zerosOutput)); err != nil {
+				return err
+			}
+			if err := os.WriteFile(zerosOutput, source, 0o644); err != nil {
+				return err
+			}
+
+			logger.Logger.Info().Str("output", zerosOutput).Str("root", tables.Zeros[len(tables.Zeros)-1].String()).Msg("Zero values generated")
+			return nil
+		},
+	}
+
+	// gen-roles options
+	rolesDefinition string
+	rolesPackage    string
+	rolesOutput     string
+
+	genRolesCMD = &cobra.Command{
+		Use:   "gen-roles",
+		Short: "Generate the Go roles (types.Role) from the zkLogin role registry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger.Logger.Info().Str("roles", rolesDefinition).Msg("🔨 Generating roles...")
+
+			registry, err := roles.Load(rolesDefinition)
+			if err != nil {
+				return err
+			}
+			source, err := registry.RenderGo(rolesPackage)
+			if err != nil {
+				return err
+			}
+
+			if err := directory.CreateDirIfNotExists(filepath.Dir(rolesOutput)); err != nil {
+				return err
+			}
+			if err := os.WriteFile(rolesOutput, source, 0o644); err != nil {
+				return err
+			}
+
+			logger.Logger.Info().Str("output", rolesOutput).Int("roles", len(registry.Roles)).Msg("Roles generated")
+			return nil
+		},
+	}
+
+	// gen-verifier options
+	verifierOptions   = verifier.Options{}
+	verifierKey       string
+	verifierOutputDir string
+	verifierNoBuild   bool
+	verifierNoBind    bool
+
+	genVerifierCMD = &cobra.Command{
+		Use:   "gen-verifier",
+		Short: "Generate, compile and bind a Groth16 verifier from a snarkjs verification_key.json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger.Logger.Info().Str("vkey", verifierKey).Msg("🔨 Generating Groth16 verifier...")
+
+			vk, err := verifier.Load(verifierKey)
+			if err != nil {
+				return err
+			}
+			source, err := verifier.Render(vk, verifierOptions)
+			if err != nil {
+				return err
+			}
+
+			if err := directory.CreateDirIfNotExists(verifierOutputDir); err != nil {
+				return err
+			}
+			output := filepath.Join(verifierOutputDir, verifierOptions.Name+".sol")
+			if err := os.WriteFile(output, source, 0o644); err != nil {
+				return err
+			}
+			logger.Logger.Info().Str("output", output).Int("nPublic", vk.NPublic).Msg("Verifier generated")
+
+			if verifierNoBuild {
+				return nil
+			}
+
+			jsonPath, err := compiler.CompileContract(cfg, verifierOutputDir, verifierOptions.Name)
+			if err != nil {
+				return err
+			}
+			if verifierNoBind {
+				logger.Logger.Info().Str("output", jsonPath).Msg("Verifier compiled")
+				return nil
+			}
+			if err := abigen.GenerateContract(cfg, jsonPath); err != nil {
+				return err
+			}
+
+			logger.Logger.Info().Str("bindings", filepath.Join(cfg.AbigenOutputDir, verifierOptions.Name)).Msg("Verifier compiled and bound")
+			return nil
+		},
+	}
+
+	// zk-build options
+	zkBuildOptions = zkbuild.Options{}
+
+	zkBuildCMD = &cobra.Command{
+		Use:   "zk-build",
+		Short: "Compile a circom circuit and run its Groth16 setup (replaces setup.sh)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := zkbuild.Run(cmd.Context(), zkBuildOptions)
+			return err
+		},
+	}
+
+	// ceremony options
+	ceremonyDir        string
+	ceremonySnarkjs    string
+	ceremonyR1CS       string
+	ceremonyPtau       string
+	ceremonyListen     string
+	ceremonyURL        string
+	ceremonyIterations int
+	ceremonyBeacon     string
+	ceremonyEnv        string
+	participant        = ceremony.Participant{}
+
+	ceremonyCMD = &cobra.Command{
+		Use:   "ceremony",
+		Short: "Run a multi-party Groth16 phase-2 trusted setup ceremony",
+	}
+
+	ceremonyInitCMD = &cobra.Command{
+		Use:   "init",
+		Short: "Start a ceremony from a circuit r1cs and a powers of tau",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := ceremony.Init(cmd.Context(), ceremonyDir, ceremonyR1CS, ceremonyPtau, ceremonySnarkjs)
+			return err
+		},
+	}
+
+	ceremonyServeCMD = &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the ceremony to the participants over HTTP",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			coordinator, err := ceremony.Open(ceremonyDir, ceremonySnarkjs)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return ceremony.NewServer(coordinator).ListenAndServe(ctx, ceremonyListen)
+		},
+	}
+
+	ceremonyContributeCMD = &cobra.Command{
+		Use:   "contribute",
+		Short: "Contribute to a ceremony, through its server or its directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			participant.Snarkjs = ceremonySnarkjs
+
+			var transport ceremony.Transport
+			if ceremonyURL != "" {
+				transport = ceremony.NewClient(ceremonyURL)
+			} else {
+				coordinator, err := ceremony.Open(ceremonyDir, ceremonySnarkjs)
+				if err != nil {
+					return err
+				}
+				transport = coordinator
+			}
+
+			_, err := participant.Contribute(cmd.Context(), transport)
+			return err
+		},
+	}
+
+	ceremonyFinalizeCMD = &cobra.Command{
+		Use:   "finalize",
+		Short: "Apply the random beacon and export the final zkey and verification key",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			coordinator, err := ceremony.Open(ceremonyDir, ceremonySnarkjs)
+			if err != nil {
+				return err
+			}
+			zkey, vkey, err := coordinator.Finalize(cmd.Context(), ceremonyBeacon, ceremonyIterations)
+			if err != nil {
+				return err
+			}
+
+			if ceremonyEnv == "" {
+				return nil
+			}
+			return zkbuild.CopyToDeployer(ceremonyEnv, map[string]string{"zkey": zkey, "vkey": vkey})
+		},
+	}
 )
 
 func init() {
@@ -49,6 +270,64 @@ func init() {
 	// Initialize the logger
 	logger.SetupLogger(cfg.LoggerMode)
 	logger.Logger.Info().Msg("Logger Initialized")
+
+	// gen-zeros flags
+	genZerosCMD.Flags().IntVar(&zerosOptions.Levels, "levels", zeros.MaxLevels, "Number of levels of the tree")
+	genZerosCMD.Flags().StringVar(&zerosOptions.Seed, "seed", zeros.DefaultSeed, "Seed of the zero leaf, ZERO_VALUE = keccak256(seed) % FIELD_SIZE")
+	genZerosCMD.Flags().StringVar(&zerosOptions.MiMCSeed, "mimc-seed", mimc.Seed, "Seed of the MiMC round constants")
+	genZerosCMD.Flags().IntVar(&zerosOptions.MiMCRounds, "mimc-rounds", mimc.NbRounds, "Number of MiMC rounds")
+	genZerosCMD.Flags().StringVar(&zerosOptions.Name, "name", "MerkleTreeZeros", "Library name, or constants prefix")
+	genZerosCMD.Flags().StringVar(&zerosOptions.Format, "format", zeros.FormatLibrary, "Output format: library or constants")
+	genZerosCMD.Flags().StringVarP(&zerosOutput, "output", "o", "../contracts/MerkleTree/MerkleTreeZeros.sol", "Output Solidity file")
+
+	// gen-roles flags
+	genRolesCMD.Flags().StringVar(&rolesDefinition, "roles", "../contracts/zkLogin/roles.json", "Role registry of zkLogin")
+	genRolesCMD.Flags().StringVar(&rolesPackage, "package", roles.DefaultPackage, "Go package of the output")
+	genRolesCMD.Flags().StringVarP(&rolesOutput, "output", "o", "../deployer/internal/types/roles_gen.go", "Output Go file")
+
+	// gen-verifier flags
+	genVerifierCMD.Flags().StringVar(&verifierKey, "vkey", "", "snarkjs verification_key.json")
+	genVerifierCMD.Flags().StringVar(&verifierOptions.Name, "name", verifier.DefaultName, "Contract name, also the Solidity file and binding package name")
+	genVerifierCMD.Flags().StringVar(&verifierOptions.Pragma, "pragma", verifier.DefaultPragma, "Solidity version pragma")
+	genVerifierCMD.Flags().StringVarP(&verifierOutputDir, "output-dir", "o", "../contracts/Verifier", "Output directory of the Solidity file")
+	genVerifierCMD.Flags().BoolVar(&verifierNoBuild, "no-build", false, "Only render the Solidity file")
+	genVerifierCMD.Flags().BoolVar(&verifierNoBind, "no-bind", false, "Render and compile without generating the Go binding")
+	genVerifierCMD.MarkFlagRequired("vkey")
+
+	// zk-build flags
+	zkBuildCMD.Flags().StringVar(&zkBuildOptions.Circuit, "circuit", "../zero-knowledge-proofs/zkPinacle/circuits/Pinacle.circom", "Circom file")
+	zkBuildCMD.Flags().StringSliceVarP(&zkBuildOptions.Includes, "include", "l", nil, "circom library paths")
+	zkBuildCMD.Flags().StringVar(&zkBuildOptions.BuildDir, "build-dir", "", "Build directory, must be empty unless --clean (default <circuit dir>/build/<name>)")
+	zkBuildCMD.Flags().BoolVar(&zkBuildOptions.Clean, "clean", false, "Delete the build directory of an earlier build first")
+	zkBuildCMD.Flags().IntVar(&zkBuildOptions.Power, "power", 0, "Power of tau, the circuit must have less than 2^power constraints")
+	zkBuildCMD.Flags().StringVar(&zkBuildOptions.Ptau, "ptau", "", "Existing powers of tau file (default: download the Hermez file)")
+	zkBuildCMD.Flags().StringVar(&zkBuildOptions.PtauDir, "ptau-dir", "../zero-knowledge-proofs/zkPinacle/powersOfTau", "Download directory of the powers of tau")
+	zkBuildCMD.Flags().StringVar(&zkBuildOptions.PtauBlake2b, "ptau-blake2b", "", "Expected blake2b-512 of the powers of tau (default: verify with snarkjs)")
+	zkBuildCMD.Flags().IntVar(&zkBuildOptions.Contributions, "contributions", 3, "Number of phase-2 contributions")
+	zkBuildCMD.Flags().StringVar(&zkBuildOptions.Beacon, "beacon", "", "Final beacon in hex (default: random)")
+	zkBuildCMD.Flags().IntVar(&zkBuildOptions.BeaconIterations, "beacon-iterations", 10, "log2 of the beacon hash iterations")
+	zkBuildCMD.Flags().StringVar(&zkBuildOptions.DeployerEnv, "deployer-env", "../deployer/.env", "Deployer .env, artifacts are copied to its ZK_* paths (empty skips the copy)")
+	zkBuildCMD.Flags().StringVar(&zkBuildOptions.CircomBin, "circom", "circom", "circom binary")
+	zkBuildCMD.Flags().StringVar(&zkBuildOptions.SnarkjsBin, "snarkjs", "snarkjs", "snarkjs binary")
+	zkBuildCMD.MarkFlagRequired("power")
+
+	// ceremony flags
+	ceremonyCMD.PersistentFlags().StringVar(&ceremonyDir, "dir", "../zero-knowledge-proofs/zkPinacle/ceremony", "Ceremony directory of the coordinator")
+	ceremonyCMD.PersistentFlags().StringVar(&ceremonySnarkjs, "snarkjs", "snarkjs", "snarkjs binary")
+	ceremonyInitCMD.Flags().StringVar(&ceremonyR1CS, "r1cs", "../zero-knowledge-proofs/zkPinacle/circuits/build/Pinacle/Pinacle.r1cs", "Circuit r1cs")
+	ceremonyInitCMD.Flags().StringVar(&ceremonyPtau, "ptau", "", "Powers of tau file")
+	ceremonyInitCMD.MarkFlagRequired("ptau")
+	ceremonyServeCMD.Flags().StringVar(&ceremonyListen, "listen", ":7080", "Listen address")
+	ceremonyContributeCMD.Flags().StringVar(&participant.Name, "name", "", "Participant name, recorded in the zkey and the transcript")
+	ceremonyContributeCMD.Flags().StringVar(&participant.Entropy, "entropy", "", "Contribution entropy (default: random)")
+	ceremonyContributeCMD.Flags().StringVar(&participant.WorkDir, "work-dir", "", "Directory of the downloaded zkeys (default: system temporary directory)")
+	ceremonyContributeCMD.Flags().StringVar(&ceremonyURL, "coordinator", "", "Coordinator server URL (default: contribute to --dir)")
+	ceremonyContributeCMD.MarkFlagRequired("name")
+	ceremonyFinalizeCMD.Flags().StringVar(&ceremonyBeacon, "beacon", "", "Beacon in hex, a public value unknown during the contributions (e.g. a future block hash)")
+	ceremonyFinalizeCMD.Flags().IntVar(&ceremonyIterations, "beacon-iterations", 10, "log2 of the beacon hash iterations")
+	ceremonyFinalizeCMD.Flags().StringVar(&ceremonyEnv, "deployer-env", "../deployer/.env", "Deployer .env, keys are copied to its ZK_* paths (empty skips the copy)")
+	ceremonyFinalizeCMD.MarkFlagRequired("beacon")
+	ceremonyCMD.AddCommand(ceremonyInitCMD, ceremonyServeCMD, ceremonyContributeCMD, ceremonyFinalizeCMD)
 }
 
 func main() {
@@ -93,16 +372,41 @@ Examples:
 
   # Generate Go bindings from compiled contracts
   contract-cli abigen
+
+  # Generate the Merkle tree zero values for a 20 levels tree
+  contract-cli gen-zeros --levels 20
+
+  # Generate the Go roles from the zkLogin role registry
+  contract-cli gen-roles
+
+  # Generate, compile and bind the Groth16 verifier of a new verification key
+  contract-cli gen-verifier --vkey verification_key.json
+
+  # Compile the Pinacle circuit and run its Groth16 setup
+  contract-cli zk-build --power 20
+
+  # Run a phase-2 ceremony: init, serve, contributions from the participants, finalize
+  contract-cli ceremony init --ptau powersOfTau28_hez_final_20.ptau
+  contract-cli ceremony serve
+  contract-cli ceremony contribute --name alice --coordinator http://coordinator:7080
+  contract-cli ceremony finalize --beacon <hex>
 `,
 		Example: `
   contract-cli compile
   contract-cli abigen
+  contract-cli gen-zeros --levels 20 --format constants -o ../contracts/MerkleTree/Zeros.sol
+  contract-cli gen-verifier --vkey ../zero-knowledge-proofs/zkPinacle/circuits/build/Pinacle/keys/verification_key.json
 `,
 		SilenceUsage:  true, // Avoid showing usage on errors like "flag not found"
 		SilenceErrors: true, // Avoid showing errors on command execution
 	}
 	rootCmd.AddCommand(compileCMD)
 	rootCmd.AddCommand(abigenCMD)
+	rootCmd.AddCommand(genZerosCMD)
+	rootCmd.AddCommand(genRolesCMD)
+	rootCmd.AddCommand(genVerifierCMD)
+	rootCmd.AddCommand(zkBuildCMD)
+	rootCmd.AddCommand(ceremonyCMD)
 
 	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
 		logger.Logger.Error().Msgf("Command failed: %v", err)
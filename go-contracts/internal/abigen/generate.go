@@ -74,37 +74,8 @@ func Generate(cfg *config.Config) error {
 	for _, jsonPath := range jsonFiles {
 		logger.Logger.Info().Msgf("✅ Found %s JSON compiled file", filepath.Base(jsonPath))
 
-		// Read the JSON file
-		data, err := os.ReadFile(jsonPath)
-		if err != nil {
-			logger.Logger.Fatal().Msgf("Failed read JSON file %s: %s", jsonPath, err)
-			return nil
-		}
-
-		// Parse JSON
-		var contractJSON *types.Contract
-		if err := json.Unmarshal(data, &contractJSON); err != nil {
-			logger.Logger.Fatal().Msgf("Failed read JSON file %s: %s", jsonPath, err)
-			return nil
-		}
-
-		// Validate the struct
-		if err := validator.ValidateStruct(contractJSON); err != nil {
-			logger.Logger.Fatal().Msgf("Failed to validate contract %s: %s", jsonPath, err)
-			return nil
-		}
-
-		var opts []Option
-		outputDir := filepath.Join(cfg.AbigenOutputDir, contractJSON.ContractName)
-		if err := directory.CreateDirIfNotExists(outputDir); err != nil {
-			logger.Logger.Fatal().Msgf("Failed to create directory %s: %s", outputDir, err)
-			return nil
-		}
-		output := filepath.Join(outputDir, fmt.Sprintf("%s.go", contractJSON.ContractName))
-		opts = append(opts, WithOutput(output), WithPackage(contractJSON.ContractName))
-		err = abigen.Generate(jsonPath, opts...)
-		if err != nil {
-			logger.Logger.Fatal().Msgf("Failed to Execute Abigen binary: %s", err)
+		if err := generateBinding(abigen, cfg.AbigenOutputDir, jsonPath); err != nil {
+			logger.Logger.Fatal().Msgf("%s", err)
 			return nil
 		}
 	}
@@ -122,3 +93,52 @@ func Generate(cfg *config.Config) error {
 
 	return nil
 }
+
+// GenerateContract generates the Go binding of a single compiled contract (JSON), keeping
+// the other bindings.
+func GenerateContract(cfg *config.Config, jsonPath string) error {
+	if err := directory.CreateDirIfNotExists(cfg.AbigenOutputDir); err != nil {
+		return fmt.Errorf("failed to create bindings directory: %w", err)
+	}
+
+	// Initialize the Abigen compiler
+	abigen := New(Version(cfg.AbigenVersion))
+
+	// Init once
+	abigen.once.Do(abigen.init)
+	if abigen.err != nil {
+		return fmt.Errorf("failed to initialize Abigen package: %w", abigen.err)
+	}
+
+	return generateBinding(abigen, cfg.AbigenOutputDir, jsonPath)
+}
+
+// generateBinding writes the binding of jsonPath to bindingsDir/<name>/<name>.go, package <name>.
+func generateBinding(abigen *Abigen, bindingsDir, jsonPath string) error {
+	// Read the JSON file
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return fmt.Errorf("Failed read JSON file %s: %w", jsonPath, err)
+	}
+
+	// Parse JSON
+	var contractJSON *types.Contract
+	if err := json.Unmarshal(data, &contractJSON); err != nil {
+		return fmt.Errorf("Failed read JSON file %s: %w", jsonPath, err)
+	}
+
+	// Validate the struct
+	if err := validator.ValidateStruct(contractJSON); err != nil {
+		return fmt.Errorf("Failed to validate contract %s: %w", jsonPath, err)
+	}
+
+	outputDir := filepath.Join(bindingsDir, contractJSON.ContractName)
+	if err := directory.CreateDirIfNotExists(outputDir); err != nil {
+		return fmt.Errorf("Failed to create directory %s: %w", outputDir, err)
+	}
+	output := filepath.Join(outputDir, fmt.Sprintf("%s.go", contractJSON.ContractName))
+	if err := abigen.Generate(jsonPath, WithOutput(output), WithPackage(contractJSON.ContractName)); err != nil {
+		return fmt.Errorf("Failed to Execute Abigen binary: %w", err)
+	}
+	return nil
+}
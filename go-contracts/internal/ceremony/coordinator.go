@@ -0,0 +1,342 @@
+// Package ceremony coordinates a multi-party Groth16 phase-2 trusted setup with snarkjs.
+//
+// The coordinator keeps the r1cs, the chain of zkeys and the transcript in a directory.
+// Participants download the latest zkey, contribute to it with snarkjs and upload the result,
+// either straight to the directory or through the HTTP server. Every upload is verified against
+// the r1cs and the powers of tau and must extend the latest zkey by exactly one contribution.
+// A random beacon finalizes the ceremony. The keys are sound as long as one participant
+// discarded their entropy.
+package ceremony
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go-contracts/internal/directory"
+	"go-contracts/internal/logger"
+	"go-contracts/internal/tool"
+	"go-contracts/internal/zkbuild"
+)
+
+const (
+	zkeysDir      = "zkeys"
+	uploadsDir    = "uploads"
+	maxNameLength = 64
+)
+
+var (
+	ErrAlreadyInitialized = errors.New("ceremony directory already has a transcript")
+	ErrFinalized          = errors.New("ceremony is finalized")
+	ErrStaleBase          = errors.New("contribution is not based on the latest zkey")
+	ErrInvalidName        = fmt.Errorf("participant name should be 1 to %d printable characters", maxNameLength)
+	ErrInvalidZkey        = errors.New("zkey does not verify against the r1cs and the powers of tau")
+	ErrNotExtending       = errors.New("zkey does not extend the latest zkey by one contribution")
+)
+
+// Coordinator accepts contributions into the ceremony of a directory. It is safe for
+// concurrent use, but only one coordinator may run on a directory.
+type Coordinator struct {
+	mu         sync.Mutex
+	dir        string
+	snarkjs    string
+	transcript *Transcript
+}
+
+// Init starts a ceremony in dir: the r1cs is copied there and groth16 setup computes the
+// initial zkey, contribution 0. The powers of tau stay where they are, the transcript records
+// their path and hash.
+func Init(ctx context.Context, dir, r1cs, ptau, snarkjs string) (*Coordinator, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(dir, TranscriptFilename)); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInitialized, dir)
+	}
+	if ptau, err = filepath.Abs(ptau); err != nil {
+		return nil, err
+	}
+	for _, sub := range []string{zkeysDir, uploadsDir} {
+		if err := directory.CreateDirIfNotExists(filepath.Join(dir, sub)); err != nil {
+			return nil, err
+		}
+	}
+
+	name := strings.TrimSuffix(filepath.Base(r1cs), ".r1cs")
+	copied := filepath.Join(dir, name+".r1cs")
+	if err := copyFile(r1cs, copied); err != nil {
+		return nil, fmt.Errorf("copy r1cs: %w", err)
+	}
+
+	initial := zkeyPath(dir, name, 0)
+	output, err := tool.Run(ctx, "setup", dir, snarkjs, "groth16", "setup", copied, ptau, initial)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Transcript{Circuit: name, CircuitHash: hashes(output)["circuit hash"], Contributions: []Contribution{}, CreatedAt: time.Now().UTC()}
+	for _, f := range []struct {
+		dst  *File
+		path string
+	}{{&t.R1CS, copied}, {&t.Ptau, ptau}, {&t.Initial, initial}} {
+		if *f.dst, err = newFile(dir, f.path); err != nil {
+			return nil, err
+		}
+	}
+	if err := t.save(dir); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info().Str("circuit", name).Str("dir", dir).Msg("🎬 Ceremony initialized")
+	return &Coordinator{dir: dir, snarkjs: snarkjs, transcript: t}, nil
+}
+
+// Open resumes the ceremony of dir and checks that the zkeys on disk match the transcript.
+func Open(dir, snarkjs string) (*Coordinator, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	t, err := loadTranscript(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Coordinator{dir: dir, snarkjs: snarkjs, transcript: t}
+	if err := c.checkFile(t.current()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Transcript returns a copy of the transcript.
+func (c *Coordinator) Transcript() Transcript {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := *c.transcript
+	t.Contributions = slices.Clone(t.Contributions)
+	return t
+}
+
+// Download writes the latest zkey to w and returns its number of contributions, the base
+// of the next contribution.
+func (c *Coordinator) Download(ctx context.Context, w io.Writer) (int, error) {
+	f, base, err := c.openLatest()
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(w, f); err != nil {
+		return 0, err
+	}
+	return base, nil
+}
+
+// openLatest opens the latest zkey with its base. The zkeys are never rewritten, the file stays
+// consistent with the base while other contributions are accepted.
+func (c *Coordinator) openLatest() (*os.File, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.transcript.Finalized() {
+		return nil, 0, ErrFinalized
+	}
+	f, err := os.Open(c.transcript.current().abs(c.dir))
+	if err != nil {
+		return nil, 0, err
+	}
+	return f, len(c.transcript.Contributions), nil
+}
+
+// Upload verifies the zkey read from r, contributed by name on top of contribution base, and
+// appends it to the transcript. The zkey must verify against the r1cs and the powers of tau,
+// and carry the contributions of the transcript followed by a new one.
+func (c *Coordinator) Upload(ctx context.Context, base int, name string, r io.Reader) (*Contribution, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	// Stage the upload before taking the lock, other uploads may be received meanwhile
+	upload, err := os.CreateTemp(filepath.Join(c.dir, uploadsDir), "*.zkey")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(upload.Name())
+	if _, err := io.Copy(upload, r); err != nil {
+		upload.Close()
+		return nil, fmt.Errorf("receive zkey: %w", err)
+	}
+	if err := upload.Close(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := c.transcript
+	if t.Finalized() {
+		return nil, ErrFinalized
+	}
+	if base != len(t.Contributions) {
+		return nil, fmt.Errorf("%w: based on %d, latest is %d", ErrStaleBase, base, len(t.Contributions))
+	}
+
+	chain, err := c.verify(ctx, upload.Name())
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) != base+1 {
+		return nil, fmt.Errorf("%w: %d contributions, expected %d", ErrNotExtending, len(chain), base+1)
+	}
+	for i, contribution := range t.Contributions {
+		if chain[i] != contribution.Hash {
+			return nil, fmt.Errorf("%w: contribution %d differs", ErrNotExtending, contribution.Index)
+		}
+	}
+
+	index := base + 1
+	path := zkeyPath(c.dir, t.Circuit, index)
+	if err := os.Rename(upload.Name(), path); err != nil {
+		return nil, err
+	}
+	zkey, err := newFile(c.dir, path)
+	if err != nil {
+		return nil, err
+	}
+
+	contribution := Contribution{Index: index, Name: name, Hash: chain[base], Zkey: zkey, SubmittedAt: time.Now().UTC()}
+	t.Contributions = append(t.Contributions, contribution)
+	if err := t.save(c.dir); err != nil {
+		t.Contributions = t.Contributions[:base]
+		return nil, err
+	}
+
+	logger.Logger.Info().Int("index", index).Str("name", name).Str("hash", contribution.Hash).Msg("✅ Contribution accepted")
+	return &contribution, nil
+}
+
+// Finalize applies the beacon (hex) with 2^iterations hash iterations to the latest zkey,
+// verifies the result and exports the verification key. It returns the paths of the final
+// zkey and of the verification key.
+func (c *Coordinator) Finalize(ctx context.Context, beacon string, iterations int) (string, string, error) {
+	if _, err := hex.DecodeString(beacon); err != nil || beacon == "" {
+		return "", "", zkbuild.ErrInvalidBeacon
+	}
+	if iterations < 0 || iterations > 63 {
+		return "", "", zkbuild.ErrInvalidIterations
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := c.transcript
+	if t.Finalized() {
+		return "", "", ErrFinalized
+	}
+	current := t.current()
+	if err := c.checkFile(current); err != nil {
+		return "", "", err
+	}
+
+	final := filepath.Join(c.dir, t.Circuit+"_final.zkey")
+	vkey := filepath.Join(c.dir, "verification_key.json")
+	if _, err := tool.Run(ctx, "beacon", c.dir, c.snarkjs, "zkey", "beacon", current.abs(c.dir), final, beacon, fmt.Sprint(iterations), "-n=Final Beacon phase2"); err != nil {
+		return "", "", err
+	}
+	chain, err := c.verify(ctx, final)
+	if err != nil {
+		return "", "", err
+	}
+	if len(chain) != len(t.Contributions)+1 {
+		return "", "", fmt.Errorf("%w: %d contributions after the beacon, expected %d", ErrNotExtending, len(chain), len(t.Contributions)+1)
+	}
+	if _, err := tool.Run(ctx, "export", c.dir, c.snarkjs, "zkey", "export", "verificationkey", final, vkey); err != nil {
+		return "", "", err
+	}
+
+	finalFile, err := newFile(c.dir, final)
+	if err != nil {
+		return "", "", err
+	}
+	vkeyFile, err := newFile(c.dir, vkey)
+	if err != nil {
+		return "", "", err
+	}
+	now := time.Now().UTC()
+	t.Beacon = &Beacon{Value: beacon, Iterations: iterations, Hash: chain[len(chain)-1]}
+	t.Final, t.Vkey, t.FinalizedAt = &finalFile, &vkeyFile, &now
+	if err := t.save(c.dir); err != nil {
+		return "", "", err
+	}
+
+	logger.Logger.Info().Int("contributions", len(t.Contributions)).Str("zkey", final).Msg("🏁 Ceremony finalized")
+	return final, vkey, nil
+}
+
+// verify runs zkey verify on zkey and returns its contribution hashes in order.
+func (c *Coordinator) verify(ctx context.Context, zkey string) ([]string, error) {
+	t := c.transcript
+	output, err := tool.Run(ctx, "verify", c.dir, c.snarkjs, "zkey", "verify", t.R1CS.abs(c.dir), t.Ptau.abs(c.dir), zkey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidZkey, err)
+	}
+	return contributionHashes(output)
+}
+
+// checkFile fails if f was modified since it was recorded.
+func (c *Coordinator) checkFile(f File) error {
+	sum, err := sha256File(f.abs(c.dir))
+	if err != nil {
+		return err
+	}
+	if sum != f.SHA256 {
+		return fmt.Errorf("%s was modified since it was recorded in the transcript", f.Path)
+	}
+	return nil
+}
+
+func zkeyPath(dir, circuit string, index int) string {
+	return filepath.Join(dir, zkeysDir, fmt.Sprintf("%s_%04d.zkey", circuit, index))
+}
+
+func validateName(name string) error {
+	if name == "" || len(name) > maxNameLength {
+		return ErrInvalidName
+	}
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return ErrInvalidName
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
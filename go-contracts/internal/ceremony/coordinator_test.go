@@ -0,0 +1,186 @@
+package ceremony
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-contracts/internal/tool/tooltest"
+	"go-contracts/internal/zkbuild"
+)
+
+// r1cs is the constraint system of the test ceremonies
+var r1cs = []byte("r1cs")
+
+func TestMain(m *testing.M) {
+	tooltest.Main()
+	os.Exit(m.Run())
+}
+
+// newCeremony initializes a ceremony with the fake snarkjs in a temporary directory.
+func newCeremony(t *testing.T) (*Coordinator, *tooltest.Tools) {
+	t.Helper()
+	tools := tooltest.Install(t)
+
+	inputs := t.TempDir()
+	r1csPath, ptauPath := filepath.Join(inputs, "Pinacle.r1cs"), filepath.Join(inputs, "pot8.ptau")
+	for path, content := range map[string][]byte{r1csPath: r1cs, ptauPath: []byte("ptau")} {
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := Init(context.Background(), filepath.Join(t.TempDir(), "ceremony"), r1csPath, ptauPath, tools.Snarkjs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, tools
+}
+
+// contribute adds the contribution of name to the ceremony through transport.
+func contribute(t *testing.T, tools *tooltest.Tools, transport Transport, name string) *Contribution {
+	t.Helper()
+	p := &Participant{Name: name, Snarkjs: tools.Snarkjs, WorkDir: t.TempDir()}
+	contribution, err := p.Contribute(context.Background(), transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contribution
+}
+
+func TestCeremony(t *testing.T) {
+	ctx := context.Background()
+	c, tools := newCeremony(t)
+
+	alice := contribute(t, tools, c, "alice")
+	bob := contribute(t, tools, c, "bob")
+	transcript := c.Transcript()
+	if len(transcript.CircuitHash) != 128 || transcript.Circuit != "Pinacle" || len(transcript.Contributions) != 2 {
+		t.Fatalf("unexpected transcript %+v", transcript)
+	}
+	for i, expected := range []*Contribution{alice, bob} {
+		contribution := transcript.Contributions[i]
+		if contribution.Index != i+1 || contribution.Name != expected.Name || contribution.Hash != expected.Hash || len(contribution.Hash) != 128 {
+			t.Fatalf("contribution %d: got %+v, want %+v", i+1, contribution, expected)
+		}
+		if err := c.checkFile(contribution.Zkey); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	final, vkey, err := c.Finalize(ctx, "0123456789abcdef", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{final, vkey} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	transcript = c.Transcript()
+	if !transcript.Finalized() || transcript.Beacon.Value != "0123456789abcdef" || transcript.Beacon.Iterations != 4 || len(transcript.Beacon.Hash) != 128 {
+		t.Fatalf("unexpected beacon %+v", transcript.Beacon)
+	}
+
+	// Nothing is accepted after the beacon
+	if _, err := c.Download(ctx, &bytes.Buffer{}); !errors.Is(err, ErrFinalized) {
+		t.Fatalf("download: got %v, want %v", err, ErrFinalized)
+	}
+	if _, err := c.Upload(ctx, 2, "carol", bytes.NewReader(tooltest.Zkey(r1cs, "x"))); !errors.Is(err, ErrFinalized) {
+		t.Fatalf("upload: got %v, want %v", err, ErrFinalized)
+	}
+	if _, _, err := c.Finalize(ctx, "00", 1); !errors.Is(err, ErrFinalized) {
+		t.Fatalf("finalize: got %v, want %v", err, ErrFinalized)
+	}
+
+	// The ceremony resumes from its directory
+	reopened, err := Open(c.dir, tools.Snarkjs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed := reopened.Transcript(); !resumed.Finalized() || len(resumed.Contributions) != 2 || resumed.Contributions[1].Hash != bob.Hash {
+		t.Fatalf("unexpected resumed transcript %+v", resumed)
+	}
+	if _, err := Init(ctx, c.dir, filepath.Join(c.dir, "Pinacle.r1cs"), transcript.Ptau.Path, tools.Snarkjs); !errors.Is(err, ErrAlreadyInitialized) {
+		t.Fatalf("init: got %v, want %v", err, ErrAlreadyInitialized)
+	}
+}
+
+func TestUploadRejected(t *testing.T) {
+	c, tools := newCeremony(t)
+	alice := contribute(t, tools, c, "alice")
+
+	tests := []struct {
+		name        string
+		base        int
+		participant string
+		zkey        []byte
+		err         error
+	}{
+		{"empty name", 1, "", tooltest.Zkey(r1cs, "alice", "x"), ErrInvalidName},
+		{"unprintable name", 1, "bob\n", tooltest.Zkey(r1cs, "alice", "x"), ErrInvalidName},
+		{"stale base", 0, "bob", tooltest.Zkey(r1cs, "x"), ErrStaleBase},
+		{"base ahead", 2, "bob", tooltest.Zkey(r1cs, "x"), ErrStaleBase},
+		{"garbage", 1, "bob", []byte("garbage"), ErrInvalidZkey},
+		{"other circuit", 1, "bob", tooltest.Zkey([]byte("other r1cs"), "x", "y"), ErrInvalidZkey},
+		{"no new contribution", 1, "bob", tooltest.Zkey(r1cs, "x"), ErrNotExtending},
+		{"two contributions", 1, "bob", tooltest.Zkey(r1cs, "x", "y", "z"), ErrNotExtending},
+		{"other history", 1, "bob", tooltest.Zkey(r1cs, "x", "y"), ErrNotExtending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.Upload(context.Background(), tt.base, tt.participant, bytes.NewReader(tt.zkey)); !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	transcript := c.Transcript()
+	if len(transcript.Contributions) != 1 || transcript.Contributions[0].Hash != alice.Hash {
+		t.Fatalf("rejected uploads recorded: %+v", transcript.Contributions)
+	}
+	if uploads, err := os.ReadDir(filepath.Join(c.dir, uploadsDir)); err != nil || len(uploads) != 0 {
+		t.Fatalf("rejected uploads left behind: %v %v", uploads, err)
+	}
+}
+
+func TestOpenModified(t *testing.T) {
+	c, tools := newCeremony(t)
+	contribution := contribute(t, tools, c, "alice")
+
+	if err := os.WriteFile(contribution.Zkey.abs(c.dir), tooltest.Zkey(r1cs, "mallory"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(c.dir, tools.Snarkjs); err == nil {
+		t.Fatal("modified zkey accepted")
+	}
+	if _, _, err := c.Finalize(context.Background(), "00", 1); err == nil {
+		t.Fatal("modified zkey finalized")
+	}
+}
+
+func TestFinalizeInvalid(t *testing.T) {
+	c, _ := newCeremony(t)
+
+	tests := []struct {
+		beacon     string
+		iterations int
+		err        error
+	}{
+		{"", 10, zkbuild.ErrInvalidBeacon},
+		{"not hex", 10, zkbuild.ErrInvalidBeacon},
+		{"00", -1, zkbuild.ErrInvalidIterations},
+		{"00", 64, zkbuild.ErrInvalidIterations},
+	}
+	for _, tt := range tests {
+		if _, _, err := c.Finalize(context.Background(), tt.beacon, tt.iterations); !errors.Is(err, tt.err) {
+			t.Fatalf("beacon %q, %d iterations: got %v, want %v", tt.beacon, tt.iterations, err, tt.err)
+		}
+	}
+	if transcript := c.Transcript(); transcript.Finalized() {
+		t.Fatal("finalized with an invalid beacon")
+	}
+}
@@ -0,0 +1,87 @@
+package ceremony
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go-contracts/internal/logger"
+	"go-contracts/internal/tool"
+)
+
+var ErrHashMismatch = errors.New("coordinator recorded another contribution hash")
+
+// Participant contributes once to a ceremony.
+type Participant struct {
+	Name    string
+	Entropy string // empty draws 32 random bytes, never stored
+	Snarkjs string
+	WorkDir string // empty uses a temporary directory, the zkeys are deleted afterwards
+}
+
+// Contribute downloads the latest zkey through t, adds a contribution with snarkjs and uploads
+// it. It checks that the coordinator recorded the hash snarkjs printed, the one the
+// participant should publish.
+func (p *Participant) Contribute(ctx context.Context, t Transport) (*Contribution, error) {
+	if err := validateName(p.Name); err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp(p.WorkDir, "ceremony-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	current, next := filepath.Join(dir, "current.zkey"), filepath.Join(dir, "next.zkey")
+	f, err := os.Create(current)
+	if err != nil {
+		return nil, err
+	}
+	base, err := t.Download(ctx, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("download zkey: %w", err)
+	}
+	logger.Logger.Info().Int("base", base).Msg("⬇️ Latest zkey downloaded")
+
+	entropy := p.Entropy
+	if entropy == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		entropy = hex.EncodeToString(b)
+	}
+	// snarkjs asks for the entropy on stdin when -e is missing, which keeps it out of the logged arguments
+	output, err := tool.RunInput(ctx, "contribute", dir, p.Snarkjs, entropy+"\n", "zkey", "contribute", current, next, "--name="+p.Name)
+	if err != nil {
+		return nil, err
+	}
+	hash, ok := hashes(output)["contribution hash"]
+	if !ok {
+		return nil, fmt.Errorf("%w: no contribution hash", ErrUnexpectedOutput)
+	}
+
+	f, err = os.Open(next)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	contribution, err := t.Upload(ctx, base, p.Name, f)
+	if err != nil {
+		return nil, fmt.Errorf("upload zkey: %w", err)
+	}
+	if contribution.Hash != hash {
+		return nil, fmt.Errorf("%w: %s, snarkjs printed %s", ErrHashMismatch, contribution.Hash, hash)
+	}
+
+	logger.Logger.Info().Int("index", contribution.Index).Str("hash", hash).Msg("✅ Contribution accepted, publish its hash")
+	return contribution, nil
+}
@@ -0,0 +1,92 @@
+package ceremony
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"go-contracts/internal/tool/tooltest"
+)
+
+func TestContributeEntropy(t *testing.T) {
+	tests := []struct {
+		name    string
+		entropy string
+	}{
+		{"given", "correct horse battery staple"},
+		{"random", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, tools := newCeremony(t)
+			logs := tooltest.CaptureLogs(t)
+
+			p := &Participant{Name: "alice", Entropy: tt.entropy, Snarkjs: tools.Snarkjs, WorkDir: t.TempDir()}
+			if _, err := p.Contribute(context.Background(), c); err != nil {
+				t.Fatal(err)
+			}
+
+			var contribute *tooltest.Invocation
+			for _, invocation := range tools.Invocations(t) {
+				if strings.Join(invocation.Args[:2], " ") == "zkey contribute" {
+					contribute = &invocation
+				}
+			}
+			if contribute == nil {
+				t.Fatal("zkey contribute not run")
+			}
+			entropy := strings.TrimSuffix(contribute.Stdin, "\n")
+			if tt.entropy != "" && entropy != tt.entropy {
+				t.Fatalf("got entropy %q, want %q", entropy, tt.entropy)
+			}
+			if tt.entropy == "" && len(entropy) != 64 {
+				t.Fatalf("got random entropy %q, want 32 bytes in hex", entropy)
+			}
+			for _, arg := range contribute.Args {
+				if strings.Contains(arg, entropy) || strings.HasPrefix(arg, "-e") {
+					t.Fatalf("entropy passed as an argument: %q", contribute.Args)
+				}
+			}
+			if !strings.Contains(logs.String(), "zkey") || strings.Contains(logs.String(), entropy) {
+				t.Fatalf("entropy logged: %s", logs)
+			}
+		})
+	}
+}
+
+// forgetfulTransport records another hash than the one of the uploaded zkey.
+type forgetfulTransport struct {
+	*Coordinator
+}
+
+func (f forgetfulTransport) Upload(ctx context.Context, base int, name string, r io.Reader) (*Contribution, error) {
+	contribution, err := f.Coordinator.Upload(ctx, base, name, r)
+	if err != nil {
+		return nil, err
+	}
+	contribution.Hash = strings.Repeat("0", 128)
+	return contribution, nil
+}
+
+func TestContributeHashMismatch(t *testing.T) {
+	c, tools := newCeremony(t)
+
+	p := &Participant{Name: "alice", Snarkjs: tools.Snarkjs, WorkDir: t.TempDir()}
+	if _, err := p.Contribute(context.Background(), forgetfulTransport{c}); !errors.Is(err, ErrHashMismatch) {
+		t.Fatalf("got %v, want %v", err, ErrHashMismatch)
+	}
+}
+
+func TestContributeInvalidName(t *testing.T) {
+	c, tools := newCeremony(t)
+
+	p := &Participant{Name: strings.Repeat("a", maxNameLength+1), Snarkjs: tools.Snarkjs}
+	if _, err := p.Contribute(context.Background(), c); !errors.Is(err, ErrInvalidName) {
+		t.Fatalf("got %v, want %v", err, ErrInvalidName)
+	}
+	if invocations := tools.Invocations(t); len(invocations) != 1 {
+		t.Fatalf("snarkjs ran for an invalid name: %+v", invocations)
+	}
+}
@@ -0,0 +1,88 @@
+package ceremony
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrUnexpectedOutput = errors.New("unexpected snarkjs output")
+
+var (
+	// ansi strips the colors snarkjs' logger may add
+	ansi = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+	// hashLine is a line of a hash formatted by snarkjs (misc.formatHash): 4 groups of 8 hex digits
+	hashLine = regexp.MustCompile(`^\s*([0-9a-f]{8}) ([0-9a-f]{8}) ([0-9a-f]{8}) ([0-9a-f]{8})\s*$`)
+
+	// contributionTitle is the title zkey verify prints above each contribution hash
+	contributionTitle = regexp.MustCompile(`contribution #(\d+)`)
+)
+
+// hashes returns the 64-byte hashes snarkjs printed in output, in hex, by their lowercased title
+// ("circuit hash", "contribution hash", "contribution #2 alice").
+func hashes(output string) map[string]string {
+	lines := strings.Split(ansi.ReplaceAllString(output, ""), "\n")
+
+	found := map[string]string{}
+	for i := 0; i+4 < len(lines); i++ {
+		title, ok := hashTitle(lines[i])
+		if !ok {
+			continue
+		}
+
+		var hash strings.Builder
+		for _, line := range lines[i+1 : i+5] {
+			groups := hashLine.FindStringSubmatch(line)
+			if groups == nil {
+				break
+			}
+			hash.WriteString(strings.Join(groups[1:], ""))
+		}
+		if hash.Len() == 128 {
+			found[title] = hash.String()
+			i += 4
+		}
+	}
+	return found
+}
+
+// hashTitle extracts the title of a hash, the text before the last colon without the logger prefix.
+func hashTitle(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasSuffix(line, ":") {
+		return "", false
+	}
+	line = strings.TrimSuffix(line, ":")
+	if _, msg, ok := strings.Cut(line, "snarkJS: "); ok {
+		line = msg
+	}
+	return strings.ToLower(strings.TrimSpace(line)), true
+}
+
+// contributionHashes returns the contribution hashes zkey verify printed, in contribution order.
+// It fails unless they are numbered from 1 without gaps.
+func contributionHashes(output string) ([]string, error) {
+	byIndex := map[int]string{}
+	for title, hash := range hashes(output) {
+		groups := contributionTitle.FindStringSubmatch(title)
+		if groups == nil {
+			continue
+		}
+		index, err := strconv.Atoi(groups[1])
+		if err != nil {
+			return nil, err
+		}
+		byIndex[index] = hash
+	}
+
+	ordered := make([]string, len(byIndex))
+	for index, hash := range byIndex {
+		if index < 1 || index > len(ordered) {
+			return nil, ErrUnexpectedOutput
+		}
+		ordered[index-1] = hash
+	}
+	return ordered, nil
+}
@@ -0,0 +1,51 @@
+package ceremony
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// formatHash prints hash like snarkjs, with the colors of its logger.
+func formatHash(title, hash string) string {
+	var s strings.Builder
+	s.WriteString("\x1b[32m[INFO] \x1b[0m snarkJS: " + title + ":\n")
+	for i := 0; i < 4; i++ {
+		line := hash[i*32 : (i+1)*32]
+		s.WriteString("\t\t" + line[0:8] + " " + line[8:16] + " " + line[16:24] + " " + line[24:32] + "\n")
+	}
+	return s.String()
+}
+
+func TestHashes(t *testing.T) {
+	a, b := strings.Repeat("0123abcd", 16), strings.Repeat("fedc9876", 16)
+	output := "Enter a random text. (Entropy): \n" + formatHash("Circuit Hash", a) + formatHash("Contribution Hash", b) + "[INFO]  snarkJS: ZKey Ok!\n"
+
+	expected := map[string]string{"circuit hash": a, "contribution hash": b}
+	if found := hashes(output); !reflect.DeepEqual(found, expected) {
+		t.Fatalf("got %v, want %v", found, expected)
+	}
+
+	// A truncated hash is not reported
+	truncated := formatHash("Contribution Hash", b)
+	if found := hashes(truncated[:len(truncated)-20]); len(found) != 0 {
+		t.Fatalf("truncated hash found: %v", found)
+	}
+}
+
+func TestContributionHashes(t *testing.T) {
+	a, b := strings.Repeat("0123abcd", 16), strings.Repeat("fedc9876", 16)
+
+	ordered, err := contributionHashes(formatHash("contribution #2 bob", b) + formatHash("contribution #1 alice", a))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ordered, []string{a, b}) {
+		t.Fatalf("got %v, want alice then bob", ordered)
+	}
+
+	if _, err := contributionHashes(formatHash("contribution #1 alice", a) + formatHash("contribution #3 carol", b)); !errors.Is(err, ErrUnexpectedOutput) {
+		t.Fatalf("gap: got %v, want %v", err, ErrUnexpectedOutput)
+	}
+}
@@ -0,0 +1,124 @@
+package ceremony
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go-contracts/internal/directory"
+)
+
+// TranscriptFilename is written in the ceremony directory.
+const TranscriptFilename = "transcript.json"
+
+// File is an input or output of the ceremony with its SHA-256.
+type File struct {
+	Path   string `json:"path"` // relative to the ceremony directory, absolute when outside of it
+	SHA256 string `json:"sha256"`
+}
+
+// Contribution is an accepted phase-2 contribution.
+type Contribution struct {
+	Index       int       `json:"index"` // 1 for the first contribution
+	Name        string    `json:"name"`
+	Hash        string    `json:"hash"` // contribution hash printed by snarkjs, participants check theirs
+	Zkey        File      `json:"zkey"`
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// Beacon is the random beacon that closed the ceremony.
+type Beacon struct {
+	Value      string `json:"value"` // hex
+	Iterations int    `json:"iterations"`
+	Hash       string `json:"hash"` // contribution hash of the beacon
+}
+
+// Transcript is the public record of the ceremony: inputs, every accepted contribution in
+// order, and the beacon with the final keys once finalized.
+type Transcript struct {
+	Circuit       string         `json:"circuit"`
+	CircuitHash   string         `json:"circuitHash"` // snarkjs hash of the constraint system
+	R1CS          File           `json:"r1cs"`
+	Ptau          File           `json:"ptau"`
+	Initial       File           `json:"initial"` // groth16 setup output, contribution 0
+	Contributions []Contribution `json:"contributions"`
+	Beacon        *Beacon        `json:"beacon,omitempty"`
+	Final         *File          `json:"final,omitempty"`
+	Vkey          *File          `json:"vkey,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	FinalizedAt   *time.Time     `json:"finalizedAt,omitempty"`
+}
+
+// Finalized reports if the beacon was applied, no contribution is accepted after it.
+func (t *Transcript) Finalized() bool {
+	return t.Final != nil
+}
+
+// current is the zkey the next contribution builds on.
+func (t *Transcript) current() File {
+	if n := len(t.Contributions); n > 0 {
+		return t.Contributions[n-1].Zkey
+	}
+	return t.Initial
+}
+
+func loadTranscript(dir string) (*Transcript, error) {
+	data, err := os.ReadFile(filepath.Join(dir, TranscriptFilename))
+	if err != nil {
+		return nil, err
+	}
+	var t Transcript
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("parse transcript: %w", err)
+	}
+	return &t, nil
+}
+
+// save writes the transcript through a temporary file, a crash never leaves it truncated.
+func (t *Transcript) save(dir string) error {
+	path := filepath.Join(dir, TranscriptFilename)
+	if err := directory.SaveToFile(path+".tmp", t); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// newFile hashes the file at path and records it relative to dir.
+func newFile(dir, path string) (File, error) {
+	sum, err := sha256File(path)
+	if err != nil {
+		return File{}, err
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		rel = path
+	}
+	return File{Path: filepath.ToSlash(rel), SHA256: sum}, nil
+}
+
+// abs resolves the path of f against the ceremony directory.
+func (f File) abs(dir string) string {
+	if filepath.IsAbs(f.Path) {
+		return f.Path
+	}
+	return filepath.Join(dir, filepath.FromSlash(f.Path))
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,81 @@
+package ceremony
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTranscriptSave(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Second)
+	transcript := &Transcript{
+		Circuit:       "Pinacle",
+		R1CS:          File{Path: "Pinacle.r1cs", SHA256: "01"},
+		Ptau:          File{Path: "/ptau/pot8.ptau", SHA256: "02"},
+		Initial:       File{Path: "zkeys/Pinacle_0000.zkey", SHA256: "03"},
+		Contributions: []Contribution{{Index: 1, Name: "alice", Hash: "04", Zkey: File{Path: "zkeys/Pinacle_0001.zkey", SHA256: "05"}, SubmittedAt: now}},
+		CreatedAt:     now,
+	}
+	if err := transcript.save(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, TranscriptFilename+".tmp")); !os.IsNotExist(err) {
+		t.Fatalf("temporary transcript left behind: %v", err)
+	}
+
+	loaded, err := loadTranscript(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, transcript) {
+		t.Fatalf("got %+v, want %+v", loaded, transcript)
+	}
+	if loaded.Finalized() || loaded.current() != transcript.Contributions[0].Zkey {
+		t.Fatalf("unexpected state of %+v", loaded)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, TranscriptFilename), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadTranscript(dir); err == nil {
+		t.Fatal("truncated transcript loaded")
+	}
+}
+
+func TestNewFile(t *testing.T) {
+	dir, outside := t.TempDir(), t.TempDir()
+	inside := filepath.Join(dir, "zkeys", "Pinacle_0000.zkey")
+	ptau := filepath.Join(outside, "pot8.ptau")
+	for _, path := range []string{inside, ptau} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("abc"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{inside, "zkeys/Pinacle_0000.zkey"},
+		{ptau, filepath.ToSlash(ptau)},
+	}
+	for _, tt := range tests {
+		f, err := newFile(dir, tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// SHA-256 of "abc"
+		if f.Path != tt.expected || f.SHA256 != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+			t.Fatalf("got %+v, want path %s", f, tt.expected)
+		}
+		if f.abs(dir) != tt.path {
+			t.Fatalf("%s resolves to %s", f.Path, f.abs(dir))
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package ceremony
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-contracts/internal/logger"
+)
+
+// Transport moves zkeys between a participant and the coordinator.
+type Transport interface {
+	// Download writes the latest zkey to w and returns the base of the next contribution.
+	Download(ctx context.Context, w io.Writer) (int, error)
+	// Upload submits the zkey contributed by name on top of base.
+	Upload(ctx context.Context, base int, name string, r io.Reader) (*Contribution, error)
+}
+
+// The coordinator is the transport of participants with access to the ceremony directory
+var _ Transport = (*Coordinator)(nil)
+
+const (
+	baseHeader = "X-Ceremony-Base"
+
+	// uploadSlack is what an upload may exceed the latest zkey by: a contribution adds a few
+	// hundred bytes to the zkey header
+	uploadSlack = 1 << 20
+)
+
+// Server exposes a coordinator over HTTP, without any external service, so a ceremony can
+// run on an offline network:
+//
+//	GET  /transcript              the transcript
+//	GET  /zkey                    the latest zkey, its base in the X-Ceremony-Base header
+//	POST /contributions?name=...  a contributed zkey, X-Ceremony-Base set to the downloaded base
+type Server struct {
+	coordinator *Coordinator
+	mux         *http.ServeMux
+}
+
+// NewServer returns the HTTP handler of the coordinator.
+func NewServer(c *Coordinator) *Server {
+	s := &Server{coordinator: c, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /transcript", s.handleTranscript)
+	s.mux.HandleFunc("GET /zkey", s.handleZkey)
+	s.mux.HandleFunc("POST /contributions", s.handleContribution)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves the coordinator on addr until ctx is done.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{Addr: addr, Handler: s, ReadHeaderTimeout: 10 * time.Second}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+	logger.Logger.Info().Str("address", addr).Msg("🌐 Ceremony server listening")
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+func (s *Server) handleTranscript(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.coordinator.Transcript())
+}
+
+func (s *Server) handleZkey(w http.ResponseWriter, r *http.Request) {
+	f, base, err := s.coordinator.openLatest()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set(baseHeader, strconv.Itoa(base))
+	if _, err := io.Copy(w, f); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to send zkey")
+	}
+}
+
+func (s *Server) handleContribution(w http.ResponseWriter, r *http.Request) {
+	base, err := strconv.Atoi(r.Header.Get(baseHeader))
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: missing %s header", ErrStaleBase, baseHeader))
+		return
+	}
+
+	// Bound the upload by the size of the zkey it extends
+	f, _, err := s.coordinator.openLatest()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	info, err := f.Stat()
+	f.Close()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body := http.MaxBytesReader(w, r.Body, info.Size()+uploadSlack)
+
+	name := r.URL.Query().Get("name")
+	logger.Logger.Info().Str("name", name).Int("base", base).Str("remote", r.RemoteAddr).Msg("📥 Contribution received")
+	contribution, err := s.coordinator.Upload(r.Context(), base, name, body)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Str("name", name).Msg("Contribution rejected")
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, contribution)
+}
+
+// Client is the transport of participants contributing through a coordinator Server.
+type Client struct {
+	url  string
+	http *http.Client
+}
+
+var _ Transport = (*Client)(nil)
+
+// NewClient returns a client of the coordinator server at url.
+func NewClient(url string) *Client {
+	return &Client{url: strings.TrimSuffix(url, "/"), http: &http.Client{}}
+}
+
+func (c *Client) Download(ctx context.Context, w io.Writer) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/zkey", nil)
+	if err != nil {
+		return 0, err
+	}
+	res, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return 0, readError(res)
+	}
+
+	base, err := strconv.Atoi(res.Header.Get(baseHeader))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s header: %w", baseHeader, err)
+	}
+	if _, err := io.Copy(w, res.Body); err != nil {
+		return 0, err
+	}
+	return base, nil
+}
+
+func (c *Client) Upload(ctx context.Context, base int, name string, r io.Reader) (*Contribution, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/contributions?name="+url.QueryEscape(name), r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set(baseHeader, strconv.Itoa(base))
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return nil, readError(res)
+	}
+
+	var contribution Contribution
+	if err := json.NewDecoder(res.Body).Decode(&contribution); err != nil {
+		return nil, err
+	}
+	return &contribution, nil
+}
+
+// errorResponse is the body of the failed requests.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// statusErrors maps the coordinator errors to statuses, and back on the client.
+var statusErrors = []struct {
+	err    error
+	status int
+}{
+	{ErrFinalized, http.StatusGone},
+	{ErrStaleBase, http.StatusConflict},
+	{ErrInvalidName, http.StatusBadRequest},
+	{ErrInvalidZkey, http.StatusUnprocessableEntity},
+	{ErrNotExtending, http.StatusUnprocessableEntity},
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		status = http.StatusRequestEntityTooLarge
+	}
+	for _, e := range statusErrors {
+		if errors.Is(err, e.err) {
+			status = e.status
+			break
+		}
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// readError rebuilds the error of a failed request, wrapping the coordinator error of its status.
+func readError(res *http.Response) error {
+	var body errorResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil || body.Error == "" {
+		body.Error = res.Status
+	}
+	for _, e := range statusErrors {
+		if e.status == res.StatusCode && strings.HasPrefix(body.Error, e.err.Error()) {
+			return fmt.Errorf("%w%s", e.err, strings.TrimPrefix(body.Error, e.err.Error()))
+		}
+	}
+	return fmt.Errorf("coordinator: %s", body.Error)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to write response")
+	}
+}
@@ -0,0 +1,87 @@
+package ceremony
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-contracts/internal/tool/tooltest"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	c, tools := newCeremony(t)
+	srv := httptest.NewServer(NewServer(c))
+	defer srv.Close()
+	client := NewClient(srv.URL + "/")
+
+	alice := contribute(t, tools, client, "alice")
+	contribute(t, tools, client, "bob")
+
+	res, err := http.Get(srv.URL + "/transcript")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var transcript Transcript
+	if err := json.NewDecoder(res.Body).Decode(&transcript); err != nil {
+		t.Fatal(err)
+	}
+	if len(transcript.Contributions) != 2 || transcript.Contributions[0].Hash != alice.Hash || transcript.Contributions[1].Name != "bob" {
+		t.Fatalf("unexpected transcript %+v", transcript)
+	}
+
+	// The coordinator errors are rebuilt by the client
+	tests := []struct {
+		name        string
+		base        int
+		participant string
+		zkey        []byte
+		err         error
+	}{
+		{"stale base", 1, "carol", tooltest.Zkey(r1cs, "x", "y"), ErrStaleBase},
+		{"invalid name", 2, "", tooltest.Zkey(r1cs, "x", "y", "z"), ErrInvalidName},
+		{"invalid zkey", 2, "carol", []byte("garbage"), ErrInvalidZkey},
+		{"not extending", 2, "carol", tooltest.Zkey(r1cs, "x", "y", "z"), ErrNotExtending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.Upload(ctx, tt.base, tt.participant, bytes.NewReader(tt.zkey)); !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	t.Run("missing base", func(t *testing.T) {
+		res, err := http.Post(srv.URL+"/contributions?name=carol", "application/octet-stream", strings.NewReader("zkey"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusConflict {
+			t.Fatalf("got status %d, want %d", res.StatusCode, http.StatusConflict)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := client.Upload(ctx, 2, "carol", bytes.NewReader(make([]byte, 2*uploadSlack)))
+		if err == nil || !strings.Contains(err.Error(), "too large") {
+			t.Fatalf("got %v, want a too large upload", err)
+		}
+	})
+
+	if _, _, err := c.Finalize(ctx, "00", 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Download(ctx, &bytes.Buffer{}); !errors.Is(err, ErrFinalized) {
+		t.Fatalf("download: got %v, want %v", err, ErrFinalized)
+	}
+	if transcript := c.Transcript(); len(transcript.Contributions) != 2 {
+		t.Fatalf("rejected uploads recorded: %+v", transcript.Contributions)
+	}
+}
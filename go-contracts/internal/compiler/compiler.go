@@ -99,13 +99,62 @@ func Compile(cfg *config.Config) error {
 	}
 
 	// Solc options
+	solcOptions, err := options(cfg)
+	if err != nil {
+		logger.Logger.Fatal().Msgf("Failed to parse solc options: %s", err)
+		return nil
+	}
+
+	// Initialize the Solidity compiler
+	c := solc.New(solc.Version(cfg.SolcVersion))
+
+	for _, contractDir := range groups {
+		for _, name := range contractMap[contractDir] {
+			if _, err := compileContract(c, contractDir, name, buildDir, solcOptions); err != nil {
+				logger.Logger.Fatal().Msgf("%s", err)
+				return nil
+			}
+		}
+	}
+
+	// When Clean Mode is activated delete after
+	if cfg.CleanMode {
+		// Remove the .solc directory
+		logger.Logger.Info().Msgf("✅ Removed solc directory")
+		// Remove the .solc directory
+		if err := directory.DeleteDir(".solc"); err != nil {
+			logger.Logger.Fatal().Msgf("Failed to remove solc directory: %s", err)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// CompileContract compiles a single contract (dir/name.sol) into the solc output directory,
+// keeping the other compiled contracts, and returns the path of its JSON.
+func CompileContract(cfg *config.Config, dir, name string) (string, error) {
+	if err := directory.CreateDirIfNotExists(cfg.SolcOutputDir); err != nil {
+		return "", fmt.Errorf("failed to create build directory: %w", err)
+	}
+
+	solcOptions, err := options(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	c := solc.New(solc.Version(cfg.SolcVersion))
+	return compileContract(c, dir, name, cfg.SolcOutputDir, solcOptions)
+}
+
+// options builds the solc options from the configuration.
+func options(cfg *config.Config) ([]solc.Option, error) {
 	var solcOptions []solc.Option
 
 	// Parse remappings from environment variable
 	remappings, err := parseRemappings(cfg.ContractsRemappings)
 	if err != nil {
-		logger.Logger.Fatal().Msgf("Failed to parse remappings: %s", err)
-		return nil
+		return nil, fmt.Errorf("failed to parse remappings: %w", err)
 	}
 
 	if len(remappings) > 0 {
@@ -118,8 +167,7 @@ func Compile(cfg *config.Config) error {
 	// Parse output selection from environment variable
 	outputSelection, err := parseOutputSelection(cfg.OutputSelection)
 	if err != nil {
-		logger.Logger.Fatal().Msgf("Failed to parse output selection: %s", err)
-		return nil
+		return nil, fmt.Errorf("failed to parse output selection: %w", err)
 	}
 
 	if len(outputSelection) > 0 {
@@ -128,71 +176,52 @@ func Compile(cfg *config.Config) error {
 	}
 
 	// Include static options
-	solcOptions = append([]solc.Option{
+	return append([]solc.Option{
 		solc.WithOptimizer(&solc.Optimizer{Enabled: cfg.SolcOptimizer, Runs: uint64(cfg.SolcOptimizerRuns)}),
 		solc.WithEVMVersion(solc.EVMVersionPetersburg),
 		solc.WithViaIR(cfg.SolcViaIR),
-	}, solcOptions...)
-
-	// Initialize the Solidity compiler
-	c := solc.New(solc.Version(cfg.SolcVersion))
-
-	for _, contractDir := range groups {
-		for _, name := range contractMap[contractDir] {
-			contracName := fmt.Sprintf("%s.sol", name)
-			mainContractFile := filepath.Join(contractDir, contracName)
-
-			if _, err := os.Stat(mainContractFile); os.IsNotExist(err) {
-				logger.Logger.Fatal().Msgf("Contract file does not exist: %s", mainContractFile)
-				return nil
-			}
+	}, solcOptions...), nil
+}
 
-			logger.Logger.Info().Msgf("🚀 Compiling %s", contracName)
+// compileContract compiles dir/name.sol and writes its ABI and bytecode to buildDir/name.json.
+func compileContract(c *solc.Compiler, dir, name, buildDir string, solcOptions []solc.Option) (string, error) {
+	contracName := fmt.Sprintf("%s.sol", name)
+	mainContractFile := filepath.Join(dir, contracName)
 
-			// Compile the contract
-			c, err := c.Compile(
-				contractDir, name,
-				solcOptions...,
-			)
-			if err != nil {
-				logger.Logger.Fatal().Msgf("Compilation failed for %s: %s", contracName, err)
-				return nil
-			}
+	if _, err := os.Stat(mainContractFile); os.IsNotExist(err) {
+		return "", fmt.Errorf("Contract file does not exist: %s", mainContractFile)
+	}
 
-			contract := &types.Contract{
-				ContractName: name,
-				ABI:          c.ABI,
-				Bytecode:     "0x" + hex.EncodeToString(c.Constructor),
-			}
+	logger.Logger.Info().Msgf("🚀 Compiling %s", contracName)
 
-			// Validate the struct
-			if err := validator.ValidateStruct(contract); err != nil {
-				logger.Logger.Fatal().Msgf("Failed to validate contract: %s", err)
-				return nil
-			}
+	// Compile the contract
+	compiled, err := c.Compile(
+		dir, name,
+		solcOptions...,
+	)
+	if err != nil {
+		return "", fmt.Errorf("Compilation failed for %s: %w", contracName, err)
+	}
 
-			// Write ABI
-			output := filepath.Join(buildDir, name+".json")
-			if err := directory.SaveToFile(output, contract); err != nil {
-				return fmt.Errorf("Fail to write bin to file: %s", err)
-			}
+	contract := &types.Contract{
+		ContractName: name,
+		ABI:          compiled.ABI,
+		Bytecode:     "0x" + hex.EncodeToString(compiled.Constructor),
+	}
 
-			logger.Logger.Info().Msgf("✅ Compiled contract in %s.json", name)
-		}
+	// Validate the struct
+	if err := validator.ValidateStruct(contract); err != nil {
+		return "", fmt.Errorf("Failed to validate contract: %w", err)
 	}
 
-	// When Clean Mode is activated delete after
-	if cfg.CleanMode {
-		// Remove the .solc directory
-		logger.Logger.Info().Msgf("✅ Removed solc directory")
-		// Remove the .solc directory
-		if err := directory.DeleteDir(".solc"); err != nil {
-			logger.Logger.Fatal().Msgf("Failed to remove solc directory: %s", err)
-			return nil
-		}
+	// Write ABI
+	output := filepath.Join(buildDir, name+".json")
+	if err := directory.SaveToFile(output, contract); err != nil {
+		return "", fmt.Errorf("Fail to write bin to file: %w", err)
 	}
 
-	return nil
+	logger.Logger.Info().Msgf("✅ Compiled contract in %s.json", name)
+	return output, nil
 }
 
 // ParseRemappings parses the remappings env variable (e.g., "lib=/path1,mimc=/path2")
@@ -0,0 +1,78 @@
+package mimc
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	NbRounds = 220          // number of rounds of the deployed mimc contract
+	Seed     = "mimcsponge" // seed of the round constants of the deployed mimc contract
+)
+
+var (
+	ErrEmptySeed     = errors.New("seed cannot be empty")
+	ErrInvalidRounds = errors.New("rounds must be greater than 1")
+)
+
+// Sponge is the circomlib MiMCSponge (MiMC-Feistel, x^5) with a zero key, as called by
+// hashLeftRight in MerkleTreeWithHistory.
+type Sponge struct {
+	constants []fr.Element
+}
+
+// New derives the round constants like circomlibjs: c_i = keccak256^i(seed), c_0 = c_last = 0.
+func New(seed string, rounds int) (*Sponge, error) {
+	if len(seed) == 0 {
+		return nil, ErrEmptySeed
+	}
+	if rounds < 2 {
+		return nil, ErrInvalidRounds
+	}
+
+	constants := make([]fr.Element, rounds)
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(seed))
+	c := hash.Sum(nil)
+	for i := 1; i < rounds-1; i++ {
+		hash.Reset()
+		hash.Write(c)
+		c = hash.Sum(nil)
+		constants[i].SetBytes(c)
+	}
+
+	return &Sponge{constants: constants}, nil
+}
+
+// HashLeftRight returns MiMCSponge(MiMCSponge(left, 0) + right), the Merkle tree node hash.
+func (s *Sponge) HashLeftRight(left, right *big.Int) *big.Int {
+	var r, c, el fr.Element
+	for _, input := range []*big.Int{left, right} {
+		el.SetBigInt(input)
+		r.Add(&r, &el)
+		r, c = s.permute(r, c)
+	}
+	return r.BigInt(new(big.Int))
+}
+
+// permute applies the Feistel permutation with a zero key.
+func (s *Sponge) permute(xL, xR fr.Element) (fr.Element, fr.Element) {
+	var t, t5 fr.Element
+	for i := range s.constants {
+		t.Add(&xL, &s.constants[i])
+		t5.Square(&t)
+		t5.Square(&t5)
+		t5.Mul(&t5, &t)
+		t5.Add(&t5, &xR)
+
+		if i < len(s.constants)-1 {
+			xL, xR = t5, xL
+		} else {
+			xR = t5
+		}
+	}
+	return xL, xR
+}
@@ -0,0 +1,144 @@
+package roles
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"text/template"
+)
+
+const (
+	MaxSubtrees = 3   // MAXIMUM_ALLOWED_SUBTREES in MerkleTreeWithHistory
+	MaxRoles    = 256 // types.Role is a uint8
+
+	// Role IDs zkLogin reserves for its food bank and user functions
+	FoodBankID = 0
+	UserID     = 1
+
+	DefaultPackage = "types"
+)
+
+var (
+	ErrNoRoles         = errors.New("no roles defined")
+	ErrInvalidName     = errors.New("role name should be a Go identifier starting with an upper case letter")
+	ErrDuplicateRole   = errors.New("role defined twice")
+	ErrInvalidTree     = fmt.Errorf("subtree should be [0, %d)", MaxSubtrees)
+	ErrSharedTree      = errors.New("tree and subtree already assigned to another role")
+	ErrUnknownRole     = errors.New("unknown role")
+	ErrReservedRole    = fmt.Errorf("roles %d and %d are reserved to the food banks and the users", FoodBankID, UserID)
+	ErrInvalidRegistry = errors.New("invalid role registry")
+)
+
+var identifier = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// Role places the members of a role in the zkLogin trees and lists the roles they may register.
+type Role struct {
+	ID          uint32   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tree        uint32   `json:"tree"`
+	Subtree     uint32   `json:"subtree"`
+	Registers   []string `json:"registers"`
+}
+
+// Registry is the roles.json definition zkLogin is deployed with and types.Role is generated from.
+type Registry struct {
+	Roles []Role `json:"roles"`
+}
+
+// Load reads and checks a roles.json.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read role registry: %w", err)
+	}
+
+	registry := &Registry{}
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRegistry, err)
+	}
+	if err := registry.Check(); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// Check applies the rules of the zkLogin constructor: one subtree per role, the food banks and
+// the users on the reserved IDs, permissions between known roles.
+func (r *Registry) Check() error {
+	if len(r.Roles) == 0 {
+		return ErrNoRoles
+	}
+	if len(r.Roles) > MaxRoles {
+		return fmt.Errorf("%w: %d roles, at most %d", ErrInvalidRegistry, len(r.Roles), MaxRoles)
+	}
+
+	ids := make(map[uint32]bool, len(r.Roles))
+	names := make(map[string]bool, len(r.Roles))
+	trees := make(map[[2]uint32]string, len(r.Roles))
+	for _, role := range r.Roles {
+		if !identifier.MatchString(role.Name) {
+			return fmt.Errorf("%w: %q", ErrInvalidName, role.Name)
+		}
+		if ids[role.ID] || names[role.Name] {
+			return fmt.Errorf("%w: %d %s", ErrDuplicateRole, role.ID, role.Name)
+		}
+		if role.ID >= MaxRoles {
+			return fmt.Errorf("%w: %s has ID %d, at most %d", ErrInvalidRegistry, role.Name, role.ID, MaxRoles-1)
+		}
+		if role.Subtree >= MaxSubtrees {
+			return fmt.Errorf("%w: %s on subtree %d", ErrInvalidTree, role.Name, role.Subtree)
+		}
+		tree := [2]uint32{role.Tree, role.Subtree}
+		if other, ok := trees[tree]; ok {
+			return fmt.Errorf("%w: %s and %s on tree %d subtree %d", ErrSharedTree, other, role.Name, role.Tree, role.Subtree)
+		}
+		ids[role.ID], names[role.Name], trees[tree] = true, true, role.Name
+	}
+	if !ids[FoodBankID] || !ids[UserID] {
+		return ErrReservedRole
+	}
+
+	for _, role := range r.Roles {
+		for _, name := range role.Registers {
+			if !names[name] {
+				return fmt.Errorf("%w: %s registers %q", ErrUnknownRole, role.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// RenderGo writes the registry as the Go source of types.Role in package pkg.
+func (r *Registry) RenderGo(pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, struct {
+		Package string
+		*Registry
+	}{pkg, r}); err != nil {
+		return nil, fmt.Errorf("failed to render roles: %w", err)
+	}
+	return format.Source(buf.Bytes())
+}
+
+var goTemplate = template.Must(template.New("roles").Parse(`// Code generated by contract-cli gen-roles. DO NOT EDIT.
+
+package {{.Package}}
+
+const (
+{{- range .Roles}}
+	Role{{.Name}} Role = {{.ID}}{{if .Description}} // {{.Description}}{{end}}
+{{- end}}
+)
+
+// roles is the registry zkLogin is deployed with, in the order of roles.json.
+var roles = []RoleDefinition{
+{{- range .Roles}}
+	{Role: Role{{.Name}}, Name: "{{.Name}}", Tree: {{.Tree}}, Subtree: {{.Subtree}}, Registers: {{if .Registers}}[]Role{ {{- range $i, $name := .Registers}}{{if $i}}, {{end}}Role{{$name}}{{end -}} }{{else}}nil{{end}}},
+{{- end}}
+}
+`))
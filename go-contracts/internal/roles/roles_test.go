@@ -0,0 +1,83 @@
+package roles
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	// zkLogin is deployed with the registry types.Role is generated from
+	registry = "../../../contracts/zkLogin/roles.json"
+	rolesGen = "../../../deployer/internal/types/roles_gen.go"
+)
+
+func TestRenderGoRolesGen(t *testing.T) {
+	r, err := Load(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.RenderGo(DefaultPackage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(rolesGen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s is not the output of gen-roles for %s, regenerate it:\n got %s\nwant %s", rolesGen, registry, got, want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	valid := func() *Registry {
+		return &Registry{Roles: []Role{
+			{ID: FoodBankID, Name: "FoodBank", Tree: 0, Subtree: 0, Registers: []string{"FoodBank", "User"}},
+			{ID: UserID, Name: "User", Tree: 1, Subtree: 0},
+			{ID: 2, Name: "Volunteer", Tree: 1, Subtree: 1},
+		}}
+	}
+	if err := valid().Check(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		modify func(r *Registry)
+		target error
+	}{
+		{"no roles", func(r *Registry) { r.Roles = nil }, ErrNoRoles},
+		{"lower case name", func(r *Registry) { r.Roles[2].Name = "volunteer" }, ErrInvalidName},
+		{"duplicate ID", func(r *Registry) { r.Roles[2].ID = UserID }, ErrDuplicateRole},
+		{"duplicate name", func(r *Registry) { r.Roles[2].Name = "User" }, ErrDuplicateRole},
+		{"ID", func(r *Registry) { r.Roles[2].ID = MaxRoles }, ErrInvalidRegistry},
+		{"subtree", func(r *Registry) { r.Roles[2].Subtree = MaxSubtrees }, ErrInvalidTree},
+		{"shared tree", func(r *Registry) { r.Roles[2].Subtree = 0 }, ErrSharedTree},
+		{"no users", func(r *Registry) { r.Roles[1].ID = 3 }, ErrReservedRole},
+		{"unknown role", func(r *Registry) { r.Roles[0].Registers = append(r.Roles[0].Registers, "Auditor") }, ErrUnknownRole},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := valid()
+			tc.modify(r)
+			if err := r.Check(); !errors.Is(err, tc.target) {
+				t.Fatalf("got %v, want %v", err, tc.target)
+			}
+		})
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roles.json")
+	if err := os.WriteFile(path, []byte(`{"roles": {}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); !errors.Is(err, ErrInvalidRegistry) {
+		t.Fatalf("got %v, want %v", err, ErrInvalidRegistry)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("got %v, want %v", err, os.ErrNotExist)
+	}
+}
@@ -0,0 +1,101 @@
+// Package tool runs the circom and snarkjs command line tools.
+package tool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go-contracts/internal/logger"
+)
+
+// Run executes a circom or snarkjs step in dir, streaming its output to the logger line by line.
+// It returns the output, stdout and stderr interleaved.
+func Run(ctx context.Context, step, dir, bin string, args ...string) (string, error) {
+	return RunInput(ctx, step, dir, bin, "", args...)
+}
+
+// RunInput is Run with input written to the standard input of the tool. The arguments are logged,
+// so secrets such as the entropy of a contribution are passed here instead.
+func RunInput(ctx context.Context, step, dir, bin, input string, args ...string) (string, error) {
+	tool := filepath.Base(bin)
+	logger.Logger.Info().Str("step", step).Str("tool", tool).Strs("args", args).Msg("▶️ Running")
+
+	out := &lineLogger{step: step, tool: tool}
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if input != "" {
+		cmd.Stdin = strings.NewReader(input)
+	}
+
+	start := time.Now()
+	err := cmd.Run()
+	out.flush()
+	if err != nil {
+		return out.output.String(), fmt.Errorf("%s: %s failed: %w", step, tool, err)
+	}
+
+	logger.Logger.Info().Str("step", step).Str("tool", tool).Dur("duration", time.Since(start)).Msg("✅ Done")
+	return out.output.String(), nil
+}
+
+// Version returns the first line a tool prints about itself, or "unknown".
+func Version(ctx context.Context, bin string, args ...string) string {
+	// snarkjs prints its version in the usage and exits with an error, ignore the status
+	out, _ := exec.CommandContext(ctx, bin, args...).CombinedOutput()
+	line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	if line == "" {
+		return "unknown"
+	}
+	return strings.TrimSpace(line)
+}
+
+// lineLogger logs what a tool writes, one entry per line, and keeps a copy of it.
+type lineLogger struct {
+	mu     sync.Mutex
+	step   string
+	tool   string
+	buf    bytes.Buffer
+	output strings.Builder
+}
+
+func (l *lineLogger) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.output.Write(p)
+	l.buf.Write(p)
+	for {
+		line, err := l.buf.ReadString('\n')
+		if err != nil {
+			// Keep the partial line for the next write
+			l.buf.Reset()
+			l.buf.WriteString(line)
+			return len(p), nil
+		}
+		l.log(line)
+	}
+}
+
+func (l *lineLogger) flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.buf.Len() > 0 {
+		l.log(l.buf.String())
+		l.buf.Reset()
+	}
+}
+
+func (l *lineLogger) log(line string) {
+	if line = strings.TrimRight(line, "\r\n"); line != "" {
+		logger.Logger.Debug().Str("step", l.step).Str("tool", l.tool).Msg(line)
+	}
+}
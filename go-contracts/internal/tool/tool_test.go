@@ -0,0 +1,71 @@
+package tool
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"go-contracts/internal/tool/tooltest"
+)
+
+func TestMain(m *testing.M) {
+	tooltest.Main()
+	os.Exit(m.Run())
+}
+
+func TestRunInput(t *testing.T) {
+	tools := tooltest.Install(t)
+	logs := tooltest.CaptureLogs(t)
+	dir := t.TempDir()
+	const entropy = "not in the logs"
+
+	if err := os.WriteFile(dir+"/current.zkey", tooltest.Zkey([]byte("r1cs")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output, err := RunInput(context.Background(), "contribute", dir, tools.Snarkjs, entropy+"\n", "zkey", "contribute", "current.zkey", "next.zkey", "--name=alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "Contribution Hash:") {
+		t.Fatalf("unexpected output %q", output)
+	}
+
+	invocations := tools.Invocations(t)
+	if len(invocations) != 1 || invocations[0].Stdin != entropy+"\n" || invocations[0].Dir != dir {
+		t.Fatalf("unexpected invocations %+v", invocations)
+	}
+	if !strings.Contains(logs.String(), "Contribution Hash") || !strings.Contains(logs.String(), "--name=alice") {
+		t.Fatalf("arguments and output not logged: %s", logs)
+	}
+	if strings.Contains(logs.String(), entropy) {
+		t.Fatalf("input logged: %s", logs)
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	tools := tooltest.Install(t)
+
+	output, err := Run(context.Background(), "verify", t.TempDir(), tools.Snarkjs, "zkey", "verify", "missing.r1cs", "missing.ptau", "missing.zkey")
+	if err == nil || !strings.Contains(err.Error(), "verify: snarkjs failed") {
+		t.Fatalf("got %v, want the failed step", err)
+	}
+	if !strings.Contains(output, "[ERROR]") {
+		t.Fatalf("error output %q not returned", output)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	tools := tooltest.Install(t)
+
+	if version := Version(context.Background(), tools.Circom, "--version"); version != "circom compiler 2.1.9" {
+		t.Fatalf("got %q, want the circom version", version)
+	}
+	// snarkjs fails after printing its version
+	if version := Version(context.Background(), tools.Snarkjs); version != "snarkjs@0.7.4" {
+		t.Fatalf("got %q, want the snarkjs version", version)
+	}
+	if version := Version(context.Background(), "/nonexistent/tool"); version != "unknown" {
+		t.Fatalf("got %q, want unknown", version)
+	}
+}
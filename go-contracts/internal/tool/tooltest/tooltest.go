@@ -0,0 +1,348 @@
+// Package tooltest fakes circom and snarkjs in the tests of the packages running them. The test
+// binary runs as a fake tool when it is started through one of the links Install creates, so the
+// tests need neither node nor circom.
+//
+// The fakes write the files the real tools would, in a text format: a zkey is the hash of its
+// r1cs followed by one line per contribution. snarkjs prints its hashes like the real one.
+package tooltest
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-contracts/internal/logger"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// envLog is set to the invocation log in the environment of the fake tools
+	envLog = "TOOLTEST_LOG"
+
+	// Failing is the marker of a circuit that fails to compile
+	Failing = "// tooltest: fail"
+
+	// NPublic is the number of public signals of the exported verification keys
+	NPublic = 2
+)
+
+// Invocation is a run of a fake tool.
+type Invocation struct {
+	Tool  string   `json:"tool"`
+	Args  []string `json:"args"`
+	Dir   string   `json:"dir"`
+	Stdin string   `json:"stdin"` // the entropy read by zkey contribute
+}
+
+// Tools are the paths of the fake tools of a test.
+type Tools struct {
+	Circom  string
+	Snarkjs string
+	log     string
+}
+
+// Main runs the fake tool and exits when the test binary was started as one. Call it first in
+// TestMain.
+func Main() {
+	log := os.Getenv(envLog)
+	if log == "" {
+		return
+	}
+	os.Exit(run(log, filepath.Base(os.Args[0]), os.Args[1:]))
+}
+
+// Install links circom and snarkjs to the test binary for the duration of the test.
+func Install(t *testing.T) *Tools {
+	t.Helper()
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	tools := &Tools{Circom: filepath.Join(dir, "circom"), Snarkjs: filepath.Join(dir, "snarkjs"), log: filepath.Join(dir, "invocations.log")}
+	for _, link := range []string{tools.Circom, tools.Snarkjs} {
+		if err := os.Symlink(self, link); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv(envLog, tools.log)
+	return tools
+}
+
+// Invocations returns the runs of the fake tools so far, in order.
+func (tools *Tools) Invocations(t *testing.T) []Invocation {
+	t.Helper()
+	f, err := os.Open(tools.log)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var invocations []Invocation
+	decoder := json.NewDecoder(f)
+	for {
+		var invocation Invocation
+		if err := decoder.Decode(&invocation); err == io.EOF {
+			return invocations
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		invocations = append(invocations, invocation)
+	}
+}
+
+// Logs is the output of the logger captured by CaptureLogs.
+type Logs struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (l *Logs) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.Write(p)
+}
+
+func (l *Logs) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.String()
+}
+
+// CaptureLogs sends the logs of every level, the tool output included, to the returned Logs
+// until the end of the test.
+func CaptureLogs(t *testing.T) *Logs {
+	t.Helper()
+	logs := &Logs{}
+	previous, level := logger.Logger, zerolog.GlobalLevel()
+	logger.Logger = zerolog.New(logs)
+	zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	t.Cleanup(func() {
+		logger.Logger = previous
+		zerolog.SetGlobalLevel(level)
+	})
+	return logs
+}
+
+// Zkey returns the content of a fake zkey of r1cs with the contributions made with entropies.
+func Zkey(r1cs []byte, entropies ...string) []byte {
+	zkey := []byte("zkey " + sha256Hex(r1cs) + "\n")
+	for i, entropy := range entropies {
+		zkey = contribute(zkey, fmt.Sprintf("Contribution %d", i+1), entropy)
+	}
+	return zkey
+}
+
+// run is the main function of the fake tools.
+func run(log, tool string, args []string) int {
+	invocation := Invocation{Tool: tool, Args: args}
+	invocation.Dir, _ = os.Getwd()
+
+	var err error
+	switch tool {
+	case "circom":
+		err = circom(args)
+	case "snarkjs":
+		err = snarkjs(args, &invocation)
+	default:
+		err = fmt.Errorf("unknown tool %s", tool)
+	}
+	if logErr := record(log, invocation); logErr != nil && err == nil {
+		err = logErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %s: %v\n", tool, err)
+		return 1
+	}
+	return 0
+}
+
+func record(log string, invocation Invocation) error {
+	f, err := os.OpenFile(log, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(invocation)
+}
+
+// circom compiles <circuit> --r1cs --wasm --sym -o . into the working directory.
+func circom(args []string) error {
+	if len(args) == 1 && args[0] == "--version" {
+		fmt.Println("circom compiler 2.1.9")
+		return nil
+	}
+	if len(args) < 6 {
+		return fmt.Errorf("unexpected arguments %q", args)
+	}
+	source, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	if strings.Contains(string(source), Failing) {
+		return fmt.Errorf("error[T3001]: %s does not compile", args[0])
+	}
+
+	name := strings.TrimSuffix(filepath.Base(args[0]), ".circom")
+	if err := os.MkdirAll(name+"_js", 0o755); err != nil {
+		return err
+	}
+	for path, content := range map[string]string{
+		name + ".r1cs":                          "r1cs " + sha256Hex(source),
+		name + ".sym":                           "1,1,0,main.out",
+		filepath.Join(name+"_js", name+".wasm"): "wasm " + sha256Hex(source),
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	fmt.Println("template instances: 1")
+	fmt.Println("Everything went okay")
+	return nil
+}
+
+func snarkjs(args []string, invocation *Invocation) error {
+	command := strings.Join(args[:min(len(args), 2)], " ")
+	switch {
+	case len(args) == 0:
+		// The real snarkjs prints its version in the usage and fails
+		fmt.Println("snarkjs@0.7.4")
+		return fmt.Errorf("missing command")
+	case command == "r1cs info":
+		fmt.Println("[INFO]  snarkJS: # of Constraints: 1")
+		return nil
+	case command == "powersoftau verify":
+		fmt.Println("[INFO]  snarkJS: Powers of Tau Ok!")
+		return nil
+	case command == "groth16 setup" && len(args) == 5:
+		r1cs, err := os.ReadFile(args[2])
+		if err != nil {
+			return err
+		}
+		printHash("Circuit Hash", sha512Hex(r1cs))
+		return os.WriteFile(args[4], Zkey(r1cs), 0o644)
+	case command == "zkey contribute" && len(args) >= 4:
+		name, entropy := flag(args, "--name="), flag(args, "-e=")
+		if entropy == "" {
+			fmt.Print("Enter a random text. (Entropy): ")
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil && line == "" {
+				return fmt.Errorf("no entropy: %w", err)
+			}
+			entropy = strings.TrimSuffix(line, "\n")
+			invocation.Stdin = line
+		}
+		return extend(args[2], args[3], name, entropy)
+	case command == "zkey beacon" && len(args) >= 6:
+		return extend(args[2], args[3], flag(args, "-n="), args[4]+"/"+args[5])
+	case command == "zkey verify" && len(args) == 5:
+		return verify(args[2], args[4])
+	case command == "zkey export" && len(args) == 5 && args[2] == "verificationkey":
+		if _, err := readZkey(args[3]); err != nil {
+			return err
+		}
+		return os.WriteFile(args[4], []byte(fmt.Sprintf(`{"protocol": "groth16", "curve": "bn128", "nPublic": %d}`, NPublic)), 0o644)
+	}
+	return fmt.Errorf("unexpected arguments %q", args)
+}
+
+// extend writes the zkey at in with one more contribution to out and prints its hash.
+func extend(in, out, name, entropy string) error {
+	if entropy == "" {
+		return fmt.Errorf("empty entropy")
+	}
+	zkey, err := readZkey(in)
+	if err != nil {
+		return err
+	}
+	zkey = contribute(zkey, name, entropy)
+	lines := strings.Split(strings.TrimSpace(string(zkey)), "\n")
+	hash, _, _ := strings.Cut(strings.TrimPrefix(lines[len(lines)-1], "contribution "), " ")
+	printHash("Contribution Hash", hash)
+	return os.WriteFile(out, zkey, 0o644)
+}
+
+func contribute(zkey []byte, name, entropy string) []byte {
+	hash := sha512Hex(append(append([]byte{}, zkey...), entropy...))
+	return append(zkey, fmt.Sprintf("contribution %s %s\n", hash, name)...)
+}
+
+// verify checks the zkey against the r1cs and prints its contributions like zkey verify.
+func verify(r1csPath, zkeyPath string) error {
+	r1cs, err := os.ReadFile(r1csPath)
+	if err != nil {
+		return err
+	}
+	zkey, err := readZkey(zkeyPath)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimSpace(string(zkey)), "\n")
+	if lines[0] != "zkey "+sha256Hex(r1cs) {
+		return fmt.Errorf("circuit does not match")
+	}
+	for i, line := range lines[1:] {
+		hash, name, _ := strings.Cut(strings.TrimPrefix(line, "contribution "), " ")
+		printHash(fmt.Sprintf("contribution #%d %s", i+1, name), hash)
+	}
+	fmt.Println("[INFO]  snarkJS: ZKey Ok!")
+	return nil
+}
+
+func readZkey(path string) ([]byte, error) {
+	zkey, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(string(zkey), "zkey ") {
+		return nil, fmt.Errorf("%s: invalid zkey", path)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(zkey)), "\n")[1:] {
+		if hash, _, _ := strings.Cut(strings.TrimPrefix(line, "contribution "), " "); len(hash) != 128 {
+			return nil, fmt.Errorf("%s: invalid contribution", path)
+		}
+	}
+	return zkey, nil
+}
+
+// printHash prints a 64-byte hash like snarkjs' misc.formatHash, after the logger prefix.
+func printHash(title, hash string) {
+	fmt.Printf("[INFO]  snarkJS: %s:\n", title)
+	for i := 0; i < 4; i++ {
+		line := hash[i*32 : (i+1)*32]
+		fmt.Printf("\t\t%s %s %s %s\n", line[0:8], line[8:16], line[16:24], line[24:32])
+	}
+}
+
+func flag(args []string, prefix string) string {
+	for _, arg := range args {
+		if value, ok := strings.CutPrefix(arg, prefix); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func sha512Hex(data []byte) string {
+	sum := sha512.Sum512(data)
+	return hex.EncodeToString(sum[:])
+}
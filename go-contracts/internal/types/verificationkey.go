@@ -0,0 +1,30 @@
+package types
+
+// VerificationKey is a snarkjs Groth16 verification_key.json. Points are in projective
+// coordinates as decimal strings: G1 [x, y, z], G2 [[x0, x1], [y0, y1], [z0, z1]].
+type VerificationKey struct {
+	Protocol string     `json:"protocol" validate:"required,eq=groth16"`
+	Curve    string     `json:"curve" validate:"required,eq=bn128"`
+	NPublic  int        `json:"nPublic" validate:"min=1"`
+	Alpha1   []string   `json:"vk_alpha_1" validate:"len=3,dive,numeric"`
+	Beta2    [][]string `json:"vk_beta_2" validate:"len=3,dive,len=2,dive,numeric"`
+	Gamma2   [][]string `json:"vk_gamma_2" validate:"len=3,dive,len=2,dive,numeric"`
+	Delta2   [][]string `json:"vk_delta_2" validate:"len=3,dive,len=2,dive,numeric"`
+	IC       [][]string `json:"IC" validate:"min=2,dive,len=3,dive,numeric"`
+}
+
+func (v VerificationKey) CustomErrorMessages() map[string]string {
+	return map[string]string{
+		"VerificationKey.Protocol.required": "Verification key protocol is required",
+		"VerificationKey.Protocol.eq":       "Verification key protocol must be groth16",
+		"VerificationKey.Curve.required":    "Verification key curve is required",
+		"VerificationKey.Curve.eq":          "Verification key curve must be bn128",
+		"VerificationKey.NPublic.min":       "Verification key must have at least one public signal",
+		"VerificationKey.Alpha1.len":        "vk_alpha_1 must be a G1 point [x, y, z]",
+		"VerificationKey.Alpha1[].numeric":  "vk_alpha_1 coordinates must be decimal numbers",
+		"VerificationKey.Beta2.len":         "vk_beta_2 must be a G2 point [[x0, x1], [y0, y1], [z0, z1]]",
+		"VerificationKey.Gamma2.len":        "vk_gamma_2 must be a G2 point [[x0, x1], [y0, y1], [z0, z1]]",
+		"VerificationKey.Delta2.len":        "vk_delta_2 must be a G2 point [[x0, x1], [y0, y1], [z0, z1]]",
+		"VerificationKey.IC.min":            "IC must contain at least two points",
+	}
+}
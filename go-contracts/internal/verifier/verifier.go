@@ -0,0 +1,373 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"text/template"
+
+	"go-contracts/internal/types"
+	"go-contracts/internal/validator"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+)
+
+const (
+	DefaultName   = "Verifier"
+	DefaultPragma = ">=0.7.0 <0.9.0"
+)
+
+var (
+	ErrICLength      = errors.New("IC should contain nPublic + 1 points")
+	ErrNotInField    = errors.New("coordinate is not inside the base field")
+	ErrNotAffine     = errors.New("point is not normalized (z should be 1)")
+	ErrNotOnCurve    = errors.New("point is not on the curve")
+	ErrNotInSubgroup = errors.New("point is not in the prime order subgroup")
+	ErrInvalidName   = errors.New("contract name should be a Solidity identifier")
+	ErrEmptyPragma   = errors.New("pragma cannot be empty")
+)
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Options names the rendered contract.
+type Options struct {
+	Name   string // contract name, also the Solidity file and the abigen package name
+	Pragma string // solidity version pragma
+}
+
+// g1 is an affine G1 point, g2 an affine G2 point with the coordinates in the (c1, c0)
+// order of the pairing precompile, like snarkjs' template.
+type g1 struct{ X, Y *big.Int }
+type g2 struct{ X1, X2, Y1, Y2 *big.Int }
+
+type contract struct {
+	Options
+	NPublic            int
+	Alpha              g1
+	Beta, Gamma, Delta g2
+	IC                 []g1
+}
+
+// Load reads and checks a snarkjs verification_key.json.
+func Load(path string) (*types.VerificationKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read verification key: %w", err)
+	}
+
+	var vk *types.VerificationKey
+	if err := json.Unmarshal(data, &vk); err != nil {
+		return nil, fmt.Errorf("parse verification key: %w", err)
+	}
+	if err := validator.ValidateStruct(vk); err != nil {
+		return nil, fmt.Errorf("failed to validate verification key: %w", err)
+	}
+	if len(vk.IC) != vk.NPublic+1 {
+		return nil, ErrICLength
+	}
+	return vk, nil
+}
+
+// Render writes the Groth16 verifier of vk, the snarkjs template for any number of public signals.
+func Render(vk *types.VerificationKey, opts Options) ([]byte, error) {
+	if !identifier.MatchString(opts.Name) {
+		return nil, ErrInvalidName
+	}
+	if len(opts.Pragma) == 0 {
+		return nil, ErrEmptyPragma
+	}
+	if len(vk.IC) != vk.NPublic+1 {
+		return nil, ErrICLength
+	}
+
+	c := &contract{Options: opts, NPublic: vk.NPublic}
+
+	var err error
+	if c.Alpha, err = parseG1(vk.Alpha1); err != nil {
+		return nil, fmt.Errorf("vk_alpha_1: %w", err)
+	}
+	if c.Beta, err = parseG2(vk.Beta2); err != nil {
+		return nil, fmt.Errorf("vk_beta_2: %w", err)
+	}
+	if c.Gamma, err = parseG2(vk.Gamma2); err != nil {
+		return nil, fmt.Errorf("vk_gamma_2: %w", err)
+	}
+	if c.Delta, err = parseG2(vk.Delta2); err != nil {
+		return nil, fmt.Errorf("vk_delta_2: %w", err)
+	}
+	for i, point := range vk.IC {
+		ic, err := parseG1(point)
+		if err != nil {
+			return nil, fmt.Errorf("IC[%d]: %w", i, err)
+		}
+		c.IC = append(c.IC, ic)
+	}
+
+	var buf bytes.Buffer
+	if err := verifierTemplate.Execute(&buf, c); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", opts.Name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// coordinate parses a base field element.
+func coordinate(value string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(value, 10)
+	if !ok || v.Sign() < 0 || v.Cmp(fp.Modulus()) >= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotInField, value)
+	}
+	return v, nil
+}
+
+func parseG1(point []string) (g1, error) {
+	if len(point) != 3 || point[2] != "1" {
+		return g1{}, ErrNotAffine
+	}
+	x, err := coordinate(point[0])
+	if err != nil {
+		return g1{}, err
+	}
+	y, err := coordinate(point[1])
+	if err != nil {
+		return g1{}, err
+	}
+
+	var p bn254.G1Affine
+	p.X.SetBigInt(x)
+	p.Y.SetBigInt(y)
+	if !p.IsOnCurve() {
+		return g1{}, ErrNotOnCurve
+	}
+	return g1{X: x, Y: y}, nil
+}
+
+func parseG2(point [][]string) (g2, error) {
+	if len(point) != 3 || len(point[2]) != 2 || point[2][0] != "1" || point[2][1] != "0" {
+		return g2{}, ErrNotAffine
+	}
+
+	var c [4]*big.Int
+	for i, value := range []string{point[0][0], point[0][1], point[1][0], point[1][1]} {
+		v, err := coordinate(value)
+		if err != nil {
+			return g2{}, err
+		}
+		c[i] = v
+	}
+
+	var p bn254.G2Affine
+	p.X.A0.SetBigInt(c[0])
+	p.X.A1.SetBigInt(c[1])
+	p.Y.A0.SetBigInt(c[2])
+	p.Y.A1.SetBigInt(c[3])
+	if !p.IsOnCurve() {
+		return g2{}, ErrNotOnCurve
+	}
+	if !p.IsInSubGroup() {
+		return g2{}, ErrNotInSubgroup
+	}
+	return g2{X1: c[1], X2: c[0], Y1: c[3], Y2: c[2]}, nil
+}
+
+var funcs = template.FuncMap{
+	"offset": func(i int) int { return (i - 1) * 32 },
+}
+
+var verifierTemplate = template.Must(template.New("verifier").Funcs(funcs).Parse(`// SPDX-License-Identifier: GPL-3.0
+/*
+    Copyright 2021 0KIMS association.
+
+    This file is generated with [snarkJS](https://github.com/iden3/snarkjs).
+
+    snarkJS is a free software: you can redistribute it and/or modify it
+    under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    snarkJS is distributed in the hope that it will be useful, but WITHOUT
+    ANY WARRANTY; without even the implied warranty of MERCHANTABILITY
+    or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General Public
+    License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with snarkJS. If not, see <https://www.gnu.org/licenses/>.
+*/
+
+pragma solidity {{.Pragma}};
+
+contract {{.Name}} {
+    // Scalar field size
+    uint256 constant r =
+        21888242871839275222246405745257275088548364400416034343698204186575808495617;
+    // Base field size
+    uint256 constant q =
+        21888242871839275222246405745257275088696311157297823662689037894645226208583;
+
+    // Verification Key data
+    uint256 constant alphax =
+        {{.Alpha.X}};
+    uint256 constant alphay =
+        {{.Alpha.Y}};
+    uint256 constant betax1 =
+        {{.Beta.X1}};
+    uint256 constant betax2 =
+        {{.Beta.X2}};
+    uint256 constant betay1 =
+        {{.Beta.Y1}};
+    uint256 constant betay2 =
+        {{.Beta.Y2}};
+    uint256 constant gammax1 =
+        {{.Gamma.X1}};
+    uint256 constant gammax2 =
+        {{.Gamma.X2}};
+    uint256 constant gammay1 =
+        {{.Gamma.Y1}};
+    uint256 constant gammay2 =
+        {{.Gamma.Y2}};
+    uint256 constant deltax1 =
+        {{.Delta.X1}};
+    uint256 constant deltax2 =
+        {{.Delta.X2}};
+    uint256 constant deltay1 =
+        {{.Delta.Y1}};
+    uint256 constant deltay2 =
+        {{.Delta.Y2}};
+{{range $i, $p := .IC}}
+    uint256 constant IC{{$i}}x =
+        {{$p.X}};
+    uint256 constant IC{{$i}}y =
+        {{$p.Y}};
+{{end}}
+    // Memory data
+    uint16 constant pVk = 0;
+    uint16 constant pPairing = 128;
+
+    uint16 constant pLastMem = 896;
+
+    function verifyProof(
+        uint[2] calldata _pA,
+        uint[2][2] calldata _pB,
+        uint[2] calldata _pC,
+        uint[{{.NPublic}}] calldata _pubSignals
+    ) public view returns (bool) {
+        assembly {
+            function checkField(v) {
+                if iszero(lt(v, r)) {
+                    mstore(0, 0)
+                    return(0, 0x20)
+                }
+            }
+
+            // G1 function to multiply a G1 value(x,y) to value in an address
+            function g1_mulAccC(pR, x, y, s) {
+                let success
+                let mIn := mload(0x40)
+                mstore(mIn, x)
+                mstore(add(mIn, 32), y)
+                mstore(add(mIn, 64), s)
+
+                success := staticcall(sub(gas(), 2000), 7, mIn, 96, mIn, 64)
+
+                if iszero(success) {
+                    mstore(0, 0)
+                    return(0, 0x20)
+                }
+
+                mstore(add(mIn, 64), mload(pR))
+                mstore(add(mIn, 96), mload(add(pR, 32)))
+
+                success := staticcall(sub(gas(), 2000), 6, mIn, 128, pR, 64)
+
+                if iszero(success) {
+                    mstore(0, 0)
+                    return(0, 0x20)
+                }
+            }
+
+            function checkPairing(pA, pB, pC, pubSignals, pMem) -> isOk {
+                let _pPairing := add(pMem, pPairing)
+                let _pVk := add(pMem, pVk)
+
+                mstore(_pVk, IC0x)
+                mstore(add(_pVk, 32), IC0y)
+
+                // Compute the linear combination vk_x
+{{range $i, $p := .IC}}{{if $i}}
+                g1_mulAccC(_pVk, IC{{$i}}x, IC{{$i}}y, calldataload(add(pubSignals, {{offset $i}})))
+{{end}}{{end}}
+                // -A
+                mstore(_pPairing, calldataload(pA))
+                mstore(
+                    add(_pPairing, 32),
+                    mod(sub(q, calldataload(add(pA, 32))), q)
+                )
+
+                // B
+                mstore(add(_pPairing, 64), calldataload(pB))
+                mstore(add(_pPairing, 96), calldataload(add(pB, 32)))
+                mstore(add(_pPairing, 128), calldataload(add(pB, 64)))
+                mstore(add(_pPairing, 160), calldataload(add(pB, 96)))
+
+                // alpha1
+                mstore(add(_pPairing, 192), alphax)
+                mstore(add(_pPairing, 224), alphay)
+
+                // beta2
+                mstore(add(_pPairing, 256), betax1)
+                mstore(add(_pPairing, 288), betax2)
+                mstore(add(_pPairing, 320), betay1)
+                mstore(add(_pPairing, 352), betay2)
+
+                // vk_x
+                mstore(add(_pPairing, 384), mload(add(pMem, pVk)))
+                mstore(add(_pPairing, 416), mload(add(pMem, add(pVk, 32))))
+
+                // gamma2
+                mstore(add(_pPairing, 448), gammax1)
+                mstore(add(_pPairing, 480), gammax2)
+                mstore(add(_pPairing, 512), gammay1)
+                mstore(add(_pPairing, 544), gammay2)
+
+                // C
+                mstore(add(_pPairing, 576), calldataload(pC))
+                mstore(add(_pPairing, 608), calldataload(add(pC, 32)))
+
+                // delta2
+                mstore(add(_pPairing, 640), deltax1)
+                mstore(add(_pPairing, 672), deltax2)
+                mstore(add(_pPairing, 704), deltay1)
+                mstore(add(_pPairing, 736), deltay2)
+
+                let success := staticcall(
+                    sub(gas(), 2000),
+                    8,
+                    _pPairing,
+                    768,
+                    _pPairing,
+                    0x20
+                )
+
+                isOk := and(success, mload(_pPairing))
+            }
+
+            let pMem := mload(0x40)
+            mstore(0x40, add(pMem, pLastMem))
+
+            // Validate that all evaluations ∈ F
+{{range $i, $p := .IC}}{{if $i}}
+            checkField(calldataload(add(_pubSignals, {{offset $i}})))
+{{end}}{{end}}
+            // Validate all evaluations
+            let isValid := checkPairing(_pA, _pB, _pC, _pubSignals, pMem)
+
+            mstore(0, isValid)
+            return(0, 0x20)
+        }
+    }
+}
+`))
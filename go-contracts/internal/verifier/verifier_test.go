@@ -0,0 +1,169 @@
+package verifier
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-contracts/internal/types"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	solc "github.com/lmittmann/go-solc"
+)
+
+// pinacleVerifier is the snarkjs verifier of the Pinacle circuit, its key in testdata/verification_key.json
+const pinacleVerifier = "../../../contracts/Verifier/Verifier.sol"
+
+func TestRenderGolden(t *testing.T) {
+	vk, err := Load("testdata/verification_key.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := Render(vk, Options{Name: DefaultName, Pragma: DefaultPragma})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err := os.ReadFile(pinacleVerifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(source, golden) {
+		got, want := strings.Split(string(source), "\n"), strings.Split(string(golden), "\n")
+		for i := range min(len(got), len(want)) {
+			if got[i] != want[i] {
+				t.Fatalf("line %d: got %q, want %q", i+1, got[i], want[i])
+			}
+		}
+		t.Fatalf("got %d lines, want %d", len(got), len(want))
+	}
+}
+
+// testKey returns a verification key of nPublic signals made of multiples of the generators.
+func testKey(nPublic int) *types.VerificationKey {
+	g1 := func(k int64) []string {
+		var p bn254.G1Affine
+		p.ScalarMultiplicationBase(big.NewInt(k))
+		return []string{p.X.String(), p.Y.String(), "1"}
+	}
+	g2 := func(k int64) [][]string {
+		var p bn254.G2Affine
+		p.ScalarMultiplicationBase(big.NewInt(k))
+		return [][]string{{p.X.A0.String(), p.X.A1.String()}, {p.Y.A0.String(), p.Y.A1.String()}, {"1", "0"}}
+	}
+
+	vk := &types.VerificationKey{Protocol: "groth16", Curve: "bn128", NPublic: nPublic, Alpha1: g1(2), Beta2: g2(3), Gamma2: g2(5), Delta2: g2(7)}
+	for i := 0; i <= nPublic; i++ {
+		vk.IC = append(vk.IC, g1(int64(11+i)))
+	}
+	return vk
+}
+
+func TestRenderPublicSignals(t *testing.T) {
+	source, err := Render(testKey(3), Options{Name: "Verifier3", Pragma: "^0.8.17"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"contract Verifier3 {", "pragma solidity ^0.8.17;", "uint[3] calldata _pubSignals", "uint256 constant IC3x", "calldataload(add(pubSignals, 64))", "checkField(calldataload(add(_pubSignals, 64)))"} {
+		if !bytes.Contains(source, []byte(expected)) {
+			t.Fatalf("%q not rendered", expected)
+		}
+	}
+	if bytes.Contains(source, []byte("IC4x")) || bytes.Count(source, []byte("g1_mulAccC(_pVk")) != 3 {
+		t.Fatal("unexpected number of IC points")
+	}
+}
+
+func TestRenderInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(vk *types.VerificationKey, opts *Options)
+		err    error
+	}{
+		{"name", func(vk *types.VerificationKey, opts *Options) { opts.Name = "1Verifier" }, ErrInvalidName},
+		{"pragma", func(vk *types.VerificationKey, opts *Options) { opts.Pragma = "" }, ErrEmptyPragma},
+		{"IC length", func(vk *types.VerificationKey, opts *Options) { vk.IC = vk.IC[:2] }, ErrICLength},
+		{"projective", func(vk *types.VerificationKey, opts *Options) { vk.Alpha1[2] = "2" }, ErrNotAffine},
+		{"not in field", func(vk *types.VerificationKey, opts *Options) {
+			vk.IC[1][0] = "21888242871839275222246405745257275088696311157297823662689037894645226208583"
+		}, ErrNotInField},
+		{"not on curve", func(vk *types.VerificationKey, opts *Options) { vk.Alpha1[1] = "1" }, ErrNotOnCurve},
+		{"G2 not on curve", func(vk *types.VerificationKey, opts *Options) { vk.Delta2[0][0] = "1" }, ErrNotOnCurve},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vk, opts := testKey(2), Options{Name: DefaultName, Pragma: DefaultPragma}
+			tt.modify(vk, &opts)
+			if _, err := Render(vk, opts); !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"not json", "{"},
+		{"protocol", `{"protocol": "plonk", "curve": "bn128", "nPublic": 1}`},
+		{"IC length", `{"protocol": "groth16", "curve": "bn128", "nPublic": 2, "vk_alpha_1": ["1", "2", "1"],
+			"vk_beta_2": [["1", "2"], ["1", "2"], ["1", "0"]], "vk_gamma_2": [["1", "2"], ["1", "2"], ["1", "0"]],
+			"vk_delta_2": [["1", "2"], ["1", "2"], ["1", "0"]], "IC": [["1", "2", "1"], ["1", "2", "1"]]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".json")
+			if err := os.WriteFile(path, []byte(tt.json), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Fatal("invalid verification key loaded")
+			}
+		})
+	}
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Fatal("missing verification key loaded")
+	}
+}
+
+// TestRenderCompiles compiles rendered verifiers with the solc version of the contracts. It
+// skips when solc cannot be downloaded.
+func TestRenderCompiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiling with solc")
+	}
+	dir := t.TempDir()
+	for _, nPublic := range []int{1, 2, 5} {
+		opts := Options{Name: fmt.Sprintf("Verifier%d", nPublic), Pragma: DefaultPragma}
+		source, err := Render(testKey(nPublic), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, opts.Name+".sol"), source, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	compiler := solc.New("0.8.17")
+	for _, nPublic := range []int{1, 2, 5} {
+		name := fmt.Sprintf("Verifier%d", nPublic)
+		contract, err := compiler.Compile(dir, name, solc.WithOptimizer(&solc.Optimizer{Enabled: true, Runs: 50}), solc.WithViaIR(true))
+		if err != nil && strings.Contains(err.Error(), "failed to download") {
+			t.Skipf("solc unavailable: %v", err)
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(contract.Runtime) == 0 {
+			t.Fatalf("%s: empty bytecode", name)
+		}
+	}
+}
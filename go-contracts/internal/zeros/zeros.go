@@ -0,0 +1,165 @@
+package zeros
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"text/template"
+
+	"go-contracts/internal/mimc"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	MaxLevels   = 32        // MAXIMUM_ALLOWED_LEVELS in MerkleTreeWithHistory, indices are uint32
+	DefaultSeed = "tornado" // seed of ZERO_VALUE in MerkleTreeWithHistory
+
+	FormatLibrary   = "library"
+	FormatConstants = "constants"
+)
+
+var (
+	ErrInvalidLevels = fmt.Errorf("levels should be (0, %d]", MaxLevels)
+	ErrEmptySeed     = errors.New("seed cannot be empty")
+	ErrInvalidFormat = fmt.Errorf("format should be %s or %s", FormatLibrary, FormatConstants)
+)
+
+// Options selects the tree and the hash the tables are generated for.
+type Options struct {
+	Levels     int
+	Seed       string // ZERO_VALUE = keccak256(Seed) % FIELD_SIZE
+	MiMCSeed   string
+	MiMCRounds int
+	Name       string // library name, or constants prefix
+	Format     string
+}
+
+// Tables are the values initZeros and initPowers hard-code.
+type Tables struct {
+	Options
+	FieldSize *big.Int
+	ZeroValue *big.Int
+	Zeros     []*big.Int // zeros[0] = ZeroValue, zeros[i] = hashLeftRight(zeros[i-1], zeros[i-1])
+	Powers    []uint32   // powers[i-1] = 2^i, 2^32 saturating to 2^32 - 1 like pow2Values[32]
+}
+
+// Compute derives the zero chain and the powers of two of a tree of opts.Levels levels.
+func Compute(opts Options) (*Tables, error) {
+	if opts.Levels <= 0 || opts.Levels > MaxLevels {
+		return nil, ErrInvalidLevels
+	}
+	if len(opts.Seed) == 0 {
+		return nil, ErrEmptySeed
+	}
+
+	sponge, err := mimc.New(opts.MiMCSeed, opts.MiMCRounds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize MiMC: %w", err)
+	}
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(opts.Seed))
+	zeroValue := new(big.Int).SetBytes(hash.Sum(nil))
+	zeroValue.Mod(zeroValue, fr.Modulus())
+
+	tables := &Tables{
+		Options:   opts,
+		FieldSize: fr.Modulus(),
+		ZeroValue: zeroValue,
+	}
+
+	current := zeroValue
+	for i := 0; i < opts.Levels; i++ {
+		tables.Zeros = append(tables.Zeros, current)
+		current = sponge.HashLeftRight(current, current)
+	}
+
+	for i := 1; i <= opts.Levels; i++ {
+		if i == 32 {
+			tables.Powers = append(tables.Powers, 1<<32-1)
+			continue
+		}
+		tables.Powers = append(tables.Powers, 1<<i)
+	}
+
+	return tables, nil
+}
+
+// Render writes the tables as Solidity source in the selected format.
+func (t *Tables) Render() ([]byte, error) {
+	var tmpl *template.Template
+	switch t.Format {
+	case FormatLibrary:
+		tmpl = libraryTemplate
+	case FormatConstants:
+		tmpl = constantsTemplate
+	default:
+		return nil, ErrInvalidFormat
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, t); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", t.Format, err)
+	}
+	return buf.Bytes(), nil
+}
+
+var funcs = template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}
+
+const header = `// SPDX-License-Identifier: MIT
+// Code generated by contract-cli gen-zeros. DO NOT EDIT.
+// levels={{.Levels}} seed="{{.Seed}}" mimc seed="{{.MiMCSeed}}" rounds={{.MiMCRounds}}
+pragma solidity ^0.8.17;
+`
+
+var libraryTemplate = template.Must(template.New(FormatLibrary).Funcs(funcs).Parse(header + `
+// Zero (empty) elements and powers of 2 of a MiMC MerkleTreeWithHistory
+library {{.Name}} {
+    uint256 internal constant FIELD_SIZE =
+        {{.FieldSize}};
+
+    // keccak256("{{.Seed}}") % FIELD_SIZE
+    uint256 internal constant ZERO_VALUE =
+        {{.ZeroValue}};
+
+    uint32 internal constant LEVELS = {{.Levels}};
+
+    function zeros(uint32 i) internal pure returns (uint256) {
+{{- range $i, $zero := .Zeros}}
+        if (i == {{$i}}) return {{$zero}};
+{{- end}}
+        revert("Index out of bounds");
+    }
+
+    function pow2(uint32 i) internal pure returns (uint32) {
+{{- range $i, $power := .Powers}}
+        if (i == {{inc $i}}) return {{$power}};
+{{- end}}
+        revert("Index out of bounds");
+    }
+}
+`))
+
+var constantsTemplate = template.Must(template.New(FormatConstants).Funcs(funcs).Parse(header + `
+// Zero (empty) elements and powers of 2 of a MiMC MerkleTreeWithHistory
+uint256 constant {{.Name}}_FIELD_SIZE =
+    {{.FieldSize}};
+
+// keccak256("{{.Seed}}") % FIELD_SIZE
+uint256 constant {{.Name}}_ZERO_VALUE =
+    {{.ZeroValue}};
+
+uint32 constant {{.Name}}_LEVELS = {{.Levels}};
+{{range $i, $zero := .Zeros}}
+uint256 constant {{$.Name}}_ZERO_{{$i}} =
+    {{$zero}};
+{{- end}}
+{{range $i, $power := .Powers}}
+uint32 constant {{$.Name}}_POW2_{{inc $i}} = {{$power}};
+{{- end}}
+`))
@@ -0,0 +1,146 @@
+package zeros
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-contracts/internal/mimc"
+)
+
+// merkleTree hard-codes the tables of the default options in initZeros and initPowers
+const merkleTree = "../../../contracts/MerkleTree/MerkleTreeWithHistory.sol"
+
+var (
+	fieldSize  = regexp.MustCompile(`FIELD_SIZE =\s*(\d+);`)
+	zeroValue  = regexp.MustCompile(`ZERO_VALUE =\s*(\d+);`)
+	zeroValues = regexp.MustCompile(`zeroValues\[\s*(\d+)\s*\] = (\d+);`)
+	pow2Values = regexp.MustCompile(`pow2Values\[\s*(\d+)\s*\] = (\d+);`)
+)
+
+func defaultOptions() Options {
+	return Options{
+		Levels:     MaxLevels,
+		Seed:       DefaultSeed,
+		MiMCSeed:   mimc.Seed,
+		MiMCRounds: mimc.NbRounds,
+		Name:       "MerkleTreeZeros",
+		Format:     FormatLibrary,
+	}
+}
+
+func TestComputeMerkleTreeWithHistory(t *testing.T) {
+	source, err := os.ReadFile(merkleTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables, err := Compute(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name string
+		re   *regexp.Regexp
+		want *big.Int
+	}{{"FIELD_SIZE", fieldSize, tables.FieldSize}, {"ZERO_VALUE", zeroValue, tables.ZeroValue}} {
+		match := c.re.FindSubmatch(source)
+		if match == nil {
+			t.Fatalf("%s not found in %s", c.name, merkleTree)
+		}
+		if got := string(match[1]); got != c.want.String() {
+			t.Fatalf("%s: contract has %s, generated %s", c.name, got, c.want)
+		}
+	}
+
+	zeros := zeroValues.FindAllSubmatch(source, -1)
+	if len(zeros) != len(tables.Zeros) {
+		t.Fatalf("contract has %d zeros, generated %d", len(zeros), len(tables.Zeros))
+	}
+	for _, match := range zeros {
+		i, _ := strconv.Atoi(string(match[1]))
+		if got := string(match[2]); i >= len(tables.Zeros) || got != tables.Zeros[i].String() {
+			t.Fatalf("zeros[%d]: contract has %s, generated %v", i, got, tables.Zeros[min(i, len(tables.Zeros)-1)])
+		}
+	}
+
+	powers := pow2Values.FindAllSubmatch(source, -1)
+	if len(powers) != len(tables.Powers) {
+		t.Fatalf("contract has %d powers of 2, generated %d", len(powers), len(tables.Powers))
+	}
+	for _, match := range powers {
+		i, _ := strconv.Atoi(string(match[1]))
+		if got := string(match[2]); i < 1 || i > len(tables.Powers) || got != strconv.FormatUint(uint64(tables.Powers[i-1]), 10) {
+			t.Fatalf("pow2Values[%d]: contract has %s", i, got)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	opts := defaultOptions()
+	opts.Levels = 3
+	tables, err := Compute(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	library, err := tables.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"library MerkleTreeZeros {",
+		"uint32 internal constant LEVELS = 3;",
+		"if (i == 2) return " + tables.Zeros[2].String() + ";",
+		"if (i == 3) return 8;",
+	} {
+		if !strings.Contains(string(library), want) {
+			t.Errorf("library has no %q:\n%s", want, library)
+		}
+	}
+
+	tables.Format, tables.Name = FormatConstants, "Tree"
+	constants, err := tables.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"uint256 constant Tree_ZERO_VALUE =\n    " + tables.ZeroValue.String() + ";",
+		"uint256 constant Tree_ZERO_2 =\n    " + tables.Zeros[2].String() + ";",
+		"uint32 constant Tree_POW2_3 = 8;",
+	} {
+		if !strings.Contains(string(constants), want) {
+			t.Errorf("constants have no %q:\n%s", want, constants)
+		}
+	}
+
+	tables.Format = "json"
+	if _, err := tables.Render(); !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("got %v, want %v", err, ErrInvalidFormat)
+	}
+}
+
+func TestComputeInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		modify func(*Options)
+		target error
+	}{
+		{"no levels", func(o *Options) { o.Levels = 0 }, ErrInvalidLevels},
+		{"too many levels", func(o *Options) { o.Levels = MaxLevels + 1 }, ErrInvalidLevels},
+		{"empty seed", func(o *Options) { o.Seed = "" }, ErrEmptySeed},
+		{"empty MiMC seed", func(o *Options) { o.MiMCSeed = "" }, mimc.ErrEmptySeed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := defaultOptions()
+			tc.modify(&opts)
+			if _, err := Compute(opts); !errors.Is(err, tc.target) {
+				t.Fatalf("got %v, want %v", err, tc.target)
+			}
+		})
+	}
+}
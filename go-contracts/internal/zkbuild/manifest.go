@@ -0,0 +1,61 @@
+package zkbuild
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go-contracts/internal/directory"
+)
+
+// ManifestFilename is written in the build directory of the circuit.
+const ManifestFilename = "manifest.json"
+
+// Artifact is a file of the build with its SHA-256.
+type Artifact struct {
+	Path   string `json:"path"` // relative to the build directory
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Manifest records how a circuit was built and the checksums of what was produced.
+type Manifest struct {
+	Circuit       string              `json:"circuit"`
+	NPublic       int                 `json:"nPublic"`
+	Power         int                 `json:"power"`
+	Contributions int                 `json:"contributions"`
+	Beacon        string              `json:"beacon"`
+	Tools         map[string]string   `json:"tools"`
+	Artifacts     map[string]Artifact `json:"artifacts"` // r1cs, sym, wasm, zkey, vkey, ptau
+	CreatedAt     time.Time           `json:"createdAt"`
+}
+
+// addArtifact hashes the file at path and records it under kind.
+func (m *Manifest) addArtifact(kind, buildDir, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(buildDir, path)
+	if err != nil {
+		rel = path
+	}
+	m.Artifacts[kind] = Artifact{Path: filepath.ToSlash(rel), SHA256: hex.EncodeToString(h.Sum(nil)), Size: size}
+	return nil
+}
+
+func (m *Manifest) save(buildDir string) (string, error) {
+	path := filepath.Join(buildDir, ManifestFilename)
+	return path, directory.SaveToFile(path, m)
+}
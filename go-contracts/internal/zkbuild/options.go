@@ -0,0 +1,87 @@
+package zkbuild
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	MinPower = 8  // smallest Hermez powers of tau file
+	MaxPower = 28 // largest Hermez powers of tau file
+)
+
+var (
+	ErrCircuitNotFound      = errors.New("circuit file does not exist")
+	ErrCircuitExtension     = errors.New("circuit file must have the .circom extension")
+	ErrInvalidPower         = fmt.Errorf("power of tau should be [%d, %d]", MinPower, MaxPower)
+	ErrInvalidContributions = errors.New("at least one phase-2 contribution is required")
+	ErrInvalidIterations    = errors.New("beacon iterations should be [0, 63]")
+	ErrBuildDirContains     = errors.New("build directory can be deleted by a build and cannot contain the circuit")
+	ErrBuildDirNotEmpty     = errors.New("build directory is not empty, pass --clean to delete it")
+)
+
+// Options configure a circuit build, the defaults reproduce setup.sh.
+type Options struct {
+	Circuit  string   // .circom file
+	Includes []string // circom -l library paths
+	BuildDir string   // empty builds into <circuit dir>/build/<name>
+	Clean    bool     // delete a non-empty BuildDir instead of failing
+
+	Power       int    // 2^Power constraints
+	Ptau        string // existing .ptau, empty downloads the Hermez file into PtauDir
+	PtauDir     string
+	PtauBlake2b string // expected blake2b-512 of the ptau (snarkjs README), empty verifies with snarkjs
+
+	Contributions    int
+	Beacon           string // hex, empty draws a random one
+	BeaconIterations int    // log2 of the beacon hash iterations
+
+	DeployerEnv string // .env of the deployer, artifacts are copied to its ZK_* paths; empty skips the copy
+
+	CircomBin  string
+	SnarkjsBin string
+}
+
+// Name is the circuit name, the .circom file without extension.
+func (o *Options) Name() string {
+	return strings.TrimSuffix(filepath.Base(o.Circuit), ".circom")
+}
+
+func (o *Options) validate() error {
+	if filepath.Ext(o.Circuit) != ".circom" {
+		return ErrCircuitExtension
+	}
+	if _, err := os.Stat(o.Circuit); err != nil {
+		return fmt.Errorf("%w: %s", ErrCircuitNotFound, o.Circuit)
+	}
+	if o.Power < MinPower || o.Power > MaxPower {
+		return ErrInvalidPower
+	}
+	if o.Contributions < 1 {
+		return ErrInvalidContributions
+	}
+	if o.BeaconIterations < 0 || o.BeaconIterations > 63 {
+		return ErrInvalidIterations
+	}
+	if o.BuildDir == "" {
+		o.BuildDir = filepath.Join(filepath.Dir(o.Circuit), "build", o.Name())
+	}
+
+	// Tools run from the build directories, every path handed to them is absolute
+	buildDir, err := filepath.Abs(o.BuildDir)
+	if err != nil {
+		return err
+	}
+	circuit, err := filepath.Abs(o.Circuit)
+	if err != nil {
+		return err
+	}
+	if rel, err := filepath.Rel(buildDir, circuit); err == nil && !strings.HasPrefix(rel, "..") {
+		return ErrBuildDirContains
+	}
+	o.BuildDir, o.Circuit = buildDir, circuit
+	return nil
+}
@@ -0,0 +1,270 @@
+package zkbuild
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"go-contracts/internal/directory"
+	"go-contracts/internal/logger"
+	"go-contracts/internal/tool"
+
+	"github.com/spf13/viper"
+)
+
+// Deployer .env keys of the artifacts
+var deployerKeys = map[string]string{
+	"wasm": "ZK_WASM_FILENAME",
+	"zkey": "ZK_ZKEY_FILENAME",
+	"vkey": "ZK_VERIFICATION_KEY_FILENAME",
+}
+
+var ErrInvalidBeacon = errors.New("beacon should be a hex string")
+
+// Run builds the circuit like setup.sh: circom compile, powers of tau, Groth16 phase 2 with
+// opts.Contributions contributions and a beacon, verification key export. It then copies the
+// wasm, zkey and verification key to the deployer paths and writes the manifest.
+func Run(ctx context.Context, opts Options) (*Manifest, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	name := opts.Name()
+	keysDir := filepath.Join(opts.BuildDir, "keys")
+	r1cs := filepath.Join(opts.BuildDir, name+".r1cs")
+	sym := filepath.Join(opts.BuildDir, name+".sym")
+	wasm := filepath.Join(opts.BuildDir, name+"_js", name+".wasm")
+	zkey := filepath.Join(keysDir, name+"_final.zkey")
+	vkey := filepath.Join(keysDir, "verification_key.json")
+
+	beacon := opts.Beacon
+	if beacon == "" {
+		beacon = randomHex()
+	} else if _, err := hex.DecodeString(beacon); err != nil {
+		return nil, ErrInvalidBeacon
+	}
+
+	manifest := &Manifest{
+		Circuit:       name,
+		Power:         opts.Power,
+		Contributions: opts.Contributions,
+		Beacon:        beacon,
+		Tools: map[string]string{
+			"circom":  tool.Version(ctx, opts.CircomBin, "--version"),
+			"snarkjs": tool.Version(ctx, opts.SnarkjsBin),
+		},
+		Artifacts: map[string]Artifact{},
+	}
+	logger.Logger.Info().Str("circuit", name).Str("build", opts.BuildDir).Interface("tools", manifest.Tools).Msg("🔨 Building circuit")
+
+	// Compile from scratch like setup.sh, an earlier build is only deleted when asked to
+	if err := prepareBuildDir(opts); err != nil {
+		return nil, err
+	}
+	if err := directory.CreateDirIfNotExists(keysDir); err != nil {
+		return nil, err
+	}
+	args := []string{opts.Circuit, "--r1cs", "--wasm", "--sym", "-o", "."}
+	for _, include := range opts.Includes {
+		abs, err := filepath.Abs(include)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-l", abs)
+	}
+	if _, err := tool.Run(ctx, "compile", opts.BuildDir, opts.CircomBin, args...); err != nil {
+		return nil, err
+	}
+	if _, err := tool.Run(ctx, "compile", opts.BuildDir, opts.SnarkjsBin, "r1cs", "info", name+".r1cs"); err != nil {
+		return nil, err
+	}
+
+	// Powers of tau
+	ptau, err := preparePtau(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+	if ptau, err = filepath.Abs(ptau); err != nil {
+		return nil, err
+	}
+
+	// Phase 2
+	current := fmt.Sprintf("%s_%04d.zkey", name, 0)
+	intermediates := []string{current}
+	if _, err := tool.Run(ctx, "setup", keysDir, opts.SnarkjsBin, "groth16", "setup", r1cs, ptau, current); err != nil {
+		return nil, err
+	}
+	for i := 1; i <= opts.Contributions; i++ {
+		next := fmt.Sprintf("%s_%04d.zkey", name, i)
+		contribution := fmt.Sprintf("--name=Contribution %d", i)
+		// The entropy goes through stdin, the arguments are logged
+		if _, err := tool.RunInput(ctx, "contribute", keysDir, opts.SnarkjsBin, randomHex()+"\n", "zkey", "contribute", current, next, contribution); err != nil {
+			return nil, err
+		}
+		current = next
+		intermediates = append(intermediates, current)
+	}
+	if _, err := tool.Run(ctx, "beacon", keysDir, opts.SnarkjsBin, "zkey", "beacon", current, filepath.Base(zkey), beacon, strconv.Itoa(opts.BeaconIterations), "-n=Final Beacon phase2"); err != nil {
+		return nil, err
+	}
+	if _, err := tool.Run(ctx, "verify", keysDir, opts.SnarkjsBin, "zkey", "verify", r1cs, ptau, filepath.Base(zkey)); err != nil {
+		return nil, err
+	}
+	for _, intermediate := range intermediates {
+		if err := os.Remove(filepath.Join(keysDir, intermediate)); err != nil {
+			logger.Logger.Warn().Err(err).Str("zkey", intermediate).Msg("Failed to remove intermediate zkey")
+		}
+	}
+
+	// Verification key
+	if _, err := tool.Run(ctx, "export", keysDir, opts.SnarkjsBin, "zkey", "export", "verificationkey", filepath.Base(zkey), filepath.Base(vkey)); err != nil {
+		return nil, err
+	}
+	if manifest.NPublic, err = readNPublic(vkey); err != nil {
+		return nil, err
+	}
+
+	for kind, path := range map[string]string{"r1cs": r1cs, "sym": sym, "wasm": wasm, "zkey": zkey, "vkey": vkey, "ptau": ptau} {
+		if err := manifest.addArtifact(kind, opts.BuildDir, path); err != nil {
+			return nil, fmt.Errorf("manifest: %s: %w", kind, err)
+		}
+	}
+
+	// Deployer paths
+	if opts.DeployerEnv != "" {
+		if err := CopyToDeployer(opts.DeployerEnv, map[string]string{"wasm": wasm, "zkey": zkey, "vkey": vkey}); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest.CreatedAt = time.Now().UTC()
+	path, err := manifest.save(opts.BuildDir)
+	if err != nil {
+		return nil, err
+	}
+	logger.Logger.Info().Str("manifest", path).Int("nPublic", manifest.NPublic).Msg("✅ Circuit built")
+	return manifest, nil
+}
+
+// prepareBuildDir deletes the build directory when opts.Clean is set, and otherwise refuses to
+// build into one that is not empty.
+func prepareBuildDir(opts Options) error {
+	if opts.Clean {
+		if err := os.RemoveAll(opts.BuildDir); err != nil {
+			return err
+		}
+		logger.Logger.Info().Str("build", opts.BuildDir).Msg("⚠️  Deleted earlier build")
+		return nil
+	}
+
+	entries, err := os.ReadDir(opts.BuildDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%w: %s", ErrBuildDirNotEmpty, opts.BuildDir)
+	}
+	return nil
+}
+
+// CopyToDeployer copies the artifacts (wasm, zkey, vkey) to the paths of the deployer .env, relative
+// to its directory.
+func CopyToDeployer(envPath string, artifacts map[string]string) error {
+	env := viper.New()
+	env.SetConfigFile(envPath)
+	env.SetConfigType("env")
+	if err := env.ReadInConfig(); err != nil {
+		return fmt.Errorf("read deployer env: %w", err)
+	}
+
+	for kind, src := range artifacts {
+		dst := env.GetString(deployerKeys[kind])
+		if dst == "" {
+			logger.Logger.Warn().Str("step", "copy").Str("key", deployerKeys[kind]).Msg("Not set in the deployer env, skipped")
+			continue
+		}
+		if !filepath.IsAbs(dst) {
+			dst = filepath.Join(filepath.Dir(envPath), dst)
+		}
+
+		if same, err := samePath(src, dst); err != nil {
+			return err
+		} else if same {
+			logger.Logger.Info().Str("step", "copy").Str(kind, dst).Msg("Already in place")
+			continue
+		}
+		if err := copyFile(src, dst); err != nil {
+			return fmt.Errorf("copy %s to %s: %w", kind, dst, err)
+		}
+		logger.Logger.Info().Str("step", "copy").Str(kind, dst).Msg("✅ Copied")
+	}
+	return nil
+}
+
+func samePath(a, b string) (bool, error) {
+	absA, err := filepath.Abs(a)
+	if err != nil {
+		return false, err
+	}
+	absB, err := filepath.Abs(b)
+	if err != nil {
+		return false, err
+	}
+	return absA == absB, nil
+}
+
+func copyFile(src, dst string) error {
+	if err := directory.CreateDirIfNotExists(filepath.Dir(dst)); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func readNPublic(vkey string) (int, error) {
+	data, err := os.ReadFile(vkey)
+	if err != nil {
+		return 0, err
+	}
+	var key struct {
+		NPublic int `json:"nPublic"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return 0, fmt.Errorf("parse verification key: %w", err)
+	}
+	return key.NPublic, nil
+}
+
+// randomHex returns 32 random bytes in hex, used as contribution entropy and default beacon.
+func randomHex() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,259 @@
+package zkbuild
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-contracts/internal/tool/tooltest"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestMain(m *testing.M) {
+	tooltest.Main()
+	os.Exit(m.Run())
+}
+
+// testOptions returns the options of a build of a fake circuit with the fake tools, the powers
+// of tau already in PtauDir.
+func testOptions(t *testing.T, tools *tooltest.Tools) Options {
+	t.Helper()
+	dir := t.TempDir()
+	circuit := filepath.Join(dir, "circuits", "Test.circom")
+	ptauDir := filepath.Join(dir, "powersOfTau")
+	env := filepath.Join(dir, "deployer", ".env")
+	for path, content := range map[string]string{
+		circuit:                                 "pragma circom 2.1.9;",
+		filepath.Join(ptauDir, ptauFilename(8)): "ptau",
+		env:                                     "ZK_WASM_FILENAME=zk/Test.wasm\nZK_ZKEY_FILENAME=zk/Test_final.zkey\nZK_VERIFICATION_KEY_FILENAME=zk/verification_key.json\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return Options{
+		Circuit:          circuit,
+		Power:            8,
+		PtauDir:          ptauDir,
+		Contributions:    2,
+		BeaconIterations: 4,
+		DeployerEnv:      env,
+		CircomBin:        tools.Circom,
+		SnarkjsBin:       tools.Snarkjs,
+	}
+}
+
+// ran returns the invocations of a snarkjs command.
+func ran(t *testing.T, tools *tooltest.Tools, command string) []tooltest.Invocation {
+	t.Helper()
+	var found []tooltest.Invocation
+	for _, invocation := range tools.Invocations(t) {
+		if strings.HasPrefix(strings.Join(invocation.Args, " "), command) {
+			found = append(found, invocation)
+		}
+	}
+	return found
+}
+
+func sha256Hex(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRun(t *testing.T) {
+	tools := tooltest.Install(t)
+	logs := tooltest.CaptureLogs(t)
+	opts := testOptions(t, tools)
+
+	manifest, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buildDir := filepath.Join(filepath.Dir(opts.Circuit), "build", "Test")
+	if manifest.Circuit != "Test" || manifest.NPublic != tooltest.NPublic || manifest.Contributions != 2 || len(manifest.Beacon) != 64 {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+	if manifest.Tools["circom"] != "circom compiler 2.1.9" || manifest.Tools["snarkjs"] != "snarkjs@0.7.4" {
+		t.Fatalf("unexpected tools %v", manifest.Tools)
+	}
+
+	expected := map[string]string{
+		"r1cs": "Test.r1cs",
+		"sym":  "Test.sym",
+		"wasm": "Test_js/Test.wasm",
+		"zkey": "keys/Test_final.zkey",
+		"vkey": "keys/verification_key.json",
+	}
+	for kind, path := range expected {
+		artifact := manifest.Artifacts[kind]
+		if artifact.Path != path || artifact.SHA256 != sha256Hex(t, filepath.Join(buildDir, path)) {
+			t.Fatalf("%s: got %+v, want %s", kind, artifact, path)
+		}
+	}
+	if ptau := manifest.Artifacts["ptau"]; ptau.SHA256 != sha256Hex(t, filepath.Join(opts.PtauDir, ptauFilename(8))) {
+		t.Fatalf("unexpected ptau %+v", ptau)
+	}
+	if _, err := os.Stat(filepath.Join(buildDir, ManifestFilename)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the final keys are kept, and copied to the deployer
+	keys, err := os.ReadDir(filepath.Join(buildDir, "keys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("intermediate zkeys left: %v", keys)
+	}
+	for kind, path := range map[string]string{"wasm": "Test.wasm", "zkey": "Test_final.zkey", "vkey": "verification_key.json"} {
+		if sha := sha256Hex(t, filepath.Join(filepath.Dir(opts.DeployerEnv), "zk", path)); sha != manifest.Artifacts[kind].SHA256 {
+			t.Fatalf("%s copied to the deployer differs", kind)
+		}
+	}
+
+	// Without a checksum snarkjs verifies the ptau
+	if len(ran(t, tools, "powersoftau verify")) != 1 {
+		t.Fatal("ptau not verified")
+	}
+
+	// The contribution entropy is random, passed through stdin and never logged
+	contributions := ran(t, tools, "zkey contribute")
+	if len(contributions) != 2 {
+		t.Fatalf("got %d contributions, want 2", len(contributions))
+	}
+	for _, contribution := range contributions {
+		entropy := strings.TrimSuffix(contribution.Stdin, "\n")
+		if len(entropy) != 64 || slices.ContainsFunc(contribution.Args, func(arg string) bool { return strings.HasPrefix(arg, "-e") }) {
+			t.Fatalf("unexpected contribution %+v", contribution)
+		}
+		if strings.Contains(logs.String(), entropy) {
+			t.Fatalf("entropy logged: %s", logs)
+		}
+	}
+	if contributions[0].Stdin == contributions[1].Stdin {
+		t.Fatal("entropy reused")
+	}
+}
+
+func TestRunPtauChecksum(t *testing.T) {
+	sum := blake2b.Sum512([]byte("ptau"))
+
+	tests := []struct {
+		name     string
+		checksum string
+		err      error
+	}{
+		{"match", "0x" + strings.ToUpper(hex.EncodeToString(sum[:])), nil},
+		{"mismatch", strings.Repeat("00", 64), ErrPtauChecksum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools := tooltest.Install(t)
+			opts := testOptions(t, tools)
+			opts.PtauBlake2b = tt.checksum
+
+			if _, err := Run(context.Background(), opts); !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if len(ran(t, tools, "powersoftau verify")) != 0 {
+				t.Fatal("ptau verified by snarkjs despite the checksum")
+			}
+		})
+	}
+}
+
+func TestRunBuildDir(t *testing.T) {
+	tools := tooltest.Install(t)
+	opts := testOptions(t, tools)
+	opts.BuildDir = filepath.Join(t.TempDir(), "build")
+	stale := filepath.Join(opts.BuildDir, "stale.zkey")
+	if err := os.MkdirAll(opts.BuildDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// An empty directory is used as is
+	if _, err := Run(context.Background(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// An earlier build is kept unless asked to
+	if err := os.WriteFile(stale, []byte("zkey"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Run(context.Background(), opts); !errors.Is(err, ErrBuildDirNotEmpty) {
+		t.Fatalf("got %v, want %v", err, ErrBuildDirNotEmpty)
+	}
+	if _, err := os.Stat(stale); err != nil {
+		t.Fatalf("build directory modified: %v", err)
+	}
+
+	opts.Clean = true
+	if _, err := Run(context.Background(), opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("earlier build not deleted: %v", err)
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	tools := tooltest.Install(t)
+	opts := testOptions(t, tools)
+	if err := os.WriteFile(opts.Circuit, []byte(tooltest.Failing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Run(context.Background(), opts); err == nil || !strings.Contains(err.Error(), "compile: circom failed") {
+		t.Fatalf("got %v, want the compile failure", err)
+	}
+	if len(ran(t, tools, "groth16 setup")) != 0 {
+		t.Fatal("setup run after a failed compilation")
+	}
+}
+
+func TestRunInvalidOptions(t *testing.T) {
+	tools := tooltest.Install(t)
+
+	tests := []struct {
+		name   string
+		modify func(opts *Options)
+		err    error
+	}{
+		{"extension", func(opts *Options) { opts.Circuit = strings.TrimSuffix(opts.Circuit, ".circom") + ".circuit" }, ErrCircuitExtension},
+		{"missing circuit", func(opts *Options) { opts.Circuit = filepath.Join(t.TempDir(), "Missing.circom") }, ErrCircuitNotFound},
+		{"power too small", func(opts *Options) { opts.Power = MinPower - 1 }, ErrInvalidPower},
+		{"power too large", func(opts *Options) { opts.Power = MaxPower + 1 }, ErrInvalidPower},
+		{"no contribution", func(opts *Options) { opts.Contributions = 0 }, ErrInvalidContributions},
+		{"iterations", func(opts *Options) { opts.BeaconIterations = 64 }, ErrInvalidIterations},
+		{"build dir contains the circuit", func(opts *Options) { opts.BuildDir = filepath.Dir(opts.Circuit) }, ErrBuildDirContains},
+		{"beacon", func(opts *Options) { opts.Beacon = "not hex" }, ErrInvalidBeacon},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := testOptions(t, tools)
+			tt.modify(&opts)
+			if _, err := Run(context.Background(), opts); !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+	if invocations := ran(t, tools, "zkey"); len(invocations) != 0 {
+		t.Fatalf("snarkjs run with invalid options: %+v", invocations)
+	}
+}
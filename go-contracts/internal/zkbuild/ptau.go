@@ -0,0 +1,117 @@
+package zkbuild
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-contracts/internal/directory"
+	"go-contracts/internal/logger"
+	"go-contracts/internal/tool"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ptauURL is the Hermez powers of tau (54 contributions and a beacon) listed in the snarkjs README.
+const ptauURL = "https://storage.googleapis.com/zkevm/ptau/%s"
+
+var ErrPtauChecksum = errors.New("ptau blake2b-512 mismatch")
+
+func ptauFilename(power int) string {
+	return fmt.Sprintf("powersOfTau28_hez_final_%02d.ptau", power)
+}
+
+// preparePtau returns a verified powers of tau file: the given one, or the Hermez file of
+// the power, downloaded once into opts.PtauDir.
+func preparePtau(ctx context.Context, opts *Options) (string, error) {
+	path := opts.Ptau
+	if path == "" {
+		if err := directory.CreateDirIfNotExists(opts.PtauDir); err != nil {
+			return "", err
+		}
+		path = filepath.Join(opts.PtauDir, ptauFilename(opts.Power))
+
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			if err := downloadPtau(ctx, path, fmt.Sprintf(ptauURL, ptauFilename(opts.Power))); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if opts.PtauBlake2b != "" {
+		if err := verifyPtauChecksum(path, opts.PtauBlake2b); err != nil {
+			return "", err
+		}
+		logger.Logger.Info().Str("step", "ptau").Str("ptau", path).Msg("✅ Checksum verified")
+		return path, nil
+	}
+
+	// Without a published hash, check every contribution of the file
+	logger.Logger.Warn().Str("step", "ptau").Msg("No ptau checksum given, verifying with snarkjs (slow)")
+	if _, err := tool.Run(ctx, "ptau", ".", opts.SnarkjsBin, "powersoftau", "verify", path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// downloadPtau fetches url into path through a temporary file, so an interrupted download
+// is never mistaken for a complete file.
+func downloadPtau(ctx context.Context, path, url string) error {
+	logger.Logger.Info().Str("step", "ptau").Str("url", url).Msg("⬇️ Downloading powers of tau")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ptau: download %s: %w", url, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("ptau: download %s: %s", url, res.Status)
+	}
+
+	tmp := path + ".part"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, res.Body); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("ptau: download %s: %w", url, err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func verifyPtauChecksum(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h, err := blake2b.New512(nil)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != strings.ToLower(strings.TrimPrefix(expected, "0x")) {
+		return fmt.Errorf("%w: %s has %s", ErrPtauChecksum, path, sum)
+	}
+	return nil
+}